@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/email"
+	grpcserver "github.com/axfinn/todoIngPlus/backend-go/internal/grpc"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
+	obs "github.com/axfinn/todoIngPlus/backend-go/internal/observability"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
+	"github.com/joho/godotenv"
+)
+
+// @title TodoIng gRPC API
+// @version 1.0
+// @description 这是 TodoIng 项目的 gRPC API 服务
+// @host localhost:9001
+// @BasePath /
+
+func main() {
+	_ = godotenv.Load()
+	obs.InitLogger()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatal("MONGO_URI not set")
+	}
+
+	mdbCtx, mdbCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer mdbCancel()
+	client, err := mongo.Connect(mdbCtx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = client.Ping(mdbCtx, nil); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("MongoDB connected (gRPC)")
+	db := client.Database("todoing")
+
+	// 初始化邮件验证码存储（10 分钟有效，最大 5 次尝试）
+	emailStore := email.NewStore(10*time.Minute, 5)
+
+	// 通知推送 (StreamNotifications)：独立于 API 进程部署时需 NOTIFICATION_BROADCAST=mongo 才能收到其他实例产生的通知
+	hub := notifications.NewHub()
+	hub.SetGate(services.NewNotificationPreferenceService(db))
+	if os.Getenv("NOTIFICATION_BROADCAST") == "mongo" {
+		hub.SetBackend(notifications.NewMongoBackend(db))
+		go hub.Run(ctx)
+		log.Println("Notification hub: cross-instance broadcast via MongoDB enabled (gRPC)")
+	}
+
+	// 提醒渠道注册表 (创建 / 更新提醒时校验渠道 ID)
+	services.RegisterNotificationChannels(notify.Default, db, hub)
+
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = "9001"
+	}
+
+	server := grpcserver.New(grpcserver.ServerConfig{Port: port}, func(s *grpc.Server) {
+		pb.RegisterAuthServiceServer(s, grpcserver.NewAuthServiceServer(db, emailStore))
+		pb.RegisterTaskServiceServer(s, grpcserver.NewTaskServiceServer(db, hub))
+		pb.RegisterEventServiceServer(s, grpcserver.NewEventServiceServer(db))
+		pb.RegisterReminderServiceServer(s, grpcserver.NewReminderServiceServer(db))
+		pb.RegisterNotificationServiceServer(s, grpcserver.NewNotificationServiceServer(db, hub))
+		pb.RegisterUnifiedServiceServer(s, grpcserver.NewUnifiedServiceServer(db))
+		pb.RegisterDashboardServiceServer(s, grpcserver.NewDashboardServiceServer(db))
+		pb.RegisterReportServiceServer(s, grpcserver.NewReportServiceServer(db))
+		pb.RegisterCaptchaServiceServer(s, grpcserver.NewCaptchaServiceServer())
+		pb.RegisterWorkspaceServiceServer(s, grpcserver.NewWorkspaceServiceServer(db))
+		pb.RegisterSearchServiceServer(s, grpcserver.NewSearchServiceServer(db))
+	})
+
+	// 监听退出信号
+	go func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+		<-ch
+		cancel()
+	}()
+
+	// 可选 grpc-gateway (HTTP/JSON)，路径为 POST /<package.Service>/<Method>，流式接口按行返回 JSON
+	if gwPort := os.Getenv("GRPC_GATEWAY_PORT"); gwPort != "" {
+		go func() {
+			if err := grpcserver.ServeGateway(ctx, ":"+gwPort, "localhost:"+port); err != nil {
+				log.Printf("gRPC gateway error: %v", err)
+			}
+		}()
+	}
+
+	if err := server.Start(ctx); err != nil {
+		log.Fatalf("gRPC server error: %v", err)
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	_ = client.Disconnect(shutdownCtx)
+	log.Println("gRPC server exited")
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"os"
 	"strings"
@@ -13,6 +14,9 @@ import (
 	"github.com/axfinn/todoIngPlus/backend-go/internal/email"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/observability"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/templates"
 	"github.com/gorilla/mux"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -261,6 +265,38 @@ func (d *AuthDeps) Me(w http.ResponseWriter, r *http.Request) {
 	JSON(w, 200, user)
 }
 
+// UpdateMe 更新个人资料 (目前支持 IANA 时区)
+// PATCH /api/auth/me {"timeZone":"Asia/Shanghai"}
+func (d *AuthDeps) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	uid := GetUserID(r)
+	if uid == "" {
+		JSON(w, 401, map[string]string{"msg": "Unauthorized"})
+		return
+	}
+	objID, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		JSON(w, 400, map[string]string{"msg": "Invalid user ID"})
+		return
+	}
+	var req models.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSON(w, 400, map[string]string{"msg": "Invalid body"})
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	user, err := services.NewUserService(repository.NewUserRepository(d.DB)).UpdateProfile(ctx, objID, req)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidTimeZone) || errors.Is(err, templates.ErrUnsupportedLocale) {
+			JSON(w, 400, map[string]string{"msg": err.Error()})
+			return
+		}
+		JSON(w, 500, map[string]string{"msg": err.Error()})
+		return
+	}
+	JSON(w, 200, user)
+}
+
 func (d *AuthDeps) SendRegisterEmailCode(w http.ResponseWriter, r *http.Request) {
 	if os.Getenv("ENABLE_EMAIL_VERIFICATION") != "true" {
 		JSON(w, 400, map[string]string{"msg": "Email verification disabled"})
@@ -290,7 +326,8 @@ func (d *AuthDeps) SendRegisterEmailCode(w http.ResponseWriter, r *http.Request)
 	}
 
 	id, code := d.EmailCodes.Generate(normalizedEmail, 6)
-	_ = email.Send(body.Email, code) // 发送邮件使用原始邮箱格式
+	locale := templates.Default.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+	_ = email.Send(body.Email, code, locale) // 发送邮件使用原始邮箱格式
 	JSON(w, 200, map[string]string{"id": id, "msg": "Verification code sent"})
 }
 
@@ -324,7 +361,12 @@ func (d *AuthDeps) SendLoginEmailCode(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id, code := d.EmailCodes.Generate(normalizedEmail, 6)
-	_ = email.Send(body.Email, code) // 发送邮件使用原始邮箱格式
+	// 优先使用用户设置的语言，未设置时按 Accept-Language
+	locale := user.Locale
+	if locale == "" {
+		locale = templates.Default.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+	}
+	_ = email.Send(body.Email, code, locale) // 发送邮件使用原始邮箱格式
 	JSON(w, 200, map[string]string{"id": id, "msg": "Login verification code sent"})
 }
 
@@ -332,6 +374,7 @@ func SetupAuthRoutes(r *mux.Router, deps *AuthDeps) {
 	r.HandleFunc("/api/auth/register", deps.Register).Methods(http.MethodPost)
 	r.HandleFunc("/api/auth/login", deps.Login).Methods(http.MethodPost)
 	r.Handle("/api/auth/me", Auth(http.HandlerFunc(deps.Me))).Methods(http.MethodGet)
+	r.Handle("/api/auth/me", Auth(http.HandlerFunc(deps.UpdateMe))).Methods(http.MethodPatch)
 	r.HandleFunc("/api/auth/send-email-code", deps.SendRegisterEmailCode).Methods(http.MethodPost)
 	// 登录邮箱验证码使用专门的函数，检查用户是否存在
 	r.HandleFunc("/api/auth/send-login-email-code", deps.SendLoginEmailCode).Methods(http.MethodPost)
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// CalendarFeedDeps iCalendar 订阅 (令牌管理需登录，订阅地址凭令牌访问)
+type CalendarFeedDeps struct{ DB *mongo.Database }
+
+// CreateCalendarFeed 创建订阅令牌
+// POST /api/calendar/feeds {"name":"手机日历"}
+func (d *CalendarFeedDeps) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	var req models.CreateCalendarFeedRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(io.LimitReader(r.Body, 1<<12)).Decode(&req); err != nil && err != io.EOF {
+			writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+			return
+		}
+	}
+	res, err := services.NewCalendarFeedService(d.DB).CreateFeed(r.Context(), uid, req.Name)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "create_feed", err.Error())
+		return
+	}
+	JSON(w, http.StatusCreated, res)
+}
+
+// ListCalendarFeeds 列出订阅令牌 (不含明文)
+// GET /api/calendar/feeds
+func (d *CalendarFeedDeps) ListCalendarFeeds(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	list, err := services.NewCalendarFeedService(d.DB).ListFeeds(r.Context(), uid)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "list_feeds", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, map[string]interface{}{"feeds": list})
+}
+
+// RevokeCalendarFeed 撤销订阅令牌，订阅地址立即失效
+// DELETE /api/calendar/feeds/{id}
+func (d *CalendarFeedDeps) RevokeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "feed_id", "Invalid feed ID")
+		return
+	}
+	if err := services.NewCalendarFeedService(d.DB).RevokeFeed(r.Context(), uid, id); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, repository.ErrFeedNotFound) {
+			status = http.StatusNotFound
+		}
+		writeJSONError(w, status, "revoke_feed", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, map[string]string{"message": "feed revoked"})
+}
+
+// ServeCalendarFeed 输出 .ics (令牌即凭证)
+// GET /api/calendar/feed/{token}.ics
+func (d *CalendarFeedDeps) ServeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	body, err := services.NewCalendarFeedService(d.DB).RenderFeed(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		if errors.Is(err, repository.ErrFeedNotFound) {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to render feed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="todoing.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	_, _ = w.Write(body)
+}
+
+func feedUser(w http.ResponseWriter, r *http.Request) (primitive.ObjectID, bool) {
+	userID := GetUserID(r)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return primitive.NilObjectID, false
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "user_id", "Invalid user ID")
+		return primitive.NilObjectID, false
+	}
+	return uid, true
+}
+
+func SetupCalendarFeedRoutes(r *mux.Router, deps *CalendarFeedDeps) {
+	s := r.PathPrefix("/api/calendar").Subrouter()
+	s.Handle("/feeds", Auth(http.HandlerFunc(deps.CreateCalendarFeed))).Methods(http.MethodPost)
+	s.Handle("/feeds", Auth(http.HandlerFunc(deps.ListCalendarFeeds))).Methods(http.MethodGet)
+	s.Handle("/feeds/{id:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.RevokeCalendarFeed))).Methods(http.MethodDelete)
+	s.HandleFunc("/feed/{token}", deps.ServeCalendarFeed).Methods(http.MethodGet, http.MethodHead)
+}
@@ -45,7 +45,7 @@ func (d *DashboardDeps) GetDashboardData(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(dashboardData)
 }
 
-// GetPriorityTasks 按优先级获取任务列表
+// GetPriorityTasks 按优先级获取任务列表，支持 q (过滤表达式) 与 saved_view (保存的视图 ID)
 func (d *DashboardDeps) GetPriorityTasks(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok {
@@ -59,8 +59,15 @@ func (d *DashboardDeps) GetPriorityTasks(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// q / saved_view：按过滤表达式或保存的视图缩小范围
+	match, err := taskMatchParams(r.Context(), d.DB, userObjID, r.URL.Query())
+	if err != nil {
+		http.Error(w, "Invalid task filter: "+err.Error(), savedViewStatus(err))
+		return
+	}
+
 	taskSortService := services.NewTaskSortService(d.DB)
-	tasks, err := taskSortService.GetPriorityTasks(context.Background(), userObjID)
+	tasks, err := taskSortService.GetPriorityTasksMatching(context.Background(), userObjID, match)
 	if err != nil {
 		http.Error(w, "Failed to get priority tasks: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// DigestDeps 邮件汇总
+type DigestDeps struct{ DB *mongo.Database }
+
+// GetDigestSettings 汇总订阅设置 (默认关闭)
+// GET /api/digest/settings
+func (d *DigestDeps) GetDigestSettings(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	settings, err := services.NewDigestService(d.DB).GetSettings(r.Context(), uid)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "get_digest_settings", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, settings)
+}
+
+// UpdateDigestSettings 开启 / 关闭汇总并设置频率与发送时间
+// PUT /api/digest/settings {"enabled":true,"frequency":"weekly","send_at":"08:30","weekday":1}
+func (d *DigestDeps) UpdateDigestSettings(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	var req models.UpdateDigestSettingsRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<12)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	settings, err := services.NewDigestService(d.DB).UpdateSettings(r.Context(), uid, req)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidDigestSettings) {
+			writeJSONError(w, http.StatusBadRequest, "invalid_digest_settings", err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "update_digest_settings", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, settings)
+}
+
+// PreviewDigest 按当前数据生成汇总但不发送；format=html 时直接返回邮件正文
+// GET /api/digest/preview?frequency=daily|weekly&format=html
+func (d *DigestDeps) PreviewDigest(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	preview, err := services.NewDigestService(d.DB).Preview(r.Context(), uid, q.Get("frequency"))
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidDigestSettings) {
+			writeJSONError(w, http.StatusBadRequest, "invalid_digest_settings", err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "preview_digest", err.Error())
+		return
+	}
+	if q.Get("format") == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(preview.HTML))
+		return
+	}
+	JSON(w, http.StatusOK, preview)
+}
+
+func SetupDigestRoutes(r *mux.Router, deps *DigestDeps) {
+	s := r.PathPrefix("/api/digest").Subrouter()
+	s.Handle("/settings", Auth(http.HandlerFunc(deps.GetDigestSettings))).Methods(http.MethodGet)
+	s.Handle("/settings", Auth(http.HandlerFunc(deps.UpdateDigestSettings))).Methods(http.MethodPut)
+	s.Handle("/preview", Auth(http.HandlerFunc(deps.PreviewDigest))).Methods(http.MethodGet)
+}
@@ -1,6 +1,9 @@
 package api
 
-import "testing"
+import (
+    "testing"
+    "time"
+)
 
 // Tests parseFlexibleEventDate for various accepted formats.
 func TestParseFlexibleEventDate(t *testing.T) {
@@ -12,11 +15,24 @@ func TestParseFlexibleEventDate(t *testing.T) {
         {"2025-08-10", true},
     }
     for _, c := range cases {
-        if _, err := parseFlexibleEventDate(c.in, c.allDay); err != nil {
+        if _, err := parseFlexibleEventDate(c.in, c.allDay, time.UTC); err != nil {
             t.Errorf("expected success for %s got %v", c.in, err)
         }
     }
-    if _, err := parseFlexibleEventDate("2025/08/10", false); err == nil {
+    if _, err := parseFlexibleEventDate("2025/08/10", false, time.UTC); err == nil {
         t.Error("expected error for unsupported format")
     }
 }
+
+// Zone-less inputs are interpreted in the event time zone; all-day dates land on local midnight.
+func TestParseFlexibleEventDateInZone(t *testing.T) {
+    loc, _ := time.LoadLocation("Asia/Shanghai")
+    got, err := parseFlexibleEventDate("2025-08-10T09:00", false, loc)
+    if err != nil || !got.Equal(time.Date(2025, 8, 10, 1, 0, 0, 0, time.UTC)) {
+        t.Errorf("datetime-local in zone: got %v err %v", got, err)
+    }
+    got, err = parseFlexibleEventDate("2025-08-10T00:00:00Z", true, loc)
+    if err != nil || !got.Equal(time.Date(2025, 8, 10, 0, 0, 0, 0, loc)) {
+        t.Errorf("all-day in zone: got %v err %v", got, err)
+    }
+}
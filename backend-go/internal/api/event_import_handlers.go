@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// maxICSUpload .ics 上传大小上限
+const maxICSUpload = 10 << 20
+
+// ImportEvents 导入 .ics 文件 (multipart 字段 file，或直接以 text/calendar 作为请求体)
+// POST /api/events/import?time_zone=Asia/Shanghai
+// 以 UID 去重，返回逐条导入结果
+func (d *EventDeps) ImportEvents(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "user_id", "Invalid user ID")
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxICSUpload)
+	var src io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "file", "Missing .ics file")
+			return
+		}
+		defer file.Close()
+		src = file
+	}
+	zone := r.URL.Query().Get("time_zone")
+	if zone == "" {
+		zone = services.NewUserService(repository.NewUserRepository(d.DB)).TimeZone(r.Context(), uid)
+	} else if err := models.ValidateTimeZone(zone); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "field_time_zone", err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+	defer cancel()
+	svc := services.NewEventImportService(repository.NewEventRepository(d.DB), repository.NewReminderRepository(d.DB))
+	res, err := svc.Import(ctx, uid, src, zone)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "file", "File too large")
+		case errors.Is(err, services.ErrInvalidImport):
+			writeJSONError(w, http.StatusBadRequest, "ics", err.Error())
+		default:
+			writeJSONError(w, http.StatusInternalServerError, "import_events", err.Error())
+		}
+		return
+	}
+	JSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// EditEventOccurrence 编辑循环事件单次发生 (仅此次 / 此次及之后)
+// POST /api/events/{id}/occurrences
+func (d *EventDeps) EditEventOccurrence(w http.ResponseWriter, r *http.Request) {
+	uid, eventID, ok := parseEventScope(w, r)
+	if !ok {
+		return
+	}
+	var req models.EditOccurrenceRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	svc := services.NewEventService(repository.NewEventRepository(d.DB))
+	res, err := svc.EditOccurrence(r.Context(), uid, eventID, req)
+	if err != nil {
+		if err.Error() == "event not found" {
+			writeJSONError(w, http.StatusNotFound, "not_found", "Event not found")
+			return
+		}
+		if code := workspaceStatus(err); code != 0 {
+			writeJSONError(w, code, "workspace", err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, "edit_occurrence", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, res)
+}
+
+// ListEventExceptions 列出事件的单次发生例外
+// GET /api/events/{id}/exceptions
+func (d *EventDeps) ListEventExceptions(w http.ResponseWriter, r *http.Request) {
+	uid, eventID, ok := parseEventScope(w, r)
+	if !ok {
+		return
+	}
+	svc := services.NewEventService(repository.NewEventRepository(d.DB))
+	list, err := svc.ListExceptions(r.Context(), uid, eventID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "list_exceptions", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, map[string]interface{}{"exceptions": list})
+}
+
+// RestoreEventOccurrence 删除例外，恢复该次发生
+// DELETE /api/events/exceptions/{exceptionID}
+func (d *EventDeps) RestoreEventOccurrence(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "user_id", "Invalid user ID")
+		return
+	}
+	exID, err := primitive.ObjectIDFromHex(mux.Vars(r)["exceptionID"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "exception_id", "Invalid exception ID")
+		return
+	}
+	svc := services.NewEventService(repository.NewEventRepository(d.DB))
+	if err := svc.RestoreOccurrence(r.Context(), uid, exID); err != nil {
+		if code := workspaceStatus(err); code != 0 {
+			writeJSONError(w, code, "workspace", err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusNotFound, "restore_occurrence", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, map[string]string{"message": "occurrence restored"})
+}
+
+// parseEventScope 解析当前用户与路径中的事件 ID，失败时已写响应
+func parseEventScope(w http.ResponseWriter, r *http.Request) (primitive.ObjectID, primitive.ObjectID, bool) {
+	userID := GetUserID(r)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "user_id", "Invalid user ID")
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	eventID, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "event_id", "Invalid event ID")
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	return uid, eventID, true
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/observability"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
 
@@ -25,7 +26,8 @@ import (
 
 // EventDeps 事件相关依赖
 type EventDeps struct {
-	DB *mongo.Database
+	DB  *mongo.Database
+	Hub *notifications.Hub // 事件与时间线变更实时推送 (WebSocket)，可为空
 }
 
 // --- 事件评论 / 时间线 Handlers ---
@@ -59,6 +61,7 @@ func (d *EventDeps) CreateEventComment(w http.ResponseWriter, r *http.Request) {
 		writeJSONError(w, http.StatusBadRequest, "add_comment", err.Error())
 		return
 	}
+	publishTimeline(d.Hub, uid, notifications.ActionCreated, c)
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(c)
 }
@@ -93,6 +96,7 @@ func (d *EventDeps) UpdateEventComment(w http.ResponseWriter, r *http.Request) {
 		writeJSONError(w, http.StatusBadRequest, "update_comment", uerr.Error())
 		return
 	}
+	publishTimeline(d.Hub, uid, notifications.ActionUpdated, c)
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(c)
 }
@@ -182,10 +186,12 @@ func (d *EventDeps) DeleteEventComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	svc := services.NewEventCommentService(d.DB)
-	if err := svc.DeleteComment(r.Context(), uid, cid); err != nil {
+	c, err := svc.DeleteComment(r.Context(), uid, cid)
+	if err != nil {
 		writeJSONError(w, http.StatusBadRequest, "delete_comment", err.Error())
 		return
 	}
+	publishTimeline(d.Hub, uid, notifications.ActionDeleted, c)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -244,6 +250,8 @@ func (d *EventDeps) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		Tags             []string               `json:"tags"`
 		Location         string                 `json:"location"`
 		IsAllDay         bool                   `json:"is_all_day"`
+		TimeZone         string                 `json:"time_zone"`
+		WorkspaceID      string                 `json:"workspace_id"`
 	}
 	bodyBytes, _ := io.ReadAll(io.LimitReader(r.Body, 1<<20))
 	trimmed := strings.TrimSpace(string(bodyBytes))
@@ -332,6 +340,14 @@ func (d *EventDeps) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		if !raw.IsAllDay {
 			mapAssignBool("isAllDay", &raw.IsAllDay)
 		}
+		mapAssignStr("time_zone", &raw.TimeZone)
+		if raw.TimeZone == "" {
+			mapAssignStr("timeZone", &raw.TimeZone)
+		}
+		mapAssignStr("workspace_id", &raw.WorkspaceID)
+		if raw.WorkspaceID == "" {
+			mapAssignStr("workspaceId", &raw.WorkspaceID)
+		}
 		// tags 处理
 		if v, ok := m["tags"]; ok {
 			if arr, ok2 := v.([]interface{}); ok2 {
@@ -361,18 +377,19 @@ func (d *EventDeps) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	parsedDate, err := parseFlexibleEventDate(raw.EventDate, raw.IsAllDay)
+	// 未指定时区时沿用用户资料中的时区
+	if raw.TimeZone == "" {
+		raw.TimeZone = services.NewUserService(repository.NewUserRepository(d.DB)).TimeZone(r.Context(), objectID)
+	}
+	if err := models.ValidateTimeZone(raw.TimeZone); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "field_time_zone", err.Error())
+		return
+	}
+
+	parsedDate, err := parseFlexibleEventDate(raw.EventDate, raw.IsAllDay, models.LoadLocation(raw.TimeZone))
 	if err != nil {
-		if len(raw.EventDate) == 16 && raw.EventDate[10] == 'T' {
-			if t2, e2 := time.ParseInLocation("2006-01-02T15:04", raw.EventDate, time.Local); e2 == nil {
-				parsedDate = t2.UTC()
-				err = nil
-			}
-		}
-		if err != nil {
-			writeJSONError(w, http.StatusBadRequest, "date_parse", "Invalid event_date format: "+err.Error())
-			return
-		}
+		writeJSONError(w, http.StatusBadRequest, "date_parse", "Invalid event_date format: "+err.Error())
+		return
 	}
 
 	req := models.CreateEventRequest{
@@ -386,15 +403,33 @@ func (d *EventDeps) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		Tags:             raw.Tags,
 		Location:         raw.Location,
 		IsAllDay:         raw.IsAllDay,
+		TimeZone:         raw.TimeZone,
+	}
+	if raw.WorkspaceID != "" {
+		wsID, err := primitive.ObjectIDFromHex(raw.WorkspaceID)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "field_workspace_id", "Invalid workspace ID")
+			return
+		}
+		req.WorkspaceID = &wsID
 	}
 
 	eventService := services.NewEventService(repository.NewEventRepository(d.DB))
 	event, err := eventService.CreateEvent(context.Background(), objectID, req)
+	if errors.Is(err, models.ErrInvalidRecurrence) {
+		writeJSONError(w, http.StatusBadRequest, "field_recurrence", err.Error())
+		return
+	}
+	if code := workspaceStatus(err); code != 0 {
+		writeJSONError(w, code, "workspace", err.Error())
+		return
+	}
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, "create_failed", fmt.Sprintf("Failed to create event: %v", err))
 		return
 	}
 	observability.LogInfo("CreateEvent success id=%s user=%s title=%s date=%s", event.ID.Hex(), userID, event.Title, event.EventDate.Format(time.RFC3339))
+	publishChange(d.Hub, userID, notifications.TopicEvents, notifications.ActionCreated, event.ID.Hex(), event)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -406,32 +441,35 @@ func (d *EventDeps) CreateEvent(w http.ResponseWriter, r *http.Request) {
 //   - 2025-08-10T12:30  (datetime-local, 视为本地时区再转 UTC)
 //   - 2025-08-10 12:30  (空格分隔)
 //   - 2025-08-10        (仅日期, 若 isAllDay=true 则使用 00:00)
-func parseFlexibleEventDate(s string, isAllDay bool) (time.Time, error) {
+func parseFlexibleEventDate(s string, isAllDay bool, loc *time.Location) (time.Time, error) {
 	// 支持：
 	//  - RFC3339 / RFC3339Nano (含毫秒或纳秒)
 	//  - YYYY-MM-DDTHH:MM (HTML datetime-local)
 	//  - YYYY-MM-DD HH:MM (空格)
-	//  - YYYY-MM-DD (仅日期, 根据 isAllDay 补 00:00)
-	layouts := []string{time.RFC3339Nano, time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04"}
+	//  - YYYY-MM-DD (仅日期, 补 00:00)
+	// 不带时区的格式按 loc (事件时区) 解释；全天事件统一落到 loc 中当天 00:00
+	if loc == nil {
+		loc = time.UTC
+	}
 	if len(s) == 10 { // 只有日期
-		if isAllDay {
-			s = s + "T00:00"
-		} else {
-			s = s + "T00:00"
+		s = s + "T00:00"
+	}
+	var t time.Time
+	var err error
+	if t, err = time.Parse(time.RFC3339Nano, s); err != nil {
+		for _, layout := range []string{"2006-01-02T15:04", "2006-01-02 15:04"} {
+			if t, err = time.ParseInLocation(layout, s, loc); err == nil {
+				break
+			}
 		}
 	}
-	for _, layout := range layouts {
-		if t, err := time.Parse(layout, s); err == nil {
-			return t, nil
-		}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unsupported format (expect RFC3339 or 'YYYY-MM-DDTHH:MM')")
 	}
-	// 再尝试加上本地时区解释 datetime-local
-	if matchLen := len(s); matchLen == 16 { // 形如 2006-01-02T15:04
-		if lt, err := time.ParseInLocation("2006-01-02T15:04", s, time.Local); err == nil {
-			return lt.UTC(), nil
-		}
+	if isAllDay {
+		return models.DateIn(t, loc), nil
 	}
-	return time.Time{}, fmt.Errorf("unsupported format (expect RFC3339 or 'YYYY-MM-DDTHH:MM')")
+	return t, nil
 }
 
 // GetEvent 获取单个事件
@@ -506,9 +544,18 @@ func (d *EventDeps) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Event not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, repository.ErrWorkspaceForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, models.ErrInvalidRecurrence) || errors.Is(err, models.ErrInvalidTimeZone) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, fmt.Sprintf("Failed to update event: %v", err), http.StatusInternalServerError)
 		return
 	}
+	publishChange(d.Hub, userID, notifications.TopicEvents, notifications.ActionUpdated, eventIDStr, event)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(event)
@@ -549,9 +596,14 @@ func (d *EventDeps) AdvanceEvent(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Event not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, repository.ErrWorkspaceForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, fmt.Sprintf("Failed to advance event: %v", err), http.StatusInternalServerError)
 		return
 	}
+	publishChange(d.Hub, userID, notifications.TopicEvents, notifications.ActionUpdated, eventIDStr, event)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(event)
 }
@@ -585,9 +637,14 @@ func (d *EventDeps) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Event not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, repository.ErrWorkspaceForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, fmt.Sprintf("Failed to delete event: %v", err), http.StatusInternalServerError)
 		return
 	}
+	publishChange(d.Hub, userID, notifications.TopicEvents, notifications.ActionDeleted, eventIDStr, nil)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -710,15 +767,18 @@ func (d *EventDeps) GetCalendarEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	query := r.URL.Query()
+	// 月份边界与日期分桶按用户时区
+	loc := services.NewUserService(repository.NewUserRepository(d.DB)).Location(r.Context(), objectID)
+	now := time.Now().In(loc)
 
-	year := time.Now().Year()
+	year := now.Year()
 	if yearStr := query.Get("year"); yearStr != "" {
 		if y, err := strconv.Atoi(yearStr); err == nil && y >= 2020 && y <= 2050 {
 			year = y
 		}
 	}
 
-	month := int(time.Now().Month())
+	month := int(now.Month())
 	if monthStr := query.Get("month"); monthStr != "" {
 		if m, err := strconv.Atoi(monthStr); err == nil && m >= 1 && m <= 12 {
 			month = m
@@ -726,7 +786,7 @@ func (d *EventDeps) GetCalendarEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	eventService := services.NewEventService(repository.NewEventRepository(d.DB))
-	calendar, err := eventService.GetCalendarEvents(context.Background(), objectID, year, month)
+	calendar, err := eventService.GetCalendarEvents(context.Background(), objectID, year, month, loc)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to get calendar events: %v", err), http.StatusInternalServerError)
 		return
@@ -835,6 +895,7 @@ func SetupEventRoutes(r *mux.Router, deps *EventDeps) {
 	s.Handle("/calendar", Auth(http.HandlerFunc(deps.GetCalendarEvents))).Methods(http.MethodGet)
 	s.Handle("/options", Auth(http.HandlerFunc(deps.GetEventOptions))).Methods(http.MethodGet)
 	s.Handle("/search", Auth(http.HandlerFunc(deps.SearchEvents))).Methods(http.MethodGet)
+	s.Handle("/import", Auth(http.HandlerFunc(deps.ImportEvents))).Methods(http.MethodPost)
 
 	// 事件列表与创建
 	s.Handle("", Auth(http.HandlerFunc(deps.ListEvents))).Methods(http.MethodGet)
@@ -846,6 +907,10 @@ func SetupEventRoutes(r *mux.Router, deps *EventDeps) {
 	s.Handle("/{id:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.DeleteEvent))).Methods(http.MethodDelete)
 	// 推进/完成
 	s.Handle("/{id:[0-9a-fA-F]{24}}/advance", Auth(http.HandlerFunc(deps.AdvanceEvent))).Methods(http.MethodPost)
+	// 单次发生例外 (仅此次 / 此次及之后)
+	s.Handle("/{id:[0-9a-fA-F]{24}}/occurrences", Auth(http.HandlerFunc(deps.EditEventOccurrence))).Methods(http.MethodPost)
+	s.Handle("/{id:[0-9a-fA-F]{24}}/exceptions", Auth(http.HandlerFunc(deps.ListEventExceptions))).Methods(http.MethodGet)
+	s.Handle("/exceptions/{exceptionID:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.RestoreEventOccurrence))).Methods(http.MethodDelete)
 
 	// 评论 / 时间线 (挂在 /api/events/{id}/comments ... )
 	s.Handle("/{id:[0-9a-fA-F]{24}}/comments", Auth(http.HandlerFunc(deps.CreateEventComment))).Methods(http.MethodPost)
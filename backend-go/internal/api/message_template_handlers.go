@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/templates"
+)
+
+// MessageTemplateDeps 用户自定义提醒模板
+type MessageTemplateDeps struct{ DB *mongo.Database }
+
+// ListMessageTemplates 当前用户的自定义模板
+// GET /api/message-templates
+func (d *MessageTemplateDeps) ListMessageTemplates(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	list, err := services.NewMessageTemplateService(d.DB).List(r.Context(), uid)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "list_templates", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, map[string]interface{}{"templates": list})
+}
+
+// DefaultMessageTemplates 内置模板源码，locale 为空时使用用户语言
+// GET /api/message-templates/defaults?locale=en-US
+func (d *MessageTemplateDeps) DefaultMessageTemplates(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	locale := r.URL.Query().Get("locale")
+	if err := templates.Default.Validate(locale); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid_locale", err.Error())
+		return
+	}
+	if locale == "" {
+		locale = services.NewUserService(repository.NewUserRepository(d.DB)).Locale(r.Context(), uid)
+	}
+	locale = templates.Default.Resolve(locale)
+	JSON(w, http.StatusOK, map[string]interface{}{
+		"locale":    locale,
+		"locales":   templates.Default.Locales(),
+		"templates": services.NewMessageTemplateService(d.DB).Defaults(locale),
+	})
+}
+
+// UpsertMessageTemplate 设置某事件类型 (all 表示全部类型) 的模板
+// PUT /api/message-templates/{event_type}/{kind} {"body":"⏰ {{.Event.Title}} {{days .DaysLeft}}"}
+func (d *MessageTemplateDeps) UpsertMessageTemplate(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	var req models.UpsertMessageTemplateRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	vars := mux.Vars(r)
+	t, err := services.NewMessageTemplateService(d.DB).Upsert(r.Context(), uid, vars["event_type"], vars["kind"], req.Body)
+	if err != nil {
+		if errors.Is(err, templates.ErrInvalidTemplate) || errors.Is(err, services.ErrInvalidEventType) {
+			writeJSONError(w, http.StatusBadRequest, "invalid_template", err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "upsert_template", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, t)
+}
+
+// DeleteMessageTemplate 删除自定义模板，恢复内置模板
+// DELETE /api/message-templates/{event_type}/{kind}
+func (d *MessageTemplateDeps) DeleteMessageTemplate(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	deleted, err := services.NewMessageTemplateService(d.DB).Delete(r.Context(), uid, vars["event_type"], vars["kind"])
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidEventType) {
+			writeJSONError(w, http.StatusBadRequest, "invalid_template", err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "delete_template", err.Error())
+		return
+	}
+	if !deleted {
+		writeJSONError(w, http.StatusNotFound, "not_found", "Template not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func SetupMessageTemplateRoutes(r *mux.Router, deps *MessageTemplateDeps) {
+	r.Handle("/api/message-templates", Auth(http.HandlerFunc(deps.ListMessageTemplates))).Methods(http.MethodGet)
+	r.Handle("/api/message-templates/defaults", Auth(http.HandlerFunc(deps.DefaultMessageTemplates))).Methods(http.MethodGet)
+	r.Handle("/api/message-templates/{event_type}/{kind}", Auth(http.HandlerFunc(deps.UpsertMessageTemplate))).Methods(http.MethodPut)
+	r.Handle("/api/message-templates/{event_type}/{kind}", Auth(http.HandlerFunc(deps.DeleteMessageTemplate))).Methods(http.MethodDelete)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// NotificationEndpointDeps 提醒渠道与外部投递目标
+type NotificationEndpointDeps struct{ DB *mongo.Database }
+
+// ListNotificationChannels 已启用的渠道 ID (提醒 reminder_type 的可选值) 与 Web Push 公钥
+// GET /api/notification-channels
+func (d *NotificationEndpointDeps) ListNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	ids, vapid := services.NewNotificationEndpointService(d.DB).Channels()
+	JSON(w, http.StatusOK, map[string]interface{}{"channels": ids, "webpush_public_key": vapid})
+}
+
+// CreateNotificationEndpoint 新增投递目标
+// POST /api/notification-endpoints {"channel":"webhook","url":"https://..."}
+func (d *NotificationEndpointDeps) CreateNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	var req models.CreateNotificationEndpointRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	res, err := services.NewNotificationEndpointService(d.DB).Create(r.Context(), uid, req)
+	if err != nil {
+		if errors.Is(err, notify.ErrUnknownChannel) || errors.Is(err, services.ErrInvalidEndpoint) {
+			writeJSONError(w, http.StatusBadRequest, "invalid_endpoint", err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "create_endpoint", err.Error())
+		return
+	}
+	JSON(w, http.StatusCreated, res)
+}
+
+// ListNotificationEndpoints 列出投递目标 (?channel= 过滤；不含密钥)
+// GET /api/notification-endpoints
+func (d *NotificationEndpointDeps) ListNotificationEndpoints(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	list, err := services.NewNotificationEndpointService(d.DB).List(r.Context(), uid, r.URL.Query().Get("channel"))
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "list_endpoints", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, map[string]interface{}{"endpoints": list})
+}
+
+// DeleteNotificationEndpoint 删除投递目标
+// DELETE /api/notification-endpoints/{id}
+func (d *NotificationEndpointDeps) DeleteNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "endpoint_id", "Invalid endpoint ID")
+		return
+	}
+	if err := services.NewNotificationEndpointService(d.DB).Delete(r.Context(), uid, id); err != nil {
+		writeJSONError(w, http.StatusNotFound, "delete_endpoint", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, map[string]string{"message": "endpoint deleted"})
+}
+
+func SetupNotificationEndpointRoutes(r *mux.Router, deps *NotificationEndpointDeps) {
+	r.Handle("/api/notification-channels", Auth(http.HandlerFunc(deps.ListNotificationChannels))).Methods(http.MethodGet)
+	s := r.PathPrefix("/api/notification-endpoints").Subrouter()
+	s.Handle("", Auth(http.HandlerFunc(deps.ListNotificationEndpoints))).Methods(http.MethodGet)
+	s.Handle("", Auth(http.HandlerFunc(deps.CreateNotificationEndpoint))).Methods(http.MethodPost)
+	s.Handle("/{id:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.DeleteNotificationEndpoint))).Methods(http.MethodDelete)
+}
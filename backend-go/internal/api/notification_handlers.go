@@ -1,8 +1,12 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
@@ -25,8 +29,14 @@ func SetupNotificationRoutes(r *mux.Router, deps *NotificationDeps) {
 	s := r.PathPrefix("/api/notifications").Subrouter()
 	s.Handle("", Auth(http.HandlerFunc(deps.listNotifications))).Methods(http.MethodGet)
 	s.Handle("/stream", Auth(http.HandlerFunc(deps.streamNotifications))).Methods(http.MethodGet)
+	s.Handle("/stream/stats", Auth(http.HandlerFunc(deps.streamStats))).Methods(http.MethodGet)
+	s.Handle("/ws", Auth(http.HandlerFunc(deps.realtime))).Methods(http.MethodGet)
 	s.Handle("/{id}/read", Auth(http.HandlerFunc(deps.markRead))).Methods(http.MethodPost)
 	s.Handle("/read_all", Auth(http.HandlerFunc(deps.markAllRead))).Methods(http.MethodPost)
+	s.Handle("/unread_counts", Auth(http.HandlerFunc(deps.unreadCounts))).Methods(http.MethodGet)
+	s.Handle("/{id}/archive", Auth(http.HandlerFunc(deps.archive(true)))).Methods(http.MethodPost)
+	s.Handle("/{id}/unarchive", Auth(http.HandlerFunc(deps.archive(false)))).Methods(http.MethodPost)
+	s.Handle("/{id}", Auth(http.HandlerFunc(deps.deleteNotification))).Methods(http.MethodDelete)
 	// 测试创建通知端点（开发使用）
 	s.Handle("/test", Auth(http.HandlerFunc(deps.createTestNotification))).Methods(http.MethodPost)
 }
@@ -45,6 +55,10 @@ func (d *NotificationDeps) createTestNotification(w http.ResponseWriter, r *http
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 	n, err := d.Service.Create(ctx, models.NotificationCreate{UserID: uid, Type: "test", Message: "测试通知 " + time.Now().Format("15:04:05"), Metadata: map[string]interface{}{"demo": true}})
+	if errors.Is(err, services.ErrNotificationMuted) {
+		http.Error(w, "Test notifications are disabled by preferences", http.StatusConflict)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to create test notification", http.StatusInternalServerError)
 		return
@@ -68,24 +82,36 @@ func (d *NotificationDeps) listNotifications(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	q := r.URL.Query()
-	unreadOnly := q.Get("unread") == "true"
-	limit := 50
+	query := models.NotificationQuery{
+		UnreadOnly: q.Get("unread") == "true",
+		Archived:   q.Get("archived") == "true",
+		Type:       q.Get("type"),
+		Limit:      50,
+	}
 	if ls := q.Get("limit"); ls != "" {
 		if v, err := strconv.Atoi(ls); err == nil {
-			limit = v
+			query.Limit = v
+		}
+	}
+	if cs := q.Get("cursor"); cs != "" {
+		if query.Cursor, err = primitive.ObjectIDFromHex(cs); err != nil {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
 		}
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
-	list, err := d.Service.List(ctx, uid, unreadOnly, limit)
+	page, err := d.Service.List(ctx, uid, query)
 	if err != nil {
 		http.Error(w, "Failed to list notifications", http.StatusInternalServerError)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(map[string]interface{}{"notifications": list})
+	_ = json.NewEncoder(w).Encode(page)
 }
 
+// streamNotifications SSE 推送；每个事件带通知 ID (id:)，重连时按 Last-Event-ID (或 ?last_event_id=) 从通知集合补发，
+// 消费过慢导致丢弃时发送 dropped 事件并从最后一条补发
 func (d *NotificationDeps) streamNotifications(w http.ResponseWriter, r *http.Request) {
 	userID := GetUserID(r)
 	if userID == "" {
@@ -106,24 +132,34 @@ func (d *NotificationDeps) streamNotifications(w http.ResponseWriter, r *http.Re
 		return
 	}
 	ctx := r.Context()
-	ch := d.Hub.Subscribe(ctx, uid)
+	// 先订阅再补发，避免两者之间的通知丢失；补发过的 ID 在实时流中跳过
+	sub := d.Hub.Listen(ctx, uid)
 	_, _ = w.Write([]byte(":ok\n\n"))
+	stream := &sseStream{w: w, replayed: map[primitive.ObjectID]struct{}{}}
+	if last := lastEventID(r); !last.IsZero() {
+		stream.last = last
+		d.replay(ctx, uid, stream)
+	}
 	flusher.Flush()
 	heartbeat := time.NewTicker(25 * time.Second)
 	defer heartbeat.Stop()
+	var dropped int64
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case n, ok := <-ch:
+		case n, ok := <-sub.C:
 			if !ok {
 				return
 			}
-			b, _ := json.Marshal(n)
-			_, _ = w.Write([]byte("event: notification\n"))
-			_, _ = w.Write([]byte("data: "))
-			_, _ = w.Write(b)
-			_, _ = w.Write([]byte("\n\n"))
+			if _, dup := stream.replayed[n.ID]; !dup {
+				stream.send("notification", n)
+			}
+			if total := sub.Dropped(); total > dropped {
+				stream.send("dropped", map[string]int64{"dropped": total - dropped, "total": total})
+				dropped = total
+				d.replay(ctx, uid, stream)
+			}
 			flusher.Flush()
 		case <-heartbeat.C:
 			_, _ = w.Write([]byte(":hb\n\n"))
@@ -132,6 +168,68 @@ func (d *NotificationDeps) streamNotifications(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// sseReplayLimit 单次补发上限，更早的通知由客户端通过列表接口获取
+const sseReplayLimit = 100
+
+// replay 从通知集合补发 stream.last 之后的通知
+func (d *NotificationDeps) replay(ctx context.Context, uid primitive.ObjectID, stream *sseStream) {
+	if stream.last.IsZero() {
+		return
+	}
+	qctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	list, err := d.Service.ListAfter(qctx, uid, stream.last, sseReplayLimit)
+	if err != nil {
+		log.Printf("sse replay for %s failed: %v", uid.Hex(), err)
+		return
+	}
+	for _, n := range list {
+		stream.replayed[n.ID] = struct{}{}
+		stream.send("notification", n)
+	}
+}
+
+// sseStream 写出 SSE 事件并记录最后一条通知 ID
+type sseStream struct {
+	w        http.ResponseWriter
+	last     primitive.ObjectID
+	replayed map[primitive.ObjectID]struct{}
+}
+
+func (s *sseStream) send(event string, v interface{}) {
+	b, _ := json.Marshal(v)
+	if n, ok := v.(models.Notification); ok && !n.ID.IsZero() {
+		_, _ = fmt.Fprintf(s.w, "id: %s\n", n.ID.Hex())
+		if bytes.Compare(n.ID[:], s.last[:]) > 0 {
+			s.last = n.ID
+		}
+	}
+	_, _ = fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, b)
+}
+
+// lastEventID 读取 Last-Event-ID 头，EventSource 无法自定义头时可用 ?last_event_id=
+func lastEventID(r *http.Request) primitive.ObjectID {
+	v := r.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = r.URL.Query().Get("last_event_id")
+	}
+	id, err := primitive.ObjectIDFromHex(v)
+	if err != nil {
+		return primitive.NilObjectID
+	}
+	return id
+}
+
+// streamStats 本实例 SSE 广播统计 (订阅数、投递 / 丢弃数、慢消费者数)
+// GET /api/notifications/stream/stats
+func (d *NotificationDeps) streamStats(w http.ResponseWriter, r *http.Request) {
+	if d.Hub == nil {
+		JSON(w, http.StatusOK, notifications.HubStats{})
+		return
+	}
+	JSON(w, http.StatusOK, d.Hub.Stats())
+}
+
 func (d *NotificationDeps) markRead(w http.ResponseWriter, r *http.Request) {
 	userID := GetUserID(r)
 	if userID == "" {
@@ -152,6 +250,10 @@ func (d *NotificationDeps) markRead(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 	if err := d.Service.MarkRead(ctx, uid, nid); err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			http.Error(w, "Notification not found", http.StatusNotFound)
+			return
+		}
 		http.Error(w, "Failed to mark read", http.StatusInternalServerError)
 		return
 	}
@@ -179,3 +281,82 @@ func (d *NotificationDeps) markAllRead(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{"updated": cnt})
 }
+
+// unreadCounts 未读数 (总数与按类型)
+// GET /api/notifications/unread_counts
+func (d *NotificationDeps) unreadCounts(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r)
+	if userID == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	counts, err := d.Service.UnreadCounts(ctx, uid)
+	if err != nil {
+		http.Error(w, "Failed to count unread notifications", http.StatusInternalServerError)
+		return
+	}
+	JSON(w, http.StatusOK, counts)
+}
+
+// archive 归档 / 取消归档
+// POST /api/notifications/{id}/archive | /api/notifications/{id}/unarchive
+func (d *NotificationDeps) archive(archived bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		uid, nid, ok := notificationTarget(w, r)
+		if !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+		if err := d.Service.Archive(ctx, uid, nid, archived); err != nil {
+			if errors.Is(err, services.ErrNotificationNotFound) {
+				http.Error(w, "Notification not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to archive notification", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// deleteNotification DELETE /api/notifications/{id}
+func (d *NotificationDeps) deleteNotification(w http.ResponseWriter, r *http.Request) {
+	uid, nid, ok := notificationTarget(w, r)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	if err := d.Service.Delete(ctx, uid, nid); err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			http.Error(w, "Notification not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to delete notification", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// notificationTarget 解析当前用户与路径中的通知 ID，失败时已写出错误响应
+func notificationTarget(w http.ResponseWriter, r *http.Request) (primitive.ObjectID, primitive.ObjectID, bool) {
+	uid, err := primitive.ObjectIDFromHex(GetUserID(r))
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	nid, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid notification ID", http.StatusBadRequest)
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	return uid, nid, true
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+func TestSSEStreamWritesEventIDs(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/notifications/stream?last_event_id=bad", nil)
+	require.True(t, lastEventID(r).IsZero())
+	id := primitive.NewObjectID()
+	r.Header.Set("Last-Event-ID", id.Hex())
+	require.Equal(t, id, lastEventID(r))
+
+	rec := httptest.NewRecorder()
+	s := &sseStream{w: rec, last: id, replayed: map[primitive.ObjectID]struct{}{}}
+	older := primitive.NewObjectIDFromTimestamp(id.Timestamp().Add(-1e9))
+	newer := primitive.NewObjectID()
+	s.send("notification", models.Notification{ID: newer, Message: "a"})
+	s.send("notification", models.Notification{ID: older, Message: "b"}) // 延后通知 ID 较旧，不回退游标
+	s.send("notification", models.Notification{Message: "ephemeral"})
+	s.send("dropped", map[string]int64{"dropped": 2, "total": 2})
+	require.Equal(t, newer, s.last)
+
+	events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+	require.Len(t, events, 4)
+	require.True(t, strings.HasPrefix(events[0], "id: "+newer.Hex()+"\nevent: notification\ndata: {"))
+	require.True(t, strings.HasPrefix(events[2], "event: notification\n"))
+	require.Equal(t, "event: dropped\ndata: {\"dropped\":2,\"total\":2}", events[3])
+}
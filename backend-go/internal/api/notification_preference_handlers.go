@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// NotificationPreferenceDeps 用户通知偏好
+type NotificationPreferenceDeps struct{ DB *mongo.Database }
+
+// GetNotificationPreferences 当前用户的通知偏好 (未设置时返回默认值)
+// GET /api/notification-preferences
+func (d *NotificationPreferenceDeps) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	p, err := services.NewNotificationPreferenceService(d.DB).Get(r.Context(), uid)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "get_preferences", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, p)
+}
+
+// UpdateNotificationPreferences 整体替换通知偏好
+// PUT /api/notification-preferences {"mode":"immediate","types":{"reminder":{"channels":["app","webhook"]}},"quiet_hours":{"start":"22:00","end":"07:00"},"mute_weekends":true}
+func (d *NotificationPreferenceDeps) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	var req models.NotificationPreferences
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	p, err := services.NewNotificationPreferenceService(d.DB).Update(r.Context(), uid, req)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidPreferences) || errors.Is(err, notify.ErrUnknownChannel) {
+			writeJSONError(w, http.StatusBadRequest, "invalid_preferences", err.Error())
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "update_preferences", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, p)
+}
+
+func SetupNotificationPreferenceRoutes(r *mux.Router, deps *NotificationPreferenceDeps) {
+	r.Handle("/api/notification-preferences", Auth(http.HandlerFunc(deps.GetNotificationPreferences))).Methods(http.MethodGet)
+	r.Handle("/api/notification-preferences", Auth(http.HandlerFunc(deps.UpdateNotificationPreferences))).Methods(http.MethodPut)
+}
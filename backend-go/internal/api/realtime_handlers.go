@@ -0,0 +1,292 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
+)
+
+// WebSocket 连接参数
+const (
+	wsWriteWait    = 10 * time.Second
+	wsPongWait     = 60 * time.Second
+	wsPingPeriod   = 50 * time.Second
+	wsMaxMessage   = 4 << 10
+	wsReplyBuffer  = 16
+	wsMaxTopics    = 50
+	wsEventTopicPx = "event:"
+)
+
+var wsUpgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 4096}
+
+// wsClientMessage 客户端命令：subscribe / unsubscribe (topic)、mark_read (id)、mark_all_read、ping；ref 原样回传
+type wsClientMessage struct {
+	Type  string `json:"type"`
+	Ref   string `json:"ref,omitempty"`
+	Topic string `json:"topic,omitempty"`
+	ID    string `json:"id,omitempty"`
+}
+
+// wsServerMessage 服务端消息：notification / change / dropped / ack / error / pong
+type wsServerMessage struct {
+	Type  string      `json:"type"`
+	Ref   string      `json:"ref,omitempty"`
+	Topic string      `json:"topic,omitempty"`
+	ID    string      `json:"id,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// wsTopics 连接当前订阅的主题
+type wsTopics struct {
+	mu  sync.RWMutex
+	set map[string]bool
+}
+
+func (t *wsTopics) has(topic string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.set[topic]
+}
+
+func (t *wsTopics) add(topic string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.set) >= wsMaxTopics && !t.set[topic] {
+		return false
+	}
+	t.set[topic] = true
+	return true
+}
+
+func (t *wsTopics) remove(topic string) {
+	t.mu.Lock()
+	delete(t.set, topic)
+	t.mu.Unlock()
+}
+
+func (t *wsTopics) list() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make([]string, 0, len(t.set))
+	for k := range t.set {
+		out = append(out, k)
+	}
+	return out
+}
+
+// validTopic notifications / tasks / events / event:<id>
+func validTopic(topic string) bool {
+	switch topic {
+	case notifications.TopicNotifications, notifications.TopicTasks, notifications.TopicEvents:
+		return true
+	}
+	if strings.HasPrefix(topic, wsEventTopicPx) {
+		_, err := primitive.ObjectIDFromHex(strings.TrimPrefix(topic, wsEventTopicPx))
+		return err == nil
+	}
+	return false
+}
+
+// realtime WebSocket 双向通道：与 SSE 使用相同 JWT (Authorization 头或 ?token=)，
+// 默认订阅 notifications，?topics=tasks,events 预订阅，?last_event_id= 补发断线期间的通知
+// GET /api/notifications/ws
+func (d *NotificationDeps) realtime(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	topics := &wsTopics{set: map[string]bool{notifications.TopicNotifications: true}}
+	if q := r.URL.Query().Get("topics"); q != "" {
+		topics.set = map[string]bool{}
+		for _, t := range strings.Split(q, ",") {
+			if t = strings.TrimSpace(t); validTopic(t) {
+				topics.add(t)
+			}
+		}
+	}
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // Upgrade 已写出错误响应
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	sub := d.Hub.Listen(ctx, uid)
+	watch := d.Hub.Watch(ctx, uid)
+	replies := make(chan wsServerMessage, wsReplyBuffer)
+	go d.wsRead(ctx, cancel, conn, uid, topics, replies)
+
+	send := func(m wsServerMessage) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+		return conn.WriteJSON(m) == nil
+	}
+	// 通知补发：与 SSE 相同，按最后一条通知 ID 从通知集合读取
+	last := lastEventID(r)
+	replayed := map[primitive.ObjectID]struct{}{}
+	replay := func() bool {
+		if last.IsZero() || !topics.has(notifications.TopicNotifications) {
+			return true
+		}
+		qctx, qcancel := context.WithTimeout(ctx, 5*time.Second)
+		defer qcancel()
+		list, err := d.Service.ListAfter(qctx, uid, last, sseReplayLimit)
+		if err != nil {
+			log.Printf("ws replay for %s failed: %v", uid.Hex(), err)
+			return true
+		}
+		for _, n := range list {
+			replayed[n.ID] = struct{}{}
+			last = n.ID
+			if !send(wsServerMessage{Type: "notification", Topic: notifications.TopicNotifications, ID: n.ID.Hex(), Data: n}) {
+				return false
+			}
+		}
+		return true
+	}
+	if !send(wsServerMessage{Type: "ack", Data: map[string]interface{}{"topics": topics.list()}}) || !replay() {
+		return
+	}
+
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+	var droppedN, droppedC int64
+	for {
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return
+		case m := <-replies:
+			ok = send(m)
+		case n, open := <-sub.C:
+			if !open {
+				return
+			}
+			ok = true
+			if _, dup := replayed[n.ID]; !dup && topics.has(notifications.TopicNotifications) {
+				if !n.ID.IsZero() {
+					last = n.ID
+				}
+				ok = send(wsServerMessage{Type: "notification", Topic: notifications.TopicNotifications, ID: n.ID.Hex(), Data: n})
+			}
+			if total := sub.Dropped(); ok && total > droppedN {
+				ok = send(wsServerMessage{Type: "dropped", Topic: notifications.TopicNotifications, Data: map[string]int64{"dropped": total - droppedN, "total": total}})
+				droppedN = total
+				ok = ok && replay()
+			}
+		case c, open := <-watch.C:
+			if !open {
+				return
+			}
+			ok = true
+			if topics.has(c.Topic) {
+				ok = send(wsServerMessage{Type: "change", Topic: c.Topic, ID: c.ID, Data: c})
+			}
+			if total := watch.Dropped(); ok && total > droppedC {
+				ok = send(wsServerMessage{Type: "dropped", Data: map[string]int64{"dropped": total - droppedC, "total": total}})
+				droppedC = total
+			}
+		case <-ping.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			ok = conn.WriteMessage(websocket.PingMessage, nil) == nil
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// wsRead 读取客户端命令，连接关闭或出错时取消 ctx
+func (d *NotificationDeps) wsRead(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, uid primitive.ObjectID, topics *wsTopics, replies chan<- wsServerMessage) {
+	defer cancel()
+	conn.SetReadLimit(wsMaxMessage)
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error { return conn.SetReadDeadline(time.Now().Add(wsPongWait)) })
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg wsClientMessage
+		reply := wsServerMessage{Type: "error", Error: "invalid_json"}
+		if json.Unmarshal(data, &msg) == nil {
+			reply = d.wsCommand(ctx, uid, topics, msg)
+		}
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// wsCommand 执行一条客户端命令并返回应答
+func (d *NotificationDeps) wsCommand(ctx context.Context, uid primitive.ObjectID, topics *wsTopics, msg wsClientMessage) wsServerMessage {
+	ack := wsServerMessage{Type: "ack", Ref: msg.Ref}
+	fail := func(e string) wsServerMessage { return wsServerMessage{Type: "error", Ref: msg.Ref, Error: e} }
+	qctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	switch msg.Type {
+	case "ping":
+		return wsServerMessage{Type: "pong", Ref: msg.Ref}
+	case "subscribe":
+		if !validTopic(msg.Topic) {
+			return fail("invalid_topic")
+		}
+		if !topics.add(msg.Topic) {
+			return fail("too_many_topics")
+		}
+		ack.Data = map[string]interface{}{"topics": topics.list()}
+		return ack
+	case "unsubscribe":
+		topics.remove(msg.Topic)
+		ack.Data = map[string]interface{}{"topics": topics.list()}
+		return ack
+	case "mark_read":
+		nid, err := primitive.ObjectIDFromHex(msg.ID)
+		if err != nil {
+			return fail("invalid_id")
+		}
+		if err := d.Service.MarkRead(qctx, uid, nid); err != nil {
+			return fail("not_found")
+		}
+		ack.ID = msg.ID
+		return ack
+	case "mark_all_read":
+		n, err := d.Service.MarkAllRead(qctx, uid)
+		if err != nil {
+			return fail("mark_all_read_failed")
+		}
+		ack.Data = map[string]int64{"updated": n}
+		return ack
+	}
+	return fail("unknown_command")
+}
+
+// publishChange 推送任务 / 事件 / 时间线变更 (WebSocket)；hub 为 nil 或用户 ID 非 ObjectID 时忽略
+func publishChange(hub *notifications.Hub, userID, topic, action, id string, data interface{}) {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if hub == nil || err != nil {
+		return
+	}
+	hub.Publish(notifications.NewChange(oid, topic, action, id, data))
+}
+
+// publishTimeline 推送事件时间线条目变更
+func publishTimeline(hub *notifications.Hub, userID primitive.ObjectID, action string, c *models.EventComment) {
+	if hub == nil || c == nil {
+		return
+	}
+	hub.Publish(notifications.NewChange(userID, notifications.EventTimelineTopic(c.EventID), action, c.ID.Hex(), c))
+}
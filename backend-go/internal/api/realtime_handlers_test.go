@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
+)
+
+func TestRealtimeTopicSubscriptions(t *testing.T) {
+	hub := notifications.NewHub()
+	d := &NotificationDeps{Hub: hub}
+	user := primitive.NewObjectID()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.realtime(w, r.WithContext(context.WithValue(r.Context(), userKey, user.Hex())))
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"?topics=tasks,bogus", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	read := func() wsServerMessage {
+		var m wsServerMessage
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		require.NoError(t, conn.ReadJSON(&m))
+		return m
+	}
+	hello := read()
+	require.Equal(t, "ack", hello.Type)
+	require.Equal(t, map[string]interface{}{"topics": []interface{}{"tasks"}}, hello.Data)
+
+	require.NoError(t, conn.WriteJSON(wsClientMessage{Type: "ping", Ref: "1"}))
+	require.Equal(t, wsServerMessage{Type: "pong", Ref: "1"}, read())
+	require.NoError(t, conn.WriteJSON(wsClientMessage{Type: "subscribe", Ref: "2", Topic: "event:nope"}))
+	require.Equal(t, wsServerMessage{Type: "error", Ref: "2", Error: "invalid_topic"}, read())
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("{")))
+	require.Equal(t, "invalid_json", read().Error)
+
+	// 未订阅 notifications 与 events：只收到 tasks 变更
+	ev := primitive.NewObjectID()
+	require.NoError(t, conn.WriteJSON(wsClientMessage{Type: "subscribe", Ref: "3", Topic: notifications.EventTimelineTopic(ev)}))
+	require.Equal(t, "3", read().Ref)
+	hub.Broadcast(models.Notification{UserID: user, Message: "skip"})
+	hub.Publish(notifications.NewChange(user, notifications.TopicEvents, notifications.ActionCreated, ev.Hex(), nil))
+	hub.Publish(notifications.NewChange(user, notifications.TopicTasks, notifications.ActionDeleted, "t1", nil))
+	m := read()
+	require.Equal(t, "change", m.Type)
+	require.Equal(t, notifications.TopicTasks, m.Topic)
+	require.Equal(t, "t1", m.ID)
+
+	hub.Publish(notifications.NewChange(user, notifications.EventTimelineTopic(ev), notifications.ActionCreated, "c1", nil))
+	m = read()
+	require.Equal(t, notifications.EventTimelineTopic(ev), m.Topic)
+	require.Equal(t, "c1", m.ID)
+}
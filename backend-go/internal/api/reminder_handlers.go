@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/email"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/observability"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
 
@@ -95,10 +96,10 @@ func (d *ReminderDeps) CreateReminder(w http.ResponseWriter, r *http.Request) {
 				}
 			}
 		}
-		if v, ok := m["reminder_type"].(string); ok {
-			req.ReminderType = v
-		} else if v, ok := m["reminderType"].(string); ok {
-			req.ReminderType = v
+		if v, ok := m["reminder_type"]; ok {
+			req.ReminderType = looseChannels(v)
+		} else if v, ok := m["reminderType"]; ok {
+			req.ReminderType = looseChannels(v)
 		}
 		if v, ok := m["custom_message"].(string); ok {
 			req.CustomMessage = v
@@ -117,7 +118,7 @@ func (d *ReminderDeps) CreateReminder(w http.ResponseWriter, r *http.Request) {
 		writeErr(http.StatusBadRequest, "field_times", "Either reminder_times or absolute_times is required")
 		return
 	}
-	if req.ReminderType == "" {
+	if len(req.ReminderType) == 0 {
 		writeErr(http.StatusBadRequest, "field_reminder_type", "Reminder type is required")
 		return
 	}
@@ -125,10 +126,18 @@ func (d *ReminderDeps) CreateReminder(w http.ResponseWriter, r *http.Request) {
 	reminderService := services.NewReminderService(repository.NewReminderRepository(d.DB))
 	reminder, err := reminderService.CreateReminder(context.Background(), objectID, req)
 	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceForbidden) {
+			writeErr(http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
 		if err.Error() == "event not found" {
 			writeErr(http.StatusBadRequest, "event_not_found", "Event not found")
 			return
 		}
+		if errors.Is(err, notify.ErrUnknownChannel) {
+			writeErr(http.StatusBadRequest, "field_reminder_type", err.Error())
+			return
+		}
 		writeErr(http.StatusInternalServerError, "create_failed", fmt.Sprintf("Failed to create reminder: %v", err))
 		return
 	}
@@ -147,6 +156,23 @@ func truncateBody(b []byte) string {
 	return s
 }
 
+// looseChannels 宽松解析渠道：字符串 ("email" / "app,webhook") 或字符串数组
+func looseChannels(v interface{}) models.ReminderChannels {
+	switch x := v.(type) {
+	case string:
+		return models.ParseReminderChannels(x)
+	case []interface{}:
+		var out models.ReminderChannels
+		for _, it := range x {
+			if s, ok := it.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out.Normalize()
+	}
+	return nil
+}
+
 // GetReminder 获取单个提醒
 func (d *ReminderDeps) GetReminder(w http.ResponseWriter, r *http.Request) {
 	userID := GetUserID(r)
@@ -215,10 +241,18 @@ func (d *ReminderDeps) UpdateReminder(w http.ResponseWriter, r *http.Request) {
 	reminderService := services.NewReminderService(repository.NewReminderRepository(d.DB))
 	reminder, err := reminderService.UpdateReminder(context.Background(), objectID, reminderID, req)
 	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if err.Error() == "reminder not found" {
 			http.Error(w, "Reminder not found", http.StatusNotFound)
 			return
 		}
+		if errors.Is(err, notify.ErrUnknownChannel) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, fmt.Sprintf("Failed to update reminder: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -252,6 +286,10 @@ func (d *ReminderDeps) DeleteReminder(w http.ResponseWriter, r *http.Request) {
 	reminderService := services.NewReminderService(repository.NewReminderRepository(d.DB))
 	err = reminderService.DeleteReminder(context.Background(), objectID, reminderID)
 	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if err.Error() == "reminder not found" {
 			http.Error(w, "Reminder not found", http.StatusNotFound)
 			return
@@ -357,6 +395,10 @@ func (d *ReminderDeps) ToggleReminderActive(w http.ResponseWriter, r *http.Reque
 	svc := services.NewReminderService(repository.NewReminderRepository(d.DB))
 	newVal, err := svc.ToggleReminderActive(r.Context(), objectID, rid)
 	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceForbidden) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
 		if err.Error() == "reminder not found" {
 			http.Error(w, "Reminder not found", http.StatusNotFound)
 			return
@@ -478,6 +520,10 @@ func (d *ReminderDeps) SnoozeReminder(w http.ResponseWriter, r *http.Request) {
 
 	reminderService := services.NewReminderService(repository.NewReminderRepository(d.DB))
 	if err := reminderService.SnoozeReminder(context.Background(), objectID, reminderID, req.SnoozeMinutes); err != nil {
+		if errors.Is(err, repository.ErrWorkspaceForbidden) {
+			writeErr(http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
 		if err.Error() == "reminder not found" {
 			writeErr(http.StatusNotFound, "reminder_not_found", "Reminder not found")
 			return
@@ -489,6 +535,52 @@ func (d *ReminderDeps) SnoozeReminder(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(map[string]interface{}{"message": "Reminder snoozed successfully", "snooze_minutes": req.SnoozeMinutes})
 }
 
+// ListReminderDeliveries 提醒投递历史 (每个渠道每次尝试一条，按时间倒序；before_id 翻页)
+func (d *ReminderDeps) ListReminderDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r)
+	if userID == "" {
+		writeJSONError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return
+	}
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "user_id", "Invalid user ID")
+		return
+	}
+	reminderID, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "reminder_id", "Invalid reminder ID")
+		return
+	}
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	var before *primitive.ObjectID
+	if v := q.Get("before_id"); v != "" {
+		id, err := primitive.ObjectIDFromHex(v)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "before_id", "Invalid before_id")
+			return
+		}
+		before = &id
+	}
+
+	reminderService := services.NewReminderService(repository.NewReminderRepository(d.DB))
+	if _, err := reminderService.GetReminder(r.Context(), objectID, reminderID); err != nil {
+		if err.Error() == "reminder not found" {
+			writeJSONError(w, http.StatusNotFound, "reminder_not_found", "Reminder not found")
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, "get_failed", err.Error())
+		return
+	}
+	list, err := repository.NewReminderDeliveryRepository(d.DB).ListByReminder(r.Context(), objectID, reminderID, limit, before)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "list_failed", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, map[string]interface{}{"deliveries": list, "count": len(list)})
+}
+
 // SetupReminderRoutes 设置提醒路由
 func SetupReminderRoutes(r *mux.Router, deps *ReminderDeps) {
 	s := r.PathPrefix("/api/reminders").Subrouter()
@@ -510,6 +602,7 @@ func SetupReminderRoutes(r *mux.Router, deps *ReminderDeps) {
 	s.Handle("/"+idPattern, Auth(http.HandlerFunc(deps.DeleteReminder))).Methods(http.MethodDelete)
 	s.Handle("/"+idPattern+"/snooze", Auth(http.HandlerFunc(deps.SnoozeReminder))).Methods(http.MethodPost)
 	s.Handle("/"+idPattern+"/toggle_active", Auth(http.HandlerFunc(deps.ToggleReminderActive))).Methods(http.MethodPost)
+	s.Handle("/"+idPattern+"/deliveries", Auth(http.HandlerFunc(deps.ListReminderDeliveries))).Methods(http.MethodGet)
 }
 
 // CreateTestReminder 直接创建一个立即或短延迟触发的测试提醒
@@ -590,14 +683,10 @@ func (d *ReminderDeps) CreateTestReminder(w http.ResponseWriter, r *http.Request
 		if errFind != nil || userDoc.Email == "" {
 			emailErr = "user email not found"
 		} else {
-			// 构造邮件内容（复用 scheduler 风格）
-			subject := fmt.Sprintf("TodoIng 测试提醒：%s", evt.Title)
-			body := fmt.Sprintf("亲爱的用户,\n\n%s\n\n事件: %s\n时间: %s\n类型: %s\n\n--\nTodoIng 系统即时测试提醒\n",
-				payload.Message,
-				evt.Title,
-				evt.EventDate.Format("2006-01-02 15:04"),
-				evt.EventType,
-			)
+			// 构造邮件内容（与 scheduler 使用相同模板）
+			messages := services.NewMessageRenderer(d.DB)
+			subject, _ := messages.Reminder(ctx, *reminder, *evt)
+			body := messages.ReminderEmail(ctx, uid, payload.Message, *evt)
 			if errSend := email.SendGeneric(userDoc.Email, subject, body); errSend != nil {
 				emailErr = errSend.Error()
 				log.Printf("CreateTestReminder immediate email failed user=%s email=%s err=%v", uid.Hex(), userDoc.Email, errSend)
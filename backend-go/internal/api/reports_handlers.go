@@ -9,6 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
 	"github.com/gorilla/mux"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -143,15 +146,28 @@ func (d *ReportDeps) GenerateReport(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 支持多种日期格式，兼容前端
-	start, err1 := parseFlexibleDate(req.StartDate)
-	end, err2 := parseFlexibleDate(req.EndDate)
+	// 不带时区的日期按用户时区解释；纯日期的 endDate 包含当天
+	loc := time.UTC
+	if oid, err := primitive.ObjectIDFromHex(uid); err == nil {
+		loc = services.NewUserService(repository.NewUserRepository(d.DB)).Location(r.Context(), oid)
+	}
+	start, err1 := parseFlexibleDate(req.StartDate, loc)
+	end, err2 := parseFlexibleDate(req.EndDate, loc)
+	if err2 == nil && len(req.EndDate) == len("2006-01-02") {
+		end = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
 	if err1 != nil || err2 != nil {
 		JSON(w, 400, map[string]string{"msg": "Invalid date format"})
 		return
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
 	defer cancel()
-	tasksCur, err := d.DB.Collection("tasks").Find(ctx, bson.M{"createdBy": uid, "createdAt": bson.M{"$gte": start, "$lte": end}})
+	scope, err := repository.TaskAccessFilter(ctx, d.DB, uid, models.WorkspaceRoleViewer)
+	if err != nil {
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	tasksCur, err := d.DB.Collection("tasks").Find(ctx, repository.Scoped(scope, bson.M{"createdAt": bson.M{"$gte": start, "$lte": end}}))
 	if err != nil {
 		JSON(w, 500, map[string]string{"msg": "DB error"})
 		return
@@ -398,17 +414,18 @@ func itoa(i int) string {
 }
 
 // 灵活的日期解析，兼容前端多种格式
-func parseFlexibleDate(dateStr string) (time.Time, error) {
+func parseFlexibleDate(dateStr string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, dateStr); err == nil {
+		return t, nil
+	}
 	formats := []string{
-		time.RFC3339,
-		"2006-01-02T15:04:05Z",
 		"2006-01-02T15:04:05",
 		"2006-01-02 15:04:05",
 		"2006-01-02",
 	}
 
 	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
+		if t, err := time.ParseInLocation(format, dateStr, loc); err == nil {
 			return t, nil
 		}
 	}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// SavedViewDeps 保存的任务视图 (命名的过滤表达式 + 排序)
+type SavedViewDeps struct{ DB *mongo.Database }
+
+func savedViews(db *mongo.Database) *services.SavedViewService {
+	return services.NewSavedViewService(repository.NewSavedViewRepository(db))
+}
+
+// savedViewStatus 视图 / 过滤表达式错误对应的 HTTP 状态码
+func savedViewStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrSavedViewNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrSavedViewNameTaken):
+		return http.StatusConflict
+	case services.IsSavedViewQueryError(err):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// taskQueryParams 解析列表参数 q / sort / saved_view：视图的过滤表达式与 q 取且，sort 覆盖视图排序
+func taskQueryParams(ctx context.Context, db *mongo.Database, userID string, v url.Values) (models.TaskFilter, []models.TaskSortField, error) {
+	uid, _ := primitive.ObjectIDFromHex(userID)
+	return savedViews(db).Resolve(ctx, uid, v.Get("saved_view"), v.Get("q"), v.Get("sort"))
+}
+
+// taskMatchParams 参数 q / saved_view 对应的任务条件 (仪表盘、统一即将到来列表)，未设置时为 nil
+func taskMatchParams(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, v url.Values) (bson.M, error) {
+	if v.Get("q") == "" && v.Get("saved_view") == "" {
+		return nil, nil
+	}
+	loc := services.NewUserService(repository.NewUserRepository(db)).Location(ctx, userID)
+	return savedViews(db).TaskMatch(ctx, userID, v.Get("saved_view"), v.Get("q"), loc)
+}
+
+// ListSavedViews 当前用户保存的视图
+// GET /api/saved-views
+func (d *SavedViewDeps) ListSavedViews(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	list, err := savedViews(d.DB).List(r.Context(), uid)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "list_saved_views", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, list)
+}
+
+// CreateSavedView 保存视图
+// POST /api/saved-views {"name":"My urgent","query":"priority:High due:<7d -status:Done","sort":"due"}
+func (d *SavedViewDeps) CreateSavedView(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	var req models.SavedViewRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	v, err := savedViews(d.DB).Create(r.Context(), uid, req)
+	if err != nil {
+		writeJSONError(w, savedViewStatus(err), "create_saved_view", err.Error())
+		return
+	}
+	JSON(w, http.StatusCreated, v)
+}
+
+// GetSavedView 视图详情
+// GET /api/saved-views/{id}
+func (d *SavedViewDeps) GetSavedView(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseSavedViewScope(w, r)
+	if !ok {
+		return
+	}
+	v, err := savedViews(d.DB).Get(r.Context(), uid, id)
+	if err != nil {
+		writeJSONError(w, savedViewStatus(err), "get_saved_view", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, v)
+}
+
+// UpdateSavedView 替换视图的名称、过滤表达式与排序
+// PUT /api/saved-views/{id}
+func (d *SavedViewDeps) UpdateSavedView(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseSavedViewScope(w, r)
+	if !ok {
+		return
+	}
+	var req models.SavedViewRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	v, err := savedViews(d.DB).Update(r.Context(), uid, id, req)
+	if err != nil {
+		writeJSONError(w, savedViewStatus(err), "update_saved_view", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, v)
+}
+
+// DeleteSavedView 删除视图
+// DELETE /api/saved-views/{id}
+func (d *SavedViewDeps) DeleteSavedView(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseSavedViewScope(w, r)
+	if !ok {
+		return
+	}
+	if err := savedViews(d.DB).Delete(r.Context(), uid, id); err != nil {
+		writeJSONError(w, savedViewStatus(err), "delete_saved_view", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseSavedViewScope 解析当前用户与路径中的视图 ID，失败时已写响应
+func parseSavedViewScope(w http.ResponseWriter, r *http.Request) (primitive.ObjectID, primitive.ObjectID, bool) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "saved_view_id", "Invalid saved view ID")
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	return uid, id, true
+}
+
+func SetupSavedViewRoutes(r *mux.Router, deps *SavedViewDeps) {
+	s := r.PathPrefix("/api/saved-views").Subrouter()
+	s.Handle("", Auth(http.HandlerFunc(deps.ListSavedViews))).Methods(http.MethodGet)
+	s.Handle("", Auth(http.HandlerFunc(deps.CreateSavedView))).Methods(http.MethodPost)
+	s.Handle("/{id}", Auth(http.HandlerFunc(deps.GetSavedView))).Methods(http.MethodGet)
+	s.Handle("/{id}", Auth(http.HandlerFunc(deps.UpdateSavedView))).Methods(http.MethodPut)
+	s.Handle("/{id}", Auth(http.HandlerFunc(deps.DeleteSavedView))).Methods(http.MethodDelete)
+}
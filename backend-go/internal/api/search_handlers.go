@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// SearchDeps 任务 / 事件 / 事件评论统一搜索
+type SearchDeps struct{ DB *mongo.Database }
+
+// Search 统一搜索
+// GET /api/search?q=周报&sources=task,event,comment&status=Done&priority=High&tags=work&from=2024-01-01&to=2024-12-31&limit=20
+// status / priority 只搜索任务，tags 搜索任务与事件；返回的 highlight / snippet 为已转义的 HTML，命中词以 <mark> 标记
+func (d *SearchDeps) Search(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	q, err := parseSearchQuery(r.URL.Query())
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "query", err.Error())
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	res, err := services.NewSearchService(repository.NewSearchRepository(d.DB)).Search(ctx, uid, q)
+	switch {
+	case errors.Is(err, services.ErrSearchQueryRequired), errors.Is(err, services.ErrInvalidSearchSource):
+		writeJSONError(w, http.StatusBadRequest, "query", err.Error())
+		return
+	case err != nil:
+		writeJSONError(w, http.StatusInternalServerError, "search", err.Error())
+		return
+	}
+	JSON(w, http.StatusOK, res)
+}
+
+func parseSearchQuery(v url.Values) (models.SearchQuery, error) {
+	q := models.SearchQuery{
+		Text:     v.Get("q"),
+		Sources:  splitList(v.Get("sources")),
+		Status:   splitList(v.Get("status")),
+		Priority: splitList(v.Get("priority")),
+		Tags:     splitList(v.Get("tags")),
+	}
+	var err error
+	if q.From, err = parseSearchDate(v.Get("from"), false); err != nil {
+		return q, errors.New("invalid from")
+	}
+	if q.To, err = parseSearchDate(v.Get("to"), true); err != nil {
+		return q, errors.New("invalid to")
+	}
+	if l := v.Get("limit"); l != "" {
+		if q.Limit, err = strconv.Atoi(l); err != nil || q.Limit <= 0 {
+			return q, errors.New("invalid limit")
+		}
+	}
+	return q, nil
+}
+
+// splitList 逗号分隔列表，忽略空项
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// parseSearchDate RFC3339 或 YYYY-MM-DD (UTC)；end 为 true 时日期取当天结束
+func parseSearchDate(s string, end bool) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, err
+	}
+	if end {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
+
+func SetupSearchRoutes(r *mux.Router, deps *SearchDeps) {
+	r.Handle("/api/search", Auth(http.HandlerFunc(deps.Search))).Methods(http.MethodGet)
+}
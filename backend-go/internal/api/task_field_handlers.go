@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// TaskFieldDeps 任务自定义字段定义 (个人 / 工作区)
+type TaskFieldDeps struct{ DB *mongo.Database }
+
+func (d *TaskFieldDeps) svc() *services.TaskFieldService {
+	return services.NewTaskFieldService(repository.NewTaskFieldRepository(d.DB), repository.NewWorkspaceRepository(d.DB))
+}
+
+// writeTaskFieldDefError 字段定义错误写入响应
+func writeTaskFieldDefError(w http.ResponseWriter, code string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTaskFieldNotFound):
+		writeJSONError(w, http.StatusNotFound, code, err.Error())
+	case errors.Is(err, services.ErrInvalidTaskField):
+		writeJSONError(w, http.StatusBadRequest, code, err.Error())
+	case errors.Is(err, services.ErrTaskFieldKeyTaken):
+		writeJSONError(w, http.StatusConflict, code, err.Error())
+	default:
+		writeWorkspaceError(w, code, err)
+	}
+}
+
+// ListTaskFields 个人字段或工作区字段
+// GET /api/task-fields?workspace=<id>
+func (d *TaskFieldDeps) ListTaskFields(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	list, err := d.svc().List(r.Context(), uid, r.URL.Query().Get("workspace"))
+	if err != nil {
+		writeTaskFieldDefError(w, "list_task_fields", err)
+		return
+	}
+	JSON(w, http.StatusOK, list)
+}
+
+// CreateTaskField 新建字段定义 (工作区字段需 owner 角色)
+// POST /api/task-fields {"key":"estimate","name":"Estimate","type":"number"}
+// POST /api/task-fields {"workspace_id":"...","key":"stage","type":"select","options":["todo","review","done"]}
+func (d *TaskFieldDeps) CreateTaskField(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	var req models.TaskFieldRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	f, err := d.svc().Create(r.Context(), uid, req)
+	if err != nil {
+		writeTaskFieldDefError(w, "create_task_field", err)
+		return
+	}
+	JSON(w, http.StatusCreated, f)
+}
+
+// UpdateTaskField 修改名称与选项
+// PUT /api/task-fields/{id} {"name":"Stage","options":["todo","review","done"]}
+func (d *TaskFieldDeps) UpdateTaskField(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseTaskFieldScope(w, r)
+	if !ok {
+		return
+	}
+	var req models.TaskFieldRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	f, err := d.svc().Update(r.Context(), uid, id, req)
+	if err != nil {
+		writeTaskFieldDefError(w, "update_task_field", err)
+		return
+	}
+	JSON(w, http.StatusOK, f)
+}
+
+// DeleteTaskField 删除字段定义并清除任务上的取值
+// DELETE /api/task-fields/{id}
+func (d *TaskFieldDeps) DeleteTaskField(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseTaskFieldScope(w, r)
+	if !ok {
+		return
+	}
+	if err := d.svc().Delete(r.Context(), uid, id); err != nil {
+		writeTaskFieldDefError(w, "delete_task_field", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTaskFieldScope 解析当前用户与路径中的字段 ID，失败时已写响应
+func parseTaskFieldScope(w http.ResponseWriter, r *http.Request) (primitive.ObjectID, primitive.ObjectID, bool) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "task_field_id", "Invalid task field ID")
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	return uid, id, true
+}
+
+func SetupTaskFieldRoutes(r *mux.Router, deps *TaskFieldDeps) {
+	s := r.PathPrefix("/api/task-fields").Subrouter()
+	s.Handle("", Auth(http.HandlerFunc(deps.ListTaskFields))).Methods(http.MethodGet)
+	s.Handle("", Auth(http.HandlerFunc(deps.CreateTaskField))).Methods(http.MethodPost)
+	s.Handle("/{id}", Auth(http.HandlerFunc(deps.UpdateTaskField))).Methods(http.MethodPut)
+	s.Handle("/{id}", Auth(http.HandlerFunc(deps.DeleteTaskField))).Methods(http.MethodDelete)
+}
@@ -3,11 +3,16 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/observability"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
 	"github.com/gorilla/mux"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,14 +20,17 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type TaskDeps struct{ DB *mongo.Database }
+type TaskDeps struct {
+	DB  *mongo.Database
+	Hub *notifications.Hub // 任务变更实时推送 (WebSocket)，可为空
+}
 
 type taskRequest struct {
 	Title         string  `json:"title"`
 	Description   string  `json:"description"`
 	Status        string  `json:"status"`
 	Priority      string  `json:"priority"`
-	Assignee      *string `json:"assignee"`
+	Assignee      *string `json:"assignee"`      // 用户 ID、用户名或邮箱；更新为空字符串时取消分配
 	Deadline      *string `json:"deadline"`      // 改为 string 类型以兼容前端
 	ScheduledDate *string `json:"scheduledDate"` // 改为 string 类型以兼容前端
 	Comments      []struct {
@@ -30,6 +38,74 @@ type taskRequest struct {
 		CreatedBy string `json:"createdBy,omitempty"`
 		CreatedAt string `json:"createdAt,omitempty"`
 	} `json:"comments"`
+	ParentID     *string                `json:"parentId"`     // 创建子任务
+	Checklist    []models.ChecklistItem `json:"checklist"`    // 更新时全量替换
+	AutoComplete *bool                  `json:"autoComplete"` // 子任务与检查项全部完成时自动完成
+	Recurrence   *string                `json:"recurrence"`   // RRULE，完成后生成下一个实例；更新为空字符串时停止循环
+	WorkspaceID  *string                `json:"workspaceId"`  // 仅创建时有效，子任务沿用父任务的工作区
+	Tags         *[]string              `json:"tags"`         // 更新时全量替换
+	CustomFields map[string]interface{} `json:"customFields"` // 按字段定义校验；更新时按键合并，null 删除
+}
+
+type checklistItemRequest struct {
+	Text *string `json:"text"`
+	Done *bool   `json:"done"`
+}
+
+// tasks 子任务 / 检查项汇总与依赖逻辑复用 TaskService
+func (d *TaskDeps) tasks() *services.TaskService {
+	return services.NewTaskService(repository.NewTaskRepository(d.DB)).
+		WithNotifications(services.NewNotificationService(d.DB), d.Hub).
+		WithDirectory(repository.NewUserRepository(d.DB), repository.NewWorkspaceRepository(d.DB)).
+		WithFields(repository.NewTaskFieldRepository(d.DB))
+}
+
+// resolveAssignee 校验负责人并写入响应，返回 false 表示已写错误
+func (d *TaskDeps) resolveAssignee(ctx context.Context, w http.ResponseWriter, login string, t *models.Task) (*string, bool) {
+	assignee, err := d.tasks().ResolveAssignee(ctx, login, t)
+	switch {
+	case errors.Is(err, services.ErrInvalidAssignee):
+		JSON(w, 400, map[string]string{"msg": "Invalid assignee"})
+		return nil, false
+	case errors.Is(err, services.ErrAssigneeNoAccess):
+		JSON(w, 400, map[string]string{"msg": "Assignee cannot access task"})
+		return nil, false
+	case err != nil:
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return nil, false
+	}
+	return assignee, true
+}
+
+// writeTaskFieldError 标签 / 自定义字段错误写入响应
+func writeTaskFieldError(w http.ResponseWriter, err error) {
+	if services.IsTaskFieldValueError(err) {
+		JSON(w, 400, map[string]string{"msg": err.Error()})
+		return
+	}
+	JSON(w, 500, map[string]string{"msg": "DB error"})
+}
+
+// access 当前用户以 minRole 身份可访问的任务条件 (个人任务与所属工作区任务)
+func (d *TaskDeps) access(ctx context.Context, uid, minRole string, filter bson.M) (bson.M, error) {
+	scope, err := repository.TaskAccessFilter(ctx, d.DB, uid, minRole)
+	if err != nil {
+		return nil, err
+	}
+	return repository.Scoped(scope, filter), nil
+}
+
+// writeTaskMiss 写操作未命中时区分 403 (工作区角色不足) 与 404
+func (d *TaskDeps) writeTaskMiss(ctx context.Context, w http.ResponseWriter, uid string, objID primitive.ObjectID) {
+	if errors.Is(repository.TaskAccessDenied(ctx, d.DB, uid, bson.M{"_id": objID}), repository.ErrWorkspaceForbidden) {
+		JSON(w, 403, map[string]string{"msg": "Workspace permission denied"})
+		return
+	}
+	JSON(w, 404, map[string]string{"msg": "Task not found"})
+}
+
+type dependencyRequest struct {
+	BlockerID string `json:"blockerId"`
 }
 
 var allowedStatus = map[string]bool{"To Do": true, "In Progress": true, "Done": true}
@@ -94,6 +170,104 @@ func (d *TaskDeps) CreateTask(w http.ResponseWriter, r *http.Request) {
 		"updatedAt":     now,
 	}
 
+	workspaceID := ""
+	if req.WorkspaceID != nil {
+		workspaceID = *req.WorkspaceID
+	}
+	if req.ParentID != nil && *req.ParentID != "" {
+		pctx, pcancel := context.WithTimeout(r.Context(), 5*time.Second)
+		parentID, err := d.tasks().ValidateParent(pctx, uid, *req.ParentID)
+		var parent *models.Task
+		if err == nil {
+			parent, err = repository.NewTaskRepository(d.DB).FindByID(pctx, uid, parentID)
+		}
+		pcancel()
+		if errors.Is(err, services.ErrInvalidParentTask) {
+			JSON(w, 400, map[string]string{"msg": "Invalid parent task"})
+			return
+		}
+		if err != nil {
+			JSON(w, 500, map[string]string{"msg": "DB error"})
+			return
+		}
+		doc["parentId"] = parentID
+		workspaceID = parent.WorkspaceID
+	}
+	if workspaceID != "" {
+		wsID, err := primitive.ObjectIDFromHex(workspaceID)
+		userObj, uerr := primitive.ObjectIDFromHex(uid)
+		if err != nil || uerr != nil {
+			JSON(w, 400, map[string]string{"msg": "Invalid workspace"})
+			return
+		}
+		wctx, wcancel := context.WithTimeout(r.Context(), 5*time.Second)
+		err = repository.RequireWorkspaceRole(wctx, d.DB, wsID, userObj, models.WorkspaceRoleEditor)
+		wcancel()
+		switch {
+		case errors.Is(err, repository.ErrWorkspaceNotFound):
+			JSON(w, 400, map[string]string{"msg": "Invalid workspace"})
+			return
+		case errors.Is(err, repository.ErrWorkspaceForbidden):
+			JSON(w, 403, map[string]string{"msg": "Workspace permission denied"})
+			return
+		case err != nil:
+			JSON(w, 500, map[string]string{"msg": "DB error"})
+			return
+		}
+		doc["workspaceId"] = workspaceID
+	}
+	var assignee *string
+	if req.Assignee != nil {
+		actx, acancel := context.WithTimeout(r.Context(), 5*time.Second)
+		a, ok := d.resolveAssignee(actx, w, *req.Assignee, &models.Task{CreatedBy: uid, WorkspaceID: workspaceID})
+		acancel()
+		if !ok {
+			return
+		}
+		assignee = a
+		doc["assignee"] = assignee
+	}
+	if req.Tags != nil {
+		tags, err := services.NormalizeTags(*req.Tags)
+		if err != nil {
+			writeTaskFieldError(w, err)
+			return
+		}
+		if len(tags) > 0 {
+			doc["tags"] = tags
+		}
+	}
+	if len(req.CustomFields) > 0 {
+		fctx, fcancel := context.WithTimeout(r.Context(), 5*time.Second)
+		fields, err := d.tasks().ResolveCustomFields(fctx, &models.Task{CreatedBy: uid, WorkspaceID: workspaceID}, nil, req.CustomFields, true)
+		fcancel()
+		if err != nil {
+			writeTaskFieldError(w, err)
+			return
+		}
+		if fields != nil {
+			doc["customFields"] = fields
+		}
+	}
+	if checklist := services.NormalizeChecklist(req.Checklist, now); len(checklist) > 0 {
+		p, _ := models.RollupProgress(nil, checklist)
+		doc["checklist"] = checklist
+		doc["progress"] = p
+	}
+	if req.AutoComplete != nil && *req.AutoComplete {
+		doc["autoComplete"] = true
+	}
+	if req.Recurrence != nil {
+		recurrence, err := services.NormalizeRecurrence(*req.Recurrence)
+		if err != nil {
+			JSON(w, 400, map[string]string{"msg": "Invalid recurrence"})
+			return
+		}
+		if recurrence != "" {
+			doc["recurrence"] = recurrence
+		}
+	}
+
 	// 处理评论数据，确保兼容原有格式
 	for _, c := range req.Comments {
 		if strings.TrimSpace(c.Text) != "" {
@@ -121,6 +295,13 @@ func (d *TaskDeps) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	doc["_id"] = res.InsertedID.(primitive.ObjectID).Hex()
+	if parentID, ok := doc["parentId"].(string); ok {
+		if err := d.tasks().Rollup(ctx, uid, parentID); err != nil {
+			observability.CtxLog(r.Context(), "CreateTask rollup parent %s error: %v", parentID, err)
+		}
+	}
+	d.tasks().NotifyAssigned(ctx, uid, nil, models.Task{ID: doc["_id"].(string), Title: req.Title, Assignee: assignee})
+	publishChange(d.Hub, uid, notifications.TopicTasks, notifications.ActionCreated, doc["_id"].(string), doc)
 	JSON(w, 200, doc)
 }
 
@@ -140,11 +321,18 @@ func parseDate(dateStr *string) *time.Time {
 
 // ListTasks 获取任务列表
 // @Summary 获取用户的所有任务
-// @Description 获取当前用户创建的所有任务列表，按创建时间倒序排列
+// @Description 获取当前用户可见的任务列表 (个人任务与所属工作区任务)，默认按创建时间倒序排列
 // @Tags 任务管理
 // @Accept json
 // @Produce json
+// @Param view query string false "视图: assigned_to_me / created_by_me / unassigned，默认全部"
+// @Param q query string false "过滤表达式，如 priority:High due:<7d -status:Done assignee:me"
+// @Param sort query string false "排序，逗号分隔，- 前缀降序，如 -priority,due"
+// @Param saved_view query string false "保存的视图 ID，与 q 取且，sort 覆盖视图排序"
+// @Param tags query string false "标签，逗号分隔，须全部包含"
 // @Success 200 {object} []map[string]interface{} "任务列表"
+// @Failure 400 {object} map[string]string "视图或过滤表达式无效"
+// @Failure 404 {object} map[string]string "保存的视图不存在"
 // @Failure 401 {object} map[string]string "未授权"
 // @Failure 500 {object} map[string]string "服务器内部错误"
 // @Router /api/tasks [get]
@@ -154,9 +342,43 @@ func (d *TaskDeps) ListTasks(w http.ResponseWriter, r *http.Request) {
 		JSON(w, 401, map[string]string{"msg": "Unauthorized"})
 		return
 	}
+	view := r.URL.Query().Get("view")
+	if !models.ValidTaskView(view) {
+		JSON(w, 400, map[string]string{"msg": "Invalid view"})
+		return
+	}
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
-	cur, err := d.DB.Collection("tasks").Find(ctx, bson.M{"createdBy": uid}, optionsFindSortCreatedAtDesc())
+	f, sort, err := taskQueryParams(ctx, d.DB, uid, r.URL.Query())
+	switch {
+	case errors.Is(err, repository.ErrSavedViewNotFound):
+		JSON(w, 404, map[string]string{"msg": "Saved view not found"})
+		return
+	case services.IsSavedViewQueryError(err):
+		JSON(w, 400, map[string]string{"msg": err.Error()})
+		return
+	case err != nil:
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	filter, err := d.access(ctx, uid, models.WorkspaceRoleViewer, repository.TaskViewFilter(view, uid))
+	if err != nil {
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	if len(f.Terms) > 0 {
+		loc := services.NewUserService(repository.NewUserRepository(d.DB)).LocationByHex(ctx, uid)
+		match, err := repository.TaskFilterBSON(f, uid, time.Now(), loc)
+		if err != nil {
+			JSON(w, 400, map[string]string{"msg": err.Error()})
+			return
+		}
+		filter = repository.Scoped(filter, match)
+	}
+	if tags := splitList(r.URL.Query().Get("tags")); len(tags) > 0 {
+		filter = repository.Scoped(filter, bson.M{"tags": bson.M{"$all": tags}})
+	}
+	cur, err := d.DB.Collection("tasks").Aggregate(ctx, repository.TaskListPipeline(filter, sort, 0, 0))
 	if err != nil {
 		JSON(w, 500, map[string]string{"msg": "DB error"})
 		return
@@ -177,7 +399,7 @@ func (d *TaskDeps) ListTasks(w http.ResponseWriter, r *http.Request) {
 
 // GetTask 获取单个任务详情
 // @Summary 获取任务详情
-// @Description 根据任务ID获取任务的详细信息
+// @Description 根据任务ID获取任务的详细信息，subtasks 为子任务层级
 // @Tags 任务管理
 // @Accept json
 // @Produce json
@@ -201,15 +423,52 @@ func (d *TaskDeps) GetTask(w http.ResponseWriter, r *http.Request) {
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
+	filter, err := d.access(ctx, uid, models.WorkspaceRoleViewer, bson.M{"_id": objID})
+	if err != nil {
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
 	var m bson.M
-	if err := d.DB.Collection("tasks").FindOne(ctx, bson.M{"_id": objID, "createdBy": uid}).Decode(&m); err != nil {
+	if err := d.DB.Collection("tasks").FindOne(ctx, filter).Decode(&m); err != nil {
 		JSON(w, 404, map[string]string{"msg": "Task not found"})
 		return
 	}
 	m["_id"] = id
+	m["subtasks"] = d.subtaskDocs(ctx, uid, id, 1)
 	JSON(w, 200, m)
 }
 
+// subtaskDocs 按层级读取子任务 (深度受限)
+func (d *TaskDeps) subtaskDocs(ctx context.Context, uid, parentID string, depth int) []bson.M {
+	out := []bson.M{}
+	if depth >= services.MaxTaskDepth {
+		return out
+	}
+	filter, err := d.access(ctx, uid, models.WorkspaceRoleViewer, bson.M{"parentId": parentID})
+	if err != nil {
+		return out
+	}
+	cur, err := d.DB.Collection("tasks").Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+	if err != nil {
+		return out
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		var m bson.M
+		if cur.Decode(&m) != nil {
+			continue
+		}
+		if oid, ok := m["_id"].(primitive.ObjectID); ok {
+			m["_id"] = oid.Hex()
+		}
+		if cid, ok := m["_id"].(string); ok {
+			m["subtasks"] = d.subtaskDocs(ctx, uid, cid, depth+1)
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
 // UpdateTask 更新任务
 // @Summary 更新任务信息
 // @Description 根据任务ID更新任务的详细信息
@@ -266,10 +525,10 @@ func (d *TaskDeps) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		update["assignee"] = req.Assignee
 	}
 	if req.Deadline != nil {
-		update["deadline"] = req.Deadline
+		update["deadline"] = parseDate(req.Deadline) // 存为日期类型，子任务汇总按 time 解码
 	}
 	if req.ScheduledDate != nil {
-		update["scheduledDate"] = req.ScheduledDate
+		update["scheduledDate"] = parseDate(req.ScheduledDate)
 	}
 	if len(req.Comments) > 0 { // replace comments, 尽量保留各自 createdAt
 		comments := make([]bson.M, 0, len(req.Comments))
@@ -286,22 +545,113 @@ func (d *TaskDeps) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		}
 		update["comments"] = comments
 	}
-	if len(update) == 0 {
+	if req.Checklist != nil {
+		update["checklist"] = services.NormalizeChecklist(req.Checklist, time.Now())
+	}
+	if req.AutoComplete != nil {
+		update["autoComplete"] = *req.AutoComplete
+	}
+	if req.Recurrence != nil {
+		recurrence, err := services.NormalizeRecurrence(*req.Recurrence)
+		if err != nil {
+			JSON(w, 400, map[string]string{"msg": "Invalid recurrence"})
+			return
+		}
+		update["recurrence"] = recurrence
+	}
+	if req.Tags != nil {
+		tags, err := services.NormalizeTags(*req.Tags)
+		if err != nil {
+			writeTaskFieldError(w, err)
+			return
+		}
+		update["tags"] = tags
+	}
+	if len(update) == 0 && len(req.CustomFields) == 0 {
 		JSON(w, 400, map[string]string{"msg": "No fields to update"})
 		return
 	}
 	update["updatedAt"] = time.Now()
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
-	res := d.DB.Collection("tasks").FindOneAndUpdate(ctx, bson.M{"_id": objID, "createdBy": uid}, bson.M{"$set": update}, optionsFindOneAndUpdateReturnAfter())
+	filter, err := d.access(ctx, uid, models.WorkspaceRoleEditor, bson.M{"_id": objID})
+	if err != nil {
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	var before *models.Task
+	if req.Assignee != nil {
+		before, err = repository.NewTaskRepository(d.DB).FindByID(ctx, uid, id)
+		if err != nil {
+			d.writeTaskMiss(ctx, w, uid, objID)
+			return
+		}
+		assignee, ok := d.resolveAssignee(ctx, w, *req.Assignee, before)
+		if !ok {
+			return
+		}
+		update["assignee"] = assignee
+	}
+	if len(req.CustomFields) > 0 { // 自定义字段按任务所属范围的定义校验，与现有取值合并
+		current, err := repository.NewTaskRepository(d.DB).FindByID(ctx, uid, id)
+		if err != nil {
+			d.writeTaskMiss(ctx, w, uid, objID)
+			return
+		}
+		fields, err := d.tasks().ResolveCustomFields(ctx, current, current.CustomFields, req.CustomFields, true)
+		if err != nil {
+			writeTaskFieldError(w, err)
+			return
+		}
+		update["customFields"] = fields
+	}
+	res := d.DB.Collection("tasks").FindOneAndUpdate(ctx, filter, bson.M{"$set": update}, optionsFindOneAndUpdateReturnAfter())
 	var m bson.M
 	if err := res.Decode(&m); err != nil {
-		JSON(w, 404, map[string]string{"msg": "Task not found"})
+		d.writeTaskMiss(ctx, w, uid, objID)
 		return
 	}
+	// 检查项 / 自动完成变化时从自身汇总 (可能自动完成)；状态或截止日期变化只影响父任务
+	rollupFrom := ""
+	if req.Checklist != nil || req.AutoComplete != nil {
+		rollupFrom = id
+	} else if parentID, ok := m["parentId"].(string); ok && (req.Status != "" || req.Deadline != nil) {
+		rollupFrom = parentID
+	}
+	// 状态变化后重新计算依赖本任务的任务 (完成时通知被解除阻塞的任务)；循环任务完成时生成下一个实例
+	reload := false
+	if req.Status != "" {
+		if _, err := d.tasks().ResolveDependents(ctx, uid, id); err != nil {
+			observability.CtxLog(r.Context(), "UpdateTask resolve dependents of %s error: %v", id, err)
+		}
+		next, err := d.tasks().SpawnNextOccurrence(ctx, uid, id)
+		if err != nil {
+			observability.CtxLog(r.Context(), "UpdateTask spawn next occurrence of %s error: %v", id, err)
+		}
+		if next != nil {
+			publishChange(d.Hub, uid, notifications.TopicTasks, notifications.ActionCreated, next.ID, next)
+			reload = true
+		}
+	}
+	if rollupFrom != "" {
+		if err := d.tasks().Rollup(ctx, uid, rollupFrom); err != nil {
+			observability.CtxLog(r.Context(), "UpdateTask rollup %s error: %v", rollupFrom, err)
+		} else if rollupFrom == id {
+			reload = true
+		}
+	}
+	if reload {
+		_ = d.DB.Collection("tasks").FindOne(ctx, bson.M{"_id": objID}).Decode(&m)
+	}
 	if idObj, ok := m["_id"].(primitive.ObjectID); ok {
 		m["_id"] = idObj.Hex()
 	}
+	if before != nil {
+		title, _ := m["title"].(string)
+		assignee, _ := update["assignee"].(*string)
+		d.tasks().NotifyAssigned(ctx, uid, before.Assignee, models.Task{ID: id, Title: title, Assignee: assignee})
+	}
+	publishChange(d.Hub, uid, notifications.TopicTasks, notifications.ActionUpdated, id, m)
 	JSON(w, 200, m)
 }
 
@@ -331,11 +681,31 @@ func (d *TaskDeps) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	}
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
-	res, err := d.DB.Collection("tasks").DeleteOne(ctx, bson.M{"_id": objID, "createdBy": uid})
-	if err != nil || res.DeletedCount == 0 {
-		JSON(w, 404, map[string]string{"msg": "Task not found"})
+	var existing struct {
+		ParentID *string `bson:"parentId"`
+	}
+	filter, err := d.access(ctx, uid, models.WorkspaceRoleEditor, bson.M{"_id": objID})
+	if err != nil {
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	if err := d.DB.Collection("tasks").FindOneAndDelete(ctx, filter).Decode(&existing); err != nil {
+		d.writeTaskMiss(ctx, w, uid, objID)
 		return
 	}
+	// 级联删除子任务、解除依赖并重新汇总父任务
+	if err := d.tasks().DeleteSubtasks(ctx, uid, id); err != nil {
+		observability.CtxLog(r.Context(), "DeleteTask subtasks of %s error: %v", id, err)
+	}
+	if err := d.tasks().DetachDependents(ctx, uid, id); err != nil {
+		observability.CtxLog(r.Context(), "DeleteTask detach dependents of %s error: %v", id, err)
+	}
+	if existing.ParentID != nil {
+		if err := d.tasks().Rollup(ctx, uid, *existing.ParentID); err != nil {
+			observability.CtxLog(r.Context(), "DeleteTask rollup parent %s error: %v", *existing.ParentID, err)
+		}
+	}
+	publishChange(d.Hub, uid, notifications.TopicTasks, notifications.ActionDeleted, id, nil)
 	JSON(w, 200, map[string]string{"msg": "Task removed"})
 }
 
@@ -392,6 +762,10 @@ func (d *TaskDeps) ImportTasks(w http.ResponseWriter, r *http.Request) {
 	col := d.DB.Collection("tasks")
 	imported := 0
 	var errorsArr []map[string]any
+	// 导入后任务 ID 会变化：记录旧 ID -> 新 ID，全部插入后再恢复父子关系
+	newIDs := map[string]string{}
+	parents := map[string]string{}
+	series := map[string]map[string]string{} // 循环任务实例间的关联
 	for i, t := range body.Tasks {
 		title, _ := t["title"].(string)
 		if strings.TrimSpace(title) == "" {
@@ -419,15 +793,351 @@ func (d *TaskDeps) ImportTasks(w http.ResponseWriter, r *http.Request) {
 			"createdAt":     now,
 			"updatedAt":     now,
 		}
-		if _, err := col.InsertOne(ctx, doc); err != nil {
+		if checklist := importChecklist(t["checklist"], now); len(checklist) > 0 {
+			p, _ := models.RollupProgress(nil, checklist)
+			doc["checklist"] = checklist
+			doc["progress"] = p
+		}
+		if auto, _ := t["autoComplete"].(bool); auto {
+			doc["autoComplete"] = true
+		}
+		if raw, ok := t["tags"].([]any); ok {
+			var tags []string
+			for _, v := range raw {
+				if tag, ok := v.(string); ok {
+					tags = append(tags, tag)
+				}
+			}
+			if tags, err := services.NormalizeTags(tags); err == nil && len(tags) > 0 {
+				doc["tags"] = tags
+			}
+		}
+		// 自定义字段按当前用户的个人字段定义导入，未定义或不合法的取值丢弃
+		if raw, ok := t["customFields"].(map[string]any); ok && len(raw) > 0 {
+			fields, err := d.tasks().ResolveCustomFields(ctx, &models.Task{CreatedBy: uid}, nil, raw, false)
+			if err == nil && fields != nil {
+				doc["customFields"] = fields
+			}
+		}
+		if rule, _ := t["recurrence"].(string); rule != "" {
+			if recurrence, err := services.NormalizeRecurrence(rule); err == nil {
+				doc["recurrence"] = recurrence
+				if start, _ := t["recurrenceStart"].(string); start != "" {
+					doc["recurrenceStart"] = parseDate(&start)
+				}
+			}
+		}
+		res, err := col.InsertOne(ctx, doc)
+		if err != nil {
 			errorsArr = append(errorsArr, map[string]any{"index": i, "error": err.Error()})
 			continue
 		}
 		imported++
+		newID := res.InsertedID.(primitive.ObjectID).Hex()
+		if oldID, _ := t["_id"].(string); oldID != "" {
+			newIDs[oldID] = newID
+		}
+		if parentID, _ := t["parentId"].(string); parentID != "" {
+			parents[newID] = parentID
+		}
+		for _, key := range []string{"seriesId", "previousId", "nextId"} {
+			if old, _ := t[key].(string); old != "" {
+				if series[newID] == nil {
+					series[newID] = map[string]string{}
+				}
+				series[newID][key] = old
+			}
+		}
+	}
+	rollup := map[string]bool{}
+	for childID, oldParent := range parents {
+		parentID, ok := newIDs[oldParent]
+		if !ok {
+			continue // 父任务不在本次导入中，作为顶层任务
+		}
+		childObj, _ := primitive.ObjectIDFromHex(childID)
+		if _, err := col.UpdateOne(ctx, bson.M{"_id": childObj, "createdBy": uid}, bson.M{"$set": bson.M{"parentId": parentID}}); err == nil {
+			rollup[parentID] = true
+		}
+	}
+	for taskID, links := range series {
+		set := bson.M{}
+		for key, old := range links {
+			if id, ok := newIDs[old]; ok {
+				set[key] = id
+			}
+		}
+		if len(set) == 0 {
+			continue // 关联的实例不在本次导入中
+		}
+		taskObj, _ := primitive.ObjectIDFromHex(taskID)
+		if _, err := col.UpdateOne(ctx, bson.M{"_id": taskObj, "createdBy": uid}, bson.M{"$set": set}); err != nil {
+			observability.CtxLog(r.Context(), "ImportTasks relink series of %s error: %v", taskID, err)
+		}
+	}
+	for parentID := range rollup {
+		if err := d.tasks().Rollup(ctx, uid, parentID); err != nil {
+			observability.CtxLog(r.Context(), "ImportTasks rollup %s error: %v", parentID, err)
+		}
 	}
 	JSON(w, 200, map[string]any{"msg": "Imported tasks", "imported": imported, "errors": errorsArr})
 }
 
+// AddChecklistItem 追加检查项
+// @Summary 追加检查项
+// @Tags 任务管理
+// @Accept json
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param item body checklistItemRequest true "检查项 (text 必填)"
+// @Success 200 {object} map[string]interface{} "检查项与进度"
+// @Router /api/tasks/{id}/checklist [post]
+func (d *TaskDeps) AddChecklistItem(w http.ResponseWriter, r *http.Request) {
+	d.editChecklist(w, r, func(ctx context.Context, svc *services.TaskService, uid, id string, req checklistItemRequest) (*models.Task, error) {
+		var text string
+		if req.Text != nil {
+			text = *req.Text
+		}
+		return svc.AddChecklistItem(ctx, uid, id, text)
+	})
+}
+
+// UpdateChecklistItem 修改检查项文本或勾选状态
+// @Summary 修改检查项
+// @Tags 任务管理
+// @Accept json
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param itemId path string true "检查项ID"
+// @Param item body checklistItemRequest true "text / done"
+// @Success 200 {object} map[string]interface{} "检查项与进度"
+// @Router /api/tasks/{id}/checklist/{itemId} [patch]
+func (d *TaskDeps) UpdateChecklistItem(w http.ResponseWriter, r *http.Request) {
+	d.editChecklist(w, r, func(ctx context.Context, svc *services.TaskService, uid, id string, req checklistItemRequest) (*models.Task, error) {
+		return svc.UpdateChecklistItem(ctx, uid, id, muxVar(r, "itemId"), req.Text, req.Done)
+	})
+}
+
+// RemoveChecklistItem 删除检查项
+// @Summary 删除检查项
+// @Tags 任务管理
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param itemId path string true "检查项ID"
+// @Success 200 {object} map[string]interface{} "检查项与进度"
+// @Router /api/tasks/{id}/checklist/{itemId} [delete]
+func (d *TaskDeps) RemoveChecklistItem(w http.ResponseWriter, r *http.Request) {
+	d.editChecklist(w, r, func(ctx context.Context, svc *services.TaskService, uid, id string, _ checklistItemRequest) (*models.Task, error) {
+		return svc.RemoveChecklistItem(ctx, uid, id, muxVar(r, "itemId"))
+	})
+}
+
+// editChecklist 检查项接口公共流程：返回检查项、进度与状态 (可能因自动完成变化)
+func (d *TaskDeps) editChecklist(w http.ResponseWriter, r *http.Request, edit func(ctx context.Context, svc *services.TaskService, uid, id string, req checklistItemRequest) (*models.Task, error)) {
+	uid := GetUserID(r)
+	if uid == "" {
+		JSON(w, 401, map[string]string{"msg": "Unauthorized"})
+		return
+	}
+	id := muxVar(r, "id")
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		JSON(w, 404, map[string]string{"msg": "Task not found"})
+		return
+	}
+	var req checklistItemRequest
+	if r.Method != http.MethodDelete {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			JSON(w, 400, map[string]string{"msg": "Invalid body"})
+			return
+		}
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	t, err := edit(ctx, d.tasks(), uid, id, req)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		JSON(w, 404, map[string]string{"msg": "Task not found"})
+		return
+	case errors.Is(err, repository.ErrWorkspaceForbidden):
+		JSON(w, 403, map[string]string{"msg": "Workspace permission denied"})
+		return
+	case errors.Is(err, services.ErrChecklistItemNotFound):
+		JSON(w, 404, map[string]string{"msg": "Checklist item not found"})
+		return
+	case errors.Is(err, services.ErrChecklistTextRequired):
+		JSON(w, 400, map[string]string{"msg": "Text is required"})
+		return
+	case err != nil:
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	out := map[string]interface{}{"_id": id, "checklist": t.Checklist, "progress": t.Progress, "status": t.Status}
+	publishChange(d.Hub, uid, notifications.TopicTasks, notifications.ActionUpdated, id, out)
+	JSON(w, 200, out)
+}
+
+// GetTaskHistory 循环任务历史
+// @Summary 获取循环任务历史
+// @Description 返回任务所在循环系列的全部实例 (按创建时间排序)，实例间通过 previousId / nextId 关联；非循环任务只返回自身
+// @Tags 任务管理
+// @Produce json
+// @Param id path string true "任务ID"
+// @Success 200 {object} map[string]interface{} "seriesId / tasks"
+// @Failure 404 {object} map[string]string "任务不存在"
+// @Router /api/tasks/{id}/history [get]
+func (d *TaskDeps) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	uid := GetUserID(r)
+	if uid == "" {
+		JSON(w, 401, map[string]string{"msg": "Unauthorized"})
+		return
+	}
+	id := muxVar(r, "id")
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	history, err := d.tasks().History(ctx, uid, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		JSON(w, 404, map[string]string{"msg": "Task not found"})
+		return
+	}
+	if err != nil {
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	seriesID := ""
+	if len(history) > 0 {
+		seriesID = history[0].SeriesID
+	}
+	JSON(w, 200, map[string]interface{}{"seriesId": seriesID, "tasks": nonNilTasks(history)})
+}
+
+// ListDependencies 任务依赖
+// @Summary 获取任务依赖
+// @Description blockedBy 为阻塞本任务的任务，blocks 为被本任务阻塞的任务
+// @Tags 任务管理
+// @Produce json
+// @Param id path string true "任务ID"
+// @Success 200 {object} map[string]interface{} "blocked / blockedBy / blocks"
+// @Router /api/tasks/{id}/dependencies [get]
+func (d *TaskDeps) ListDependencies(w http.ResponseWriter, r *http.Request) {
+	uid := GetUserID(r)
+	if uid == "" {
+		JSON(w, 401, map[string]string{"msg": "Unauthorized"})
+		return
+	}
+	id := muxVar(r, "id")
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	svc := d.tasks()
+	blockedBy, blocks, err := svc.Dependencies(ctx, uid, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		JSON(w, 404, map[string]string{"msg": "Task not found"})
+		return
+	}
+	if err != nil {
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	blocked := false
+	for _, b := range blockedBy {
+		blocked = blocked || !models.IsTaskDone(b.Status)
+	}
+	JSON(w, 200, map[string]interface{}{"blocked": blocked, "blockedBy": nonNilTasks(blockedBy), "blocks": nonNilTasks(blocks)})
+}
+
+// AddDependency 添加依赖：blockerId 完成前本任务处于阻塞状态
+// @Summary 添加任务依赖
+// @Tags 任务管理
+// @Accept json
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param body body dependencyRequest true "阻塞任务ID"
+// @Success 200 {object} map[string]interface{} "更新后的依赖状态"
+// @Failure 400 {object} map[string]string "阻塞任务不存在"
+// @Failure 409 {object} map[string]string "形成循环依赖"
+// @Router /api/tasks/{id}/dependencies [post]
+func (d *TaskDeps) AddDependency(w http.ResponseWriter, r *http.Request) {
+	var req dependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.BlockerID == "" {
+		JSON(w, 400, map[string]string{"msg": "blockerId is required"})
+		return
+	}
+	d.editDependency(w, r, func(ctx context.Context, svc *services.TaskService, uid, id string) (*models.Task, error) {
+		return svc.AddDependency(ctx, uid, id, req.BlockerID)
+	})
+}
+
+// RemoveDependency 移除依赖
+// @Summary 移除任务依赖
+// @Tags 任务管理
+// @Produce json
+// @Param id path string true "任务ID"
+// @Param blockerId path string true "阻塞任务ID"
+// @Success 200 {object} map[string]interface{} "更新后的依赖状态"
+// @Router /api/tasks/{id}/dependencies/{blockerId} [delete]
+func (d *TaskDeps) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	d.editDependency(w, r, func(ctx context.Context, svc *services.TaskService, uid, id string) (*models.Task, error) {
+		return svc.RemoveDependency(ctx, uid, id, muxVar(r, "blockerId"))
+	})
+}
+
+func (d *TaskDeps) editDependency(w http.ResponseWriter, r *http.Request, edit func(ctx context.Context, svc *services.TaskService, uid, id string) (*models.Task, error)) {
+	uid := GetUserID(r)
+	if uid == "" {
+		JSON(w, 401, map[string]string{"msg": "Unauthorized"})
+		return
+	}
+	id := muxVar(r, "id")
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	t, err := edit(ctx, d.tasks(), uid, id)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		JSON(w, 404, map[string]string{"msg": "Task not found"})
+		return
+	case errors.Is(err, repository.ErrWorkspaceForbidden):
+		JSON(w, 403, map[string]string{"msg": "Workspace permission denied"})
+		return
+	case errors.Is(err, services.ErrInvalidDependency):
+		JSON(w, 400, map[string]string{"msg": "Invalid dependency"})
+		return
+	case errors.Is(err, services.ErrDependencyCycle):
+		JSON(w, 409, map[string]string{"msg": "Dependency would create a cycle"})
+		return
+	case err != nil:
+		JSON(w, 500, map[string]string{"msg": "DB error"})
+		return
+	}
+	out := map[string]interface{}{"_id": id, "blockedBy": t.BlockedBy, "blocked": t.Blocked}
+	publishChange(d.Hub, uid, notifications.TopicTasks, notifications.ActionUpdated, id, out)
+	JSON(w, 200, out)
+}
+
+func nonNilTasks(ts []models.Task) []models.Task {
+	if ts == nil {
+		return []models.Task{}
+	}
+	return ts
+}
+
+// importChecklist 解析备份文件中的检查项
+func importChecklist(v any, now time.Time) []models.ChecklistItem {
+	raw, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	items := make([]models.ChecklistItem, 0, len(raw))
+	for _, it := range raw {
+		m, ok := it.(map[string]any)
+		if !ok {
+			continue
+		}
+		text, _ := m["text"].(string)
+		done, _ := m["done"].(bool)
+		items = append(items, models.ChecklistItem{Text: text, Done: done})
+	}
+	return services.NormalizeChecklist(items, now)
+}
+
 // Helper utilities
 func muxVar(r *http.Request, key string) string { return mux.Vars(r)[key] }
 
@@ -435,10 +1145,6 @@ func optionsFindOneAndUpdateReturnAfter() *options.FindOneAndUpdateOptions {
 	return &options.FindOneAndUpdateOptions{ReturnDocument: func(rd options.ReturnDocument) *options.ReturnDocument { v := options.After; return &v }(options.After)}
 }
 
-func optionsFindSortCreatedAtDesc() *options.FindOptions {
-	return &options.FindOptions{Sort: bson.D{{Key: "createdAt", Value: -1}}}
-}
-
 func SetupTaskRoutes(r *mux.Router, deps *TaskDeps) {
 	s := r.PathPrefix("/api/tasks").Subrouter()
 	s.Handle("", Auth(http.HandlerFunc(deps.ListTasks))).Methods(http.MethodGet)
@@ -448,4 +1154,11 @@ func SetupTaskRoutes(r *mux.Router, deps *TaskDeps) {
 	s.Handle("/{id}", Auth(http.HandlerFunc(deps.GetTask))).Methods(http.MethodGet)
 	s.Handle("/{id}", Auth(http.HandlerFunc(deps.UpdateTask))).Methods(http.MethodPut)
 	s.Handle("/{id}", Auth(http.HandlerFunc(deps.DeleteTask))).Methods(http.MethodDelete)
+	s.Handle("/{id}/checklist", Auth(http.HandlerFunc(deps.AddChecklistItem))).Methods(http.MethodPost)
+	s.Handle("/{id}/checklist/{itemId}", Auth(http.HandlerFunc(deps.UpdateChecklistItem))).Methods(http.MethodPatch)
+	s.Handle("/{id}/checklist/{itemId}", Auth(http.HandlerFunc(deps.RemoveChecklistItem))).Methods(http.MethodDelete)
+	s.Handle("/{id}/history", Auth(http.HandlerFunc(deps.GetTaskHistory))).Methods(http.MethodGet)
+	s.Handle("/{id}/dependencies", Auth(http.HandlerFunc(deps.ListDependencies))).Methods(http.MethodGet)
+	s.Handle("/{id}/dependencies", Auth(http.HandlerFunc(deps.AddDependency))).Methods(http.MethodPost)
+	s.Handle("/{id}/dependencies/{blockerId}", Auth(http.HandlerFunc(deps.RemoveDependency))).Methods(http.MethodDelete)
 }
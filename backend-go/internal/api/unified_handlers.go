@@ -16,7 +16,7 @@ import (
 
 type UnifiedDeps struct{ DB *mongo.Database }
 
-// GetUpcomingUnified 获取统一聚合
+// GetUpcomingUnified 获取统一聚合，q (过滤表达式) / saved_view (保存的视图 ID) 只筛选任务
 func (d *UnifiedDeps) GetUpcomingUnified(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value("userID").(string)
 	if !ok {
@@ -30,12 +30,18 @@ func (d *UnifiedDeps) GetUpcomingUnified(w http.ResponseWriter, r *http.Request)
 	}
 
 	parsed := parseUpcomingParams(r.URL.Query())
+	// q / saved_view 只筛选任务项
+	taskMatch, err := taskMatchParams(r.Context(), d.DB, objectID, r.URL.Query())
+	if err != nil {
+		http.Error(w, "Invalid task filter: "+err.Error(), savedViewStatus(err))
+		return
+	}
 	svc := services.NewUnifiedService(d.DB)
 	baseCtx := context.Background()
 	if r.URL.Query().Get("debug") == "1" {
 		baseCtx = context.WithValue(baseCtx, "unifiedDebug", true)
 	}
-	items, debugInfo, err := svc.GetUpcoming(baseCtx, objectID, parsed.Hours, parsed.Sources, parsed.Limit)
+	items, debugInfo, err := svc.GetUpcomingFiltered(baseCtx, objectID, parsed.Hours, parsed.Sources, parsed.Limit, taskMatch)
 	if err != nil {
 		http.Error(w, "Failed to get unified items: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+)
+
+// WorkspaceDeps 共享工作区与成员管理
+type WorkspaceDeps struct{ DB *mongo.Database }
+
+func (d *WorkspaceDeps) svc() *services.WorkspaceService {
+	return services.NewWorkspaceService(repository.NewWorkspaceRepository(d.DB), repository.NewUserRepository(d.DB))
+}
+
+// workspaceStatus 工作区错误对应的 HTTP 状态码，非工作区错误返回 0
+func workspaceStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrWorkspaceNotFound), errors.Is(err, services.ErrWorkspaceMemberNotFound), errors.Is(err, repository.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrWorkspaceForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrWorkspaceNameRequired), errors.Is(err, services.ErrInvalidWorkspaceRole):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrWorkspaceMemberExists), errors.Is(err, services.ErrWorkspaceLastOwner), errors.Is(err, repository.ErrWorkspaceMembersChanged):
+		return http.StatusConflict
+	}
+	return 0
+}
+
+func writeWorkspaceError(w http.ResponseWriter, code string, err error) {
+	status := workspaceStatus(err)
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	writeJSONError(w, status, code, err.Error())
+}
+
+// parseWorkspaceScope 解析当前用户与路径中的工作区 ID，失败时已写响应
+func parseWorkspaceScope(w http.ResponseWriter, r *http.Request) (primitive.ObjectID, primitive.ObjectID, bool) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(r)["id"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "workspace_id", "Invalid workspace ID")
+		return primitive.NilObjectID, primitive.NilObjectID, false
+	}
+	return uid, id, true
+}
+
+// CreateWorkspace 创建工作区，创建者为 owner
+// POST /api/workspaces {"name":"Team","description":"..."}
+func (d *WorkspaceDeps) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	var req models.CreateWorkspaceRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	ws, err := d.svc().Create(r.Context(), uid, req)
+	if err != nil {
+		writeWorkspaceError(w, "create_workspace", err)
+		return
+	}
+	JSON(w, http.StatusCreated, ws)
+}
+
+// ListWorkspaces 当前用户所属的工作区
+// GET /api/workspaces
+func (d *WorkspaceDeps) ListWorkspaces(w http.ResponseWriter, r *http.Request) {
+	uid, ok := feedUser(w, r)
+	if !ok {
+		return
+	}
+	list, err := d.svc().List(r.Context(), uid)
+	if err != nil {
+		writeWorkspaceError(w, "list_workspaces", err)
+		return
+	}
+	JSON(w, http.StatusOK, map[string]interface{}{"workspaces": list})
+}
+
+// GetWorkspace 工作区详情 (含成员)
+// GET /api/workspaces/{id}
+func (d *WorkspaceDeps) GetWorkspace(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseWorkspaceScope(w, r)
+	if !ok {
+		return
+	}
+	ws, err := d.svc().Get(r.Context(), uid, id)
+	if err != nil {
+		writeWorkspaceError(w, "get_workspace", err)
+		return
+	}
+	JSON(w, http.StatusOK, ws)
+}
+
+// UpdateWorkspace 修改名称 / 描述 (owner)
+// PUT /api/workspaces/{id}
+func (d *WorkspaceDeps) UpdateWorkspace(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseWorkspaceScope(w, r)
+	if !ok {
+		return
+	}
+	var req models.UpdateWorkspaceRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	ws, err := d.svc().Update(r.Context(), uid, id, req)
+	if err != nil {
+		writeWorkspaceError(w, "update_workspace", err)
+		return
+	}
+	JSON(w, http.StatusOK, ws)
+}
+
+// DeleteWorkspace 删除工作区 (owner)，其中的任务 / 事件 / 提醒转为创建者的个人数据
+// DELETE /api/workspaces/{id}
+func (d *WorkspaceDeps) DeleteWorkspace(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseWorkspaceScope(w, r)
+	if !ok {
+		return
+	}
+	if err := d.svc().Delete(r.Context(), uid, id); err != nil {
+		writeWorkspaceError(w, "delete_workspace", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddWorkspaceMember 添加成员 (owner)，user 为用户 ID、用户名或邮箱
+// POST /api/workspaces/{id}/members {"user":"alice@example.com","role":"editor"}
+func (d *WorkspaceDeps) AddWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseWorkspaceScope(w, r)
+	if !ok {
+		return
+	}
+	var req models.WorkspaceMemberRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	ws, err := d.svc().AddMember(r.Context(), uid, id, req)
+	if err != nil {
+		writeWorkspaceError(w, "add_member", err)
+		return
+	}
+	JSON(w, http.StatusOK, ws)
+}
+
+// UpdateWorkspaceMember 修改成员角色 (owner)
+// PUT /api/workspaces/{id}/members/{userId} {"role":"viewer"}
+func (d *WorkspaceDeps) UpdateWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseWorkspaceScope(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := primitive.ObjectIDFromHex(mux.Vars(r)["userId"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "user_id", "Invalid member ID")
+		return
+	}
+	var req models.WorkspaceMemberRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<14)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "decode", "Invalid JSON")
+		return
+	}
+	ws, err := d.svc().UpdateMember(r.Context(), uid, id, memberID, req.Role)
+	if err != nil {
+		writeWorkspaceError(w, "update_member", err)
+		return
+	}
+	JSON(w, http.StatusOK, ws)
+}
+
+// RemoveWorkspaceMember 移除成员 (owner) 或退出工作区 (成员本人)
+// DELETE /api/workspaces/{id}/members/{userId}
+func (d *WorkspaceDeps) RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	uid, id, ok := parseWorkspaceScope(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := primitive.ObjectIDFromHex(mux.Vars(r)["userId"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "user_id", "Invalid member ID")
+		return
+	}
+	ws, err := d.svc().RemoveMember(r.Context(), uid, id, memberID)
+	if err != nil {
+		writeWorkspaceError(w, "remove_member", err)
+		return
+	}
+	JSON(w, http.StatusOK, ws)
+}
+
+func SetupWorkspaceRoutes(r *mux.Router, deps *WorkspaceDeps) {
+	s := r.PathPrefix("/api/workspaces").Subrouter()
+	s.Handle("", Auth(http.HandlerFunc(deps.ListWorkspaces))).Methods(http.MethodGet)
+	s.Handle("", Auth(http.HandlerFunc(deps.CreateWorkspace))).Methods(http.MethodPost)
+	s.Handle("/{id:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.GetWorkspace))).Methods(http.MethodGet)
+	s.Handle("/{id:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.UpdateWorkspace))).Methods(http.MethodPut)
+	s.Handle("/{id:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.DeleteWorkspace))).Methods(http.MethodDelete)
+	s.Handle("/{id:[0-9a-fA-F]{24}}/members", Auth(http.HandlerFunc(deps.AddWorkspaceMember))).Methods(http.MethodPost)
+	s.Handle("/{id:[0-9a-fA-F]{24}}/members/{userId:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.UpdateWorkspaceMember))).Methods(http.MethodPut)
+	s.Handle("/{id:[0-9a-fA-F]{24}}/members/{userId:[0-9a-fA-F]{24}}", Auth(http.HandlerFunc(deps.RemoveWorkspaceMember))).Methods(http.MethodDelete)
+}
@@ -24,6 +24,7 @@ func UserToProto(user *models.User) *pb.User {
 		Email:     user.Email,
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.CreatedAt), // 使用 CreatedAt 因为模型中没有 UpdatedAt
+		TimeZone:  user.TimeZone,
 	}
 }
 
@@ -142,10 +143,86 @@ func TaskToProto(task *models.Task) *pb.Task {
 			}
 			return ""
 		}(),
-		Comments: taskCommentsToProto(task.Comments),
+		Comments:        taskCommentsToProto(task.Comments),
+		ParentId:        stringOrEmpty(task.ParentID),
+		Checklist:       ChecklistToProto(task.Checklist),
+		AutoComplete:    task.AutoComplete,
+		Progress:        taskProgressToProto(task.Progress),
+		SubtaskDeadline: tsOrNil(task.SubtaskDeadline),
+		Subtasks:        tasksToProto(task.Subtasks),
+		BlockedBy:       task.BlockedBy,
+		Blocked:         task.Blocked,
+		UnblockedAt:     tsOrNil(task.UnblockedAt),
+		Recurrence:      task.Recurrence,
+		SeriesId:        task.SeriesID,
+		PreviousId:      task.PreviousID,
+		NextId:          task.NextID,
+		WorkspaceId:     task.WorkspaceID,
+		Tags:            task.Tags,
+		CustomFields:    customFieldsToProto(task.CustomFields),
 	}
 }
 
+// customFieldsToProto 自定义字段取值转为字符串 (数字为十进制文本，日期为 RFC3339)
+func customFieldsToProto(fields map[string]interface{}) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(fields))
+	for k, v := range fields {
+		out[k] = models.FormatCustomFieldValue(v)
+	}
+	return out
+}
+
+func tasksToProto(ts []models.Task) []*pb.Task {
+	if len(ts) == 0 {
+		return nil
+	}
+	out := make([]*pb.Task, 0, len(ts))
+	for i := range ts {
+		out = append(out, TaskToProto(&ts[i]))
+	}
+	return out
+}
+
+func taskProgressToProto(p *models.TaskProgress) *pb.TaskProgress {
+	if p == nil {
+		return nil
+	}
+	return &pb.TaskProgress{Done: int32(p.Done), Total: int32(p.Total)}
+}
+
+// ChecklistToProto 检查项 (model -> proto)
+func ChecklistToProto(items []models.ChecklistItem) []*pb.ChecklistItem {
+	out := make([]*pb.ChecklistItem, 0, len(items))
+	for _, it := range items {
+		out = append(out, &pb.ChecklistItem{Id: it.ID, Text: it.Text, Done: it.Done, DoneAt: tsOrNil(it.DoneAt)})
+	}
+	return out
+}
+
+// ProtoToChecklist 检查项 (proto -> model)，ID / 完成时间由服务层补全
+func ProtoToChecklist(items []*pb.ChecklistItem) []models.ChecklistItem {
+	out := make([]models.ChecklistItem, 0, len(items))
+	for _, it := range items {
+		ci := models.ChecklistItem{ID: it.GetId(), Text: it.GetText(), Done: it.GetDone()}
+		if it.GetDoneAt() != nil {
+			at := it.GetDoneAt().AsTime()
+			ci.DoneAt = &at
+		}
+		out = append(out, ci)
+	}
+	return out
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // ReportTypeToProto 将内部报表类型转换为 protobuf 类型
 func ReportTypeToProto(reportType string) pb.ReportType {
 	switch reportType {
@@ -250,6 +327,14 @@ func ProtoToTask(pbTask *pb.Task) *models.Task {
 		Priority:    ProtoToTaskPriority(pbTask.Priority),
 		CreatedBy:   pbTask.UserId,
 		Comments:    protoTaskCommentsToModel(pbTask.Comments),
+		WorkspaceID: pbTask.WorkspaceId,
+		Tags:        pbTask.Tags,
+	}
+	if len(pbTask.CustomFields) > 0 { // 字符串取值，由 TaskService 按字段定义转换
+		m.CustomFields = make(map[string]interface{}, len(pbTask.CustomFields))
+		for k, v := range pbTask.CustomFields {
+			m.CustomFields[k] = v
+		}
 	}
 	if pbTask.Deadline != nil {
 		d := pbTask.Deadline.AsTime()
@@ -391,6 +476,10 @@ func EventToProto(e *models.Event) *pb.Event {
 	for k, v := range e.RecurrenceConfig {
 		cfg[k] = stringify(v)
 	}
+	var occ *timestamppb.Timestamp
+	if e.OccurrenceDate != nil {
+		occ = timestamppb.New(*e.OccurrenceDate)
+	}
 	return &pb.Event{
 		Id: e.ID.Hex(), UserId: e.UserID.Hex(), Title: e.Title, Description: e.Description,
 		EventType: EventTypeToProto(e.EventType), EventDate: timestamppb.New(e.EventDate),
@@ -398,6 +487,8 @@ func EventToProto(e *models.Event) *pb.Event {
 		ImportanceLevel: int32(e.ImportanceLevel), Tags: e.Tags, Location: e.Location,
 		IsAllDay: e.IsAllDay, CreatedAt: timestamppb.New(e.CreatedAt), UpdatedAt: timestamppb.New(e.UpdatedAt),
 		IsActive: e.IsActive, LastTriggeredAt: last,
+		OccurrenceDate: occ, OccurrenceKey: e.OccurrenceKey, TimeZone: e.TimeZone, IcalUid: e.ICalUID,
+		WorkspaceId: hexOrEmpty(e.WorkspaceID),
 	}
 }
 
@@ -416,6 +507,11 @@ func ProtoToEvent(p *pb.Event) *models.Event {
 	for k, v := range p.RecurrenceConfig {
 		cfg[k] = v
 	}
+	var occ *time.Time
+	if p.OccurrenceDate != nil {
+		t := p.OccurrenceDate.AsTime()
+		occ = &t
+	}
 	return &models.Event{
 		ID: id, UserID: uid, Title: p.Title, Description: p.Description,
 		EventType: ProtoToEventType(p.EventType), EventDate: p.EventDate.AsTime(),
@@ -423,17 +519,39 @@ func ProtoToEvent(p *pb.Event) *models.Event {
 		ImportanceLevel: int(p.ImportanceLevel), Tags: p.Tags, Location: p.Location,
 		IsAllDay: p.IsAllDay, CreatedAt: p.CreatedAt.AsTime(), UpdatedAt: p.UpdatedAt.AsTime(),
 		IsActive: p.IsActive, LastTriggeredAt: lt,
+		OccurrenceDate: occ, OccurrenceKey: p.OccurrenceKey, TimeZone: p.TimeZone, ICalUID: p.IcalUid,
+		WorkspaceID: ProtoToObjectIDPtr(p.WorkspaceId),
 	}
 }
 
-// ReminderType <-> Proto
-func ReminderTypeToProto(t string) pb.ReminderType {
-	switch t {
-	case "app":
+// EventImportResultToProto .ics 导入报告
+func EventImportResultToProto(r *models.EventImportResult) *pb.ImportEventsResponse {
+	if r == nil {
+		return nil
+	}
+	out := &pb.ImportEventsResponse{
+		Total: int32(r.Total), Created: int32(r.Created), Updated: int32(r.Updated),
+		Skipped: int32(r.Skipped), Failed: int32(r.Failed),
+		Items: make([]*pb.EventImportItem, 0, len(r.Items)),
+	}
+	for _, it := range r.Items {
+		out.Items = append(out.Items, &pb.EventImportItem{
+			Index: int32(it.Index), Uid: it.UID, Title: it.Title, Status: it.Status,
+			EventId: it.EventID, Reminders: int32(it.Reminders), Error: it.Error, Warnings: it.Warnings,
+		})
+	}
+	return out
+}
+
+// ReminderType <-> Proto (枚举只能表达 app / email 组合，完整渠道列表见 channels 字段)
+func ReminderTypeToProto(c models.ReminderChannels) pb.ReminderType {
+	c = c.Normalize()
+	switch {
+	case len(c) == 1 && c[0] == "app":
 		return pb.ReminderType_REMINDER_TYPE_APP
-	case "email":
+	case len(c) == 1 && c[0] == "email":
 		return pb.ReminderType_REMINDER_TYPE_EMAIL
-	case "both":
+	case len(c) == 2 && c.Has("app") && c.Has("email"):
 		return pb.ReminderType_REMINDER_TYPE_BOTH
 	default:
 		return pb.ReminderType_REMINDER_TYPE_UNSPECIFIED
@@ -452,6 +570,14 @@ func ProtoToReminderType(t pb.ReminderType) string {
 	}
 }
 
+// ProtoToReminderChannels channels 非空时优先，否则按 reminder_type 枚举
+func ProtoToReminderChannels(t pb.ReminderType, channels []string) models.ReminderChannels {
+	if len(channels) > 0 {
+		return models.ReminderChannels(channels).Normalize()
+	}
+	return models.ParseReminderChannels(ProtoToReminderType(t))
+}
+
 // Reminder conversions
 func ReminderToProto(r *models.Reminder) *pb.Reminder {
 	if r == nil {
@@ -470,7 +596,47 @@ func ReminderToProto(r *models.Reminder) *pb.Reminder {
 	}
 	return &pb.Reminder{Id: r.ID.Hex(), EventId: r.EventID.Hex(), UserId: r.UserID.Hex(), AdvanceDays: int32(r.AdvanceDays),
 		ReminderTimes: r.ReminderTimes, AbsoluteTimes: abs, ReminderType: ReminderTypeToProto(r.ReminderType),
-		CustomMessage: r.CustomMessage, IsActive: r.IsActive, LastSent: last, NextSend: next, CreatedAt: timestamppb.New(r.CreatedAt), UpdatedAt: timestamppb.New(r.UpdatedAt)}
+		CustomMessage: r.CustomMessage, IsActive: r.IsActive, LastSent: last, NextSend: next, CreatedAt: timestamppb.New(r.CreatedAt), UpdatedAt: timestamppb.New(r.UpdatedAt),
+		DeliveryAttempts: int32(r.DeliveryAttempts), LastError: r.LastError, DeadLetterAt: tsOrNil(r.DeadLetterAt), Channels: r.Channels(),
+		WorkspaceId: hexOrEmpty(r.WorkspaceID)}
+}
+
+// WorkspaceToProto 工作区及成员
+func WorkspaceToProto(w *models.Workspace) *pb.Workspace {
+	if w == nil {
+		return nil
+	}
+	members := make([]*pb.WorkspaceMember, 0, len(w.Members))
+	for _, m := range w.Members {
+		members = append(members, &pb.WorkspaceMember{UserId: m.UserID.Hex(), Role: m.Role, AddedAt: timestamppb.New(m.AddedAt)})
+	}
+	return &pb.Workspace{Id: w.ID.Hex(), Name: w.Name, Description: w.Description, OwnerId: w.OwnerID.Hex(), Members: members,
+		CreatedAt: timestamppb.New(w.CreatedAt), UpdatedAt: timestamppb.New(w.UpdatedAt)}
+}
+
+// ProtoToObjectIDPtr 空串或非法 ID 返回 nil
+func ProtoToObjectIDPtr(s string) *primitive.ObjectID {
+	id, err := primitive.ObjectIDFromHex(s)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+func hexOrEmpty(id *primitive.ObjectID) string {
+	if id == nil {
+		return ""
+	}
+	return id.Hex()
+}
+
+func ReminderDeliveryToProto(d *models.ReminderDelivery) *pb.ReminderDelivery {
+	if d == nil {
+		return nil
+	}
+	return &pb.ReminderDelivery{Id: d.ID.Hex(), ReminderId: d.ReminderID.Hex(), EventId: d.EventID.Hex(), Channel: d.Channel,
+		Status: d.Status, Error: d.Error, Attempt: int32(d.Attempt), LatencyMs: d.LatencyMs, ScheduledFor: tsOrNil(d.ScheduledFor),
+		Instance: d.Instance, CreatedAt: timestamppb.New(d.CreatedAt)}
 }
 
 // Notification conversions
@@ -490,7 +656,8 @@ func NotificationToProto(n *models.Notification) *pb.Notification {
 	if n.EventID != nil {
 		eventID = n.EventID.Hex()
 	}
-	return &pb.Notification{Id: n.ID.Hex(), Type: n.Type, Message: n.Message, EventId: eventID, IsRead: n.ReadAt != nil, ReadAt: readAt, CreatedAt: timestamppb.New(n.CreatedAt), Metadata: meta}
+	return &pb.Notification{Id: n.ID.Hex(), Type: n.Type, Message: n.Message, EventId: eventID, IsRead: n.ReadAt != nil, ReadAt: readAt, CreatedAt: timestamppb.New(n.CreatedAt), Metadata: meta,
+		ArchivedAt: tsOrNil(n.ArchivedAt), GroupKey: n.GroupKey, Count: int32(n.Count), FirstAt: tsOrNil(n.FirstAt)}
 }
 
 func ProtoToNotificationCreate(req *pb.CreateNotificationRequest, user primitive.ObjectID) models.NotificationCreate {
@@ -506,6 +673,14 @@ func ProtoToNotificationCreate(req *pb.CreateNotificationRequest, user primitive
 // stringify helper
 func stringify(v interface{}) string { return fmt.Sprintf("%v", v) }
 
+// tsOrNil 可空时间
+func tsOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
 // TODO: Unified / Dashboard conversions 后续继续添加
 
 // TimeToString 将时间转换为字符串（保持与现有API兼容）
@@ -515,3 +690,13 @@ func TimeToString(t time.Time) string {
 	}
 	return t.Format(time.RFC3339)
 }
+
+// SearchHitToProto 搜索结果
+func SearchHitToProto(h models.SearchHit) *pb.SearchHit {
+	out := &pb.SearchHit{Id: h.ID, Source: h.Source, Title: h.Title, Highlight: h.Highlight, Snippet: h.Snippet, Field: h.Field, Score: h.Score,
+		Status: h.Status, Priority: h.Priority, Tags: h.Tags, EventId: h.EventID, DetailUrl: h.DetailURL}
+	if h.Date != nil {
+		out.Date = timestamppb.New(*h.Date)
+	}
+	return out
+}
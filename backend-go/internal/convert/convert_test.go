@@ -152,3 +152,29 @@ func TestNilInputs(t *testing.T) {
 		t.Error("Expected nil result for nil input")
 	}
 }
+
+func TestTaskTagsAndCustomFields(t *testing.T) {
+	due := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	task := &models.Task{
+		Title:        "t",
+		Tags:         []string{"backend", "urgent"},
+		CustomFields: map[string]interface{}{"estimate": 2.5, "points": float64(3), "due_review": due, "stage": "Review"},
+	}
+	p := TaskToProto(task)
+	if len(p.Tags) != 2 || p.Tags[0] != "backend" {
+		t.Errorf("unexpected tags %v", p.Tags)
+	}
+	want := map[string]string{"estimate": "2.5", "points": "3", "due_review": "2026-03-01T09:30:00Z", "stage": "Review"}
+	for k, v := range want {
+		if p.CustomFields[k] != v {
+			t.Errorf("custom field %s: expected %q, got %q", k, v, p.CustomFields[k])
+		}
+	}
+	back := ProtoToTask(p)
+	if len(back.Tags) != 2 || back.CustomFields["stage"] != "Review" {
+		t.Errorf("unexpected round trip %v %v", back.Tags, back.CustomFields)
+	}
+	if TaskToProto(&models.Task{Title: "empty"}).CustomFields != nil {
+		t.Error("Expected nil custom fields for task without values")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	mail "github.com/go-mail/mail/v2"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/templates"
 )
 
 type Code struct {
@@ -81,7 +83,16 @@ func (s *Store) Cleanup() {
 	})
 }
 
-func Send(to, code string) error {
+// Send 发送验证码邮件，主题与正文按 locale 渲染 verification_* 模板
+func Send(to, code, locale string) error {
+	subject, err := templates.Default.Render(locale, templates.VerificationSubject, nil)
+	if err != nil {
+		return err
+	}
+	body, err := templates.Default.Render(locale, templates.VerificationEmail, templates.VerificationData{Code: code})
+	if err != nil {
+		return err
+	}
 	host := os.Getenv("EMAIL_HOST")
 	user := os.Getenv("EMAIL_USER")
 	pass := os.Getenv("EMAIL_PASS")
@@ -99,8 +110,8 @@ func Send(to, code string) error {
 	}
 	m.SetHeader("From", from)
 	m.SetHeader("To", to)
-	m.SetHeader("Subject", "TodoIng 邮箱验证码")
-	m.SetBody("text/html", fmt.Sprintf("<p>您的验证码: <b>%s</b> (10分钟内有效)</p>", code))
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/html", body)
 	return d.DialAndSend(m)
 }
 
@@ -16,10 +16,11 @@ import (
 type DashboardServiceServer struct {
 	pb.UnimplementedDashboardServiceServer
 	core *services.TaskSortService
+	db   *mongo.Database
 }
 
 func NewDashboardServiceServer(db *mongo.Database) *DashboardServiceServer {
-	return &DashboardServiceServer{core: services.NewTaskSortService(db)}
+	return &DashboardServiceServer{core: services.NewTaskSortService(db), db: db}
 }
 
 func (s *DashboardServiceServer) GetDashboardData(ctx context.Context, req *pb.GetDashboardDataRequest) (*pb.GetDashboardDataResponse, error) {
@@ -43,7 +44,7 @@ func (s *DashboardServiceServer) GetDashboardData(ctx context.Context, req *pb.G
 	priorityTasks := make([]*pb.PriorityTask, 0, len(data.PriorityTasks))
 	for i := range data.PriorityTasks {
 		pt := data.PriorityTasks[i]
-		priorityTasks = append(priorityTasks, &pb.PriorityTask{Task: convert.TaskToProto(&pt.Task), PriorityScore: pt.PriorityScore, DaysLeft: int32(pt.DaysLeft), IsUrgent: pt.IsUrgent})
+		priorityTasks = append(priorityTasks, &pb.PriorityTask{Task: convert.TaskToProto(&pt.Task), PriorityScore: pt.PriorityScore, DaysLeft: int32(pt.DaysLeft), IsUrgent: pt.IsUrgent, NewlyUnblocked: pt.NewlyUnblocked})
 	}
 	pendingReminders := make([]*pb.UpcomingReminder, 0, len(data.PendingReminders))
 	for i := range data.PendingReminders {
@@ -64,14 +65,18 @@ func (s *DashboardServiceServer) GetPriorityTasks(ctx context.Context, req *pb.G
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "bad user id")
 	}
-	list, err := s.core.GetPriorityTasks(ctx, userObj)
+	match, err := savedViewMatch(ctx, s.db, userObj, req.GetSavedViewId(), req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.core.GetPriorityTasksMatching(ctx, userObj, match)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "priority tasks err: %v", err)
 	}
 	items := make([]*pb.PriorityTask, 0, len(list))
 	for i := range list {
 		pt := list[i]
-		items = append(items, &pb.PriorityTask{Task: convert.TaskToProto(&pt.Task), PriorityScore: pt.PriorityScore, DaysLeft: int32(pt.DaysLeft), IsUrgent: pt.IsUrgent})
+		items = append(items, &pb.PriorityTask{Task: convert.TaskToProto(&pt.Task), PriorityScore: pt.PriorityScore, DaysLeft: int32(pt.DaysLeft), IsUrgent: pt.IsUrgent, NewlyUnblocked: pt.NewlyUnblocked})
 	}
 	return &pb.GetPriorityTasksResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Tasks: items, Total: int32(len(items))}, nil
 }
@@ -1,7 +1,9 @@
 package grpcserver
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/convert"
@@ -18,12 +20,18 @@ import (
 // EventServiceServer gRPC 包装
 type EventServiceServer struct {
 	pb.UnimplementedEventServiceServer
-	core *services.EventService
+	core     *services.EventService
+	users    *services.UserService
+	importer *services.EventImportService
 }
 
 func NewEventServiceServer(db *mongo.Database) *EventServiceServer { // 保留签名兼容现有调用
 	repo := repository.NewEventRepository(db)
-	return &EventServiceServer{core: services.NewEventService(repo)}
+	return &EventServiceServer{
+		core:     services.NewEventService(repo),
+		users:    services.NewUserService(repository.NewUserRepository(db)),
+		importer: services.NewEventImportService(repo, repository.NewReminderRepository(db)),
+	}
 }
 
 // CreateEvent
@@ -42,6 +50,9 @@ func (s *EventServiceServer) CreateEvent(ctx context.Context, req *pb.CreateEven
 	if req == nil || req.Title == "" || req.EventDate == nil {
 		return nil, status.Error(codes.InvalidArgument, "title/date required")
 	}
+	if req.WorkspaceId != "" && convert.ProtoToObjectIDPtr(req.WorkspaceId) == nil {
+		return nil, status.Error(codes.InvalidArgument, "bad workspace id")
+	}
 	var recCfg map[string]interface{}
 	if len(req.RecurrenceConfig) > 0 {
 		recCfg = make(map[string]interface{}, len(req.RecurrenceConfig))
@@ -49,7 +60,10 @@ func (s *EventServiceServer) CreateEvent(ctx context.Context, req *pb.CreateEven
 			recCfg[k] = v
 		}
 	}
-	mReq := models.CreateEventRequest{Title: req.Title, Description: req.Description, EventType: convert.ProtoToEventType(req.EventType), EventDate: req.EventDate.AsTime(), RecurrenceType: convert.ProtoToRecurrenceType(req.RecurrenceType), RecurrenceConfig: recCfg, ImportanceLevel: int(req.ImportanceLevel), Tags: req.Tags, Location: req.Location, IsAllDay: req.IsAllDay}
+	mReq := models.CreateEventRequest{Title: req.Title, Description: req.Description, EventType: convert.ProtoToEventType(req.EventType), EventDate: req.EventDate.AsTime(), RecurrenceType: convert.ProtoToRecurrenceType(req.RecurrenceType), RecurrenceConfig: recCfg, ImportanceLevel: int(req.ImportanceLevel), Tags: req.Tags, Location: req.Location, IsAllDay: req.IsAllDay, TimeZone: req.TimeZone, WorkspaceID: convert.ProtoToObjectIDPtr(req.WorkspaceId)}
+	if mReq.TimeZone == "" { // 默认沿用用户时区
+		mReq.TimeZone = s.users.TimeZone(ctx, userObj)
+	}
 	if mReq.EventType == "" {
 		mReq.EventType = "custom"
 	}
@@ -60,8 +74,11 @@ func (s *EventServiceServer) CreateEvent(ctx context.Context, req *pb.CreateEven
 		mReq.ImportanceLevel = 3
 	}
 	ev, err := s.core.CreateEvent(ctx, userObj, mReq)
+	if errors.Is(err, models.ErrInvalidRecurrence) || errors.Is(err, models.ErrInvalidTimeZone) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "create event err: %v", err)
+		return nil, workspaceErr("create event", err)
 	}
 	return &pb.CreateEventResponse{Response: &pb.Response{Code: 201, Message: "created"}, Event: convert.EventToProto(ev)}, nil
 }
@@ -161,12 +178,19 @@ func (s *EventServiceServer) UpdateEvent(ctx context.Context, req *pb.UpdateEven
 		b := req.IsActive
 		upd.IsActive = &b
 	}
+	if req.TimeZone != "" {
+		tz := req.TimeZone
+		upd.TimeZone = &tz
+	}
 	ev, err := s.core.UpdateEvent(ctx, userObj, id, upd)
 	if err != nil {
 		if err.Error() == "event not found" {
 			return nil, status.Error(codes.NotFound, "event not found")
 		}
-		return nil, status.Errorf(codes.Internal, "update event err: %v", err)
+		if errors.Is(err, models.ErrInvalidRecurrence) || errors.Is(err, models.ErrInvalidTimeZone) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, workspaceErr("update event", err)
 	}
 	return &pb.UpdateEventResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Event: convert.EventToProto(ev)}, nil
 }
@@ -195,7 +219,7 @@ func (s *EventServiceServer) DeleteEvent(ctx context.Context, req *pb.DeleteEven
 		if err.Error() == "event not found" {
 			return nil, status.Error(codes.NotFound, "event not found")
 		}
-		return nil, status.Errorf(codes.Internal, "delete event err: %v", err)
+		return nil, workspaceErr("delete event", err)
 	}
 	return &pb.Response{Code: 200, Message: "deleted"}, nil
 }
@@ -286,13 +310,14 @@ func (s *EventServiceServer) GetCalendarEvents(ctx context.Context, req *pb.GetC
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "bad user id")
 	}
+	loc := s.users.Location(ctx, userObj)
 	year, month := int(req.GetYear()), int(req.GetMonth())
 	if year == 0 || month == 0 {
-		now := time.Now()
+		now := time.Now().In(loc)
 		year = now.Year()
 		month = int(now.Month())
 	}
-	cal, err := s.core.GetCalendarEvents(ctx, userObj, year, month)
+	cal, err := s.core.GetCalendarEvents(ctx, userObj, year, month, loc)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "calendar events err: %v", err)
 	}
@@ -320,3 +345,37 @@ func (s *EventServiceServer) DeleteEventComment(ctx context.Context, req *pb.Del
 func (s *EventServiceServer) ListEventTimeline(ctx context.Context, req *pb.ListEventTimelineRequest) (*pb.ListEventTimelineResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "comment feature not migrated")
 }
+
+// ImportEvents 导入 .ics，按 UID 去重并返回逐条结果
+func (s *EventServiceServer) ImportEvents(ctx context.Context, req *pb.ImportEventsRequest) (*pb.ImportEventsResponse, error) {
+	if s.importer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "service not init")
+	}
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	userObj, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad user id")
+	}
+	if req == nil || len(req.IcsData) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "ics_data required")
+	}
+	zone := req.TimeZone
+	if zone == "" {
+		zone = s.users.TimeZone(ctx, userObj)
+	} else if err := models.ValidateTimeZone(zone); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	res, err := s.importer.Import(ctx, userObj, bytes.NewReader(req.IcsData), zone)
+	if errors.Is(err, services.ErrInvalidImport) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "import events err: %v", err)
+	}
+	out := convert.EventImportResultToProto(res)
+	out.Response = &pb.Response{Code: 200, Message: "ok"}
+	return out, nil
+}
@@ -0,0 +1,62 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
+)
+
+// gatewayHandlers 通过 gateway 暴露的服务；使用 FromEndpoint (经 gRPC 客户端转发) 以支持流式接口
+var gatewayHandlers = []func(context.Context, *runtime.ServeMux, string, []grpc.DialOption) error{
+	pb.RegisterAuthServiceHandlerFromEndpoint,
+	pb.RegisterTaskServiceHandlerFromEndpoint,
+	pb.RegisterEventServiceHandlerFromEndpoint,
+	pb.RegisterReminderServiceHandlerFromEndpoint,
+	pb.RegisterNotificationServiceHandlerFromEndpoint,
+	pb.RegisterUnifiedServiceHandlerFromEndpoint,
+	pb.RegisterDashboardServiceHandlerFromEndpoint,
+	pb.RegisterReportServiceHandlerFromEndpoint,
+	pb.RegisterCaptchaServiceHandlerFromEndpoint,
+	pb.RegisterWorkspaceServiceHandlerFromEndpoint,
+	pb.RegisterSearchServiceHandlerFromEndpoint,
+}
+
+// NewGateway 创建转发到 endpoint 的 grpc-gateway；Authorization 头原样作为 metadata 转发
+func NewGateway(ctx context.Context, endpoint string) (http.Handler, error) {
+	mux := runtime.NewServeMux()
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	for _, register := range gatewayHandlers {
+		if err := register(ctx, mux, endpoint, opts); err != nil {
+			return nil, err
+		}
+	}
+	return mux, nil
+}
+
+// ServeGateway 在 addr 上提供 gateway，ctx 取消时关闭
+func ServeGateway(ctx context.Context, addr, endpoint string) error {
+	h, err := NewGateway(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Addr: addr, Handler: h, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		sctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(sctx)
+	}()
+	log.Printf("gRPC gateway listening on %s", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -1,9 +1,14 @@
 package grpcserver
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/convert"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
 	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,10 +20,12 @@ import (
 type NotificationServiceServer struct {
 	pb.UnimplementedNotificationServiceServer
 	core *services.NotificationService
+	hub  *notifications.Hub
 }
 
-func NewNotificationServiceServer(db *mongo.Database) *NotificationServiceServer {
-	return &NotificationServiceServer{core: services.NewNotificationService(db)}
+// NewNotificationServiceServer hub 为 nil 时 StreamNotifications 不可用
+func NewNotificationServiceServer(db *mongo.Database, hub *notifications.Hub) *NotificationServiceServer {
+	return &NotificationServiceServer{core: services.NewNotificationService(db), hub: hub}
 }
 
 func (s *NotificationServiceServer) CreateNotification(ctx context.Context, req *pb.CreateNotificationRequest) (*pb.CreateNotificationResponse, error) {
@@ -35,9 +42,15 @@ func (s *NotificationServiceServer) CreateNotification(ctx context.Context, req
 	}
 	create := convert.ProtoToNotificationCreate(req, userObj)
 	n, err := s.core.Create(ctx, create)
+	if errors.Is(err, services.ErrNotificationMuted) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "create notification err: %v", err)
 	}
+	if s.hub != nil {
+		s.hub.Broadcast(n)
+	}
 	return &pb.CreateNotificationResponse{Response: &pb.Response{Code: 201, Message: "created"}, Notification: convert.NotificationToProto(&n)}, nil
 }
 
@@ -50,19 +63,24 @@ func (s *NotificationServiceServer) ListNotifications(ctx context.Context, req *
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "bad user id")
 	}
-	limit := int(req.GetLimit())
-	if limit <= 0 {
-		limit = 50
+	q := models.NotificationQuery{UnreadOnly: req.GetUnreadOnly(), Archived: req.GetArchived(), Type: req.GetType(), Limit: int(req.GetLimit())}
+	if q.Limit <= 0 {
+		q.Limit = 50
+	}
+	if c := req.GetCursor(); c != "" {
+		if q.Cursor, err = primitive.ObjectIDFromHex(c); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "bad cursor")
+		}
 	}
-	list, err := s.core.List(ctx, userObj, req.GetUnreadOnly(), limit)
+	page, err := s.core.List(ctx, userObj, q)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "list notifications err: %v", err)
 	}
-	out := make([]*pb.Notification, 0, len(list))
-	for i := range list {
-		out = append(out, convert.NotificationToProto(&list[i]))
+	out := make([]*pb.Notification, 0, len(page.Notifications))
+	for i := range page.Notifications {
+		out = append(out, convert.NotificationToProto(&page.Notifications[i]))
 	}
-	return &pb.ListNotificationsResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Notifications: out, Count: int32(len(out))}, nil
+	return &pb.ListNotificationsResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Notifications: out, Count: int32(len(out)), NextCursor: page.NextCursor}, nil
 }
 
 func (s *NotificationServiceServer) MarkNotificationRead(ctx context.Context, req *pb.MarkNotificationReadRequest) (*pb.MarkNotificationReadResponse, error) {
@@ -82,7 +100,7 @@ func (s *NotificationServiceServer) MarkNotificationRead(ctx context.Context, re
 		return nil, status.Error(codes.InvalidArgument, "bad id")
 	}
 	if err := s.core.MarkRead(ctx, userObj, id); err != nil {
-		if err.Error() == "not_found" {
+		if errors.Is(err, services.ErrNotificationNotFound) {
 			return nil, status.Error(codes.NotFound, "not found")
 		}
 		return nil, status.Errorf(codes.Internal, "mark read err: %v", err)
@@ -105,3 +123,147 @@ func (s *NotificationServiceServer) MarkAllNotificationsRead(ctx context.Context
 	}
 	return &pb.MarkAllNotificationsReadResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Modified: mod}, nil
 }
+
+func (s *NotificationServiceServer) ArchiveNotification(ctx context.Context, req *pb.ArchiveNotificationRequest) (*pb.ArchiveNotificationResponse, error) {
+	userObj, id, err := notificationTarget(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.core.Archive(ctx, userObj, id, !req.GetUnarchive()); err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		return nil, status.Errorf(codes.Internal, "archive err: %v", err)
+	}
+	return &pb.ArchiveNotificationResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Success: true}, nil
+}
+
+func (s *NotificationServiceServer) DeleteNotification(ctx context.Context, req *pb.DeleteNotificationRequest) (*pb.DeleteNotificationResponse, error) {
+	userObj, id, err := notificationTarget(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.core.Delete(ctx, userObj, id); err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		return nil, status.Errorf(codes.Internal, "delete err: %v", err)
+	}
+	return &pb.DeleteNotificationResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Success: true}, nil
+}
+
+func (s *NotificationServiceServer) GetUnreadCounts(ctx context.Context, req *pb.GetUnreadCountsRequest) (*pb.GetUnreadCountsResponse, error) {
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	userObj, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad user id")
+	}
+	counts, err := s.core.UnreadCounts(ctx, userObj)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unread counts err: %v", err)
+	}
+	return &pb.GetUnreadCountsResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Total: counts.Total, ByType: counts.ByType}, nil
+}
+
+// notificationTarget 解析当前用户与通知 ID
+func notificationTarget(ctx context.Context, id string) (primitive.ObjectID, primitive.ObjectID, error) {
+	if id == "" {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "id required")
+	}
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	userObj, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "bad user id")
+	}
+	nid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "bad id")
+	}
+	return userObj, nid, nil
+}
+
+// streamReplayLimit 单次补发上限，与 SSE 一致
+const streamReplayLimit = 100
+
+// StreamNotifications 推送通知 (与 SSE /api/notifications/stream 语义相同)：先订阅再按 last_event_id 补发，
+// 消费过慢被丢弃时先发送 dropped 再补发
+func (s *NotificationServiceServer) StreamNotifications(req *pb.StreamNotificationsRequest, stream pb.NotificationService_StreamNotificationsServer) error {
+	if s.hub == nil {
+		return status.Error(codes.Unimplemented, "notification stream not enabled")
+	}
+	ctx := stream.Context()
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return status.Error(codes.Unauthenticated, "user id missing")
+	}
+	userObj, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "bad user id")
+	}
+	var last primitive.ObjectID
+	if req.GetLastEventId() != "" {
+		if last, err = primitive.ObjectIDFromHex(req.GetLastEventId()); err != nil {
+			return status.Error(codes.InvalidArgument, "bad last_event_id")
+		}
+	}
+	sub := s.hub.Listen(ctx, userObj)
+	replayed := map[primitive.ObjectID]struct{}{}
+	send := func(n models.Notification) error {
+		if !n.ID.IsZero() && bytes.Compare(n.ID[:], last[:]) > 0 {
+			last = n.ID
+		}
+		return stream.Send(&pb.StreamNotificationsResponse{Event: "notification", Notification: convert.NotificationToProto(&n)})
+	}
+	replay := func() error {
+		if last.IsZero() {
+			return nil
+		}
+		qctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		list, err := s.core.ListAfter(qctx, userObj, last, streamReplayLimit)
+		if err != nil {
+			return status.Errorf(codes.Internal, "replay notifications err: %v", err)
+		}
+		for _, n := range list {
+			replayed[n.ID] = struct{}{}
+			if err := send(n); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := replay(); err != nil {
+		return err
+	}
+	var dropped int64
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+			if _, dup := replayed[n.ID]; !dup {
+				if err := send(n); err != nil {
+					return err
+				}
+			}
+			if total := sub.Dropped(); total > dropped {
+				if err := stream.Send(&pb.StreamNotificationsResponse{Event: "dropped", Dropped: total - dropped, TotalDropped: total}); err != nil {
+					return err
+				}
+				dropped = total
+				if err := replay(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package grpcserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/auth"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
+	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
+)
+
+func TestStreamNotifications(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hub := notifications.NewHub()
+	srv := New(ServerConfig{}, func(s *grpc.Server) {
+		pb.RegisterNotificationServiceServer(s, &NotificationServiceServer{hub: hub})
+	})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = srv.server.Serve(lis) }()
+	defer srv.server.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewNotificationServiceClient(conn)
+
+	// 流式接口同样需要 JWT
+	anon, err := client.StreamNotifications(ctx, &pb.StreamNotificationsRequest{})
+	require.NoError(t, err)
+	_, err = anon.Recv()
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	user := primitive.NewObjectID()
+	token, err := auth.Generate(user.Hex(), time.Hour)
+	require.NoError(t, err)
+	stream, err := client.StreamNotifications(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), &pb.StreamNotificationsRequest{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return hub.Stats().Subscribers == 1 }, time.Second, 5*time.Millisecond)
+	id := primitive.NewObjectID()
+	hub.Broadcast(models.Notification{ID: id, UserID: user, Type: "test", Message: "hi"})
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "notification", msg.Event)
+	require.Equal(t, id.Hex(), msg.Notification.Id)
+
+	// 经 gateway：Authorization 头转发为 metadata，流式结果按行返回
+	gw, err := NewGateway(ctx, lis.Addr().String())
+	require.NoError(t, err)
+	hs := httptest.NewServer(gw)
+	defer hs.Close()
+	// ForwardResponseStream 收到首条消息才写响应头，因此请求需在后台发出，订阅建立后再广播
+	reqCtx, cancelReq := context.WithTimeout(ctx, 5*time.Second)
+	defer cancelReq()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, hs.URL+"/todoing.api.v1.NotificationService/StreamNotifications", strings.NewReader("{}"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	type result struct {
+		resp *http.Response
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := http.DefaultClient.Do(req)
+		done <- result{resp, err}
+	}()
+	require.Eventually(t, func() bool { return hub.Stats().Subscribers == 2 }, time.Second, 5*time.Millisecond)
+	hub.Broadcast(models.Notification{ID: id, UserID: user, Type: "test", Message: "via gateway"})
+	res := <-done
+	require.NoError(t, res.err)
+	resp := res.resp
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	var chunk struct {
+		Result struct {
+			Event        string `json:"event"`
+			Notification struct {
+				Message string `json:"message"`
+			} `json:"notification"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(line), &chunk))
+	require.Equal(t, "notification", chunk.Result.Event)
+	require.Equal(t, "via gateway", chunk.Result.Notification.Message)
+}
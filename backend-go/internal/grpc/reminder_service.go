@@ -2,10 +2,12 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/convert"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
 	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
@@ -18,12 +20,13 @@ import (
 
 type ReminderServiceServer struct {
 	pb.UnimplementedReminderServiceServer
-	core *services.ReminderService
+	core       *services.ReminderService
+	deliveries repository.ReminderDeliveryRepository
 }
 
 func NewReminderServiceServer(db *mongo.Database) *ReminderServiceServer { // 保留签名
 	repo := repository.NewReminderRepository(db)
-	return &ReminderServiceServer{core: services.NewReminderService(repo)}
+	return &ReminderServiceServer{core: services.NewReminderService(repo), deliveries: repository.NewReminderDeliveryRepository(db)}
 }
 
 // CreateReminder
@@ -47,10 +50,13 @@ func (s *ReminderServiceServer) CreateReminder(ctx context.Context, req *pb.Crea
 	for _, t := range req.AbsoluteTimes {
 		abs = append(abs, t.AsTime())
 	}
-	mReq := models.CreateReminderRequest{EventID: eid, AdvanceDays: int(req.AdvanceDays), ReminderTimes: req.ReminderTimes, AbsoluteTimes: abs, ReminderType: convert.ProtoToReminderType(req.ReminderType), CustomMessage: req.CustomMessage}
+	mReq := models.CreateReminderRequest{EventID: eid, AdvanceDays: int(req.AdvanceDays), ReminderTimes: req.ReminderTimes, AbsoluteTimes: abs, ReminderType: convert.ProtoToReminderChannels(req.ReminderType, req.Channels), CustomMessage: req.CustomMessage}
 	r, err := s.core.CreateReminder(ctx, userObj, mReq)
+	if errors.Is(err, notify.ErrUnknownChannel) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "create reminder err: %v", err)
+		return nil, workspaceErr("create reminder", err)
 	}
 	return &pb.CreateReminderResponse{Response: &pb.Response{Code: 201, Message: "created"}, Reminder: convert.ReminderToProto(r)}, nil
 }
@@ -116,8 +122,8 @@ func (s *ReminderServiceServer) UpdateReminder(ctx context.Context, req *pb.Upda
 		}
 		upd.AbsoluteTimes = &at
 	}
-	if req.ReminderType != pb.ReminderType_REMINDER_TYPE_UNSPECIFIED {
-		rt := convert.ProtoToReminderType(req.ReminderType)
+	if req.ReminderType != pb.ReminderType_REMINDER_TYPE_UNSPECIFIED || len(req.Channels) > 0 {
+		rt := convert.ProtoToReminderChannels(req.ReminderType, req.Channels)
 		upd.ReminderType = &rt
 	}
 	if req.CustomMessage != "" {
@@ -133,7 +139,10 @@ func (s *ReminderServiceServer) UpdateReminder(ctx context.Context, req *pb.Upda
 		if err.Error() == "reminder not found" {
 			return nil, status.Error(codes.NotFound, "not found")
 		}
-		return nil, status.Errorf(codes.Internal, "update reminder err: %v", err)
+		if errors.Is(err, notify.ErrUnknownChannel) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, workspaceErr("update reminder", err)
 	}
 	return &pb.UpdateReminderResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Reminder: convert.ReminderToProto(r)}, nil
 }
@@ -159,7 +168,7 @@ func (s *ReminderServiceServer) DeleteReminder(ctx context.Context, req *pb.Dele
 		if err.Error() == "reminder not found" {
 			return nil, status.Error(codes.NotFound, "not found")
 		}
-		return nil, status.Errorf(codes.Internal, "delete reminder err: %v", err)
+		return nil, workspaceErr("delete reminder", err)
 	}
 	return &pb.Response{Code: 200, Message: "deleted"}, nil
 }
@@ -216,7 +225,7 @@ func (s *ReminderServiceServer) ListSimpleReminders(ctx context.Context, req *pb
 	}
 	items := make([]*pb.Reminder, 0, len(list))
 	for i := range list { // 组装 Reminder proto (无事件)
-		items = append(items, &pb.Reminder{Id: list[i].ID.Hex(), EventId: list[i].EventID.Hex(), UserId: userObj.Hex(), AdvanceDays: int32(list[i].AdvanceDays), ReminderTimes: list[i].ReminderTimes, ReminderType: convert.ReminderTypeToProto(list[i].ReminderType), CustomMessage: list[i].CustomMessage, IsActive: list[i].IsActive})
+		items = append(items, &pb.Reminder{Id: list[i].ID.Hex(), EventId: list[i].EventID.Hex(), UserId: userObj.Hex(), AdvanceDays: int32(list[i].AdvanceDays), ReminderTimes: list[i].ReminderTimes, ReminderType: convert.ReminderTypeToProto(list[i].ReminderType), Channels: list[i].ReminderType, CustomMessage: list[i].CustomMessage, IsActive: list[i].IsActive})
 	}
 	return &pb.ListSimpleRemindersResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Reminders: items, Count: int32(len(items))}, nil
 }
@@ -295,7 +304,7 @@ func (s *ReminderServiceServer) SnoozeReminder(ctx context.Context, req *pb.Snoo
 		if err.Error() == "reminder not found" {
 			return nil, status.Error(codes.NotFound, "not found")
 		}
-		return nil, status.Errorf(codes.Internal, "snooze reminder err: %v", err)
+		return nil, workspaceErr("snooze reminder", err)
 	}
 	return &pb.SnoozeReminderResponse{Response: &pb.Response{Code: 200, Message: "ok"}, SnoozeMinutes: req.SnoozeMinutes}, nil
 }
@@ -322,7 +331,7 @@ func (s *ReminderServiceServer) ToggleReminderActive(ctx context.Context, req *p
 		if err.Error() == "reminder not found" {
 			return nil, status.Error(codes.NotFound, "not found")
 		}
-		return nil, status.Errorf(codes.Internal, "toggle reminder err: %v", err)
+		return nil, workspaceErr("toggle reminder", err)
 	}
 	return &pb.ToggleReminderActiveResponse{Response: &pb.Response{Code: 200, Message: "ok"}, IsActive: val}, nil
 }
@@ -349,7 +358,49 @@ func (s *ReminderServiceServer) CreateTestReminder(ctx context.Context, req *pb.
 	}
 	r, ev, err := s.core.CreateImmediateTestReminder(ctx, userObj, eid, req.Message, int(req.DelaySeconds))
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "create test reminder err: %v", err)
+		return nil, workspaceErr("create test reminder", err)
 	}
 	return &pb.CreateTestReminderResponse{Response: &pb.Response{Code: 201, Message: "created"}, Reminder: convert.ReminderToProto(r), Event: convert.EventToProto(ev), EmailSent: req.SendEmail}, nil
 }
+
+// ListReminderDeliveries 提醒投递历史
+func (s *ReminderServiceServer) ListReminderDeliveries(ctx context.Context, req *pb.ListReminderDeliveriesRequest) (*pb.ListReminderDeliveriesResponse, error) {
+	if req == nil || req.ReminderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "reminder_id required")
+	}
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	userObj, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad user id")
+	}
+	rid, err := primitive.ObjectIDFromHex(req.ReminderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad reminder id")
+	}
+	var before *primitive.ObjectID
+	if req.BeforeId != "" {
+		id, err := primitive.ObjectIDFromHex(req.BeforeId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "bad before_id")
+		}
+		before = &id
+	}
+	if _, err := s.core.GetReminder(ctx, userObj, rid); err != nil {
+		if err.Error() == "reminder not found" {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		return nil, status.Errorf(codes.Internal, "get reminder err: %v", err)
+	}
+	list, err := s.deliveries.ListByReminder(ctx, userObj, rid, int(req.Limit), before)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list deliveries err: %v", err)
+	}
+	out := make([]*pb.ReminderDelivery, 0, len(list))
+	for i := range list {
+		out = append(out, convert.ReminderDeliveryToProto(&list[i]))
+	}
+	return &pb.ListReminderDeliveriesResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Deliveries: out, Count: int32(len(out))}, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/convert"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
 	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
 	"go.mongodb.org/mongo-driver/bson"
@@ -150,17 +151,10 @@ func (s *ReportServiceServer) ExportReport(ctx context.Context, req *pb.ExportRe
 		}
 		return nil, status.Errorf(codes.Internal, "export get report err: %v", err)
 	}
-	// 时间范围
-	end := r.CreatedAt
-	var start time.Time
-	switch r.Type {
-	case "weekly":
-		start = end.AddDate(0, 0, -7)
-	case "monthly":
-		start = end.AddDate(0, -1, 0)
-	default:
-		start = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
-	}
+	// 时间范围 (按用户时区)
+	loc := services.NewUserService(repository.NewUserRepository(s.db)).LocationByHex(ctx, uid)
+	end := r.CreatedAt.In(loc)
+	start := models.ReportPeriodStart(r.Type, end, loc)
 	// Events
 	eventColl := s.db.Collection("events")
 	eventCur, _ := eventColl.Find(ctx, bson.M{"user_id": uid, "event_date": bson.M{"$gte": start, "$lte": end}})
@@ -0,0 +1,61 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/convert"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchServiceServer 任务 / 事件 / 事件评论统一搜索
+type SearchServiceServer struct {
+	pb.UnimplementedSearchServiceServer
+	core *services.SearchService
+}
+
+func NewSearchServiceServer(db *mongo.Database) *SearchServiceServer {
+	return &SearchServiceServer{core: services.NewSearchService(repository.NewSearchRepository(db))}
+}
+
+func (s *SearchServiceServer) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	userObj, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad user id")
+	}
+	q := models.SearchQuery{Text: req.GetQuery(), Sources: req.GetSources(), Status: req.GetStatus(), Priority: req.GetPriority(), Tags: req.GetTags(), Limit: int(req.GetLimit())}
+	if req.From != nil {
+		from := req.From.AsTime()
+		q.From = &from
+	}
+	if req.To != nil {
+		to := req.To.AsTime()
+		q.To = &to
+	}
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	res, err := s.core.Search(cctx, userObj, q)
+	if errors.Is(err, services.ErrSearchQueryRequired) || errors.Is(err, services.ErrInvalidSearchSource) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "search err: %v", err)
+	}
+	items := make([]*pb.SearchHit, 0, len(res.Items))
+	for _, h := range res.Items {
+		items = append(items, convert.SearchHitToProto(h))
+	}
+	return &pb.SearchResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Items: items, Total: int32(res.Total)}, nil
+}
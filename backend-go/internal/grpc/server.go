@@ -38,8 +38,14 @@ func New(cfg ServerConfig, register func(s *grpc.Server)) *Server {
 		authInterceptor,
 	}
 
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		loggingStreamInterceptor,
+		authStreamInterceptor,
+	}
+
 	server := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	)
 
 	// 注册健康检查服务
@@ -99,11 +105,45 @@ func loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnarySe
 	return resp, err
 }
 
+// loggingStreamInterceptor 流式调用日志 (连接结束时记录)
+func loggingStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	obs.LogInfo("rpc=%s stream code=%s duration=%s", info.FullMethod, status.Code(err).String(), time.Since(start))
+	return err
+}
+
 // authInterceptor 处理 JWT 鉴权（允许部分公共方法）
 func authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStreamInterceptor 流式调用的 JWT 鉴权，与 authInterceptor 规则相同
+func authStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authedStream 替换 Context 以携带 userId
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authedStream) Context() context.Context { return s.ctx }
+
+// authenticate 校验 metadata 中的 JWT 并将 userId 放入 context；公共方法直接放行
+func authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
 	// 允许匿名的方法前缀
-	if isPublicMethod(info.FullMethod) {
-		return handler(ctx, req)
+	if isPublicMethod(fullMethod) {
+		return ctx, nil
 	}
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
@@ -128,8 +168,7 @@ func authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServe
 		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
 	// 将 userId 放入 context
-	ctx = context.WithValue(ctx, ctxKeyUserID{}, claims.UserID)
-	return handler(ctx, req)
+	return context.WithValue(ctx, ctxKeyUserID{}, claims.UserID), nil
 }
 
 // isPublicMethod 判断是否公共方法
@@ -7,9 +7,11 @@ import (
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/convert"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
 	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc/codes"
@@ -24,8 +26,13 @@ type TaskServiceServer struct {
 	db   *mongo.Database
 }
 
-func NewTaskServiceServer(db *mongo.Database) *TaskServiceServer {
-	return &TaskServiceServer{core: services.NewTaskService(repository.NewTaskRepository(db)), db: db}
+// NewTaskServiceServer hub 可为空 (依赖解除通知仍写入通知集合，只是不实时推送)
+func NewTaskServiceServer(db *mongo.Database, hub *notifications.Hub) *TaskServiceServer {
+	core := services.NewTaskService(repository.NewTaskRepository(db)).
+		WithNotifications(services.NewNotificationService(db), hub).
+		WithDirectory(repository.NewUserRepository(db), repository.NewWorkspaceRepository(db)).
+		WithFields(repository.NewTaskFieldRepository(db))
+	return &TaskServiceServer{core: core, db: db}
 }
 
 // helper 保留
@@ -61,9 +68,28 @@ func (s *TaskServiceServer) CreateTask(ctx context.Context, req *pb.CreateTaskRe
 		a := req.Assignee
 		m.Assignee = &a
 	}
+	if req.ParentId != "" {
+		p := req.ParentId
+		m.ParentID = &p
+	}
+	m.Checklist = convert.ProtoToChecklist(req.Checklist)
+	m.AutoComplete = req.AutoComplete
+	m.Recurrence = req.Recurrence
+	m.WorkspaceID = req.WorkspaceId
+	m.Tags = req.Tags
+	m.CustomFields = customFieldsFromProto(req.CustomFields)
 	res, err := s.core.Create(ctx, uid, m)
+	if errors.Is(err, services.ErrInvalidParentTask) {
+		return nil, status.Error(codes.InvalidArgument, "invalid parent task")
+	}
+	if st := assigneeStatus(err); st != nil {
+		return nil, st
+	}
+	if errors.Is(err, models.ErrInvalidRecurrence) || services.IsTaskFieldValueError(err) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "create err: %v", err)
+		return nil, workspaceErr("create", err)
 	}
 	return &pb.CreateTaskResponse{Response: &pb.Response{Code: 201, Message: "created"}, Task: taskModelToProto(res)}, nil
 }
@@ -91,7 +117,17 @@ func (s *TaskServiceServer) GetTasks(ctx context.Context, req *pb.GetTasksReques
 			limit = int64(req.Pagination.Limit)
 		}
 	}
-	list, total, err := s.core.List(ctx, uid, st, page, limit)
+	view := map[pb.TaskView]string{pb.TaskView_TASK_VIEW_ASSIGNED_TO_ME: models.TaskViewAssignedToMe, pb.TaskView_TASK_VIEW_CREATED_BY_ME: models.TaskViewCreatedByMe, pb.TaskView_TASK_VIEW_UNASSIGNED: models.TaskViewUnassigned}[req.View]
+	userObj, _ := primitive.ObjectIDFromHex(uid)
+	filter, sort, err := s.savedViews().Resolve(ctx, userObj, req.SavedViewId, req.Query, req.Sort)
+	if err != nil {
+		return nil, savedViewErr(err)
+	}
+	loc := services.NewUserService(repository.NewUserRepository(s.db)).Location(ctx, userObj)
+	list, total, err := s.core.List(ctx, uid, models.TaskQuery{Status: st, View: view, Filter: filter, Sort: sort, Loc: loc, Tags: req.Tags, Page: page, Limit: limit})
+	if errors.Is(err, models.ErrInvalidTaskFilter) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "list err: %v", err)
 	}
@@ -103,7 +139,27 @@ func (s *TaskServiceServer) GetTasks(ctx context.Context, req *pb.GetTasksReques
 	return &pb.GetTasksResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Tasks: tasks, Pagination: pg}, nil
 }
 
-// GetTask 详情
+// customFieldsFromProto 自定义字段字符串取值，由服务层按字段定义转换 (空字符串删除该字段)
+func customFieldsFromProto(in map[string]string) map[string]interface{} {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// assigneeStatus 负责人校验错误映射为 InvalidArgument，其他错误返回 nil
+func assigneeStatus(err error) error {
+	if errors.Is(err, services.ErrInvalidAssignee) || errors.Is(err, services.ErrAssigneeNoAccess) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// GetTask 详情 (含子任务层级)
 func (s *TaskServiceServer) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.GetTaskResponse, error) {
 	if s.core == nil {
 		return nil, status.Error(codes.FailedPrecondition, "service not init")
@@ -115,7 +171,7 @@ func (s *TaskServiceServer) GetTask(ctx context.Context, req *pb.GetTaskRequest)
 	if uid == "" {
 		return nil, status.Error(codes.Unauthenticated, "user id missing")
 	}
-	m, err := s.core.Get(ctx, uid, req.Id)
+	m, err := s.core.Tree(ctx, uid, req.Id)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, status.Error(codes.NotFound, "task not found")
@@ -144,9 +200,7 @@ func (s *TaskServiceServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRe
 	if req.Description != "" {
 		upd.Description = &req.Description
 	}
-	if req.Assignee != "" {
-		upd.Assignee = &req.Assignee
-	}
+	upd.Assignee = req.Assignee
 	if req.Status != pb.TaskStatus_TASK_STATUS_UNSPECIFIED {
 		m := map[pb.TaskStatus]string{pb.TaskStatus_TASK_STATUS_TODO: "Todo", pb.TaskStatus_TASK_STATUS_IN_PROGRESS: "InProgress", pb.TaskStatus_TASK_STATUS_DONE: "Done"}[req.Status]
 		upd.Status = &m
@@ -174,12 +228,28 @@ func (s *TaskServiceServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRe
 		}
 		upd.Comments = cs
 	}
+	if len(req.Checklist) > 0 {
+		upd.Checklist = convert.ProtoToChecklist(req.Checklist)
+	}
+	upd.AutoComplete = req.AutoComplete
+	upd.Recurrence = req.Recurrence
+	if len(req.Tags) > 0 || req.ClearTags {
+		tags := req.Tags
+		upd.Tags = &tags
+	}
+	upd.CustomFields = customFieldsFromProto(req.CustomFields)
 	m, err := s.core.Update(ctx, uid, upd)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, status.Error(codes.NotFound, "task not found")
 		}
-		return nil, status.Errorf(codes.Internal, "update err: %v", err)
+		if errors.Is(err, models.ErrInvalidRecurrence) || services.IsTaskFieldValueError(err) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if st := assigneeStatus(err); st != nil {
+			return nil, st
+		}
+		return nil, workspaceErr("update", err)
 	}
 	return &pb.UpdateTaskResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Task: taskModelToProto(m)}, nil
 }
@@ -197,11 +267,78 @@ func (s *TaskServiceServer) DeleteTask(ctx context.Context, req *pb.DeleteTaskRe
 		return nil, status.Error(codes.Unauthenticated, "user id missing")
 	}
 	if err := s.core.Delete(ctx, uid, req.Id); err != nil {
-		return nil, status.Errorf(codes.Internal, "delete err: %v", err)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		return nil, workspaceErr("delete", err)
 	}
 	return &pb.Response{Code: 200, Message: "deleted"}, nil
 }
 
+// GetTaskHistory 循环任务所在系列的全部实例
+func (s *TaskServiceServer) GetTaskHistory(ctx context.Context, req *pb.GetTaskRequest) (*pb.GetTaskHistoryResponse, error) {
+	if s.core == nil {
+		return nil, status.Error(codes.FailedPrecondition, "service not init")
+	}
+	if req == nil || req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	list, err := s.core.History(ctx, uid, req.Id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		return nil, status.Errorf(codes.Internal, "history err: %v", err)
+	}
+	out := &pb.GetTaskHistoryResponse{Response: &pb.Response{Code: 200, Message: "ok"}}
+	for i := range list {
+		out.Tasks = append(out.Tasks, taskModelToProto(&list[i]))
+	}
+	if len(list) > 0 {
+		out.SeriesId = list[0].SeriesID
+	}
+	return out, nil
+}
+
+// AddTaskDependency blocker 完成前任务处于阻塞状态
+func (s *TaskServiceServer) AddTaskDependency(ctx context.Context, req *pb.TaskDependencyRequest) (*pb.TaskDependencyResponse, error) {
+	return s.editDependency(ctx, req, s.core.AddDependency)
+}
+
+// RemoveTaskDependency 移除依赖
+func (s *TaskServiceServer) RemoveTaskDependency(ctx context.Context, req *pb.TaskDependencyRequest) (*pb.TaskDependencyResponse, error) {
+	return s.editDependency(ctx, req, s.core.RemoveDependency)
+}
+
+func (s *TaskServiceServer) editDependency(ctx context.Context, req *pb.TaskDependencyRequest, edit func(ctx context.Context, userID, taskID, blockerID string) (*models.Task, error)) (*pb.TaskDependencyResponse, error) {
+	if s.core == nil {
+		return nil, status.Error(codes.FailedPrecondition, "service not init")
+	}
+	if req == nil || req.TaskId == "" || req.BlockerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "task_id/blocker_id required")
+	}
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	m, err := edit(ctx, uid, req.TaskId, req.BlockerId)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return nil, status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, services.ErrInvalidDependency):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrDependencyCycle):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, workspaceErr("dependency", err)
+	}
+	return &pb.TaskDependencyResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Task: taskModelToProto(m)}, nil
+}
+
 // GetTaskSortConfig & UpdateTaskSortConfig: 暂时仍直接使用排序服务（独立复杂逻辑）
 func (s *TaskServiceServer) GetTaskSortConfig(ctx context.Context, req *pb.GetTaskSortConfigRequest) (*pb.GetTaskSortConfigResponse, error) {
 	uid, _ := UserIDFromContext(ctx)
@@ -253,3 +390,191 @@ func (s *TaskServiceServer) UpdateTaskSortConfig(ctx context.Context, req *pb.Up
 	}
 	return &pb.UpdateTaskSortConfigResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Config: &pb.TaskSortConfig{UserId: uid, PriorityDays: int32(cfg.PriorityDays), MaxDisplayCount: int32(cfg.MaxDisplayCount), WeightUrgent: cfg.WeightUrgent, WeightImportant: cfg.WeightImportant, CreatedAt: timestamppb.New(cfg.CreatedAt), UpdatedAt: timestamppb.New(cfg.UpdatedAt)}}, nil
 }
+
+func (s *TaskServiceServer) savedViews() *services.SavedViewService {
+	return services.NewSavedViewService(repository.NewSavedViewRepository(s.db))
+}
+
+// savedViewMatch 保存的视图 / 过滤表达式对应的任务条件 (仪表盘、统一即将到来列表)，都未设置时为 nil
+func savedViewMatch(ctx context.Context, db *mongo.Database, userObj primitive.ObjectID, viewID, query string) (bson.M, error) {
+	if viewID == "" && query == "" {
+		return nil, nil
+	}
+	loc := services.NewUserService(repository.NewUserRepository(db)).Location(ctx, userObj)
+	match, err := services.NewSavedViewService(repository.NewSavedViewRepository(db)).TaskMatch(ctx, userObj, viewID, query, loc)
+	if err != nil {
+		return nil, savedViewErr(err)
+	}
+	return match, nil
+}
+
+// savedViewErr 视图 / 过滤表达式错误映射为 gRPC 状态码
+func savedViewErr(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrSavedViewNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrSavedViewNameTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case services.IsSavedViewQueryError(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Errorf(codes.Internal, "saved view err: %v", err)
+}
+
+// userObjectID 当前用户 ID
+func userObjectID(ctx context.Context) (primitive.ObjectID, error) {
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return primitive.NilObjectID, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	userObj, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return primitive.NilObjectID, status.Error(codes.InvalidArgument, "bad user id")
+	}
+	return userObj, nil
+}
+
+func savedViewToProto(v *models.SavedView) *pb.SavedView {
+	return &pb.SavedView{Id: v.ID.Hex(), Name: v.Name, Query: v.Query, Sort: v.Sort, CreatedAt: timestamppb.New(v.CreatedAt), UpdatedAt: timestamppb.New(v.UpdatedAt)}
+}
+
+// ListSavedViews 当前用户保存的视图
+func (s *TaskServiceServer) ListSavedViews(ctx context.Context, req *pb.ListSavedViewsRequest) (*pb.ListSavedViewsResponse, error) {
+	userObj, err := userObjectID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.savedViews().List(ctx, userObj)
+	if err != nil {
+		return nil, savedViewErr(err)
+	}
+	out := make([]*pb.SavedView, 0, len(list))
+	for i := range list {
+		out = append(out, savedViewToProto(&list[i]))
+	}
+	return &pb.ListSavedViewsResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Views: out}, nil
+}
+
+// SaveView id 为空时创建，否则整体替换
+func (s *TaskServiceServer) SaveView(ctx context.Context, req *pb.SaveViewRequest) (*pb.SaveViewResponse, error) {
+	userObj, err := userObjectID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	in := models.SavedViewRequest{Name: req.GetName(), Query: req.GetQuery(), Sort: req.GetSort()}
+	var v *models.SavedView
+	code := int32(201)
+	if req.GetId() == "" {
+		v, err = s.savedViews().Create(ctx, userObj, in)
+	} else {
+		id, perr := primitive.ObjectIDFromHex(req.GetId())
+		if perr != nil {
+			return nil, status.Error(codes.InvalidArgument, "bad saved view id")
+		}
+		code = 200
+		v, err = s.savedViews().Update(ctx, userObj, id, in)
+	}
+	if err != nil {
+		return nil, savedViewErr(err)
+	}
+	return &pb.SaveViewResponse{Response: &pb.Response{Code: code, Message: "ok"}, View: savedViewToProto(v)}, nil
+}
+
+// DeleteSavedView 删除视图
+func (s *TaskServiceServer) DeleteSavedView(ctx context.Context, req *pb.DeleteSavedViewRequest) (*pb.Response, error) {
+	userObj, err := userObjectID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad saved view id")
+	}
+	if err := s.savedViews().Delete(ctx, userObj, id); err != nil {
+		return nil, savedViewErr(err)
+	}
+	return &pb.Response{Code: 200, Message: "deleted"}, nil
+}
+
+func (s *TaskServiceServer) taskFields() *services.TaskFieldService {
+	return services.NewTaskFieldService(repository.NewTaskFieldRepository(s.db), repository.NewWorkspaceRepository(s.db))
+}
+
+// taskFieldErr 字段定义错误映射为 gRPC 状态码
+func taskFieldErr(op string, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrTaskFieldNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrTaskFieldKeyTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, services.ErrInvalidTaskField):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return workspaceErr(op, err)
+}
+
+func taskFieldToProto(f *models.TaskFieldDefinition) *pb.TaskFieldDefinition {
+	out := &pb.TaskFieldDefinition{Id: f.ID.Hex(), Key: f.Key, Name: f.Name, Type: f.Type, Options: f.Options, CreatedAt: timestamppb.New(f.CreatedAt), UpdatedAt: timestamppb.New(f.UpdatedAt)}
+	if !f.WorkspaceID.IsZero() {
+		out.WorkspaceId = f.WorkspaceID.Hex()
+	}
+	return out
+}
+
+// ListTaskFields 个人字段或工作区字段
+func (s *TaskServiceServer) ListTaskFields(ctx context.Context, req *pb.ListTaskFieldsRequest) (*pb.ListTaskFieldsResponse, error) {
+	userObj, err := userObjectID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.taskFields().List(ctx, userObj, req.GetWorkspaceId())
+	if err != nil {
+		return nil, taskFieldErr("list task fields", err)
+	}
+	out := make([]*pb.TaskFieldDefinition, 0, len(list))
+	for i := range list {
+		out = append(out, taskFieldToProto(&list[i]))
+	}
+	return &pb.ListTaskFieldsResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Fields: out}, nil
+}
+
+// SaveTaskField id 为空时创建，否则修改名称与选项
+func (s *TaskServiceServer) SaveTaskField(ctx context.Context, req *pb.SaveTaskFieldRequest) (*pb.SaveTaskFieldResponse, error) {
+	userObj, err := userObjectID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	in := models.TaskFieldRequest{WorkspaceID: req.GetWorkspaceId(), Key: req.GetKey(), Name: req.GetName(), Type: req.GetType(), Options: req.GetOptions()}
+	var f *models.TaskFieldDefinition
+	code := int32(201)
+	if req.GetId() == "" {
+		f, err = s.taskFields().Create(ctx, userObj, in)
+	} else {
+		id, perr := primitive.ObjectIDFromHex(req.GetId())
+		if perr != nil {
+			return nil, status.Error(codes.InvalidArgument, "bad task field id")
+		}
+		code = 200
+		f, err = s.taskFields().Update(ctx, userObj, id, in)
+	}
+	if err != nil {
+		return nil, taskFieldErr("save task field", err)
+	}
+	return &pb.SaveTaskFieldResponse{Response: &pb.Response{Code: code, Message: "ok"}, Field: taskFieldToProto(f)}, nil
+}
+
+// DeleteTaskField 删除字段定义并清除任务上的取值
+func (s *TaskServiceServer) DeleteTaskField(ctx context.Context, req *pb.DeleteTaskFieldRequest) (*pb.Response, error) {
+	userObj, err := userObjectID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad task field id")
+	}
+	if err := s.taskFields().Delete(ctx, userObj, id); err != nil {
+		return nil, taskFieldErr("delete task field", err)
+	}
+	return &pb.Response{Code: 200, Message: "deleted"}, nil
+}
@@ -17,10 +17,11 @@ import (
 type UnifiedServiceServer struct {
 	pb.UnimplementedUnifiedServiceServer
 	core *services.UnifiedService
+	db   *mongo.Database
 }
 
 func NewUnifiedServiceServer(db *mongo.Database) *UnifiedServiceServer {
-	return &UnifiedServiceServer{core: services.NewUnifiedService(db)}
+	return &UnifiedServiceServer{core: services.NewUnifiedService(db), db: db}
 }
 
 // GetUnifiedUpcoming 聚合未来窗口数据
@@ -47,7 +48,11 @@ func (s *UnifiedServiceServer) GetUnifiedUpcoming(ctx context.Context, req *pb.G
 	if req.Debug {
 		ctx = context.WithValue(ctx, "unifiedDebug", true)
 	}
-	items, dbg, err := s.core.GetUpcoming(ctx, userObj, hours, req.GetSources(), limit)
+	taskMatch, err := savedViewMatch(ctx, s.db, userObj, req.GetSavedViewId(), req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+	items, dbg, err := s.core.GetUpcomingFiltered(ctx, userObj, hours, req.GetSources(), limit, taskMatch)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "get upcoming err: %v", err)
 	}
@@ -55,7 +60,7 @@ func (s *UnifiedServiceServer) GetUnifiedUpcoming(ctx context.Context, req *pb.G
 	stats := &pb.UnifiedUpcomingStats{}
 	for i := range items {
 		it := &items[i]
-		respItems = append(respItems, &pb.UnifiedItem{Id: it.ID, Source: it.Source, Title: it.Title, ScheduledAt: timestamppb.New(it.ScheduledAt), CountdownSeconds: int32(it.CountdownSeconds), DaysLeft: int32(it.DaysLeft), Importance: int32(it.Importance), PriorityScore: it.PriorityScore, RelatedEventId: it.RelatedEventID, DetailUrl: it.DetailURL, SourceId: it.SourceID, IsUnscheduled: it.IsUnscheduled})
+		respItems = append(respItems, &pb.UnifiedItem{Id: it.ID, Source: it.Source, Title: it.Title, ScheduledAt: timestamppb.New(it.ScheduledAt), CountdownSeconds: int32(it.CountdownSeconds), DaysLeft: int32(it.DaysLeft), Importance: int32(it.Importance), PriorityScore: it.PriorityScore, RelatedEventId: it.RelatedEventID, DetailUrl: it.DetailURL, SourceId: it.SourceID, IsUnscheduled: it.IsUnscheduled, OccurrenceKey: it.OccurrenceKey, Blocked: it.Blocked, NewlyUnblocked: it.NewlyUnblocked})
 		switch it.Source {
 		case "task":
 			stats.Tasks++
@@ -111,7 +116,7 @@ func (s *UnifiedServiceServer) GetUnifiedCalendar(ctx context.Context, req *pb.G
 		items := make([]*pb.UnifiedCalendarItem, 0, len(list))
 		for i := range list {
 			it := &list[i]
-			items = append(items, &pb.UnifiedCalendarItem{Id: it.ID, Source: it.Source, Title: it.Title, ScheduledAt: timestamppb.New(it.ScheduledAt), Importance: int32(it.Importance), DetailUrl: it.DetailURL})
+			items = append(items, &pb.UnifiedCalendarItem{Id: it.ID, Source: it.Source, Title: it.Title, ScheduledAt: timestamppb.New(it.ScheduledAt), Importance: int32(it.Importance), DetailUrl: it.DetailURL, OccurrenceKey: it.OccurrenceKey})
 		}
 		outDays = append(outDays, &pb.UnifiedCalendarDay{Date: day, Items: items})
 	}
@@ -0,0 +1,202 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/convert"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/services"
+	pb "github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WorkspaceServiceServer 共享工作区与成员管理
+type WorkspaceServiceServer struct {
+	pb.UnimplementedWorkspaceServiceServer
+	core *services.WorkspaceService
+}
+
+func NewWorkspaceServiceServer(db *mongo.Database) *WorkspaceServiceServer {
+	return &WorkspaceServiceServer{core: services.NewWorkspaceService(repository.NewWorkspaceRepository(db), repository.NewUserRepository(db))}
+}
+
+// workspaceStatus 工作区相关错误映射为 gRPC 状态，其他错误返回 nil
+func workspaceStatus(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrWorkspaceNotFound), errors.Is(err, services.ErrWorkspaceMemberNotFound), errors.Is(err, repository.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repository.ErrWorkspaceForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, services.ErrWorkspaceNameRequired), errors.Is(err, services.ErrInvalidWorkspaceRole):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrWorkspaceMemberExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, services.ErrWorkspaceLastOwner):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repository.ErrWorkspaceMembersChanged):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return nil
+}
+
+// workspaceErr 映射工作区错误，其余按 Internal 返回
+func workspaceErr(op string, err error) error {
+	if st := workspaceStatus(err); st != nil {
+		return st
+	}
+	return status.Errorf(codes.Internal, "%s err: %v", op, err)
+}
+
+// workspaceScope 当前用户与请求中的工作区 ID
+func workspaceScope(ctx context.Context, id string) (primitive.ObjectID, primitive.ObjectID, error) {
+	uid, _ := UserIDFromContext(ctx)
+	if uid == "" {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.Unauthenticated, "user id missing")
+	}
+	userObj, err := primitive.ObjectIDFromHex(uid)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "bad user id")
+	}
+	if id == "" {
+		return userObj, primitive.NilObjectID, nil
+	}
+	wsID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, primitive.NilObjectID, status.Error(codes.InvalidArgument, "bad workspace id")
+	}
+	return userObj, wsID, nil
+}
+
+func workspaceResponse(w *models.Workspace, code int32, msg string) *pb.WorkspaceResponse {
+	return &pb.WorkspaceResponse{Response: &pb.Response{Code: code, Message: msg}, Workspace: convert.WorkspaceToProto(w)}
+}
+
+func (s *WorkspaceServiceServer) CreateWorkspace(ctx context.Context, req *pb.CreateWorkspaceRequest) (*pb.WorkspaceResponse, error) {
+	uid, _, err := workspaceScope(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.core.Create(ctx, uid, models.CreateWorkspaceRequest{Name: req.GetName(), Description: req.GetDescription()})
+	if err != nil {
+		return nil, workspaceErr("create workspace", err)
+	}
+	return workspaceResponse(w, 201, "created"), nil
+}
+
+func (s *WorkspaceServiceServer) ListWorkspaces(ctx context.Context, _ *pb.ListWorkspacesRequest) (*pb.ListWorkspacesResponse, error) {
+	uid, _, err := workspaceScope(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.core.List(ctx, uid)
+	if err != nil {
+		return nil, workspaceErr("list workspaces", err)
+	}
+	out := make([]*pb.Workspace, 0, len(list))
+	for i := range list {
+		out = append(out, convert.WorkspaceToProto(&list[i]))
+	}
+	return &pb.ListWorkspacesResponse{Response: &pb.Response{Code: 200, Message: "ok"}, Workspaces: out}, nil
+}
+
+func (s *WorkspaceServiceServer) GetWorkspace(ctx context.Context, req *pb.GetWorkspaceRequest) (*pb.WorkspaceResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	uid, id, err := workspaceScope(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.core.Get(ctx, uid, id)
+	if err != nil {
+		return nil, workspaceErr("get workspace", err)
+	}
+	return workspaceResponse(w, 200, "ok"), nil
+}
+
+func (s *WorkspaceServiceServer) UpdateWorkspace(ctx context.Context, req *pb.UpdateWorkspaceRequest) (*pb.WorkspaceResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	uid, id, err := workspaceScope(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.core.Update(ctx, uid, id, models.UpdateWorkspaceRequest{Name: req.Name, Description: req.Description})
+	if err != nil {
+		return nil, workspaceErr("update workspace", err)
+	}
+	return workspaceResponse(w, 200, "ok"), nil
+}
+
+func (s *WorkspaceServiceServer) DeleteWorkspace(ctx context.Context, req *pb.DeleteWorkspaceRequest) (*pb.DeleteWorkspaceResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	uid, id, err := workspaceScope(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.core.Delete(ctx, uid, id); err != nil {
+		return nil, workspaceErr("delete workspace", err)
+	}
+	return &pb.DeleteWorkspaceResponse{Response: &pb.Response{Code: 200, Message: "deleted"}, Success: true}, nil
+}
+
+func (s *WorkspaceServiceServer) AddWorkspaceMember(ctx context.Context, req *pb.AddWorkspaceMemberRequest) (*pb.WorkspaceResponse, error) {
+	if req.GetId() == "" || req.GetUser() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id/user required")
+	}
+	uid, id, err := workspaceScope(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.core.AddMember(ctx, uid, id, models.WorkspaceMemberRequest{User: req.GetUser(), Role: req.GetRole()})
+	if err != nil {
+		return nil, workspaceErr("add member", err)
+	}
+	return workspaceResponse(w, 200, "ok"), nil
+}
+
+func (s *WorkspaceServiceServer) UpdateWorkspaceMember(ctx context.Context, req *pb.UpdateWorkspaceMemberRequest) (*pb.WorkspaceResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	uid, id, err := workspaceScope(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	memberID, err := primitive.ObjectIDFromHex(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad member id")
+	}
+	w, err := s.core.UpdateMember(ctx, uid, id, memberID, req.GetRole())
+	if err != nil {
+		return nil, workspaceErr("update member", err)
+	}
+	return workspaceResponse(w, 200, "ok"), nil
+}
+
+func (s *WorkspaceServiceServer) RemoveWorkspaceMember(ctx context.Context, req *pb.RemoveWorkspaceMemberRequest) (*pb.WorkspaceResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id required")
+	}
+	uid, id, err := workspaceScope(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	memberID, err := primitive.ObjectIDFromHex(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "bad member id")
+	}
+	w, err := s.core.RemoveMember(ctx, uid, id, memberID)
+	if err != nil {
+		return nil, workspaceErr("remove member", err)
+	}
+	return workspaceResponse(w, 200, "ok"), nil
+}
@@ -318,7 +318,7 @@ func (h *EventHandler) GetCalendarEvents(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	calendar, err := h.eventService.GetCalendarEvents(context.Background(), objectID, year, month)
+	calendar, err := h.eventService.GetCalendarEvents(context.Background(), objectID, year, month, nil) // legacy: UTC
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to get calendar events: %v", err), http.StatusInternalServerError)
 		return
@@ -64,7 +64,7 @@ func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "At least one reminder time is required", http.StatusBadRequest)
 		return
 	}
-	if req.ReminderType == "" {
+	if len(req.ReminderType) == 0 {
 		http.Error(w, "Reminder type is required", http.StatusBadRequest)
 		return
 	}
@@ -0,0 +1,248 @@
+// Package ical 提供 RFC 5545 iCalendar 的最小编码实现 (订阅导出使用)
+package ical
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 常用时间格式
+const (
+	DateLayout     = "20060102"
+	DateTimeLayout = "20060102T150405"
+	UTCLayout      = "20060102T150405Z"
+)
+
+// Property 单个属性行，如 DTSTART;TZID=Asia/Shanghai:20250101T090000
+type Property struct {
+	Name   string
+	Params [][2]string // 保持参数顺序
+	Value  string
+}
+
+// Component 组件 (VCALENDAR / VEVENT / VTODO / VALARM / VTIMEZONE ...)
+type Component struct {
+	Name     string
+	Props    []Property
+	Children []*Component
+}
+
+// NewComponent 创建组件
+func NewComponent(name string) *Component { return &Component{Name: name} }
+
+// Add 追加属性 (值原样写出，文本值需先 EscapeText)
+func (c *Component) Add(name, value string, params ...[2]string) *Component {
+	c.Props = append(c.Props, Property{Name: name, Params: params, Value: value})
+	return c
+}
+
+// AddText 追加文本属性 (自动转义)，空值忽略
+func (c *Component) AddText(name, value string, params ...[2]string) *Component {
+	if value == "" {
+		return c
+	}
+	return c.Add(name, EscapeText(value), params...)
+}
+
+// AddChild 追加子组件
+func (c *Component) AddChild(child *Component) *Component {
+	c.Children = append(c.Children, child)
+	return c
+}
+
+// Get 返回第一个同名属性
+func (c *Component) Get(name string) *Property {
+	for i := range c.Props {
+		if c.Props[i].Name == name {
+			return &c.Props[i]
+		}
+	}
+	return nil
+}
+
+// Param 返回属性参数值
+func (p *Property) Param(name string) string {
+	for _, kv := range p.Params {
+		if strings.EqualFold(kv[0], name) {
+			return kv[1]
+		}
+	}
+	return ""
+}
+
+// Encode 按 CRLF 与 75 字节折行写出组件
+func (c *Component) Encode(w io.Writer) error {
+	if err := writeLine(w, "BEGIN:"+c.Name); err != nil {
+		return err
+	}
+	for _, p := range c.Props {
+		var b strings.Builder
+		b.WriteString(p.Name)
+		for _, kv := range p.Params {
+			b.WriteString(";" + kv[0] + "=" + quoteParam(kv[1]))
+		}
+		b.WriteString(":" + p.Value)
+		if err := writeLine(w, b.String()); err != nil {
+			return err
+		}
+	}
+	for _, ch := range c.Children {
+		if err := ch.Encode(w); err != nil {
+			return err
+		}
+	}
+	return writeLine(w, "END:"+c.Name)
+}
+
+// writeLine 折行：每行不超过 75 字节，且不拆分 UTF-8 多字节字符
+func writeLine(w io.Writer, line string) error {
+	const max = 75
+	var b strings.Builder
+	n := 0
+	limit := max
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 0
+			limit = max - 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	b.WriteString("\r\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func quoteParam(v string) string {
+	if strings.ContainsAny(v, ":;,") {
+		return `"` + strings.ReplaceAll(v, `"`, "") + `"`
+	}
+	return v
+}
+
+// EscapeText 转义 TEXT 值中的 \ ; , 与换行
+func EscapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return r.Replace(s)
+}
+
+// UnescapeText EscapeText 的逆过程
+func UnescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// FormatUTC 格式化为 UTC DATE-TIME
+func FormatUTC(t time.Time) string { return t.UTC().Format(UTCLayout) }
+
+// FormatDuration 格式化 DURATION (如 -P1DT15H)，用于 VALARM TRIGGER
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	var b strings.Builder
+	b.WriteString(sign + "P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if h > 0 || m > 0 || days == 0 {
+		b.WriteString("T")
+		if h > 0 {
+			fmt.Fprintf(&b, "%dH", h)
+		}
+		if m > 0 || h == 0 {
+			fmt.Fprintf(&b, "%dM", m)
+		}
+	}
+	return b.String()
+}
+
+// VTimezone 根据 Go 时区数据生成 [from, to] 内各次偏移切换的 VTIMEZONE (无切换时输出固定偏移)
+func VTimezone(loc *time.Location, from, to time.Time) *Component {
+	tz := NewComponent("VTIMEZONE").Add("TZID", loc.String())
+	type change struct {
+		at       time.Time
+		from, to int
+		name     string
+		dst      bool
+	}
+	var changes []change
+	cur := from.In(loc)
+	_, prevOff := cur.Zone()
+	for day := cur; day.Before(to); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		if _, off := next.Zone(); off != prevOff {
+			// 二分定位切换时刻 (精确到秒)
+			lo, hi := day, next
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2)
+				if _, o := mid.Zone(); o == prevOff {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			name, _ := hi.Zone()
+			changes = append(changes, change{at: hi, from: prevOff, to: off, name: name, dst: hi.IsDST()})
+			prevOff = off
+		}
+	}
+	if len(changes) == 0 {
+		name, off := cur.Zone()
+		std := NewComponent("STANDARD").
+			Add("DTSTART", "19700101T000000").
+			Add("TZOFFSETFROM", formatOffset(off)).
+			Add("TZOFFSETTO", formatOffset(off)).
+			Add("TZNAME", name)
+		return tz.AddChild(std)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].at.Before(changes[j].at) })
+	for _, c := range changes {
+		kind := "STANDARD"
+		if c.dst {
+			kind = "DAYLIGHT"
+		}
+		// DTSTART 为切换前偏移下的本地时间
+		local := c.at.UTC().Add(time.Duration(c.from) * time.Second)
+		tz.AddChild(NewComponent(kind).
+			Add("DTSTART", local.Format(DateTimeLayout)).
+			Add("TZOFFSETFROM", formatOffset(c.from)).
+			Add("TZOFFSETTO", formatOffset(c.to)).
+			Add("TZNAME", c.name))
+	}
+	return tz
+}
+
+func formatOffset(sec int) string {
+	sign := "+"
+	if sec < 0 {
+		sign = "-"
+		sec = -sec
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, sec/3600, (sec%3600)/60)
+}
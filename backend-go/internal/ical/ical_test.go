@@ -0,0 +1,75 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeParseRoundTrip(t *testing.T) {
+	long := strings.Repeat("周会议程; 第一项, 第二项\n", 6)
+	cal := NewComponent("VCALENDAR").Add("VERSION", "2.0")
+	ev := NewComponent("VEVENT").
+		Add("UID", "abc@example.com").
+		Add("DTSTART", "20250106T090000", [2]string{"TZID", "Asia/Shanghai"}).
+		AddText("DESCRIPTION", long)
+	cal.AddChild(ev)
+	var buf bytes.Buffer
+	require.NoError(t, cal.Encode(&buf))
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+
+	roots, err := Parse(&buf)
+	require.NoError(t, err)
+	require.Len(t, roots, 1)
+	evs := roots[0].Components("VEVENT")
+	require.Len(t, evs, 1)
+	require.Equal(t, long, evs[0].Text("DESCRIPTION"))
+	ts, allDay, tzid, err := evs[0].Get("DTSTART").Times(time.UTC)
+	require.NoError(t, err)
+	require.False(t, allDay)
+	require.Equal(t, "Asia/Shanghai", tzid)
+	require.Equal(t, time.Date(2025, 1, 6, 1, 0, 0, 0, time.UTC), ts[0].UTC())
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n"))
+	require.ErrorIs(t, err, ErrMalformed)
+	_, err = Parse(strings.NewReader("SUMMARY:x\n"))
+	require.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestPropertyTimes(t *testing.T) {
+	p := Property{Name: "EXDATE", Params: [][2]string{{"VALUE", "DATE"}}, Value: "20250308,20250309"}
+	ts, allDay, _, err := p.Times(time.UTC)
+	require.NoError(t, err)
+	require.True(t, allDay)
+	require.Len(t, ts, 2)
+
+	p = Property{Name: "DTSTART", Params: [][2]string{{"TZID", "Pacific Standard Time"}}, Value: "20250101T080000"}
+	ts, _, tzid, err := p.Times(time.UTC)
+	require.NoError(t, err)
+	require.Empty(t, tzid) // 非 IANA 名称按浮动时间处理
+	require.Equal(t, time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC), ts[0])
+}
+
+func TestDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"-P1DT15H": -(39 * time.Hour),
+		"PT30M":    30 * time.Minute,
+		"-P1W":     -7 * 24 * time.Hour,
+		"PT0S":     0,
+	}
+	for in, want := range cases {
+		d, err := ParseDuration(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, d, in)
+	}
+	_, err := ParseDuration("P1H")
+	require.Error(t, err)
+	require.Equal(t, "-P1DT15H", FormatDuration(-39*time.Hour))
+}
@@ -0,0 +1,241 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMalformed 无法解析的 iCalendar 内容
+var ErrMalformed = errors.New("malformed icalendar")
+
+// Parse 解析 iCalendar 文本，返回顶层组件 (通常为一个或多个 VCALENDAR)
+func Parse(r io.Reader) ([]*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	var roots []*Component
+	var stack []*Component
+	for i, line := range lines {
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrMalformed, i+1, err)
+		}
+		switch p.Name {
+		case "BEGIN":
+			c := NewComponent(strings.ToUpper(p.Value))
+			if len(stack) > 0 {
+				stack[len(stack)-1].AddChild(c)
+			} else {
+				roots = append(roots, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrMalformed, i+1, p.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: line %d: property outside component", ErrMalformed, i+1)
+			}
+			cur := stack[len(stack)-1]
+			cur.Props = append(cur.Props, p)
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", ErrMalformed, stack[len(stack)-1].Name)
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("%w: no components", ErrMalformed)
+	}
+	return roots, nil
+}
+
+// unfold 合并折行 (以空格或制表符开头的续行)，兼容 LF 换行
+func unfold(r io.Reader) ([]string, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	var out []string
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if len(out) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(out) > 0 {
+			out[len(out)-1] += line[1:]
+			continue
+		}
+		out = append(out, line)
+	}
+	return out, sc.Err()
+}
+
+// parseLine 解析 NAME;PARAM=V;PARAM="V":VALUE
+func parseLine(line string) (Property, error) {
+	var p Property
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return p, fmt.Errorf("missing ':' in %q", line)
+	}
+	p.Name = strings.ToUpper(line[:i])
+	rest := line[i:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return p, fmt.Errorf("bad parameter in %q", line)
+		}
+		key := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+		var val string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return p, fmt.Errorf("unterminated quote in %q", line)
+			}
+			val = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return p, fmt.Errorf("missing ':' in %q", line)
+			}
+			val = rest[:end]
+			rest = rest[end:]
+		}
+		p.Params = append(p.Params, [2]string{key, val})
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return p, fmt.Errorf("missing ':' in %q", line)
+	}
+	p.Value = rest[1:]
+	return p, nil
+}
+
+// All 返回全部同名属性
+func (c *Component) All(name string) []Property {
+	var out []Property
+	for _, p := range c.Props {
+		if p.Name == name {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Text 返回第一个同名属性的反转义文本值
+func (c *Component) Text(name string) string {
+	if p := c.Get(name); p != nil {
+		return UnescapeText(p.Value)
+	}
+	return ""
+}
+
+// Components 返回指定名称的直接子组件
+func (c *Component) Components(name string) []*Component {
+	var out []*Component
+	for _, ch := range c.Children {
+		if ch.Name == name {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+// Times 解析 DATE / DATE-TIME 属性值 (可为逗号分隔的多个值)。
+// 带 Z 为 UTC；带 TZID 按该时区；浮动时间按 floating 时区。allDay 表示 VALUE=DATE。
+// TZID 无法识别时返回的 tzid 为空，时间按 floating 解释。
+func (p *Property) Times(floating *time.Location) (ts []time.Time, allDay bool, tzid string, err error) {
+	loc := floating
+	if id := p.Param("TZID"); id != "" {
+		if l, lerr := time.LoadLocation(strings.TrimPrefix(id, "/")); lerr == nil {
+			loc, tzid = l, l.String()
+		}
+	}
+	allDay = strings.EqualFold(p.Param("VALUE"), "DATE")
+	for _, v := range strings.Split(p.Value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		var t time.Time
+		switch {
+		case len(v) == 8:
+			allDay = true
+			t, err = time.ParseInLocation(DateLayout, v, loc)
+		case strings.HasSuffix(v, "Z"):
+			t, err = time.Parse(UTCLayout, v)
+		default:
+			t, err = time.ParseInLocation(DateTimeLayout, v, loc)
+		}
+		if err != nil {
+			return nil, false, "", fmt.Errorf("%w: bad %s value %q", ErrMalformed, p.Name, v)
+		}
+		ts = append(ts, t)
+	}
+	if len(ts) == 0 {
+		return nil, false, "", fmt.Errorf("%w: empty %s", ErrMalformed, p.Name)
+	}
+	return ts, allDay, tzid, nil
+}
+
+// ParseDuration 解析 DURATION，如 -P1DT15H / PT30M / P1W
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(strings.ToUpper(s))
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("%w: bad duration %q", ErrMalformed, s)
+	}
+	s = s[1:]
+	var d time.Duration
+	inTime := false
+	num := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			num += string(r)
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, fmt.Errorf("%w: bad duration %q", ErrMalformed, s)
+		}
+		num = ""
+		switch {
+		case r == 'W' && !inTime:
+			d += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			d += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			d += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			d += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			d += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("%w: bad duration %q", ErrMalformed, s)
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("%w: bad duration %q", ErrMalformed, s)
+	}
+	return sign * d, nil
+}
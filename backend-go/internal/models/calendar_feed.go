@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CalendarFeedToken iCalendar 订阅令牌 (集合 calendar_feed_tokens)
+// 仅保存令牌的 SHA-256 摘要，明文只在创建时返回一次；撤销后订阅地址立即失效
+type CalendarFeedToken struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name       string             `bson:"name" json:"name"`
+	TokenHash  string             `bson:"token_hash" json:"-"`
+	TokenHint  string             `bson:"token_hint" json:"token_hint"` // 令牌末 4 位，便于用户辨认
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	LastUsedAt *time.Time         `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time         `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+// CreateCalendarFeedRequest 创建订阅令牌请求
+type CreateCalendarFeedRequest struct {
+	Name string `json:"name"`
+}
+
+// CalendarFeedCreated 创建结果 (含一次性明文令牌与订阅路径)
+type CalendarFeedCreated struct {
+	Feed  CalendarFeedToken `json:"feed"`
+	Token string            `json:"token"`
+	URL   string            `json:"url"`
+}
+
+// CalendarFeedData 订阅导出所需的用户数据 (由 UnifiedService 聚合)
+type CalendarFeedData struct {
+	TimeZone  string
+	Events    []Event    // 循环事件为系列本身 (含例外)，不展开
+	Reminders []Reminder // 仅有效提醒
+	Tasks     []Task     // 具有 deadline / scheduledDate 的任务
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 汇总邮件频率
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// ErrInvalidDigestSettings 汇总设置不合法
+var ErrInvalidDigestSettings = errors.New("invalid digest settings")
+
+// DigestSettings 邮件汇总订阅 (集合 digest_settings，每个用户一条，需用户主动开启)
+type DigestSettings struct {
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Enabled    bool               `bson:"enabled" json:"enabled"`
+	Frequency  string             `bson:"frequency" json:"frequency"` // daily / weekly
+	SendAt     string             `bson:"send_at" json:"send_at"`     // 用户时区的 HH:MM，默认 08:00
+	Weekday    int                `bson:"weekday" json:"weekday"`     // weekly 发送日，0=周日 … 6=周六，默认周一
+	NextSendAt *time.Time         `bson:"next_send_at,omitempty" json:"next_send_at,omitempty"`
+	LastSentAt *time.Time         `bson:"last_sent_at,omitempty" json:"last_sent_at,omitempty"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// UpdateDigestSettingsRequest 更新汇总订阅 (未提供的字段保持不变)
+type UpdateDigestSettingsRequest struct {
+	Enabled   *bool   `json:"enabled,omitempty"`
+	Frequency *string `json:"frequency,omitempty"`
+	SendAt    *string `json:"send_at,omitempty"`
+	Weekday   *int    `json:"weekday,omitempty"`
+}
+
+// DefaultDigestSettings 未订阅用户的默认设置 (关闭)
+func DefaultDigestSettings(userID primitive.ObjectID) *DigestSettings {
+	return &DigestSettings{UserID: userID, Frequency: DigestDaily, SendAt: "08:00", Weekday: int(time.Monday)}
+}
+
+// Validate 校验频率、发送时间与星期
+func (d *DigestSettings) Validate() error {
+	if d.Frequency != DigestDaily && d.Frequency != DigestWeekly {
+		return fmt.Errorf("%w: frequency %q", ErrInvalidDigestSettings, d.Frequency)
+	}
+	if _, err := parseClock(d.SendAt); err != nil {
+		return fmt.Errorf("%w: send_at %q", ErrInvalidDigestSettings, d.SendAt)
+	}
+	if d.Weekday < 0 || d.Weekday > 6 {
+		return fmt.Errorf("%w: weekday %d", ErrInvalidDigestSettings, d.Weekday)
+	}
+	return nil
+}
+
+// Window 汇总覆盖的时间窗口 (每日 24 小时，每周 7 天)
+func (d *DigestSettings) Window() time.Duration {
+	if d.Frequency == DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// NextAfter after 之后 (不含) 的下一次发送时间，按 loc 的墙上时间计算
+func (d *DigestSettings) NextAfter(after time.Time, loc *time.Location) time.Time {
+	minutes, err := parseClock(d.SendAt)
+	if err != nil {
+		minutes = 8 * 60
+	}
+	day := StartOfDay(after, loc)
+	for i := 0; i <= 8; i++ {
+		c := day.AddDate(0, 0, i)
+		at := time.Date(c.Year(), c.Month(), c.Day(), minutes/60, minutes%60, 0, 0, loc)
+		if !at.After(after) {
+			continue
+		}
+		if d.Frequency == DigestWeekly && int(at.Weekday()) != d.Weekday {
+			continue
+		}
+		return at
+	}
+	return day.AddDate(0, 0, 7)
+}
+
+// Digest 一封汇总邮件的内容
+type Digest struct {
+	UserID        primitive.ObjectID `json:"user_id"`
+	Frequency     string             `json:"frequency"`
+	GeneratedAt   time.Time          `json:"generated_at"`
+	WindowEnd     time.Time          `json:"window_end"`
+	OverdueTasks  []UnifiedItem      `json:"overdue_tasks"`
+	Events        []UnifiedItem      `json:"events"`
+	Reminders     []UnifiedItem      `json:"reminders"`
+	UpcomingTasks []UnifiedItem      `json:"upcoming_tasks"`
+	PriorityTasks []PriorityTask     `json:"priority_tasks"`
+	Notifications []Notification     `json:"notifications"` // 汇总模式下暂存、尚未读的通知
+}
+
+// IsEmpty 无任何条目时不发送
+func (d *Digest) IsEmpty() bool {
+	return len(d.OverdueTasks)+len(d.Events)+len(d.Reminders)+len(d.UpcomingTasks)+len(d.PriorityTasks)+len(d.Notifications) == 0
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDigestSettingsNextAfter(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	d := DefaultDigestSettings(primitive.NewObjectID())
+	require.NoError(t, d.Validate())
+
+	// 2030-03-09 周六 09:00：当天 08:00 已过，下一次为次日 08:00 (夏令时切换日，按墙上时间)
+	now := time.Date(2030, 3, 9, 9, 0, 0, 0, loc)
+	require.Equal(t, time.Date(2030, 3, 10, 8, 0, 0, 0, loc), d.NextAfter(now, loc))
+	require.Equal(t, time.Date(2030, 3, 9, 8, 0, 0, 0, loc), d.NextAfter(now.Add(-2*time.Hour), loc))
+
+	d.Frequency, d.Weekday = DigestWeekly, int(time.Monday)
+	require.Equal(t, time.Date(2030, 3, 11, 8, 0, 0, 0, loc), d.NextAfter(now, loc))
+	require.Equal(t, time.Date(2030, 3, 18, 8, 0, 0, 0, loc), d.NextAfter(time.Date(2030, 3, 11, 8, 0, 0, 0, loc), loc))
+	require.Equal(t, 7*24*time.Hour, d.Window())
+
+	d.SendAt = "8am"
+	require.ErrorIs(t, d.Validate(), ErrInvalidDigestSettings)
+}
@@ -20,10 +20,20 @@ type Event struct {
 	Tags             []string               `bson:"tags" json:"tags"`
 	Location         string                 `bson:"location,omitempty" json:"location,omitempty"`
 	IsAllDay         bool                   `bson:"is_all_day" json:"is_all_day"`
+	TimeZone         string                 `bson:"time_zone,omitempty" json:"time_zone,omitempty"` // IANA 时区，空表示 UTC
+	ICalUID          string                 `bson:"ical_uid,omitempty" json:"ical_uid,omitempty"`   // 从 .ics 导入时的 UID，重复导入据此更新
 	CreatedAt        time.Time              `bson:"created_at" json:"created_at"`
 	UpdatedAt        time.Time              `bson:"updated_at" json:"updated_at"`
 	IsActive         bool                   `bson:"is_active" json:"is_active"`
 	LastTriggeredAt  *time.Time             `bson:"last_triggered_at,omitempty" json:"last_triggered_at,omitempty"` // 系统自动时间线记录最近一次事件开始触发时间
+	WorkspaceID      *primitive.ObjectID    `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"`           // 所属工作区，空为个人事件
+	NextStart        *time.Time             `bson:"next_start,omitempty" json:"-"`                                  // 调度扫描用的下次发生时间，由仓储维护，缺失表示待计算
+
+	// 以下为循环展开后的虚拟发生字段，不落库
+	OccurrenceDate *time.Time `bson:"-" json:"occurrence_date,omitempty"`
+	OccurrenceKey  string     `bson:"-" json:"occurrence_key,omitempty"`
+	// Exceptions 由仓储按需加载的单次发生例外，参与展开与下次发生计算
+	Exceptions []EventException `bson:"-" json:"-"`
 }
 
 // CreateEventRequest 创建事件请求
@@ -38,6 +48,8 @@ type CreateEventRequest struct {
 	Tags             []string               `json:"tags"`
 	Location         string                 `json:"location,omitempty"`
 	IsAllDay         bool                   `json:"is_all_day"`
+	TimeZone         string                 `json:"time_zone,omitempty"`
+	WorkspaceID      *primitive.ObjectID    `json:"workspace_id,omitempty"`
 }
 
 // UpdateEventRequest 更新事件请求
@@ -52,6 +64,7 @@ type UpdateEventRequest struct {
 	Tags             []string               `json:"tags,omitempty"`
 	Location         *string                `json:"location,omitempty"`
 	IsAllDay         *bool                  `json:"is_all_day,omitempty"`
+	TimeZone         *string                `json:"time_zone,omitempty"`
 	IsActive         *bool                  `json:"is_active,omitempty"`
 }
 
@@ -70,55 +83,44 @@ type EventCalendarResponse struct {
 	Events []Event `json:"events"`
 }
 
-// GetNextOccurrence 获取下次发生时间
+// GetNextOccurrence 获取严格晚于 after 的下次发生时间 (基于 RRULE 引擎)，无后续发生返回 nil
 func (e *Event) GetNextOccurrence(after time.Time) *time.Time {
-	if e.RecurrenceType == "none" {
+	rule := e.EffectiveRecurrenceRule()
+	if rule == nil {
 		if e.EventDate.After(after) {
 			return &e.EventDate
 		}
 		return nil
 	}
-
-	baseDate := e.EventDate
-	next := baseDate
-
-	switch e.RecurrenceType {
-	case "yearly":
-		// 年度循环：每年同一天
-		year := after.Year()
-		if after.Month() > baseDate.Month() ||
-			(after.Month() == baseDate.Month() && after.Day() >= baseDate.Day()) {
-			year++
+	if len(e.Exceptions) == 0 {
+		return rule.Next(e.LocalEventDate(), after)
+	}
+	// 跳过被取消/改期的原始发生，再与改期后的时间取最早
+	var next *time.Time
+	rule.iterate(e.LocalEventDate(), after, func(t time.Time) bool {
+		if !t.After(after) {
+			return true
 		}
-		next = time.Date(year, baseDate.Month(), baseDate.Day(),
-			baseDate.Hour(), baseDate.Minute(), baseDate.Second(), 0, baseDate.Location())
-
-	case "monthly":
-		// 月度循环：每月同一天
-		year, month := after.Year(), after.Month()
-		if after.Day() >= baseDate.Day() {
-			month++
-			if month > 12 {
-				year++
-				month = 1
-			}
+		if ex := e.exceptionAt(t); ex != nil && (ex.Cancelled || ex.NewDate != nil) {
+			return true
+		}
+		next = &t
+		return false
+	})
+	for i := range e.Exceptions {
+		ex := &e.Exceptions[i]
+		if ex.Cancelled || ex.NewDate == nil || !ex.NewDate.After(after) {
+			continue
+		}
+		if len(e.OccurrencesBetween(ex.OccurrenceDate, ex.OccurrenceDate, 1)) == 0 { // 原始发生已不属于该系列
+			continue
+		}
+		if next == nil || ex.NewDate.Before(*next) {
+			t := *ex.NewDate
+			next = &t
 		}
-		next = time.Date(year, month, baseDate.Day(),
-			baseDate.Hour(), baseDate.Minute(), baseDate.Second(), 0, baseDate.Location())
-
-	case "weekly":
-		// 周循环：每周同一天
-		days := int(after.Sub(baseDate).Hours() / 24)
-		weeks := (days / 7) + 1
-		next = baseDate.AddDate(0, 0, weeks*7)
-
-	case "daily":
-		// 日循环：每天
-		days := int(after.Sub(baseDate).Hours()/24) + 1
-		next = baseDate.AddDate(0, 0, days)
 	}
-
-	return &next
+	return next
 }
 
 // IsUpcoming 检查是否为即将到来的事件（7天内）
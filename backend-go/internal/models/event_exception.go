@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 单次发生编辑范围
+const (
+	OccurrenceScopeThis      = "this"      // 仅此次
+	OccurrenceScopeFollowing = "following" // 此次及之后
+)
+
+// 单次发生编辑动作
+const (
+	OccurrenceActionCancel = "cancel" // 取消 (跳过)
+	OccurrenceActionModify = "modify" // 改期 / 覆盖标题地点
+)
+
+// EventException 循环事件的单次发生例外记录 (集合 event_exceptions)
+// OccurrenceDate 为按规则计算出的原始发生时间 (等价 iCalendar RECURRENCE-ID)，与 event_id 组成唯一键
+type EventException struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	EventID        primitive.ObjectID `bson:"event_id" json:"event_id"`
+	UserID         primitive.ObjectID `bson:"user_id" json:"user_id"`
+	OccurrenceDate time.Time          `bson:"occurrence_date" json:"occurrence_date"`
+	Cancelled      bool               `bson:"cancelled" json:"cancelled"`
+	NewDate        *time.Time         `bson:"new_date,omitempty" json:"new_date,omitempty"`
+	Title          *string            `bson:"title,omitempty" json:"title,omitempty"`
+	Location       *string            `bson:"location,omitempty" json:"location,omitempty"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// OccurrenceKey 该例外对应的发生标识
+func (x *EventException) OccurrenceKey() string {
+	return BuildOccurrenceKey(x.EventID, x.OccurrenceDate)
+}
+
+// EditOccurrenceRequest 编辑单次发生请求
+// occurrence_key 与 occurrence_date 二选一；scope 默认 this；action 默认 modify
+type EditOccurrenceRequest struct {
+	OccurrenceKey  string     `json:"occurrence_key,omitempty"`
+	OccurrenceDate *time.Time `json:"occurrence_date,omitempty"`
+	Scope          string     `json:"scope,omitempty"`
+	Action         string     `json:"action,omitempty"`
+	NewDate        *time.Time `json:"new_date,omitempty"`
+	Title          *string    `json:"title,omitempty"`
+	Location       *string    `json:"location,omitempty"`
+}
+
+// EditOccurrenceResult 编辑结果：仅此次返回例外记录；此次及之后返回被截断的原系列与新系列
+type EditOccurrenceResult struct {
+	Scope     string          `json:"scope"`
+	Exception *EventException `json:"exception,omitempty"`
+	Series    *Event          `json:"series,omitempty"`
+	NewSeries *Event          `json:"new_series,omitempty"`
+}
@@ -0,0 +1,47 @@
+package models
+
+// 导入结果状态
+const (
+	ImportStatusCreated = "created"
+	ImportStatusUpdated = "updated"
+	ImportStatusSkipped = "skipped"
+	ImportStatusError   = "error"
+)
+
+// EventImportItem 单个 VEVENT 的导入结果
+type EventImportItem struct {
+	Index     int      `json:"index"` // VEVENT 在文件中的序号 (从 0 开始)
+	UID       string   `json:"uid,omitempty"`
+	Title     string   `json:"title,omitempty"`
+	Status    string   `json:"status"`
+	EventID   string   `json:"event_id,omitempty"`
+	Reminders int      `json:"reminders"`          // 写入的提醒数
+	Error     string   `json:"error,omitempty"`    // status=error / skipped 的原因
+	Warnings  []string `json:"warnings,omitempty"` // 部分字段无法映射时的提示
+}
+
+// EventImportResult .ics 导入报告
+type EventImportResult struct {
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Items   []EventImportItem `json:"items"`
+}
+
+// Add 记录单项结果并累计计数
+func (r *EventImportResult) Add(item EventImportItem) {
+	switch item.Status {
+	case ImportStatusCreated:
+		r.Created++
+	case ImportStatusUpdated:
+		r.Updated++
+	case ImportStatusSkipped:
+		r.Skipped++
+	default:
+		r.Failed++
+	}
+	r.Total++
+	r.Items = append(r.Items, item)
+}
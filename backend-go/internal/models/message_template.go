@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MessageTemplateAllTypes URL 中表示“全部事件类型”的占位 (存储为空串)
+const MessageTemplateAllTypes = "all"
+
+// MessageTemplate 用户自定义提醒模板 (集合 message_templates)，按 (user_id, event_type, kind) 唯一；
+// EventType 为空表示适用于所有事件类型，精确类型优先
+type MessageTemplate struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	EventType string             `bson:"event_type" json:"event_type"` // birthday / meeting ...，空表示全部
+	Kind      string             `bson:"kind" json:"kind"`             // reminder_subject / reminder_text / reminder_email
+	Body      string             `bson:"body" json:"body"`             // Go 模板源码
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// UpsertMessageTemplateRequest 设置模板
+type UpsertMessageTemplateRequest struct {
+	Body string `json:"body"`
+}
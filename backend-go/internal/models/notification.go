@@ -16,6 +16,15 @@ type Notification struct {
 	ReadAt    *time.Time             `bson:"read_at,omitempty" json:"read_at,omitempty"`
 	CreatedAt time.Time              `bson:"created_at" json:"created_at"`
 	Metadata  map[string]interface{} `bson:"metadata,omitempty" json:"metadata,omitempty"`
+	Urgent    bool                   `bson:"urgent,omitempty" json:"urgent,omitempty"`         // 紧急通知不受免打扰限制
+	Digest    bool                   `bson:"digest,omitempty" json:"digest,omitempty"`         // 汇总模式：不实时推送
+	DeliverAt *time.Time             `bson:"deliver_at,omitempty" json:"deliver_at,omitempty"` // 免打扰延后：此前不推送、不出现在列表中
+	// 重复通知合并为线程：同一 GroupKey 的未读通知只保留最新一条，Count 为合并条数，FirstAt 为线程首条时间
+	GroupKey   string              `bson:"group_key,omitempty" json:"group_key,omitempty"`
+	Count      int                 `bson:"count,omitempty" json:"count,omitempty"`
+	FirstAt    *time.Time          `bson:"first_at,omitempty" json:"first_at,omitempty"`
+	ArchivedAt *time.Time          `bson:"archived_at,omitempty" json:"archived_at,omitempty"` // 归档：默认列表不返回，按归档保留期自动删除
+	Replaces   *primitive.ObjectID `bson:"-" json:"replaces,omitempty"`                        // 合并时被替换的上一条线程通知 (仅推送时携带)
 }
 
 // NotificationCreate 用于创建
@@ -25,4 +34,38 @@ type NotificationCreate struct {
 	Message  string
 	EventID  *primitive.ObjectID
 	Metadata map[string]interface{}
+	Urgent   bool
+	GroupKey string // 为空时按 Type + EventID 合并同一事件的重复通知
+}
+
+// ThreadKey 合并键：显式 GroupKey 优先，其次为 "类型:事件ID"；无事件的通知不合并
+func (in NotificationCreate) ThreadKey() string {
+	if in.GroupKey != "" {
+		return in.GroupKey
+	}
+	if in.EventID != nil && !in.EventID.IsZero() {
+		return in.Type + ":" + in.EventID.Hex()
+	}
+	return ""
+}
+
+// NotificationQuery 通知列表条件；Cursor 为上一页返回的 next_cursor (按 ID 倒序翻页)
+type NotificationQuery struct {
+	UnreadOnly bool
+	Archived   bool   // true 只列归档通知，false 只列未归档
+	Type       string // 为空不过滤
+	Cursor     primitive.ObjectID
+	Limit      int
+}
+
+// NotificationPage 一页通知；NextCursor 为空表示没有更多
+type NotificationPage struct {
+	Notifications []Notification `json:"notifications"`
+	NextCursor    string         `json:"next_cursor,omitempty"`
+}
+
+// UnreadCounts 未读数 (按类型)，合并后的线程计为一条
+type UnreadCounts struct {
+	Total  int64            `json:"total"`
+	ByType map[string]int64 `json:"by_type"`
 }
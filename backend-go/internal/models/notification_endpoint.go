@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotificationEndpoint 用户为外部渠道配置的投递目标 (集合 notification_endpoints)。
+// 同一渠道可配置多个目标，提醒会发送到该用户该渠道下的全部目标。
+type NotificationEndpoint struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID  primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Channel string             `bson:"channel" json:"channel"` // webhook / chat / telegram / webpush
+	Name    string             `bson:"name,omitempty" json:"name,omitempty"`
+	URL     string             `bson:"url,omitempty" json:"url,omitempty"`       // webhook / chat 地址；webpush 为订阅 endpoint
+	Format  string             `bson:"format,omitempty" json:"format,omitempty"` // chat 消息格式：slack / discord / feishu / dingtalk / wecom
+	Secret  string             `bson:"secret,omitempty" json:"-"`                // webhook HMAC 密钥 / 飞书、钉钉签名密钥
+	ChatID  string             `bson:"chat_id,omitempty" json:"chat_id,omitempty"`
+	// Web Push 订阅 (PushSubscription.keys)
+	P256dh    string    `bson:"p256dh,omitempty" json:"p256dh,omitempty"`
+	Auth      string    `bson:"auth,omitempty" json:"-"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CreateNotificationEndpointRequest 新增投递目标
+type CreateNotificationEndpointRequest struct {
+	Channel string `json:"channel"`
+	Name    string `json:"name,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Format  string `json:"format,omitempty"`
+	Secret  string `json:"secret,omitempty"` // webhook 未提供时自动生成
+	ChatID  string `json:"chat_id,omitempty"`
+	P256dh  string `json:"p256dh,omitempty"`
+	Auth    string `json:"auth,omitempty"`
+}
+
+// NotificationEndpointCreated 创建结果；webhook 自动生成的密钥仅在此返回一次
+type NotificationEndpointCreated struct {
+	Endpoint NotificationEndpoint `json:"endpoint"`
+	Secret   string               `json:"secret,omitempty"`
+}
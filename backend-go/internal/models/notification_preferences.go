@@ -0,0 +1,173 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 通知投递方式
+const (
+	NotificationModeImmediate = "immediate" // 立即推送
+	NotificationModeDigest    = "digest"    // 只写入通知列表，不实时推送，由汇总统一发送
+)
+
+// UrgentImportanceLevel 事件重要程度达到该值的提醒视为紧急，不受免打扰与周末静音限制
+const UrgentImportanceLevel = 5
+
+// ErrInvalidPreferences 通知偏好不合法
+var ErrInvalidPreferences = errors.New("invalid notification preferences")
+
+// NotificationPreferences 用户通知偏好 (集合 notification_preferences，每个用户一条)
+type NotificationPreferences struct {
+	UserID       primitive.ObjectID                    `bson:"user_id" json:"user_id"`
+	Mode         string                                `bson:"mode,omitempty" json:"mode,omitempty"`   // 全局投递方式，默认 immediate
+	Types        map[string]NotificationTypePreference `bson:"types,omitempty" json:"types,omitempty"` // 按通知 Type (reminder / timeline_event / email ...) 覆盖
+	QuietHours   *QuietHours                           `bson:"quiet_hours,omitempty" json:"quiet_hours,omitempty"`
+	MuteWeekends bool                                  `bson:"mute_weekends" json:"mute_weekends"` // 周六、周日整天视为免打扰
+	UpdatedAt    time.Time                             `bson:"updated_at" json:"updated_at"`
+}
+
+// NotificationTypePreference 单个通知类型的设置
+type NotificationTypePreference struct {
+	// Channels 允许的渠道 (app / email / webhook ...)；null 表示不限制，空数组表示关闭该类型
+	Channels []string `bson:"channels" json:"channels"`
+	Mode     string   `bson:"mode,omitempty" json:"mode,omitempty"` // 为空时沿用全局 Mode
+}
+
+// QuietHours 免打扰时段 (用户时区的 HH:MM)，Start > End 表示跨午夜，如 22:00-07:00
+type QuietHours struct {
+	Start string `bson:"start" json:"start"`
+	End   string `bson:"end" json:"end"`
+}
+
+// Validate 校验投递方式与免打扰时段
+func (p *NotificationPreferences) Validate() error {
+	if !validNotificationMode(p.Mode) {
+		return fmt.Errorf("%w: mode %q", ErrInvalidPreferences, p.Mode)
+	}
+	for t, tp := range p.Types {
+		if !validNotificationMode(tp.Mode) {
+			return fmt.Errorf("%w: mode %q for type %s", ErrInvalidPreferences, tp.Mode, t)
+		}
+	}
+	if q := p.QuietHours; q != nil {
+		if _, err := parseClock(q.Start); err != nil {
+			return fmt.Errorf("%w: quiet_hours.start %q", ErrInvalidPreferences, q.Start)
+		}
+		if _, err := parseClock(q.End); err != nil {
+			return fmt.Errorf("%w: quiet_hours.end %q", ErrInvalidPreferences, q.End)
+		}
+	}
+	return nil
+}
+
+func validNotificationMode(m string) bool {
+	return m == "" || m == NotificationModeImmediate || m == NotificationModeDigest
+}
+
+// parseClock 解析 HH:MM 为当天的分钟数
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// AllowsChannel 该类型通知是否允许经 channel 投递
+func (p *NotificationPreferences) AllowsChannel(notificationType, channel string) bool {
+	if p == nil {
+		return true
+	}
+	tp, ok := p.Types[notificationType]
+	if !ok || tp.Channels == nil {
+		return true
+	}
+	for _, c := range tp.Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// ModeFor 该类型通知的投递方式
+func (p *NotificationPreferences) ModeFor(notificationType string) string {
+	if p == nil {
+		return NotificationModeImmediate
+	}
+	if tp, ok := p.Types[notificationType]; ok && tp.Mode != "" {
+		return tp.Mode
+	}
+	if p.Mode != "" {
+		return p.Mode
+	}
+	return NotificationModeImmediate
+}
+
+// QuietUntil now 处于免打扰时段或周末静音时返回其结束时间，否则返回零值
+func (p *NotificationPreferences) QuietUntil(now time.Time, loc *time.Location) time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	t := now.In(loc)
+	// 周末静音与跨午夜的免打扰可能首尾相接 (周一 00:00 仍在 22:00-07:00 内)，推进到两者都不生效为止
+	for i := 0; i < 4; i++ {
+		next := t
+		if p.MuteWeekends && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+			days := 1
+			if t.Weekday() == time.Saturday {
+				days = 2
+			}
+			next = StartOfDay(t, loc).AddDate(0, 0, days)
+		} else if end, ok := p.quietEnd(t, loc); ok {
+			next = end
+		}
+		if next.Equal(t) {
+			break
+		}
+		t = next
+	}
+	if t.Equal(now.In(loc)) {
+		return time.Time{}
+	}
+	return t
+}
+
+// quietEnd t 落在免打扰时段内时返回时段结束时间
+func (p *NotificationPreferences) quietEnd(t time.Time, loc *time.Location) (time.Time, bool) {
+	q := p.QuietHours
+	if q == nil {
+		return time.Time{}, false
+	}
+	start, err1 := parseClock(q.Start)
+	end, err2 := parseClock(q.End)
+	if err1 != nil || err2 != nil || start == end {
+		return time.Time{}, false
+	}
+	day := StartOfDay(t, loc)
+	at := func(d time.Time, minutes int) time.Time {
+		return time.Date(d.Year(), d.Month(), d.Day(), minutes/60, minutes%60, 0, 0, loc)
+	}
+	cur := t.Hour()*60 + t.Minute()
+	switch {
+	case start < end && cur >= start && cur < end:
+		return at(day, end), true
+	case start > end && cur >= start: // 跨午夜，当晚
+		return at(day.AddDate(0, 0, 1), end), true
+	case start > end && cur < end: // 跨午夜，次日凌晨
+		return at(day, end), true
+	}
+	return time.Time{}, false
+}
+
+// DeferUntil 非紧急、立即投递的通知在免打扰期间应延后到的时间；可立即投递时返回零值
+func (p *NotificationPreferences) DeferUntil(notificationType string, urgent bool, now time.Time, loc *time.Location) time.Time {
+	if urgent || p.ModeFor(notificationType) == NotificationModeDigest {
+		return time.Time{}
+	}
+	return p.QuietUntil(now, loc)
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferencesQuietUntil(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	p := &NotificationPreferences{QuietHours: &QuietHours{Start: "22:00", End: "07:00"}}
+	at := func(d, hm string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", d+" "+hm, loc)
+		require.NoError(t, err)
+		return v
+	}
+
+	// 2030-01-09 为周三
+	require.True(t, p.QuietUntil(at("2030-01-09", "12:00"), loc).IsZero())
+	require.True(t, p.QuietUntil(at("2030-01-09", "07:00"), loc).IsZero())
+	require.Equal(t, at("2030-01-10", "07:00"), p.QuietUntil(at("2030-01-09", "23:30"), loc))
+	require.Equal(t, at("2030-01-10", "07:00"), p.QuietUntil(at("2030-01-10", "03:00"), loc))
+
+	// 周末静音与跨午夜免打扰首尾相接：周五深夜延后到周一 07:00
+	p.MuteWeekends = true
+	require.Equal(t, at("2030-01-14", "07:00"), p.QuietUntil(at("2030-01-11", "23:00"), loc))
+	require.Equal(t, at("2030-01-14", "07:00"), p.QuietUntil(at("2030-01-12", "15:00"), loc))
+	require.True(t, p.QuietUntil(at("2030-01-11", "18:00"), loc).IsZero())
+
+	// 当日时段
+	day := &NotificationPreferences{QuietHours: &QuietHours{Start: "12:00", End: "14:00"}}
+	require.Equal(t, at("2030-01-09", "14:00"), day.QuietUntil(at("2030-01-09", "12:30"), loc))
+	require.True(t, day.QuietUntil(at("2030-01-09", "14:00"), loc).IsZero())
+
+	// 紧急与汇总不延后
+	now := at("2030-01-09", "23:30")
+	require.True(t, p.DeferUntil("reminder", true, now, loc).IsZero())
+	p.Types = map[string]NotificationTypePreference{"reminder": {Mode: NotificationModeDigest}}
+	require.True(t, p.DeferUntil("reminder", false, now, loc).IsZero())
+	require.False(t, p.DeferUntil("timeline_event", false, now, loc).IsZero())
+}
+
+func TestNotificationPreferencesChannelsAndValidate(t *testing.T) {
+	var unset *NotificationPreferences
+	require.True(t, unset.AllowsChannel("reminder", "email"))
+	require.Equal(t, NotificationModeImmediate, unset.ModeFor("reminder"))
+
+	p := &NotificationPreferences{Mode: NotificationModeDigest, Types: map[string]NotificationTypePreference{
+		"reminder":       {Channels: []string{"app", "webhook"}, Mode: NotificationModeImmediate},
+		"timeline_event": {Channels: []string{}},
+	}}
+	require.True(t, p.AllowsChannel("reminder", "webhook"))
+	require.False(t, p.AllowsChannel("reminder", "email"))
+	require.False(t, p.AllowsChannel("timeline_event", "app")) // 空数组：关闭该类型
+	require.True(t, p.AllowsChannel("test", "app"))            // 未配置：不限制
+	require.Equal(t, NotificationModeImmediate, p.ModeFor("reminder"))
+	require.Equal(t, NotificationModeDigest, p.ModeFor("test"))
+	require.NoError(t, p.Validate())
+
+	require.ErrorIs(t, (&NotificationPreferences{Mode: "hourly"}).Validate(), ErrInvalidPreferences)
+	require.ErrorIs(t, (&NotificationPreferences{QuietHours: &QuietHours{Start: "25:00", End: "07:00"}}).Validate(), ErrInvalidPreferences)
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNotificationThreadKey(t *testing.T) {
+	ev := primitive.NewObjectID()
+	require.Equal(t, "event_reminder:"+ev.Hex(), NotificationCreate{Type: "event_reminder", EventID: &ev}.ThreadKey())
+	require.Equal(t, "digest:daily", NotificationCreate{Type: "event_reminder", EventID: &ev, GroupKey: "digest:daily"}.ThreadKey())
+	// 无事件、无显式键的通知不合并
+	require.Empty(t, NotificationCreate{Type: "test"}.ThreadKey())
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// occurrenceKeyLayout 发生时间在 key 中的格式 (与 iCalendar RECURRENCE-ID 的 UTC 基本格式一致)
+const occurrenceKeyLayout = "20060102T150405Z"
+
+// BuildOccurrenceKey 生成单次发生的稳定标识: <event_id>@<UTC 发生时间>
+func BuildOccurrenceKey(eventID primitive.ObjectID, at time.Time) string {
+	return eventID.Hex() + "@" + at.UTC().Format(occurrenceKeyLayout)
+}
+
+// ParseOccurrenceKey 解析 BuildOccurrenceKey 生成的标识
+func ParseOccurrenceKey(key string) (primitive.ObjectID, time.Time, error) {
+	parts := strings.SplitN(key, "@", 2)
+	if len(parts) != 2 {
+		return primitive.NilObjectID, time.Time{}, errors.New("invalid occurrence key")
+	}
+	id, err := primitive.ObjectIDFromHex(parts[0])
+	if err != nil {
+		return primitive.NilObjectID, time.Time{}, errors.New("invalid occurrence key")
+	}
+	at, err := time.Parse(occurrenceKeyLayout, parts[1])
+	if err != nil {
+		return primitive.NilObjectID, time.Time{}, errors.New("invalid occurrence key")
+	}
+	return id, at, nil
+}
+
+// Occurrence 返回事件在 at 时刻的一次虚拟发生 (副本，EventDate 替换为发生时间)
+func (e Event) Occurrence(at time.Time) Event {
+	occ := at
+	e.EventDate = at
+	e.OccurrenceDate = &occ
+	e.OccurrenceKey = BuildOccurrenceKey(e.ID, at)
+	e.Exceptions = nil
+	return e
+}
+
+// exceptionAt 查找原始发生时间为 at 的例外
+func (e *Event) exceptionAt(at time.Time) *EventException {
+	for i := range e.Exceptions {
+		if e.Exceptions[i].OccurrenceDate.Equal(at) {
+			return &e.Exceptions[i]
+		}
+	}
+	return nil
+}
+
+// applyException 将例外覆盖到单次发生 (OccurrenceDate/OccurrenceKey 保持原始时间以保证标识稳定)
+func applyException(occ Event, ex *EventException) Event {
+	if ex.NewDate != nil {
+		occ.EventDate = *ex.NewDate
+	}
+	if ex.Title != nil {
+		occ.Title = *ex.Title
+	}
+	if ex.Location != nil {
+		occ.Location = *ex.Location
+	}
+	return occ
+}
+
+// ExpandOccurrences 将事件列表展开为 [from, to] 内的全部发生，按发生时间升序。
+// 会应用 Event.Exceptions：取消的发生被跳过；改期的发生按新时间判断是否落入窗口。
+// limitPerEvent<=0 表示不限制单个事件的展开数量。
+func ExpandOccurrences(events []Event, from, to time.Time, limitPerEvent int) []Event {
+	out := make([]Event, 0, len(events))
+	for _, ev := range events {
+		for _, at := range ev.OccurrencesBetween(from, to, limitPerEvent) {
+			ex := ev.exceptionAt(at)
+			if ex == nil {
+				out = append(out, ev.Occurrence(at))
+				continue
+			}
+			if ex.Cancelled || ex.NewDate != nil { // 改期的在下面按新时间统一处理
+				continue
+			}
+			out = append(out, applyException(ev.Occurrence(at), ex))
+		}
+		for i := range ev.Exceptions {
+			ex := &ev.Exceptions[i]
+			if ex.Cancelled || ex.NewDate == nil || ex.NewDate.Before(from) || ex.NewDate.After(to) {
+				continue
+			}
+			if len(ev.OccurrencesBetween(ex.OccurrenceDate, ex.OccurrenceDate, 1)) == 0 { // 原始发生已不属于该系列
+				continue
+			}
+			out = append(out, applyException(ev.Occurrence(ex.OccurrenceDate), ex))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].EventDate.Before(out[j].EventDate) })
+	return out
+}
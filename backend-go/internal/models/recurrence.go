@@ -0,0 +1,793 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 循环频率 (RFC 5545 FREQ)
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxRecurrencePeriods 展开时最多遍历的周期数，防止无解规则（如 2 月 30 日）死循环
+const maxRecurrencePeriods = 100000
+
+// ErrInvalidRecurrence 循环规则非法
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+// WeekdayNum BYDAY 单项，N 为序号 (0 表示不限定；负数表示倒数第 N 个)
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// RecurrenceRule RFC 5545 RRULE 子集
+// 支持 FREQ / INTERVAL / BYDAY / BYMONTHDAY / BYMONTH / BYSETPOS / UNTIL / COUNT / WKST，以及 EXDATE 排除日期
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []int
+	BySetPos   []int
+	Until      *time.Time
+	Count      int
+	WeekStart  time.Weekday
+	ExDates    []time.Time
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// recurrenceTypeFreq 将旧的 recurrence_type 映射为 FREQ，none/空 返回 ""
+func recurrenceTypeFreq(t string) string {
+	switch strings.ToLower(t) {
+	case "daily":
+		return FreqDaily
+	case "weekly":
+		return FreqWeekly
+	case "monthly":
+		return FreqMonthly
+	case "yearly":
+		return FreqYearly
+	}
+	return ""
+}
+
+// FreqToRecurrenceType FREQ -> recurrence_type
+func FreqToRecurrenceType(freq string) string {
+	switch strings.ToUpper(freq) {
+	case FreqDaily:
+		return "daily"
+	case FreqWeekly:
+		return "weekly"
+	case FreqMonthly:
+		return "monthly"
+	case FreqYearly:
+		return "yearly"
+	}
+	return "none"
+}
+
+// ParseRRule 解析 RRULE 字符串，例如 "FREQ=MONTHLY;BYDAY=MO,TU;BYSETPOS=-1"
+func ParseRRule(s string) (*RecurrenceRule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "RRULE:"), "rrule:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rrule", ErrInvalidRecurrence)
+	}
+	r := &RecurrenceRule{Interval: 1, WeekStart: time.Monday}
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: bad part %q", ErrInvalidRecurrence, part)
+		}
+		if err := r.set(strings.ToUpper(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// set 设置单个 RRULE 属性 (值为 RRULE 文本格式)
+func (r *RecurrenceRule) set(key, val string) error {
+	var err error
+	switch key {
+	case "FREQ":
+		r.Freq = strings.ToUpper(val)
+	case "INTERVAL":
+		r.Interval, err = strconv.Atoi(val)
+	case "COUNT":
+		r.Count, err = strconv.Atoi(val)
+	case "UNTIL":
+		var t time.Time
+		if t, err = parseRecurrenceTime(val); err == nil {
+			if isDateOnly(val) { // 纯日期 UNTIL 包含当天
+				t = t.Add(24*time.Hour - time.Second)
+			}
+			r.Until = &t
+		}
+	case "BYDAY":
+		r.ByDay, err = parseByDay(splitList(val))
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseIntList(splitList(val), -31, 31)
+	case "BYMONTH":
+		r.ByMonth, err = parseIntList(splitList(val), 1, 12)
+	case "BYSETPOS":
+		r.BySetPos, err = parseIntList(splitList(val), -366, 366)
+	case "WKST":
+		wd, ok := weekdayCodes[strings.ToUpper(val)]
+		if !ok {
+			return fmt.Errorf("%w: bad WKST %q", ErrInvalidRecurrence, val)
+		}
+		r.WeekStart = wd
+	case "EXDATE":
+		for _, it := range splitList(val) {
+			t, perr := parseRecurrenceTime(it)
+			if perr != nil {
+				return fmt.Errorf("%w: bad EXDATE %q", ErrInvalidRecurrence, it)
+			}
+			r.ExDates = append(r.ExDates, t)
+		}
+	default:
+		// 未支持的部分 (BYHOUR 等) 忽略
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidRecurrence, key, err)
+	}
+	return nil
+}
+
+// Validate 校验规则
+func (r *RecurrenceRule) Validate() error {
+	switch r.Freq {
+	case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+	default:
+		return fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRecurrence, r.Freq)
+	}
+	if r.Interval < 1 {
+		return fmt.Errorf("%w: INTERVAL must be >= 1", ErrInvalidRecurrence)
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("%w: COUNT must be >= 0", ErrInvalidRecurrence)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRecurrence)
+	}
+	for _, d := range r.ByMonthDay {
+		if d == 0 {
+			return fmt.Errorf("%w: BYMONTHDAY cannot be 0", ErrInvalidRecurrence)
+		}
+	}
+	for _, p := range r.BySetPos {
+		if p == 0 {
+			return fmt.Errorf("%w: BYSETPOS cannot be 0", ErrInvalidRecurrence)
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return fmt.Errorf("%w: BYSETPOS requires another BYxxx part", ErrInvalidRecurrence)
+	}
+	for _, wd := range r.ByDay {
+		if wd.N != 0 && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+			return fmt.Errorf("%w: numeric BYDAY only valid for MONTHLY/YEARLY", ErrInvalidRecurrence)
+		}
+	}
+	return nil
+}
+
+// String 输出 RRULE 文本 (不含 EXDATE)
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			s := weekdayNames[wd.Day]
+			if wd.N != 0 {
+				s = strconv.Itoa(wd.N) + s
+			}
+			days = append(days, s)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Iterate 从 dtstart 起按时间顺序回调每个发生时间 (已应用 COUNT / UNTIL / EXDATE)，fn 返回 false 时停止。
+// dtstart 本身总是第一个发生时间，并计入 COUNT。
+func (r *RecurrenceRule) Iterate(dtstart time.Time, fn func(time.Time) bool) {
+	r.iterate(dtstart, time.Time{}, fn)
+}
+
+// Next 返回严格晚于 after 的第一个发生时间，规则已结束返回 nil
+func (r *RecurrenceRule) Next(dtstart, after time.Time) *time.Time {
+	var next *time.Time
+	r.iterate(dtstart, after, func(t time.Time) bool {
+		if t.After(after) {
+			next = &t
+			return false
+		}
+		return true
+	})
+	return next
+}
+
+// Between 返回 [from, to] 区间内的发生时间，limit<=0 表示不限制
+func (r *RecurrenceRule) Between(dtstart, from, to time.Time, limit int) []time.Time {
+	var out []time.Time
+	r.iterate(dtstart, from, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if !t.Before(from) {
+			out = append(out, t)
+		}
+		return limit <= 0 || len(out) < limit
+	})
+	return out
+}
+
+// iterate 核心展开；hint 非零且无 COUNT 时直接跳到 hint 附近的周期以避免从头遍历
+func (r *RecurrenceRule) iterate(dtstart, hint time.Time, fn func(time.Time) bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	emitted := 0
+	emit := func(t time.Time) bool {
+		if r.Until != nil && t.After(*r.Until) {
+			return false
+		}
+		if r.Count > 0 && emitted >= r.Count {
+			return false
+		}
+		emitted++
+		if r.isExcluded(t) {
+			return true
+		}
+		return fn(t)
+	}
+	period := r.periodStart(dtstart)
+	if r.Count == 0 && !hint.IsZero() && hint.After(dtstart) {
+		period = r.skipPeriods(period, r.periodStart(hint.In(dtstart.Location())), interval)
+	} else if !emit(dtstart) {
+		return
+	}
+	for i := 0; i < maxRecurrencePeriods && period.Year() <= 9999; i++ {
+		for _, c := range r.expand(period, dtstart) {
+			if !c.After(dtstart) {
+				continue
+			}
+			if !emit(c) {
+				return
+			}
+		}
+		period = r.addPeriods(period, interval)
+	}
+}
+
+// periodStart 返回 t 所在周期的起点 (本地日期零点)
+func (r *RecurrenceRule) periodStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch r.Freq {
+	case FreqWeekly:
+		day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+		diff := (int(day.Weekday()) - int(r.WeekStart) + 7) % 7
+		return day.AddDate(0, 0, -diff)
+	case FreqMonthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case FreqYearly:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func (r *RecurrenceRule) addPeriods(p time.Time, n int) time.Time {
+	switch r.Freq {
+	case FreqWeekly:
+		return p.AddDate(0, 0, 7*n)
+	case FreqMonthly:
+		return p.AddDate(0, n, 0)
+	case FreqYearly:
+		return p.AddDate(n, 0, 0)
+	}
+	return p.AddDate(0, 0, n)
+}
+
+// skipPeriods 从 start 出发按 interval 对齐地跳到不晚于 target 的最后一个周期 (再回退一个周期保证不漏)
+func (r *RecurrenceRule) skipPeriods(start, target time.Time, interval int) time.Time {
+	var units int
+	switch r.Freq {
+	case FreqMonthly:
+		units = (target.Year()-start.Year())*12 + int(target.Month()-start.Month())
+	case FreqYearly:
+		units = target.Year() - start.Year()
+	case FreqWeekly:
+		units = civilDays(start, target) / 7
+	default:
+		units = civilDays(start, target)
+	}
+	steps := units/interval - 1
+	if steps <= 0 {
+		return start
+	}
+	return r.addPeriods(start, steps*interval)
+}
+
+// civilDays 两个本地日期之间的自然日差 (忽略 DST 造成的 23/25 小时)
+func civilDays(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	ua := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	ub := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+// expand 生成一个周期内的候选发生时间 (已排序 & 应用 BYSETPOS)
+func (r *RecurrenceRule) expand(period, dtstart time.Time) []time.Time {
+	var days []time.Time // UTC 零点表示的日期，避免 DST 干扰
+	y, m, d := period.Date()
+	first := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	switch r.Freq {
+	case FreqDaily:
+		if r.matchMonth(first) && r.matchMonthDay(first) && r.matchWeekday(first) {
+			days = append(days, first)
+		}
+	case FreqWeekly:
+		for i := 0; i < 7; i++ {
+			day := first.AddDate(0, 0, i)
+			if !r.matchMonth(day) {
+				continue
+			}
+			if len(r.ByDay) == 0 {
+				if day.Weekday() == dtstart.Weekday() {
+					days = append(days, day)
+				}
+			} else if r.matchWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case FreqMonthly:
+		if r.matchMonth(first) {
+			days = r.expandMonth(first, dtstart)
+		}
+	case FreqYearly:
+		if len(r.ByDay) > 0 && len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 {
+			// 年内第 N 个星期几
+			days = expandByDay(first, first.AddDate(1, 0, 0), r.ByDay)
+		} else {
+			months := r.ByMonth
+			if len(months) == 0 {
+				months = []int{int(dtstart.Month())}
+			}
+			for _, mo := range months {
+				days = append(days, r.expandMonth(time.Date(y, time.Month(mo), 1, 0, 0, 0, 0, time.UTC), dtstart)...)
+			}
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	days = dedupeDays(days)
+	if len(r.BySetPos) > 0 {
+		days = applySetPos(days, r.BySetPos)
+	}
+	out := make([]time.Time, 0, len(days))
+	hh, mm, ss := dtstart.Clock()
+	for _, day := range days {
+		out = append(out, time.Date(day.Year(), day.Month(), day.Day(), hh, mm, ss, dtstart.Nanosecond(), dtstart.Location()))
+	}
+	return out
+}
+
+// expandMonth 展开某个月内的日期：BYMONTHDAY 优先 (BYDAY 作为过滤)，否则 BYDAY，否则 dtstart 同日
+func (r *RecurrenceRule) expandMonth(first, dtstart time.Time) []time.Time {
+	next := first.AddDate(0, 1, 0)
+	n := int(next.Sub(first).Hours() / 24)
+	var days []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, md := range r.ByMonthDay {
+			d := md
+			if d < 0 {
+				d = n + d + 1
+			}
+			if d < 1 || d > n {
+				continue
+			}
+			day := first.AddDate(0, 0, d-1)
+			if len(r.ByDay) == 0 || r.matchWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case len(r.ByDay) > 0:
+		days = expandByDay(first, next, r.ByDay)
+	default:
+		if dtstart.Day() <= n { // 不存在的日期 (如 2 月 30 日) 按 RFC 跳过
+			days = append(days, first.AddDate(0, 0, dtstart.Day()-1))
+		}
+	}
+	return days
+}
+
+// expandByDay 在 [from, to) 中展开 BYDAY，N!=0 时取第 N 个 (负数倒数)
+func expandByDay(from, to time.Time, byDay []WeekdayNum) []time.Time {
+	var out []time.Time
+	for _, wd := range byDay {
+		var all []time.Time
+		offset := (int(wd.Day) - int(from.Weekday()) + 7) % 7
+		for d := from.AddDate(0, 0, offset); d.Before(to); d = d.AddDate(0, 0, 7) {
+			all = append(all, d)
+		}
+		switch {
+		case wd.N == 0:
+			out = append(out, all...)
+		case wd.N > 0 && wd.N <= len(all):
+			out = append(out, all[wd.N-1])
+		case wd.N < 0 && -wd.N <= len(all):
+			out = append(out, all[len(all)+wd.N])
+		}
+	}
+	return out
+}
+
+func (r *RecurrenceRule) matchMonth(day time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if int(day.Month()) == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) matchMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	n := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, md := range r.ByMonthDay {
+		if md == day.Day() || (md < 0 && n+md+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) matchWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Day == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// isExcluded EXDATE 匹配：精确时间相同，或 EXDATE 为纯日期 (零点) 且同一天
+func (r *RecurrenceRule) isExcluded(t time.Time) bool {
+	for _, ex := range r.ExDates {
+		if ex.Equal(t) {
+			return true
+		}
+		if h, m, s := ex.Clock(); h == 0 && m == 0 && s == 0 {
+			ey, em, ed := ex.Date()
+			ty, tm, td := t.Date()
+			if ey == ty && em == tm && ed == td {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func dedupeDays(days []time.Time) []time.Time {
+	if len(days) < 2 {
+		return days
+	}
+	out := days[:1]
+	for _, d := range days[1:] {
+		if !d.Equal(out[len(out)-1]) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func applySetPos(days []time.Time, pos []int) []time.Time {
+	var out []time.Time
+	for _, p := range pos {
+		idx := p - 1
+		if p < 0 {
+			idx = len(days) + p
+		}
+		if idx >= 0 && idx < len(days) {
+			out = append(out, days[idx])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return dedupeDays(out)
+}
+
+// ---- RecurrenceConfig 解析 ----
+
+// RecurrenceRule 根据 RecurrenceType + RecurrenceConfig 构造规则；非循环事件返回 nil, nil。
+// RecurrenceConfig 支持：
+//
+//	rrule: "FREQ=WEEKLY;BYDAY=MO,WE" (完整 RRULE，FREQ 优先于 recurrence_type)
+//	interval / count / until / by_day / by_month_day / by_month / by_set_pos / wkst / exdates
+//
+// 单项键会覆盖 rrule 中的同名部分；值可为字符串 ("MO,WE")、数字或数组 (gRPC 侧均为字符串)。
+func (e *Event) RecurrenceRule() (*RecurrenceRule, error) {
+	freq := recurrenceTypeFreq(e.RecurrenceType)
+	if freq == "" {
+		return nil, nil
+	}
+	rule := &RecurrenceRule{Freq: freq, Interval: 1, WeekStart: time.Monday}
+	if s := configString(e.RecurrenceConfig["rrule"]); s != "" {
+		parsed, err := ParseRRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rule = parsed
+	}
+	keys := map[string]string{
+		"interval": "INTERVAL", "count": "COUNT", "until": "UNTIL", "wkst": "WKST",
+		"by_day": "BYDAY", "by_month_day": "BYMONTHDAY", "by_month": "BYMONTH", "by_set_pos": "BYSETPOS",
+		"exdates": "EXDATE", "exdate": "EXDATE",
+	}
+	for k, part := range keys {
+		v, ok := e.RecurrenceConfig[k]
+		if !ok || v == nil {
+			continue
+		}
+		if part == "EXDATE" {
+			ts, err := configTimes(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRecurrence, k, err)
+			}
+			rule.ExDates = append(rule.ExDates, ts...)
+			continue
+		}
+		if part == "UNTIL" {
+			if t, ok := configTime(v); ok {
+				rule.Until = &t
+				continue
+			}
+		}
+		s := configString(v)
+		if s == "" {
+			continue
+		}
+		if err := rule.set(part, s); err != nil {
+			return nil, err
+		}
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// EffectiveRecurrenceRule 配置非法时退化为仅按 recurrence_type 的基础规则，保证历史数据可用
+func (e *Event) EffectiveRecurrenceRule() *RecurrenceRule {
+	rule, err := e.RecurrenceRule()
+	if err == nil {
+		return rule
+	}
+	freq := recurrenceTypeFreq(e.RecurrenceType)
+	if freq == "" {
+		return nil
+	}
+	return &RecurrenceRule{Freq: freq, Interval: 1, WeekStart: time.Monday}
+}
+
+// OccurrencesBetween 返回 [from, to] 内的发生时间 (按事件时区展开)；非循环事件仅考虑 EventDate 本身
+func (e *Event) OccurrencesBetween(from, to time.Time, limit int) []time.Time {
+	rule := e.EffectiveRecurrenceRule()
+	if rule == nil {
+		if !e.EventDate.Before(from) && !e.EventDate.After(to) {
+			return []time.Time{e.EventDate}
+		}
+		return nil
+	}
+	return rule.Between(e.LocalEventDate(), from, to, limit)
+}
+
+func configString(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(x)
+	case int, int32, int64:
+		return fmt.Sprintf("%d", x)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case []string:
+		return strings.Join(x, ",")
+	case []interface{}:
+		return joinAny(x)
+	case primitive.A:
+		return joinAny(x)
+	case time.Time:
+		return x.UTC().Format("20060102T150405Z")
+	case primitive.DateTime:
+		return x.Time().UTC().Format("20060102T150405Z")
+	}
+	return fmt.Sprint(v)
+}
+
+func joinAny(list []interface{}) string {
+	parts := make([]string, 0, len(list))
+	for _, it := range list {
+		if s := configString(it); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func configTime(v interface{}) (time.Time, bool) {
+	switch x := v.(type) {
+	case time.Time:
+		return x, true
+	case primitive.DateTime:
+		return x.Time(), true
+	}
+	return time.Time{}, false
+}
+
+func configTimes(v interface{}) ([]time.Time, error) {
+	if t, ok := configTime(v); ok {
+		return []time.Time{t}, nil
+	}
+	var list []interface{}
+	switch x := v.(type) {
+	case []interface{}:
+		list = x
+	case primitive.A:
+		list = x
+	case []string:
+		for _, s := range x {
+			list = append(list, s)
+		}
+	case []time.Time:
+		return x, nil
+	default:
+		for _, s := range splitList(configString(v)) {
+			list = append(list, s)
+		}
+	}
+	var out []time.Time
+	for _, it := range list {
+		if t, ok := configTime(it); ok {
+			out = append(out, t)
+			continue
+		}
+		s := configString(it)
+		if s == "" {
+			continue
+		}
+		t, err := parseRecurrenceTime(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// parseRecurrenceTime 支持 iCalendar 基本格式、纯日期与 RFC3339
+func parseRecurrenceTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	layouts := []string{"20060102T150405Z", "20060102T150405", "20060102", time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("bad time %q", s)
+}
+
+func isDateOnly(s string) bool {
+	s = strings.TrimSpace(s)
+	return len(s) == 8 || (len(s) == 10 && strings.Count(s, "-") == 2)
+}
+
+func parseByDay(items []string) ([]WeekdayNum, error) {
+	out := make([]WeekdayNum, 0, len(items))
+	for _, it := range items {
+		it = strings.ToUpper(it)
+		if len(it) < 2 {
+			return nil, fmt.Errorf("bad BYDAY %q", it)
+		}
+		wd, ok := weekdayCodes[it[len(it)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("bad BYDAY %q", it)
+		}
+		n := 0
+		if prefix := it[:len(it)-2]; prefix != "" {
+			v, err := strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+			if err != nil || v == 0 || v > 53 || v < -53 {
+				return nil, fmt.Errorf("bad BYDAY %q", it)
+			}
+			n = v
+		}
+		out = append(out, WeekdayNum{N: n, Day: wd})
+	}
+	return out, nil
+}
+
+func parseIntList(items []string, min, max int) ([]int, error) {
+	out := make([]int, 0, len(items))
+	for _, it := range items {
+		v, err := strconv.Atoi(strings.TrimPrefix(it, "+"))
+		if err != nil || v < min || v > max {
+			return nil, fmt.Errorf("bad value %q", it)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, it := range strings.Split(s, ",") {
+		if it = strings.TrimSpace(it); it != "" {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+func joinInts(list []int) string {
+	parts := make([]string, len(list))
+	for i, v := range list {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
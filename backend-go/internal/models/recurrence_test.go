@@ -0,0 +1,145 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func utc(y int, m time.Month, day, h, min int) time.Time {
+	return time.Date(y, m, day, h, min, 0, 0, time.UTC)
+}
+
+func TestRecurrenceRuleBetween(t *testing.T) {
+	cases := []struct {
+		name    string
+		rrule   string
+		dtstart time.Time
+		from    time.Time
+		to      time.Time
+		want    []time.Time
+	}{
+		{
+			name:    "weekly interval byday",
+			rrule:   "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+			dtstart: utc(2025, 1, 6, 9, 0), // Monday
+			from:    utc(2025, 1, 1, 0, 0),
+			to:      utc(2025, 1, 31, 0, 0),
+			want:    []time.Time{utc(2025, 1, 6, 9, 0), utc(2025, 1, 8, 9, 0), utc(2025, 1, 20, 9, 0), utc(2025, 1, 22, 9, 0)},
+		},
+		{
+			name:    "last weekday of month via bysetpos",
+			rrule:   "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3",
+			dtstart: utc(2025, 1, 31, 18, 0),
+			from:    utc(2025, 1, 1, 0, 0),
+			to:      utc(2025, 12, 31, 0, 0),
+			want:    []time.Time{utc(2025, 1, 31, 18, 0), utc(2025, 2, 28, 18, 0), utc(2025, 3, 31, 18, 0)},
+		},
+		{
+			name:    "monthday skips short months",
+			rrule:   "FREQ=MONTHLY;BYMONTHDAY=31;UNTIL=20250531T235959Z",
+			dtstart: utc(2025, 1, 31, 8, 0),
+			from:    utc(2025, 1, 1, 0, 0),
+			to:      utc(2025, 12, 31, 0, 0),
+			want:    []time.Time{utc(2025, 1, 31, 8, 0), utc(2025, 3, 31, 8, 0), utc(2025, 5, 31, 8, 0)},
+		},
+		{
+			name:    "negative monthday",
+			rrule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: utc(2024, 1, 31, 0, 0),
+			from:    utc(2024, 2, 1, 0, 0),
+			to:      utc(2024, 3, 1, 0, 0),
+			want:    []time.Time{utc(2024, 2, 29, 0, 0)},
+		},
+		{
+			name:    "yearly nth weekday",
+			rrule:   "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			dtstart: utc(2024, 11, 28, 12, 0),
+			from:    utc(2025, 1, 1, 0, 0),
+			to:      utc(2026, 12, 31, 0, 0),
+			want:    []time.Time{utc(2025, 11, 27, 12, 0), utc(2026, 11, 26, 12, 0)},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := ParseRRule(tc.rrule)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, r.Between(tc.dtstart, tc.from, tc.to, 0))
+		})
+	}
+}
+
+func TestEventRecurrenceConfig(t *testing.T) {
+	e := Event{
+		EventDate:      utc(2025, 3, 3, 10, 0), // Monday
+		RecurrenceType: "daily",
+		RecurrenceConfig: map[string]interface{}{
+			"by_day":  "MO,TU,WE,TH,FR",
+			"count":   "4",
+			"exdates": []interface{}{"2025-03-04"},
+		},
+	}
+	rule, err := e.RecurrenceRule()
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=DAILY;COUNT=4;BYDAY=MO,TU,WE,TH,FR", rule.String())
+	// COUNT 包含被 EXDATE 排除的 3/4
+	require.Equal(t, []time.Time{utc(2025, 3, 3, 10, 0), utc(2025, 3, 5, 10, 0), utc(2025, 3, 6, 10, 0)},
+		e.OccurrencesBetween(utc(2025, 3, 1, 0, 0), utc(2025, 3, 31, 0, 0), 0))
+
+	next := e.GetNextOccurrence(utc(2025, 3, 5, 10, 0))
+	require.NotNil(t, next)
+	require.Equal(t, utc(2025, 3, 6, 10, 0), *next)
+	require.Nil(t, e.GetNextOccurrence(utc(2025, 3, 6, 10, 0)))
+
+	e.RecurrenceConfig = map[string]interface{}{"interval": 0}
+	_, err = e.RecurrenceRule()
+	require.ErrorIs(t, err, ErrInvalidRecurrence)
+}
+
+func TestRecurrenceNextSkipsAhead(t *testing.T) {
+	r, err := ParseRRule("FREQ=WEEKLY;INTERVAL=3;BYDAY=FR")
+	require.NoError(t, err)
+	start := utc(2020, 1, 3, 9, 30) // Friday
+	next := r.Next(start, utc(2025, 6, 1, 0, 0))
+	require.NotNil(t, next)
+	require.Equal(t, time.Friday, next.Weekday())
+	weeks := int(next.Sub(start).Hours() / 24 / 7)
+	require.Equal(t, 0, weeks%3)
+}
+
+func TestExpandOccurrences(t *testing.T) {
+	weekly := Event{ID: primitive.NewObjectID(), Title: "standup", EventDate: utc(2025, 1, 6, 9, 0), RecurrenceType: "weekly"}
+	once := Event{ID: primitive.NewObjectID(), Title: "once", EventDate: utc(2025, 1, 15, 12, 0), RecurrenceType: "none"}
+	out := ExpandOccurrences([]Event{weekly, once}, utc(2025, 1, 10, 0, 0), utc(2025, 1, 21, 0, 0), 0)
+	require.Len(t, out, 3)
+	require.Equal(t, utc(2025, 1, 13, 9, 0), out[0].EventDate)
+	require.Equal(t, "once", out[1].Title)
+	require.Equal(t, utc(2025, 1, 20, 9, 0), *out[2].OccurrenceDate)
+
+	id, at, err := ParseOccurrenceKey(out[2].OccurrenceKey)
+	require.NoError(t, err)
+	require.Equal(t, weekly.ID, id)
+	require.True(t, at.Equal(utc(2025, 1, 20, 9, 0)))
+}
+
+func TestOccurrenceExceptions(t *testing.T) {
+	moved := utc(2025, 1, 22, 15, 0)
+	title := "standup (moved)"
+	ev := Event{ID: primitive.NewObjectID(), Title: "standup", EventDate: utc(2025, 1, 6, 9, 0), RecurrenceType: "weekly"}
+	ev.Exceptions = []EventException{
+		{EventID: ev.ID, OccurrenceDate: utc(2025, 1, 13, 9, 0), Cancelled: true},
+		{EventID: ev.ID, OccurrenceDate: utc(2025, 1, 20, 9, 0), NewDate: &moved, Title: &title},
+	}
+	out := ExpandOccurrences([]Event{ev}, utc(2025, 1, 10, 0, 0), utc(2025, 1, 31, 0, 0), 0)
+	require.Len(t, out, 2)
+	require.Equal(t, moved, out[0].EventDate)
+	require.Equal(t, title, out[0].Title)
+	require.Equal(t, BuildOccurrenceKey(ev.ID, utc(2025, 1, 20, 9, 0)), out[0].OccurrenceKey)
+	require.Equal(t, utc(2025, 1, 27, 9, 0), out[1].EventDate)
+
+	next := ev.GetNextOccurrence(utc(2025, 1, 10, 0, 0))
+	require.NotNil(t, next)
+	require.Equal(t, moved, *next)
+}
@@ -8,20 +8,38 @@ import (
 
 // Reminder 提醒模型
 type Reminder struct {
-	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	EventID       primitive.ObjectID `bson:"event_id" json:"event_id"`
-	UserID        primitive.ObjectID `bson:"user_id" json:"user_id"`
-	AdvanceDays   int                `bson:"advance_days" json:"advance_days" validate:"min=0,max=365"`
-	ReminderTimes []string           `bson:"reminder_times" json:"reminder_times"` // ["09:00", "18:00"]
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	EventID       primitive.ObjectID  `bson:"event_id" json:"event_id"`
+	UserID        primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	WorkspaceID   *primitive.ObjectID `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"` // 继承自所属事件，工作区成员按角色共享
+	AdvanceDays   int                 `bson:"advance_days" json:"advance_days" validate:"min=0,max=365"`
+	ReminderTimes []string            `bson:"reminder_times" json:"reminder_times"` // ["09:00", "18:00"]
 	// AbsoluteTimes 允许直接指定绝对提醒时间（UTC）列表，优先于基于事件/AdvanceDays + ReminderTimes 的计算
-	AbsoluteTimes []time.Time `bson:"absolute_times,omitempty" json:"absolute_times,omitempty"`
-	ReminderType  string      `bson:"reminder_type" json:"reminder_type" validate:"required,oneof=app email both"`
-	CustomMessage string      `bson:"custom_message,omitempty" json:"custom_message,omitempty"`
-	IsActive      bool        `bson:"is_active" json:"is_active"`
-	LastSent      *time.Time  `bson:"last_sent,omitempty" json:"last_sent,omitempty"`
-	NextSend      *time.Time  `bson:"next_send,omitempty" json:"next_send,omitempty"`
-	CreatedAt     time.Time   `bson:"created_at" json:"created_at"`
-	UpdatedAt     time.Time   `bson:"updated_at" json:"updated_at"`
+	AbsoluteTimes []time.Time      `bson:"absolute_times,omitempty" json:"absolute_times,omitempty"`
+	ReminderType  ReminderChannels `bson:"reminder_type" json:"reminder_type" validate:"required,min=1"` // 发送渠道 ID 列表
+	CustomMessage string           `bson:"custom_message,omitempty" json:"custom_message,omitempty"`
+	IsActive      bool             `bson:"is_active" json:"is_active"`
+	LastSent      *time.Time       `bson:"last_sent,omitempty" json:"last_sent,omitempty"`
+	NextSend      *time.Time       `bson:"next_send,omitempty" json:"next_send,omitempty"`
+	CreatedAt     time.Time        `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time        `bson:"updated_at" json:"updated_at"`
+	// 投递重试状态：当前这次提醒已失败的次数、待重试渠道、多目标渠道中已成功的目标与最近错误；成功或进入死信后重置
+	DeliveryAttempts   int        `bson:"delivery_attempts,omitempty" json:"delivery_attempts,omitempty"`
+	RetryChannels      []string   `bson:"retry_channels,omitempty" json:"retry_channels,omitempty"`
+	DeliveredEndpoints []string   `bson:"delivered_endpoints,omitempty" json:"delivered_endpoints,omitempty"` // 重试时跳过
+	LastError          string     `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	DeadLetterAt       *time.Time `bson:"dead_letter_at,omitempty" json:"dead_letter_at,omitempty"` // 最近一次放弃投递的时间
+	// 调度租约：多实例部署时到期提醒由领取到租约的实例发送；租约过期未完成 (实例崩溃) 时可被其他实例接管
+	LeaseOwner string     `bson:"lease_owner,omitempty" json:"-"`
+	LeaseToken string     `bson:"lease_token,omitempty" json:"-"`
+	LeaseUntil *time.Time `bson:"lease_until,omitempty" json:"-"`
+}
+
+// ReminderClaim 调度实例领取到的到期提醒
+type ReminderClaim struct {
+	ReminderWithEvent
+	Token         string // 租约令牌，完成 / 释放时校验
+	RecoveredFrom string // 非空表示接管了该实例过期未完成的租约
 }
 
 // CreateReminderRequest 创建提醒请求
@@ -30,18 +48,18 @@ type CreateReminderRequest struct {
 	AdvanceDays   int                `json:"advance_days" validate:"min=0,max=365"`
 	ReminderTimes []string           `json:"reminder_times" validate:"required,min=1"`
 	AbsoluteTimes []time.Time        `json:"absolute_times,omitempty"`
-	ReminderType  string             `json:"reminder_type" validate:"required,oneof=app email both"`
+	ReminderType  ReminderChannels   `json:"reminder_type" validate:"required,min=1"`
 	CustomMessage string             `json:"custom_message,omitempty"`
 }
 
 // UpdateReminderRequest 更新提醒请求
 type UpdateReminderRequest struct {
-	AdvanceDays   *int         `json:"advance_days,omitempty" validate:"omitempty,min=0,max=365"`
-	ReminderTimes []string     `json:"reminder_times,omitempty" validate:"omitempty,min=1"`
-	ReminderType  *string      `json:"reminder_type,omitempty" validate:"omitempty,oneof=app email both"`
-	CustomMessage *string      `json:"custom_message,omitempty"`
-	IsActive      *bool        `json:"is_active,omitempty"`
-	AbsoluteTimes *[]time.Time `json:"absolute_times,omitempty"`
+	AdvanceDays   *int              `json:"advance_days,omitempty" validate:"omitempty,min=0,max=365"`
+	ReminderTimes []string          `json:"reminder_times,omitempty" validate:"omitempty,min=1"`
+	ReminderType  *ReminderChannels `json:"reminder_type,omitempty" validate:"omitempty,min=1"`
+	CustomMessage *string           `json:"custom_message,omitempty"`
+	IsActive      *bool             `json:"is_active,omitempty"`
+	AbsoluteTimes *[]time.Time      `json:"absolute_times,omitempty"`
 }
 
 // ReminderListResponse 提醒列表响应
@@ -92,9 +110,10 @@ type UpdateTaskSortConfigRequest struct {
 // PriorityTask 优先任务
 type PriorityTask struct {
 	Task
-	PriorityScore float64 `json:"priority_score"`
-	DaysLeft      int     `json:"days_left"`
-	IsUrgent      bool    `json:"is_urgent"`
+	PriorityScore  float64 `json:"priority_score"`
+	DaysLeft       int     `json:"days_left"`
+	IsUrgent       bool    `json:"is_urgent"`
+	NewlyUnblocked bool    `json:"newly_unblocked,omitempty"` // 阻塞任务刚完成
 }
 
 // DashboardData 看板数据
@@ -143,52 +162,49 @@ func (r *Reminder) CalculateNextSendTime(event Event) *time.Time {
 		return next
 	}
 
+	// 提醒日期与 HH:MM 均按事件时区的墙上时间计算 (夏令时切换由 time.Date 归一化)
+	loc := event.TimeLocation()
 	now := time.Now()
-
-	// 获取事件的下次发生时间
-	var eventTime *time.Time
-	if event.RecurrenceType == "none" {
-		if event.EventDate.After(now) {
-			eventTime = &event.EventDate
-		} else {
-			return nil // 事件已过期
-		}
-	} else {
-		eventTime = event.GetNextOccurrence(now)
-		if eventTime == nil {
-			return nil
-		}
-	}
-
-	// 计算提醒日期
-	reminderDate := eventTime.AddDate(0, 0, -r.AdvanceDays)
-
-	// 如果提醒日期已过，返回 nil
-	if reminderDate.Before(now.Truncate(24 * time.Hour)) {
-		return nil
-	}
-
-	// 找到今天或之后的第一个提醒时间
-	for _, timeStr := range r.ReminderTimes {
-		if len(timeStr) == 5 { // HH:MM 格式
-			hour := int((timeStr[0]-'0')*10 + (timeStr[1] - '0'))
-			minute := int((timeStr[3]-'0')*10 + (timeStr[4] - '0'))
-
-			reminderTime := time.Date(
-				reminderDate.Year(), reminderDate.Month(), reminderDate.Day(),
-				hour, minute, 0, 0, reminderDate.Location(),
-			)
-
-			// 如果这个时间在未来，返回它
-			if reminderTime.After(now) {
-				return &reminderTime
+	today := StartOfDay(now, loc)
+
+	// 依次检查后续发生时间，直到找到仍在未来的提醒时间 (提醒窗口已过的发生直接跳过)
+	eventTime := event.GetNextOccurrence(now)
+	for i := 0; eventTime != nil && i < 366; i++ {
+		// 计算提醒日期
+		reminderDate := eventTime.In(loc).AddDate(0, 0, -r.AdvanceDays)
+		if !reminderDate.Before(today) {
+			// 找到今天或之后的第一个提醒时间
+			for _, timeStr := range r.ReminderTimes {
+				if len(timeStr) == 5 { // HH:MM 格式
+					hour := int((timeStr[0]-'0')*10 + (timeStr[1] - '0'))
+					minute := int((timeStr[3]-'0')*10 + (timeStr[4] - '0'))
+
+					reminderTime := time.Date(
+						reminderDate.Year(), reminderDate.Month(), reminderDate.Day(),
+						hour, minute, 0, 0, loc,
+					)
+
+					// 如果这个时间在未来，返回它
+					if reminderTime.After(now) {
+						return &reminderTime
+					}
+				}
 			}
 		}
+		eventTime = event.GetNextOccurrence(*eventTime)
 	}
 
 	return nil
 }
 
+// Channels 发送渠道 (未设置时默认站内通知)
+func (r *Reminder) Channels() []string {
+	if c := r.ReminderType.Normalize(); len(c) > 0 {
+		return c
+	}
+	return []string{"app"}
+}
+
 // ShouldSendReminder 检查是否应该发送提醒
 func (r *Reminder) ShouldSendReminder() bool {
 	if !r.IsActive || r.NextSend == nil {
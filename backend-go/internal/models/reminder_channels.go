@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// ReminderChannels 提醒的发送渠道 ID 列表 (如 ["app","email","webhook"])，取值由 notify 注册表校验。
+// 兼容旧数据与旧客户端的单字符串写法：app / email / both (= app + email)，也接受逗号分隔。
+type ReminderChannels []string
+
+// ParseReminderChannels 解析单字符串写法
+func ParseReminderChannels(s string) ReminderChannels {
+	return ReminderChannels(strings.Split(s, ",")).Normalize()
+}
+
+// Normalize 去空白、转小写、去重，并展开 both
+func (c ReminderChannels) Normalize() ReminderChannels {
+	out := ReminderChannels{}
+	seen := map[string]bool{}
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	for _, id := range c {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if id == "both" {
+			add("app")
+			add("email")
+			continue
+		}
+		add(id)
+	}
+	return out
+}
+
+// Has 是否包含某渠道
+func (c ReminderChannels) Has(id string) bool {
+	for _, x := range c {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (c ReminderChannels) String() string { return strings.Join(c, ",") }
+
+// UnmarshalJSON 接受字符串或字符串数组
+func (c *ReminderChannels) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*c = ParseReminderChannels(s)
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return fmt.Errorf("reminder_type must be a string or an array of channel ids")
+	}
+	*c = ReminderChannels(list).Normalize()
+	return nil
+}
+
+// UnmarshalBSONValue 兼容旧文档中的字符串值
+func (c *ReminderChannels) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.Null, bsontype.Undefined:
+		*c = nil
+		return nil
+	case bsontype.String:
+		var s string
+		if err := bson.UnmarshalValue(t, data, &s); err != nil {
+			return err
+		}
+		*c = ParseReminderChannels(s)
+		return nil
+	}
+	var list []string
+	if err := bson.UnmarshalValue(t, data, &list); err != nil {
+		return err
+	}
+	*c = ReminderChannels(list).Normalize()
+	return nil
+}
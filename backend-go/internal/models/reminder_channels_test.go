@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestReminderChannelsLegacyValues(t *testing.T) {
+	var r Reminder
+	require.NoError(t, json.Unmarshal([]byte(`{"reminder_type":"both"}`), &r))
+	require.Equal(t, ReminderChannels{"app", "email"}, r.ReminderType)
+	require.NoError(t, json.Unmarshal([]byte(`{"reminder_type":["Webhook","app","webhook"]}`), &r))
+	require.Equal(t, ReminderChannels{"webhook", "app"}, r.ReminderType)
+
+	// 旧文档中的字符串值
+	raw, err := bson.Marshal(bson.M{"reminder_type": "email"})
+	require.NoError(t, err)
+	var doc Reminder
+	require.NoError(t, bson.Unmarshal(raw, &doc))
+	require.Equal(t, ReminderChannels{"email"}, doc.ReminderType)
+
+	// 新文档按数组存储
+	doc.ReminderType = ReminderChannels{"app", "telegram"}
+	raw, err = bson.Marshal(doc)
+	require.NoError(t, err)
+	var back Reminder
+	require.NoError(t, bson.Unmarshal(raw, &back))
+	require.Equal(t, doc.ReminderType, back.ReminderType)
+	require.Equal(t, bson.TypeArray, bson.Raw(raw).Lookup("reminder_type").Type)
+
+	require.Equal(t, []string{"app"}, (&Reminder{}).Channels())
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 投递状态
+const (
+	DeliveryStatusSent   = "sent"        // 发送成功
+	DeliveryStatusFailed = "failed"      // 发送失败，将按退避重试
+	DeliveryStatusDead   = "dead_letter" // 达到最大重试次数，放弃本次提醒
+	DeliveryStatusDefer  = "deferred"    // 处于用户免打扰时段，延后到时段结束再发送
+)
+
+// ReminderDelivery 提醒的单次投递尝试记录 (集合 reminder_deliveries)，每个渠道一条
+type ReminderDelivery struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ReminderID   primitive.ObjectID `bson:"reminder_id" json:"reminder_id"`
+	EventID      primitive.ObjectID `bson:"event_id" json:"event_id"`
+	UserID       primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Channel      string             `bson:"channel" json:"channel"` // app / email
+	Status       string             `bson:"status" json:"status"`
+	Error        string             `bson:"error,omitempty" json:"error,omitempty"`
+	Attempt      int                `bson:"attempt" json:"attempt"` // 本次提醒的第几次尝试 (从 1 开始)
+	LatencyMs    int64              `bson:"latency_ms" json:"latency_ms"`
+	ScheduledFor *time.Time         `bson:"scheduled_for,omitempty" json:"scheduled_for,omitempty"` // 对应的 next_send
+	DeferredTo   *time.Time         `bson:"deferred_to,omitempty" json:"deferred_to,omitempty"`     // 免打扰延后到的时间
+	Instance     string             `bson:"instance,omitempty" json:"instance,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// ReminderRetry 发送失败后的重试状态
+type ReminderRetry struct {
+	Attempts  int       // 已失败次数
+	Channels  []string  // 仍需重试的渠道 (已成功的渠道不再重复发送)
+	Endpoints []string  // 已投递成功的目标 ID (多目标渠道重试时跳过)
+	Error     string    // 最近一次错误
+	RetryAt   time.Time // 下一次可领取时间
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SavedView 用户保存的任务视图 (集合 saved_views)：命名的过滤表达式与排序，按 (user_id, name) 唯一，
+// 可用于任务列表、仪表盘优先任务与统一即将到来列表
+type SavedView struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name      string             `bson:"name" json:"name"`
+	Query     string             `bson:"query" json:"query"`                   // 过滤表达式，见 ParseTaskFilter
+	Sort      string             `bson:"sort,omitempty" json:"sort,omitempty"` // 排序，见 ParseTaskSort
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// SavedViewRequest 创建 / 更新视图
+type SavedViewRequest struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	Sort  string `json:"sort"`
+}
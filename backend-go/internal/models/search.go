@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// 搜索结果来源
+const (
+	SearchSourceTask    = "task"
+	SearchSourceEvent   = "event"
+	SearchSourceComment = "comment" // 事件评论
+)
+
+// SearchQuery 统一搜索条件。Status / Priority 只对任务生效，Tags 只对事件生效，
+// 设置后其余来源不再参与搜索；日期范围对任务取截止 / 计划日期，对事件取事件日期，对评论取创建时间。
+type SearchQuery struct {
+	Text     string
+	Sources  []string // 空为全部来源
+	Status   []string
+	Priority []string
+	Tags     []string // 须全部包含
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+}
+
+// SearchHit 单条搜索结果，按 Score 降序排列
+type SearchHit struct {
+	ID        string     `json:"id"`
+	Source    string     `json:"source"` // task | event | comment
+	Title     string     `json:"title"`
+	Highlight string     `json:"highlight"`          // 标题，命中词以 <mark> 标记 (其余内容已 HTML 转义)
+	Snippet   string     `json:"snippet,omitempty"`  // 正文命中片段，格式同 Highlight
+	Field     string     `json:"field,omitempty"`    // 片段所在字段
+	Score     float64    `json:"score"`              // 相关度
+	Date      *time.Time `json:"date,omitempty"`     // 任务截止 / 计划日期、事件日期或评论时间
+	Status    string     `json:"status,omitempty"`   // 任务
+	Priority  string     `json:"priority,omitempty"` // 任务
+	Tags      []string   `json:"tags,omitempty"`     // 事件
+	EventID   string     `json:"event_id,omitempty"` // 评论所属事件
+	DetailURL string     `json:"detail_url,omitempty"`
+}
+
+// SearchResponse 搜索响应
+type SearchResponse struct {
+	Query string      `json:"query"`
+	Items []SearchHit `json:"items"`
+	Total int         `json:"total"`
+}
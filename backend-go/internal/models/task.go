@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type Comment struct {
 	Text      string    `bson:"text" json:"text"`
@@ -14,13 +17,197 @@ type Task struct {
 	Description   string     `bson:"description" json:"description"`
 	Status        string     `bson:"status" json:"status"`
 	Priority      string     `bson:"priority" json:"priority"`
-	Assignee      *string    `bson:"assignee" json:"assignee"`
+	Assignee      *string    `bson:"assignee" json:"assignee"` // 负责人用户 ID，须能看到该任务
 	CreatedBy     string     `bson:"createdBy" json:"createdBy"`
 	CreatedAt     time.Time  `bson:"createdAt" json:"createdAt"`
 	UpdatedAt     time.Time  `bson:"updatedAt" json:"updatedAt"`
 	Deadline      *time.Time `bson:"deadline" json:"deadline"`
 	ScheduledDate *time.Time `bson:"scheduledDate" json:"scheduledDate"`
 	Comments      []Comment  `bson:"comments" json:"comments"`
+	WorkspaceID   string     `bson:"workspaceId,omitempty" json:"workspaceId,omitempty"` // 所属工作区，空为个人任务
+	// 标签与自定义字段 (取值按 TaskFieldDefinition 校验，键为字段 Key)
+	Tags         []string               `bson:"tags,omitempty" json:"tags,omitempty"`
+	CustomFields map[string]interface{} `bson:"customFields,omitempty" json:"customFields,omitempty"`
+	// 子任务与检查项
+	ParentID        *string         `bson:"parentId,omitempty" json:"parentId,omitempty"`
+	Checklist       []ChecklistItem `bson:"checklist,omitempty" json:"checklist,omitempty"`
+	AutoComplete    bool            `bson:"autoComplete,omitempty" json:"autoComplete,omitempty"`       // 子任务与检查项全部完成时自动完成
+	Progress        *TaskProgress   `bson:"progress,omitempty" json:"progress,omitempty"`               // 由子任务与检查项汇总
+	SubtaskDeadline *time.Time      `bson:"subtaskDeadline,omitempty" json:"subtaskDeadline,omitempty"` // 未完成子任务中最早的截止日期
+	Subtasks        []Task          `bson:"-" json:"subtasks,omitempty"`                                // 详情接口返回的层级
+	// 依赖：BlockedBy 中的任务全部完成前本任务处于阻塞状态
+	BlockedBy   []string   `bson:"blockedBy,omitempty" json:"blockedBy,omitempty"`
+	Blocked     bool       `bson:"blocked,omitempty" json:"blocked"`
+	UnblockedAt *time.Time `bson:"unblockedAt,omitempty" json:"unblockedAt,omitempty"` // 最近一次解除阻塞的时间
+	// 循环任务：完成后按 Recurrence 生成下一个实例，同一系列的实例通过 SeriesID / PreviousID / NextID 串联
+	Recurrence      string     `bson:"recurrence,omitempty" json:"recurrence,omitempty"`           // RRULE，如 FREQ=WEEKLY;BYDAY=MO
+	RecurrenceStart *time.Time `bson:"recurrenceStart,omitempty" json:"recurrenceStart,omitempty"` // 系列首个实例的锚点时间，COUNT / BYDAY 等据此展开
+	SeriesID        string     `bson:"seriesId,omitempty" json:"seriesId,omitempty"`               // 系列首个实例 ID
+	PreviousID      string     `bson:"previousId,omitempty" json:"previousId,omitempty"`
+	NextID          string     `bson:"nextId,omitempty" json:"nextId,omitempty"`
+}
+
+// RecurrenceAnchor 循环展开的锚点：优先计划日期，其次截止日期，都没有时取创建时间
+func (t Task) RecurrenceAnchor() time.Time {
+	switch {
+	case t.ScheduledDate != nil:
+		return *t.ScheduledDate
+	case t.Deadline != nil:
+		return *t.Deadline
+	}
+	return t.CreatedAt
+}
+
+// NextRecurrence 下一个实例的计划日期与截止日期 (保持两者原有间隔)。
+// 下一次发生取锚点与 now 中较晚者之后的第一个发生时间，逾期完成不会补生成已错过的实例；
+// 非循环任务、规则非法或规则已结束时 ok 为 false。
+func (t Task) NextRecurrence(now time.Time) (scheduled, deadline *time.Time, ok bool) {
+	if t.Recurrence == "" {
+		return nil, nil, false
+	}
+	rule, err := ParseRRule(t.Recurrence)
+	if err != nil {
+		return nil, nil, false
+	}
+	anchor := t.RecurrenceAnchor()
+	start := anchor
+	if t.RecurrenceStart != nil {
+		start = *t.RecurrenceStart
+	}
+	after := anchor
+	if now.After(after) {
+		after = now
+	}
+	next := rule.Next(start, after)
+	if next == nil {
+		return nil, nil, false
+	}
+	delta := next.Sub(anchor)
+	shift := func(d *time.Time) *time.Time {
+		if d == nil {
+			return nil
+		}
+		v := d.Add(delta)
+		return &v
+	}
+	scheduled, deadline = shift(t.ScheduledDate), shift(t.Deadline)
+	if scheduled == nil && deadline == nil {
+		scheduled = next // 无日期的循环任务按计划日期排期
+	}
+	return scheduled, deadline, true
+}
+
+// RecentlyUnblockedWindow 解除阻塞后在优先列表 / 统一看板中前置展示的时长
+const RecentlyUnblockedWindow = 24 * time.Hour
+
+// RecentlyUnblocked 最近解除阻塞且仍未完成
+func (t Task) RecentlyUnblocked(now time.Time) bool {
+	return !t.Blocked && !IsTaskDone(t.Status) && t.UnblockedAt != nil && now.Sub(*t.UnblockedAt) < RecentlyUnblockedWindow
+}
+
+// ChecklistItem 轻量检查项 (不是独立任务)
+type ChecklistItem struct {
+	ID     string     `bson:"id" json:"id"`
+	Text   string     `bson:"text" json:"text"`
+	Done   bool       `bson:"done" json:"done"`
+	DoneAt *time.Time `bson:"doneAt,omitempty" json:"doneAt,omitempty"`
+}
+
+// TaskProgress 完成进度 (n / m)
+type TaskProgress struct {
+	Done  int `bson:"done" json:"done"`
+	Total int `bson:"total" json:"total"`
+}
+
+// Complete 有子项且全部完成
+func (p TaskProgress) Complete() bool { return p.Total > 0 && p.Done == p.Total }
+
+// TaskStatusDone 自动完成写入的状态 (与 REST / gRPC 一致)
+const TaskStatusDone = "Done"
+
+// TaskStatusTodo 循环任务新实例的初始状态 (与 REST 一致)
+const TaskStatusTodo = "To Do"
+
+// IsTaskDone 兼容各入口的完成状态写法
+func IsTaskDone(status string) bool {
+	switch strings.ToLower(status) {
+	case "done", "completed", "已完成":
+		return true
+	}
+	return false
+}
+
+// TaskStatusVariants REST ("To Do"/"In Progress") 与 gRPC ("Todo"/"InProgress") 同一状态的全部写法，用于按状态过滤
+func TaskStatusVariants(status string) []string {
+	switch strings.ToLower(strings.ReplaceAll(status, " ", "")) {
+	case "todo":
+		return []string{"To Do", "Todo"}
+	case "inprogress":
+		return []string{"In Progress", "InProgress"}
+	case "done":
+		return []string{"Done"}
+	}
+	return []string{status}
+}
+
+// EffectiveDeadline 自身截止日期，未设置时取子任务中最早的截止日期
+func (t Task) EffectiveDeadline() *time.Time {
+	if t.Deadline != nil {
+		return t.Deadline
+	}
+	return t.SubtaskDeadline
+}
+
+// RollupProgress 汇总直接子任务与检查项的完成进度，并返回未完成子任务 (含其子任务) 中最早的截止日期
+func RollupProgress(children []Task, checklist []ChecklistItem) (TaskProgress, *time.Time) {
+	var p TaskProgress
+	var earliest *time.Time
+	for _, c := range children {
+		p.Total++
+		if IsTaskDone(c.Status) {
+			p.Done++
+			continue
+		}
+		if d := c.EffectiveDeadline(); d != nil && (earliest == nil || d.Before(*earliest)) {
+			earliest = d
+		}
+	}
+	for _, it := range checklist {
+		p.Total++
+		if it.Done {
+			p.Done++
+		}
+	}
+	return p, earliest
+}
+
+// 任务列表视图 (按负责人 / 创建者筛选)
+const (
+	TaskViewAll          = ""
+	TaskViewAssignedToMe = "assigned_to_me"
+	TaskViewCreatedByMe  = "created_by_me"
+	TaskViewUnassigned   = "unassigned"
+)
+
+// ValidTaskView 是否为已知视图 (空串为全部)
+func ValidTaskView(view string) bool {
+	switch view {
+	case TaskViewAll, TaskViewAssignedToMe, TaskViewCreatedByMe, TaskViewUnassigned:
+		return true
+	}
+	return false
+}
+
+// TaskQuery 任务列表查询条件
+type TaskQuery struct {
+	Status string
+	View   string          // TaskView*
+	Tags   []string        // 须包含全部标签
+	Filter TaskFilter      // 过滤表达式 (ParseTaskFilter)
+	Sort   []TaskSortField // 为空时按创建时间倒序
+	Loc    *time.Location  // 过滤表达式中日期的时区，nil 为 UTC
+	Page   int64
+	Limit  int64
 }
 
 // TaskUpdateRequest 用于部分更新
@@ -34,4 +221,9 @@ type TaskUpdateRequest struct {
 	Deadline      *time.Time
 	ScheduledDate *time.Time
 	Comments      []Comment
+	Checklist     []ChecklistItem // 全量替换
+	AutoComplete  *bool
+	Recurrence    *string                // 空字符串表示停止循环
+	Tags          *[]string              // 全量替换
+	CustomFields  map[string]interface{} // 按键合并，取值为 nil 或空字符串时删除该字段
 }
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 自定义字段类型
+const (
+	TaskFieldText   = "text"
+	TaskFieldNumber = "number"
+	TaskFieldDate   = "date"   // 存为时间，输入支持 RFC3339 与 YYYY-MM-DD
+	TaskFieldSelect = "select" // 取值须为 Options 之一
+)
+
+// ValidTaskFieldType 是否为已知字段类型
+func ValidTaskFieldType(t string) bool {
+	switch t {
+	case TaskFieldText, TaskFieldNumber, TaskFieldDate, TaskFieldSelect:
+		return true
+	}
+	return false
+}
+
+// TaskFieldDefinition 任务自定义字段定义 (集合 task_field_definitions)。
+// 个人字段 (UserID) 作用于该用户的个人任务，工作区字段 (WorkspaceID) 作用于工作区任务；Key 在所属范围内唯一，
+// 任务上的取值保存在 Task.CustomFields[Key]
+type TaskFieldDefinition struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID      primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty" json:"workspace_id,omitempty"`
+	Key         string             `bson:"key" json:"key"` // 小写字母开头，字母 / 数字 / 下划线
+	Name        string             `bson:"name" json:"name"`
+	Type        string             `bson:"type" json:"type"` // TaskField*
+	Options     []string           `bson:"options,omitempty" json:"options,omitempty"`
+	CreatedBy   primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// TaskFieldRequest 创建 / 更新字段定义；更新时 Key、Type 与 WorkspaceID 不可修改
+type TaskFieldRequest struct {
+	WorkspaceID string   `json:"workspace_id"` // 为空时为个人字段
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Options     []string `json:"options"`
+}
+
+// FormatCustomFieldValue 自定义字段取值的字符串形式 (gRPC / 导出)：数字不带多余小数，日期为 RFC3339 (UTC)
+func FormatCustomFieldValue(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case int32:
+		return strconv.FormatInt(int64(x), 10)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case int:
+		return strconv.Itoa(x)
+	case time.Time:
+		return x.UTC().Format(time.RFC3339)
+	case primitive.DateTime:
+		return x.Time().UTC().Format(time.RFC3339)
+	}
+	return ""
+}
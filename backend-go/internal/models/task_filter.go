@@ -0,0 +1,339 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// 过滤表达式 / 排序相关错误
+var (
+	ErrInvalidTaskFilter = errors.New("invalid task filter")
+	ErrInvalidTaskSort   = errors.New("invalid task sort")
+)
+
+// 过滤字段 (规范名)
+const (
+	FilterFieldText      = "text" // 无字段前缀的关键词，匹配标题与描述
+	FilterFieldStatus    = "status"
+	FilterFieldPriority  = "priority"
+	FilterFieldAssignee  = "assignee"
+	FilterFieldCreator   = "creator"
+	FilterFieldDue       = "due"
+	FilterFieldScheduled = "scheduled"
+	FilterFieldCreated   = "created"
+	FilterFieldUpdated   = "updated"
+	FilterFieldTag       = "tag"
+	FilterFieldBlocked   = "blocked"
+	FilterFieldRecurring = "recurring"
+	FilterFieldParent    = "parent"
+	FilterFieldWorkspace = "workspace"
+
+	FilterFieldCustomPrefix = "cf." // 自定义字段，如 cf.estimate:>3、cf.stage:review
+)
+
+// 特殊取值
+const (
+	FilterValueMe   = "me"   // assignee / creator：当前用户
+	FilterValueNone = "none" // 字段为空
+)
+
+type filterKind int
+
+const (
+	filterKindString filterKind = iota
+	filterKindDate
+	filterKindBool
+	filterKindCustom // 类型由字段定义决定：支持比较符，取值按原文保留
+)
+
+// taskFilterFields 字段别名 -> 规范名
+var taskFilterFields = map[string]string{
+	"text": FilterFieldText, "title": FilterFieldText,
+	"status": FilterFieldStatus, "priority": FilterFieldPriority,
+	"assignee": FilterFieldAssignee, "assigned": FilterFieldAssignee,
+	"creator": FilterFieldCreator, "created_by": FilterFieldCreator, "author": FilterFieldCreator,
+	"due": FilterFieldDue, "deadline": FilterFieldDue,
+	"scheduled": FilterFieldScheduled,
+	"created":   FilterFieldCreated, "updated": FilterFieldUpdated,
+	"tag": FilterFieldTag, "tags": FilterFieldTag, "label": FilterFieldTag,
+	"blocked": FilterFieldBlocked, "recurring": FilterFieldRecurring,
+	"parent": FilterFieldParent, "workspace": FilterFieldWorkspace,
+}
+
+func filterFieldKind(field string) filterKind {
+	if strings.HasPrefix(field, FilterFieldCustomPrefix) {
+		return filterKindCustom
+	}
+	switch field {
+	case FilterFieldDue, FilterFieldScheduled, FilterFieldCreated, FilterFieldUpdated:
+		return filterKindDate
+	case FilterFieldBlocked, FilterFieldRecurring:
+		return filterKindBool
+	}
+	return filterKindString
+}
+
+// TaskFilterTerm 过滤表达式中的单个条件；同一条件的多个取值为「或」，条件之间为「且」
+type TaskFilterTerm struct {
+	Field  string   `json:"field"`
+	Op     string   `json:"op,omitempty"` // 日期与自定义字段比较：< <= > >= =，其余字段为空
+	Values []string `json:"values"`
+	Negate bool     `json:"negate,omitempty"`
+}
+
+// TaskFilter 解析后的过滤表达式
+type TaskFilter struct {
+	Terms []TaskFilterTerm `json:"terms"`
+}
+
+// And 合并两个过滤条件
+func (f TaskFilter) And(other TaskFilter) TaskFilter {
+	terms := make([]TaskFilterTerm, 0, len(f.Terms)+len(other.Terms))
+	return TaskFilter{Terms: append(append(terms, f.Terms...), other.Terms...)}
+}
+
+// ParseTaskFilter 解析任务过滤表达式，例如：
+//
+//	priority:High due:<7d -status:Done tag:ops assignee:me "weekly report"
+//
+// 以空格分隔条件，条件之间为「且」；`-` 前缀取反；逗号分隔多个取值为「或」；含空格的取值用双引号。
+// 无字段前缀的词匹配标题与描述。日期字段 (due / scheduled / created / updated) 支持比较符
+// < <= > >= 与取值：相对时间 (7d、-2w、12h)、today / tomorrow / yesterday、YYYY-MM-DD；
+// assignee / creator 可用 me，assignee / due / scheduled / parent / workspace 可用 none 表示为空。
+// 自定义字段写作 cf.<key>，同样支持比较符与 none，取值按字段类型 (数字 / 日期 / 文本) 匹配。
+func ParseTaskFilter(expr string) (TaskFilter, error) {
+	var f TaskFilter
+	tokens, err := filterTokens(expr)
+	if err != nil {
+		return f, err
+	}
+	now := time.Now()
+	for _, tok := range tokens {
+		term, err := parseFilterTerm(tok, now)
+		if err != nil {
+			return TaskFilter{}, err
+		}
+		f.Terms = append(f.Terms, term)
+	}
+	return f, nil
+}
+
+// filterTokens 按空白切分，双引号内的空白保留
+func filterTokens(expr string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	quoted := false
+	for _, r := range expr {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated quote", ErrInvalidTaskFilter)
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+var (
+	filterKeyPattern    = regexp.MustCompile(`^[a-zA-Z_]+$`)
+	customFieldKeyRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+)
+
+// ValidCustomFieldKey 自定义字段 Key：小写字母开头，小写字母 / 数字 / 下划线，最长 40
+func ValidCustomFieldKey(key string) bool { return customFieldKeyRegex.MatchString(key) }
+
+// customFilterField cf.<key> / field.<key> 形式的自定义字段前缀，返回规范名
+func customFilterField(prefix string) (string, bool) {
+	lower := strings.ToLower(prefix)
+	for _, p := range []string{FilterFieldCustomPrefix, "field."} {
+		if key := strings.TrimPrefix(lower, p); key != lower && ValidCustomFieldKey(key) {
+			return FilterFieldCustomPrefix + key, true
+		}
+	}
+	return "", false
+}
+
+func parseFilterTerm(tok string, now time.Time) (TaskFilterTerm, error) {
+	var t TaskFilterTerm
+	if len(tok) > 1 && tok[0] == '-' {
+		t.Negate = true
+		tok = tok[1:]
+	}
+	field, raw := FilterFieldText, tok
+	if i := strings.Index(tok, ":"); i > 0 && !strings.HasPrefix(tok, `"`) {
+		canonical, ok := customFilterField(tok[:i])
+		if !ok && filterKeyPattern.MatchString(tok[:i]) {
+			if canonical, ok = taskFilterFields[strings.ToLower(tok[:i])]; !ok {
+				return t, fmt.Errorf("%w: unknown field %q", ErrInvalidTaskFilter, tok[:i])
+			}
+		}
+		if ok {
+			field, raw = canonical, tok[i+1:]
+		}
+	}
+	t.Field = field
+	kind := filterFieldKind(field)
+	if kind == filterKindDate || kind == filterKindCustom {
+		for _, op := range []string{"<=", ">=", "<", ">", "="} {
+			if strings.HasPrefix(raw, op) {
+				t.Op, raw = op, raw[len(op):]
+				break
+			}
+		}
+	}
+	values := strings.Split(raw, ",")
+	if field == FilterFieldText {
+		values = []string{raw} // 关键词中的逗号按原文匹配
+	}
+	for _, v := range values {
+		v = strings.TrimSpace(strings.Trim(v, `"`))
+		if v == "" {
+			return t, fmt.Errorf("%w: empty value for %s", ErrInvalidTaskFilter, field)
+		}
+		norm, err := normalizeFilterValue(field, kind, v, now)
+		if err != nil {
+			return t, err
+		}
+		t.Values = append(t.Values, norm)
+	}
+	if len(t.Values) == 0 {
+		return t, fmt.Errorf("%w: empty value for %s", ErrInvalidTaskFilter, field)
+	}
+	if (kind == filterKindDate || (kind == filterKindCustom && t.Op != "")) && len(t.Values) > 1 {
+		return t, fmt.Errorf("%w: %s takes a single value", ErrInvalidTaskFilter, field)
+	}
+	if t.Op != "" && t.Values[0] == FilterValueNone {
+		return t, fmt.Errorf("%w: cannot compare %s with none", ErrInvalidTaskFilter, field)
+	}
+	return t, nil
+}
+
+// normalizeFilterValue 校验取值并规范大小写
+func normalizeFilterValue(field string, kind filterKind, v string, now time.Time) (string, error) {
+	lower := strings.ToLower(v)
+	switch kind {
+	case filterKindBool:
+		switch lower {
+		case "true", "yes":
+			return "true", nil
+		case "false", "no":
+			return "false", nil
+		}
+		return "", fmt.Errorf("%w: %s expects true/false", ErrInvalidTaskFilter, field)
+	case filterKindDate:
+		if lower == FilterValueNone && (field == FilterFieldDue || field == FilterFieldScheduled) {
+			return FilterValueNone, nil
+		}
+		if _, _, err := ResolveFilterDate(lower, now, time.UTC); err != nil {
+			return "", err
+		}
+		return lower, nil
+	}
+	switch field {
+	case FilterFieldPriority:
+		for _, p := range []string{"Low", "Medium", "High"} {
+			if strings.EqualFold(p, v) {
+				return p, nil
+			}
+		}
+		return "", fmt.Errorf("%w: unknown priority %q", ErrInvalidTaskFilter, v)
+	case FilterFieldAssignee, FilterFieldCreator:
+		if lower == FilterValueMe || (lower == FilterValueNone && field == FilterFieldAssignee) {
+			return lower, nil
+		}
+	case FilterFieldParent, FilterFieldWorkspace:
+		if lower == FilterValueNone {
+			return lower, nil
+		}
+	}
+	if kind == filterKindCustom && lower == FilterValueNone {
+		return FilterValueNone, nil
+	}
+	return v, nil
+}
+
+var relativeDatePattern = regexp.MustCompile(`^([+-]?\d+)([hdw])$`)
+
+// ResolveFilterDate 解析过滤表达式中的日期取值：相对时间 (7d / -2w / 12h) 为时间点 (start == end)，
+// today / tomorrow / yesterday 与 YYYY-MM-DD 为 loc 时区下的整天 [start, end)
+func ResolveFilterDate(v string, now time.Time, loc *time.Location) (start, end time.Time, err error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if m := relativeDatePattern.FindStringSubmatch(v); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		unit := map[string]time.Duration{"h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}[m[2]]
+		at := now.Add(time.Duration(n) * unit)
+		return at, at, nil
+	}
+	local := now.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	var day time.Time
+	switch v {
+	case "today":
+		day = today
+	case "tomorrow":
+		day = today.AddDate(0, 0, 1)
+	case "yesterday":
+		day = today.AddDate(0, 0, -1)
+	default:
+		d, perr := time.ParseInLocation("2006-01-02", v, loc)
+		if perr != nil {
+			return start, end, fmt.Errorf("%w: bad date %q", ErrInvalidTaskFilter, v)
+		}
+		day = d
+	}
+	return day, day.AddDate(0, 0, 1), nil
+}
+
+// TaskSortField 排序字段
+type TaskSortField struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// taskSortFields 可排序字段 (别名 -> 规范名)
+var taskSortFields = map[string]string{
+	"title": "title", "status": FilterFieldStatus, "priority": FilterFieldPriority,
+	"due": FilterFieldDue, "deadline": FilterFieldDue, "scheduled": FilterFieldScheduled,
+	"created": FilterFieldCreated, "updated": FilterFieldUpdated,
+}
+
+// ParseTaskSort 解析排序参数，逗号分隔，`-` 前缀为降序，例如 "-priority,due,cf.estimate"
+func ParseTaskSort(s string) ([]TaskSortField, error) {
+	var out []TaskSortField
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		desc := strings.HasPrefix(part, "-")
+		name := strings.ToLower(strings.TrimPrefix(part, "-"))
+		field, ok := taskSortFields[name]
+		if !ok {
+			field, ok = customFilterField(name)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidTaskSort, part)
+		}
+		out = append(out, TaskSortField{Field: field, Desc: desc})
+	}
+	return out, nil
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaskFilter(t *testing.T) {
+	f, err := ParseTaskFilter(`priority:high,medium due:<7d -status:Done tag:ops assignee:me "weekly report"`)
+	require.NoError(t, err)
+	require.Equal(t, []TaskFilterTerm{
+		{Field: FilterFieldPriority, Values: []string{"High", "Medium"}},
+		{Field: FilterFieldDue, Op: "<", Values: []string{"7d"}},
+		{Field: FilterFieldStatus, Values: []string{"Done"}, Negate: true},
+		{Field: FilterFieldTag, Values: []string{"ops"}},
+		{Field: FilterFieldAssignee, Values: []string{FilterValueMe}},
+		{Field: FilterFieldText, Values: []string{"weekly report"}},
+	}, f.Terms)
+
+	f, err = ParseTaskFilter(`deadline:none blocked:yes label:"on call"`)
+	require.NoError(t, err)
+	require.Equal(t, []TaskFilterTerm{
+		{Field: FilterFieldDue, Values: []string{FilterValueNone}},
+		{Field: FilterFieldBlocked, Values: []string{"true"}},
+		{Field: FilterFieldTag, Values: []string{"on call"}},
+	}, f.Terms)
+
+	f, err = ParseTaskFilter(`cf.estimate:>=2.5 field.Stage:review,done -cf.owner:none`)
+	require.NoError(t, err)
+	require.Equal(t, []TaskFilterTerm{
+		{Field: "cf.estimate", Op: ">=", Values: []string{"2.5"}},
+		{Field: "cf.stage", Values: []string{"review", "done"}},
+		{Field: "cf.owner", Values: []string{FilterValueNone}, Negate: true},
+	}, f.Terms)
+
+	f, err = ParseTaskFilter("   ")
+	require.NoError(t, err)
+	require.Empty(t, f.Terms)
+
+	for _, bad := range []string{"color:red", "priority:urgent", `"open`, "due:<soon", "due:<none", "blocked:maybe", "due:today,tomorrow", "tag:"} {
+		_, err := ParseTaskFilter(bad)
+		require.Truef(t, errors.Is(err, ErrInvalidTaskFilter), "%q: %v", bad, err)
+	}
+}
+
+func TestResolveFilterDate(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	now := time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC) // 本地 3 月 11 日 04:00
+
+	start, end, err := ResolveFilterDate("today", now, loc)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 3, 11, 0, 0, 0, 0, loc), start)
+	require.Equal(t, start.AddDate(0, 0, 1), end)
+
+	start, end, err = ResolveFilterDate("-2w", now, loc)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-14*24*time.Hour), start)
+	require.Equal(t, start, end)
+
+	start, _, err = ResolveFilterDate("2025-01-02", now, loc)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, loc), start)
+}
+
+func TestParseTaskSort(t *testing.T) {
+	s, err := ParseTaskSort("-priority, deadline ,")
+	require.NoError(t, err)
+	require.Equal(t, []TaskSortField{{Field: FilterFieldPriority, Desc: true}, {Field: FilterFieldDue}}, s)
+
+	s, err = ParseTaskSort("-cf.estimate")
+	require.NoError(t, err)
+	require.Equal(t, []TaskSortField{{Field: "cf.estimate", Desc: true}}, s)
+
+	_, err = ParseTaskSort("assignee")
+	require.ErrorIs(t, err, ErrInvalidTaskSort)
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata" // 内置 IANA 时区库，避免精简镜像缺少 zoneinfo
+)
+
+// ErrInvalidTimeZone 非法的 IANA 时区名
+var ErrInvalidTimeZone = errors.New("invalid time zone")
+
+// ValidateTimeZone 校验 IANA 时区名 (空串表示未设置，合法)
+func ValidateTimeZone(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidTimeZone, name)
+	}
+	return nil
+}
+
+// LoadLocation 加载时区，未设置或非法时回退 UTC
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// TimeLocation 事件所在时区 (循环展开、全天事件与提醒时间均按此时区的墙上时间计算)
+func (e *Event) TimeLocation() *time.Location {
+	return LoadLocation(e.TimeZone)
+}
+
+// LocalEventDate 事件起始时间转换到事件时区，作为 RRULE 的 DTSTART
+func (e *Event) LocalEventDate() time.Time {
+	return e.EventDate.In(e.TimeLocation())
+}
+
+// StartOfDay 返回 t 在 loc 中所在日期的 00:00
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// DateIn 取 t 自身的年月日 (不做时区换算)，作为 loc 中的 00:00；用于全天事件的日期落地
+func DateIn(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// DayKey 按 loc 计算日历分桶的日期键 (YYYY-MM-DD)
+func DayKey(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("2006-01-02")
+}
+
+// MonthRange 返回 loc 中某月的 [start, end) 边界
+func MonthRange(year, month int, loc *time.Location) (time.Time, time.Time) {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// ReportPeriodStart 按报表类型计算截至 end 的周期起点 (日报为 loc 中当天 00:00)
+func ReportPeriodStart(reportType string, end time.Time, loc *time.Location) time.Time {
+	local := end.In(loc)
+	switch reportType {
+	case "weekly":
+		return local.AddDate(0, 0, -7)
+	case "monthly":
+		return local.AddDate(0, -1, 0)
+	}
+	return StartOfDay(local, loc)
+}
+
+// CalendarDaysBetween 返回 loc 中 from 到 to 相差的日历天数 (不受夏令时 23/25 小时日影响)
+func CalendarDaysBetween(from, to time.Time, loc *time.Location) int {
+	fy, fm, fd := from.In(loc).Date()
+	ty, tm, td := to.In(loc).Date()
+	a := time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)
+	b := time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
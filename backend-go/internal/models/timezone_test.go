@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurrenceKeepsWallClockAcrossDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// 2025-03-09 美东进入夏令时：09:00 本地时间前后分别为 14:00Z / 13:00Z
+	ev := Event{EventDate: utc(2025, 3, 3, 14, 0), RecurrenceType: "weekly", TimeZone: "America/New_York"}
+	got := ev.OccurrencesBetween(utc(2025, 3, 1, 0, 0), utc(2025, 3, 20, 0, 0), 0)
+	require.Len(t, got, 3)
+	for _, at := range got {
+		require.Equal(t, 9, at.In(ny).Hour())
+	}
+	require.True(t, got[1].Equal(utc(2025, 3, 10, 13, 0)))
+
+	next := ev.GetNextOccurrence(utc(2025, 3, 5, 0, 0))
+	require.NotNil(t, next)
+	require.True(t, next.Equal(utc(2025, 3, 10, 13, 0)))
+}
+
+func TestReminderTimesInEventZone(t *testing.T) {
+	// 2030-03-10 美东进入夏令时；提醒 HH:MM 按事件时区的墙上时间计算
+	ev := Event{EventDate: time.Date(2030, 3, 10, 0, 0, 0, 0, LoadLocation("America/New_York")), RecurrenceType: "none", IsAllDay: true, TimeZone: "America/New_York"}
+	r := Reminder{IsActive: true, AdvanceDays: 1, ReminderTimes: []string{"09:00"}}
+	next := r.CalculateNextSendTime(ev)
+	require.NotNil(t, next)
+	require.True(t, next.Equal(utc(2030, 3, 9, 14, 0)))
+
+	r.AdvanceDays = 0
+	next = r.CalculateNextSendTime(ev)
+	require.NotNil(t, next)
+	require.True(t, next.Equal(utc(2030, 3, 10, 13, 0)))
+}
+
+func TestCalendarHelpersInZone(t *testing.T) {
+	sh := LoadLocation("Asia/Shanghai")
+	require.Equal(t, "2025-08-01", DayKey(utc(2025, 7, 31, 20, 0), sh))
+	start, end := MonthRange(2025, 8, sh)
+	require.True(t, start.Equal(utc(2025, 7, 31, 16, 0)))
+	require.True(t, end.Equal(utc(2025, 8, 31, 16, 0)))
+
+	ny := LoadLocation("America/New_York")
+	require.Equal(t, 1, CalendarDaysBetween(time.Date(2025, 3, 8, 23, 0, 0, 0, ny), time.Date(2025, 3, 9, 23, 0, 0, 0, ny), ny))
+	require.True(t, ReportPeriodStart("daily", utc(2025, 3, 9, 20, 0), ny).Equal(utc(2025, 3, 9, 5, 0)))
+
+	require.Equal(t, time.UTC, LoadLocation("Not/AZone"))
+	require.ErrorIs(t, ValidateTimeZone("Not/AZone"), ErrInvalidTimeZone)
+}
@@ -14,8 +14,11 @@ type UnifiedItem struct {
 	PriorityScore    float64   `json:"priority_score,omitempty"`
 	RelatedEventID   string    `json:"related_event_id,omitempty"`
 	DetailURL        string    `json:"detail_url,omitempty"`
-	SourceID         string    `json:"source_id,omitempty"`      // 原始对象ID（与 ID 相同或不同）
-	IsUnscheduled    bool      `json:"is_unscheduled,omitempty"` // 对于无日期临时展示的任务
+	SourceID         string    `json:"source_id,omitempty"`       // 原始对象ID（与 ID 相同或不同）
+	IsUnscheduled    bool      `json:"is_unscheduled,omitempty"`  // 对于无日期临时展示的任务
+	OccurrenceKey    string    `json:"occurrence_key,omitempty"`  // 事件单次发生标识（循环事件展开）
+	Blocked          bool      `json:"blocked,omitempty"`         // 任务被未完成的依赖阻塞 (排在后面)
+	NewlyUnblocked   bool      `json:"newly_unblocked,omitempty"` // 任务刚解除阻塞 (排在前面)
 }
 
 // UnifiedUpcomingResponse 响应
@@ -60,6 +63,8 @@ type UnifiedCalendarItem struct {
 	ScheduledAt time.Time `json:"scheduled_at"`
 	Importance  int       `json:"importance,omitempty"`
 	DetailURL   string    `json:"detail_url,omitempty"`
+	// OccurrenceKey 事件单次发生标识（循环事件同一 ID 会出现多次）
+	OccurrenceKey string `json:"occurrence_key,omitempty"`
 }
 
 type UnifiedCalendarResponse struct {
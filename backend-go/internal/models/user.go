@@ -10,5 +10,13 @@ type User struct {
 	Username  string    `bson:"username" json:"username"`
 	Email     string    `bson:"email" json:"email"`
 	Password  string    `bson:"password" json:"-"`
+	TimeZone  string    `bson:"timeZone,omitempty" json:"timeZone,omitempty"` // IANA 时区，例如 Asia/Shanghai
+	Locale    string    `bson:"locale,omitempty" json:"locale,omitempty"`     // 通知与邮件语言，例如 zh-CN / en-US
 	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
 }
+
+// UpdateProfileRequest 更新个人资料请求
+type UpdateProfileRequest struct {
+	TimeZone *string `json:"timeZone,omitempty"`
+	Locale   *string `json:"locale,omitempty"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 工作区成员角色：owner 管理成员与工作区，editor 可增删改其中的任务 / 事件 / 提醒，viewer 只读
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleEditor = "editor"
+	WorkspaceRoleViewer = "viewer"
+)
+
+var workspaceRoleRank = map[string]int{WorkspaceRoleViewer: 1, WorkspaceRoleEditor: 2, WorkspaceRoleOwner: 3}
+
+// Workspace 共享工作区 (项目)，成员与角色内嵌保存
+type Workspace struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	OwnerID     primitive.ObjectID `bson:"owner_id" json:"owner_id"` // 创建者
+	Members     []WorkspaceMember  `bson:"members" json:"members"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// WorkspaceMember 工作区成员
+type WorkspaceMember struct {
+	UserID  primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Role    string             `bson:"role" json:"role"`
+	AddedAt time.Time          `bson:"added_at" json:"added_at"`
+}
+
+// CreateWorkspaceRequest 创建工作区请求
+type CreateWorkspaceRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// UpdateWorkspaceRequest 更新工作区请求
+type UpdateWorkspaceRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// WorkspaceMemberRequest 添加 / 修改成员：user 为用户 ID、用户名或邮箱
+type WorkspaceMemberRequest struct {
+	User string `json:"user"`
+	Role string `json:"role"`
+}
+
+// ValidWorkspaceRole 是否为已知角色
+func ValidWorkspaceRole(role string) bool { return workspaceRoleRank[role] > 0 }
+
+// WorkspaceRoleAtLeast role 是否不低于 min
+func WorkspaceRoleAtLeast(role, min string) bool {
+	return workspaceRoleRank[role] > 0 && workspaceRoleRank[role] >= workspaceRoleRank[min]
+}
+
+// WorkspaceRolesAtLeast 不低于 min 的全部角色，供查询使用
+func WorkspaceRolesAtLeast(min string) []string {
+	var out []string
+	for _, r := range []string{WorkspaceRoleViewer, WorkspaceRoleEditor, WorkspaceRoleOwner} {
+		if WorkspaceRoleAtLeast(r, min) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// RoleOf 用户在工作区中的角色，非成员返回空串
+func (w *Workspace) RoleOf(userID primitive.ObjectID) string {
+	for _, m := range w.Members {
+		if m.UserID == userID {
+			return m.Role
+		}
+	}
+	return ""
+}
+
+// OwnerCount 拥有者人数 (最后一名拥有者不能降级或移除)
+func (w *Workspace) OwnerCount() int {
+	n := 0
+	for _, m := range w.Members {
+		if m.Role == WorkspaceRoleOwner {
+			n++
+		}
+	}
+	return n
+}
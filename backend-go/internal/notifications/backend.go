@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"context"
+	"sync"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// Envelope 跨实例广播的一条通知或变更 (Change 非空时为变更)，Origin 为发布方 Hub 标识 (发布方已在本地投递，收到自己的消息时跳过)
+type Envelope struct {
+	Origin       string              `bson:"origin"`
+	Notification models.Notification `bson:"notification"`
+	Change       *Change             `bson:"change,omitempty"`
+}
+
+// Backend 跨实例广播后端：Publish 的消息需通过 Listen 送达所有实例
+type Backend interface {
+	Publish(ctx context.Context, e Envelope) error
+	// Listen 阻塞地把收到的消息交给 handle，直到 ctx 取消或连接出错
+	Listen(ctx context.Context, handle func(Envelope)) error
+}
+
+// MemoryBackend 进程内广播 (单实例部署与测试)，多个 Hub 共用同一实例即可模拟多副本
+type MemoryBackend struct {
+	mu       sync.RWMutex
+	next     int
+	handlers map[int]func(Envelope)
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{handlers: map[int]func(Envelope){}}
+}
+
+func (b *MemoryBackend) Publish(_ context.Context, e Envelope) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, h := range b.handlers {
+		h(e)
+	}
+	return nil
+}
+
+func (b *MemoryBackend) Listen(ctx context.Context, handle func(Envelope)) error {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.handlers[id] = handle
+	b.mu.Unlock()
+	<-ctx.Done()
+	b.mu.Lock()
+	delete(b.handlers, id)
+	b.mu.Unlock()
+	return ctx.Err()
+}
@@ -0,0 +1,110 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 实时推送主题
+const (
+	TopicNotifications = "notifications" // 站内通知
+	TopicTasks         = "tasks"         // 任务增删改
+	TopicEvents        = "events"        // 事件增删改
+)
+
+// 变更动作
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+// EventTimelineTopic 某个事件的时间线主题 (event:<id>)
+func EventTimelineTopic(eventID primitive.ObjectID) string { return "event:" + eventID.Hex() }
+
+// Change 任务 / 事件 / 时间线的实时变更推送，只发给 UserID，不经过通知偏好
+type Change struct {
+	UserID primitive.ObjectID `bson:"user_id" json:"-"`
+	Topic  string             `bson:"topic" json:"topic"`
+	Action string             `bson:"action" json:"action"`
+	ID     string             `bson:"id" json:"id"` // 变更对象 ID (任务 / 事件 / 时间线条目)
+	Data   json.RawMessage    `bson:"data,omitempty" json:"data,omitempty"`
+	At     time.Time          `bson:"at" json:"at"`
+}
+
+// NewChange 构造变更，data 序列化为 JSON (失败时省略)
+func NewChange(userID primitive.ObjectID, topic, action, id string, data interface{}) Change {
+	c := Change{UserID: userID, Topic: topic, Action: action, ID: id, At: time.Now()}
+	if data != nil {
+		if b, err := json.Marshal(data); err == nil {
+			c.Data = b
+		}
+	}
+	return c
+}
+
+// Watch 一个变更订阅，消费过慢时丢弃并计数
+type Watch struct {
+	C       <-chan Change
+	ch      chan Change
+	dropped atomic.Int64
+}
+
+// Dropped 因缓冲已满被丢弃的累计条数
+func (w *Watch) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// Watch 订阅用户的变更推送，context 取消时自动解除
+func (h *Hub) Watch(ctx context.Context, userID primitive.ObjectID) *Watch {
+	ch := make(chan Change, subscriptionBuffer*4)
+	w := &Watch{C: ch, ch: ch}
+	h.mu.Lock()
+	if _, ok := h.watches[userID]; !ok {
+		h.watches[userID] = make(map[*Watch]struct{})
+	}
+	h.watches[userID][w] = struct{}{}
+	h.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if m, ok := h.watches[userID]; ok {
+			if _, ok := m[w]; ok {
+				delete(m, w)
+				close(w.ch)
+			}
+			if len(m) == 0 {
+				delete(h.watches, userID)
+			}
+		}
+	}()
+	return w
+}
+
+// Publish 推送一条变更给本实例订阅者并发布给其他实例；h 为 nil 时忽略
+func (h *Hub) Publish(c Change) {
+	if h == nil {
+		return
+	}
+	h.deliverChange(c)
+	h.publish(Envelope{Origin: h.origin, Change: &c})
+}
+
+func (h *Hub) deliverChange(c Change) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for w := range h.watches[c.UserID] {
+		select {
+		case w.ch <- c:
+			h.delivered.Add(1)
+		default:
+			w.dropped.Add(1)
+			h.dropped.Add(1)
+		}
+	}
+}
@@ -2,33 +2,106 @@ package notifications
 
 import (
 	"context"
+	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// Hub 负责在单实例内广播通知 (SSE)
+// Gate 推送前按用户偏好放行：ok=false 表示不实时推送，at 晚于当前时间表示延后到该时间推送
+type Gate interface {
+	Admit(n models.Notification) (at time.Time, ok bool)
+}
+
+// Hub 负责广播通知 (SSE)：本实例的订阅直接投递，设置 Backend 后同时发布给其他实例
 type Hub struct {
-	mu   sync.RWMutex
-	subs map[primitive.ObjectID]map[chan models.Notification]struct{}
+	mu      sync.RWMutex
+	subs    map[primitive.ObjectID]map[chan models.Notification]*Subscription
+	watches map[primitive.ObjectID]map[*Watch]struct{}
+	gate    Gate
+	backend Backend // nil 表示仅本实例
+	origin  string  // 本 Hub 标识，用于跳过自己发布的消息
+
+	delivered atomic.Int64
+	dropped   atomic.Int64
+}
+
+// subscriptionBuffer 单个订阅的缓冲条数，写满后新通知计入丢弃
+const subscriptionBuffer = 10
+
+// Subscription 一个订阅：C 接收通知，消费过慢导致缓冲写满时丢弃并计数
+type Subscription struct {
+	C       <-chan models.Notification
+	ch      chan models.Notification
+	dropped atomic.Int64
+}
+
+// Dropped 因缓冲已满被丢弃的累计条数
+func (s *Subscription) Dropped() int64 { return s.dropped.Load() }
+
+// HubStats 本实例的广播统计
+type HubStats struct {
+	Subscribers   int   `json:"subscribers"`
+	Delivered     int64 `json:"delivered"`
+	Dropped       int64 `json:"dropped"`
+	SlowConsumers int   `json:"slow_consumers"` // 当前发生过丢弃的订阅数
 }
 
 func NewHub() *Hub {
-	return &Hub{subs: make(map[primitive.ObjectID]map[chan models.Notification]struct{})}
+	return &Hub{
+		subs:    make(map[primitive.ObjectID]map[chan models.Notification]*Subscription),
+		watches: make(map[primitive.ObjectID]map[*Watch]struct{}),
+		origin:  primitive.NewObjectID().Hex(),
+	}
 }
 
+// publishTimeout 单次跨实例发布的超时
+const publishTimeout = 3 * time.Second
+
 // Subscribe 返回 channel, context 取消时自动解除订阅
 func (h *Hub) Subscribe(ctx context.Context, userID primitive.ObjectID) chan models.Notification {
-	ch := make(chan models.Notification, 10)
+	return h.Listen(ctx, userID).ch
+}
+
+// Listen 同 Subscribe，返回可查询丢弃计数的订阅
+func (h *Hub) Listen(ctx context.Context, userID primitive.ObjectID) *Subscription {
+	ch := make(chan models.Notification, subscriptionBuffer)
+	sub := &Subscription{C: ch, ch: ch}
 	h.mu.Lock()
 	if _, ok := h.subs[userID]; !ok {
-		h.subs[userID] = make(map[chan models.Notification]struct{})
+		h.subs[userID] = make(map[chan models.Notification]*Subscription)
 	}
-	h.subs[userID][ch] = struct{}{}
+	h.subs[userID][ch] = sub
 	h.mu.Unlock()
 	go func() { <-ctx.Done(); h.Unsubscribe(userID, ch) }()
-	return ch
+	return sub
+}
+
+// Stats 当前订阅数与累计投递 / 丢弃数
+func (h *Hub) Stats() HubStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	st := HubStats{Delivered: h.delivered.Load(), Dropped: h.dropped.Load()}
+	for _, m := range h.subs {
+		for _, sub := range m {
+			st.Subscribers++
+			if sub.Dropped() > 0 {
+				st.SlowConsumers++
+			}
+		}
+	}
+	for _, m := range h.watches {
+		for w := range m {
+			st.Subscribers++
+			if w.Dropped() > 0 {
+				st.SlowConsumers++
+			}
+		}
+	}
+	return st
 }
 
 // Unsubscribe 移除 channel
@@ -46,15 +119,105 @@ func (h *Hub) Unsubscribe(userID primitive.ObjectID, ch chan models.Notification
 	}
 }
 
-// Broadcast 发送一条通知
+// SetGate 设置推送前的偏好检查 (nil 表示全部立即推送)
+func (h *Hub) SetGate(g Gate) {
+	h.mu.Lock()
+	h.gate = g
+	h.mu.Unlock()
+}
+
+// SetBackend 设置跨实例广播后端，需配合 Run 接收其他实例的通知
+func (h *Hub) SetBackend(b Backend) {
+	h.mu.Lock()
+	h.backend = b
+	h.mu.Unlock()
+}
+
+// Run 阻塞地接收其他实例发布的通知并投递给本实例订阅者，连接出错时退避重连，直到 ctx 取消
+func (h *Hub) Run(ctx context.Context) {
+	h.mu.RLock()
+	backend := h.backend
+	h.mu.RUnlock()
+	if backend == nil {
+		return
+	}
+	backoff := time.Second
+	for {
+		err := backend.Listen(ctx, func(e Envelope) {
+			switch {
+			case e.Origin == h.origin:
+			case e.Change != nil:
+				h.deliverChange(*e.Change)
+			default:
+				h.deliver(e.Notification)
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("notification hub: broadcast backend disconnected: %v (retry in %s)", err, backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// Broadcast 发送一条通知；免打扰期间的通知延后推送 (仅发布方实例内存，重启后以通知列表为准)
 func (h *Hub) Broadcast(n models.Notification) {
+	h.mu.RLock()
+	gate := h.gate
+	h.mu.RUnlock()
+	if gate != nil {
+		at, ok := gate.Admit(n)
+		if !ok {
+			return
+		}
+		if d := time.Until(at); d > 0 {
+			time.AfterFunc(d, func() { h.fanout(n) })
+			return
+		}
+	}
+	h.fanout(n)
+}
+
+// fanout 投递给本实例订阅者并发布给其他实例
+func (h *Hub) fanout(n models.Notification) {
+	h.deliver(n)
+	h.publish(Envelope{Origin: h.origin, Notification: n})
+}
+
+// publish 发布给其他实例 (未设置 Backend 时忽略)
+func (h *Hub) publish(e Envelope) {
+	h.mu.RLock()
+	backend := h.backend
+	h.mu.RUnlock()
+	if backend == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := backend.Publish(ctx, e); err != nil {
+		log.Printf("notification hub: publish failed: %v", err)
+	}
+}
+
+// deliver 非阻塞投递给本实例订阅者，缓冲已满时丢弃并计数 (SSE 据此从通知列表补发)
+func (h *Hub) deliver(n models.Notification) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 	if m, ok := h.subs[n.UserID]; ok {
-		for ch := range m {
+		for ch, sub := range m {
 			select {
 			case ch <- n:
+				h.delivered.Add(1)
 			default:
+				sub.dropped.Add(1)
+				h.dropped.Add(1)
 			}
 		}
 	}
@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+func TestHubFanOutAcrossInstances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backend := NewMemoryBackend()
+	a, b := NewHub(), NewHub()
+	a.SetBackend(backend)
+	b.SetBackend(backend)
+	go a.Run(ctx)
+	go b.Run(ctx)
+	require.Eventually(t, func() bool {
+		backend.mu.RLock()
+		defer backend.mu.RUnlock()
+		return len(backend.handlers) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	user := primitive.NewObjectID()
+	onA := a.Subscribe(ctx, user)
+	onB := b.Subscribe(ctx, user)
+	a.Broadcast(models.Notification{UserID: user, Message: "hi"})
+
+	for _, ch := range []chan models.Notification{onA, onB} {
+		select {
+		case n := <-ch:
+			require.Equal(t, "hi", n.Message)
+		case <-time.After(time.Second):
+			t.Fatal("notification not delivered")
+		}
+	}
+	// 发布方只投递一次，不会收到自己发布的回环消息
+	select {
+	case n := <-onA:
+		t.Fatalf("duplicate delivery: %+v", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// 单实例 (无 backend) 行为不变
+	solo := NewHub()
+	ch := solo.Subscribe(ctx, user)
+	solo.Broadcast(models.Notification{UserID: user, Message: "solo"})
+	require.Equal(t, "solo", (<-ch).Message)
+}
+
+func TestHubCountsDroppedForSlowConsumer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := NewHub()
+	user := primitive.NewObjectID()
+	slow := h.Listen(ctx, user)
+	for i := 0; i < subscriptionBuffer+3; i++ {
+		h.Broadcast(models.Notification{UserID: user, Message: "x"})
+	}
+	require.Equal(t, int64(3), slow.Dropped())
+	st := h.Stats()
+	require.Equal(t, HubStats{Subscribers: 1, Delivered: subscriptionBuffer, Dropped: 3, SlowConsumers: 1}, st)
+
+	cancel()
+	require.Eventually(t, func() bool { return h.Stats().Subscribers == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestHubPublishChangeAcrossInstances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	backend := NewMemoryBackend()
+	a, b := NewHub(), NewHub()
+	a.SetBackend(backend)
+	b.SetBackend(backend)
+	go a.Run(ctx)
+	go b.Run(ctx)
+	require.Eventually(t, func() bool {
+		backend.mu.RLock()
+		defer backend.mu.RUnlock()
+		return len(backend.handlers) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	user, other := primitive.NewObjectID(), primitive.NewObjectID()
+	onA, onB := a.Watch(ctx, user), b.Watch(ctx, user)
+	otherW := b.Watch(ctx, other)
+	notes := a.Subscribe(ctx, user)
+	a.Publish(NewChange(user, TopicTasks, ActionUpdated, "t1", map[string]string{"title": "x"}))
+
+	for _, w := range []*Watch{onA, onB} {
+		select {
+		case c := <-w.C:
+			require.Equal(t, TopicTasks, c.Topic)
+			require.Equal(t, "t1", c.ID)
+			require.JSONEq(t, `{"title":"x"}`, string(c.Data))
+		case <-time.After(time.Second):
+			t.Fatal("change not delivered")
+		}
+	}
+	// 变更只投递给所属用户，也不会进入通知订阅
+	select {
+	case c := <-otherW.C:
+		t.Fatalf("leaked change: %+v", c)
+	case n := <-notes:
+		t.Fatalf("change delivered as notification: %+v", n)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	var nilHub *Hub
+	nilHub.Publish(NewChange(user, TopicTasks, ActionDeleted, "t1", nil))
+}
@@ -0,0 +1,121 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 广播集合默认配置
+const (
+	DefaultBroadcastCollection = "notification_broadcasts"
+	defaultBroadcastSize       = 16 << 20 // 16MB，旧消息由固定集合自动淘汰
+)
+
+// MongoBackend 基于固定集合 (capped collection) 与 tailable 游标的跨实例广播，单节点 MongoDB 亦可使用
+type MongoBackend struct {
+	db   *mongo.Database
+	name string
+	size int64
+}
+
+func NewMongoBackend(db *mongo.Database) *MongoBackend {
+	return &MongoBackend{db: db, name: DefaultBroadcastCollection, size: defaultBroadcastSize}
+}
+
+// broadcastDoc 广播集合中的文档
+type broadcastDoc struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	PublishedAt time.Time          `bson:"published_at"`
+	Envelope    `bson:",inline"`
+}
+
+func (b *MongoBackend) coll() *mongo.Collection { return b.db.Collection(b.name) }
+
+// ensure 创建固定集合 (已存在时忽略)
+func (b *MongoBackend) ensure(ctx context.Context) error {
+	err := b.db.CreateCollection(ctx, b.name, options.CreateCollection().SetCapped(true).SetSizeInBytes(b.size))
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == 48 { // NamespaceExists
+		return nil
+	}
+	return err
+}
+
+func (b *MongoBackend) Publish(ctx context.Context, e Envelope) error {
+	_, err := b.coll().InsertOne(ctx, broadcastDoc{ID: primitive.NewObjectID(), PublishedAt: time.Now(), Envelope: e})
+	return err
+}
+
+// Listen 跟踪监听开始之后写入的消息。固定集合按插入顺序 ($natural) 保存与返回，
+// 游标失效后从集合开头重新打开并跳过已处理的部分，不使用发布方时间戳 (各实例时钟可能不一致)
+func (b *MongoBackend) Listen(ctx context.Context, handle func(Envelope)) error {
+	if err := b.ensure(ctx); err != nil {
+		return err
+	}
+	last, err := b.tail(ctx) // 最后处理 (或监听开始前已存在) 的消息，零值表示集合为空
+	if err != nil {
+		return err
+	}
+	opts := options.Find().SetCursorType(options.TailableAwait).SetMaxAwaitTime(time.Second)
+	for {
+		// last 仍在集合中时跳过它及之前的消息；已被淘汰时集合中剩余消息都在其后
+		skipping, err := b.contains(ctx, last)
+		if err != nil {
+			return err
+		}
+		cur, err := b.coll().Find(ctx, bson.M{}, opts)
+		if err != nil {
+			return err
+		}
+		for cur.Next(ctx) {
+			var doc broadcastDoc
+			if err := cur.Decode(&doc); err != nil {
+				continue
+			}
+			if skipping {
+				skipping = doc.ID != last
+				continue
+			}
+			last = doc.ID
+			handle(doc.Envelope)
+		}
+		err = cur.Err()
+		cur.Close(context.Background())
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// tail 按插入顺序的最后一条消息 ID，集合为空时返回零值
+func (b *MongoBackend) tail(ctx context.Context) (primitive.ObjectID, error) {
+	var doc broadcastDoc
+	err := b.coll().FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.D{{Key: "$natural", Value: -1}})).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return primitive.NilObjectID, nil
+	}
+	return doc.ID, err
+}
+
+// contains 消息是否仍在固定集合中 (零值视为不存在)
+func (b *MongoBackend) contains(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	if id.IsZero() {
+		return false, nil
+	}
+	n, err := b.coll().CountDocuments(ctx, bson.M{"_id": id}, options.Count().SetLimit(1))
+	return n > 0, err
+}
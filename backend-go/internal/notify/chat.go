@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// 群机器人 incoming webhook 消息格式
+const (
+	ChatFormatSlack    = "slack"
+	ChatFormatDiscord  = "discord"
+	ChatFormatFeishu   = "feishu"
+	ChatFormatDingTalk = "dingtalk"
+	ChatFormatWeCom    = "wecom"
+)
+
+// ChatChannel 群聊机器人 (Slack / Discord / 飞书 / 钉钉 / 企业微信)；飞书、钉钉配置 secret 时附加签名
+type ChatChannel struct {
+	store  EndpointStore
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewChatChannel(store EndpointStore) *ChatChannel {
+	return &ChatChannel{store: store, client: newHTTPClient(), now: time.Now}
+}
+
+func (c *ChatChannel) ID() string { return "chat" }
+
+func (c *ChatChannel) ValidateEndpoint(e *models.NotificationEndpoint) error {
+	if err := validateURL(e.URL); err != nil {
+		return err
+	}
+	switch e.Format {
+	case "":
+		e.Format = ChatFormatSlack
+	case ChatFormatSlack, ChatFormatDiscord, ChatFormatFeishu, ChatFormatDingTalk, ChatFormatWeCom:
+	default:
+		return fmt.Errorf("unsupported chat format: %s", e.Format)
+	}
+	return nil
+}
+
+func (c *ChatChannel) Send(ctx context.Context, msg Message) error {
+	text := chatText(msg)
+	return fanOut(ctx, c.store, msg, c.ID(), func(ctx context.Context, ep models.NotificationEndpoint) error {
+		target, body, err := chatRequest(ep, text, c.now())
+		if err != nil {
+			return Permanent(err)
+		}
+		resp, err := post(ctx, c.client, target, "application/json", body, nil)
+		if err != nil {
+			return err
+		}
+		return chatResponseError(resp)
+	})
+}
+
+// chatText 提醒正文 + 事件时间 (事件时区)
+func chatText(msg Message) string {
+	if msg.Event.EventDate.IsZero() {
+		return msg.Text
+	}
+	return fmt.Sprintf("%s\n时间：%s", msg.Text, msg.Event.LocalEventDate().Format("2006-01-02 15:04"))
+}
+
+// chatRequest 按格式组装请求地址与请求体
+func chatRequest(ep models.NotificationEndpoint, text string, now time.Time) (string, []byte, error) {
+	var payload map[string]interface{}
+	target := ep.URL
+	switch ep.Format {
+	case ChatFormatDiscord:
+		payload = map[string]interface{}{"content": text}
+	case ChatFormatFeishu:
+		payload = map[string]interface{}{"msg_type": "text", "content": map[string]string{"text": text}}
+		if ep.Secret != "" {
+			// 飞书：以 timestamp + "\n" + secret 为密钥对空串做 HMAC-SHA256
+			ts := strconv.FormatInt(now.Unix(), 10)
+			mac := hmac.New(sha256.New, []byte(ts+"\n"+ep.Secret))
+			payload["timestamp"] = ts
+			payload["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+		}
+	case ChatFormatDingTalk, ChatFormatWeCom:
+		payload = map[string]interface{}{"msgtype": "text", "text": map[string]string{"content": text}}
+		if ep.Format == ChatFormatDingTalk && ep.Secret != "" {
+			// 钉钉：HMAC-SHA256(secret, timestamp_ms + "\n" + secret)，附加在 URL 上
+			ts := strconv.FormatInt(now.UnixMilli(), 10)
+			mac := hmac.New(sha256.New, []byte(ep.Secret))
+			mac.Write([]byte(ts + "\n" + ep.Secret))
+			u, err := url.Parse(ep.URL)
+			if err != nil {
+				return "", nil, err
+			}
+			q := u.Query()
+			q.Set("timestamp", ts)
+			q.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+			u.RawQuery = q.Encode()
+			target = u.String()
+		}
+	default:
+		payload = map[string]interface{}{"text": text}
+	}
+	body, err := json.Marshal(payload)
+	return target, body, err
+}
+
+// chatResponseError 飞书 / 钉钉 / 企业微信在 HTTP 200 中以 code / errcode 返回业务错误
+func chatResponseError(resp []byte) error {
+	var r struct {
+		Code    *int   `json:"code"`
+		Msg     string `json:"msg"`
+		ErrCode *int   `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if len(resp) == 0 || json.Unmarshal(resp, &r) != nil {
+		return nil
+	}
+	// 错误描述只写服务端日志，投递记录只保留错误码
+	if r.Code != nil && *r.Code != 0 {
+		log.Printf("Chat webhook error %d: %s", *r.Code, r.Msg)
+		return Permanent(errors.New("chat webhook error " + strconv.Itoa(*r.Code)))
+	}
+	if r.ErrCode != nil && *r.ErrCode != 0 {
+		log.Printf("Chat webhook error %d: %s", *r.ErrCode, r.ErrMsg)
+		return Permanent(errors.New("chat webhook error " + strconv.Itoa(*r.ErrCode)))
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// httpTimeout 外部渠道单次请求超时
+const httpTimeout = 10 * time.Second
+
+// AllowPrivateTargets 允许投递到回环 / 内网 / 链路本地地址。默认关闭，防止用户配置的目标地址访问内部服务 (SSRF)；
+// 仅在自托管且确需投递到内网服务时通过 NOTIFY_ALLOW_PRIVATE_TARGETS 开启
+var AllowPrivateTargets = false
+
+// ErrBlockedTarget 目标地址解析到禁止访问的网段
+var ErrBlockedTarget = errors.New("target address is not allowed")
+
+// errRedirect 外部渠道不跟随重定向 (重定向目标可能指向内部地址)
+var errRedirect = errors.New("redirects are not followed")
+
+// lookupIPAddr 解析目标主机 (测试可替换)
+var lookupIPAddr = net.DefaultResolver.LookupIPAddr
+
+// sharedAddressSpace 运营商级 NAT 网段 (RFC 6598)，部分云厂商的元数据服务位于其中
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// blockedIP 回环、私有、链路本地、未指定、组播与共享地址
+func blockedIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// dialControl 在建立连接前检查实际连接的 IP，DNS 重绑定无法绕过保存时的校验
+func dialControl(_, address string, _ syscall.RawConn) error {
+	if AllowPrivateTargets {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || blockedIP(ip) {
+		return fmt.Errorf("%w: %s", ErrBlockedTarget, host)
+	}
+	return nil
+}
+
+// newHTTPClient 外部渠道客户端：连接前校验目标 IP，不跟随重定向，不走环境代理 (代理地址会绕过 IP 校验)
+func newHTTPClient() *http.Client {
+	dialer := &net.Dialer{Timeout: httpTimeout, Control: dialControl}
+	return &http.Client{
+		Timeout: httpTimeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          20,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   httpTimeout,
+			ExpectContinueTimeout: time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return errRedirect },
+	}
+}
+
+// StatusError 对方返回非 2xx。错误信息只含状态码：响应体可能包含对方服务的内部信息，
+// 而投递错误会写入 reminder_deliveries 并返回给用户
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("http %d", e.Code)
+}
+
+// post 发送请求并返回响应体 (截断)；2xx 视为成功，4xx (408 / 429 除外) 视为不可重试失败
+func post(ctx context.Context, client *http.Client, target, contentType string, body []byte, header http.Header) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, Permanent(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "TodoIng-Notify/1.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, errRedirect) || errors.Is(err, ErrBlockedTarget) {
+			return nil, Permanent(err)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return respBody, nil
+	}
+	if len(respBody) > 512 {
+		respBody = respBody[:512]
+	}
+	// 响应体只写服务端日志，便于排查
+	log.Printf("Notify %s: http %d: %s", req.URL.Host, resp.StatusCode, respBody)
+	serr := &StatusError{Code: resp.StatusCode}
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return nil, Permanent(serr)
+	}
+	return nil, serr
+}
+
+// validateURL 目标地址必须是 http(s) 绝对地址，且主机解析到的全部地址都可以访问
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return errors.New("url must be an absolute http(s) url")
+	}
+	if AllowPrivateTargets {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	addrs, err := lookupIPAddr(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("cannot resolve host %s", u.Hostname())
+	}
+	for _, a := range addrs {
+		if blockedIP(a.IP) {
+			return fmt.Errorf("%w: %s", ErrBlockedTarget, u.Hostname())
+		}
+	}
+	return nil
+}
+
+// fanOut 发送到用户在该渠道下的全部目标 (跳过 msg.Delivered 中之前已成功的目标)：未配置目标为不可重试失败；
+// 任一目标失败即返回错误，全部失败都不可重试时整体不可重试，否则返回携带本次成功目标的 DeliveryError
+func fanOut(ctx context.Context, store EndpointStore, msg Message, channel string, send func(context.Context, models.NotificationEndpoint) error) error {
+	userID := msg.UserID
+	if store == nil {
+		return Permanent(fmt.Errorf("%w: %s", ErrNoEndpoint, channel))
+	}
+	list, err := store.ListByUser(ctx, userID, channel)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return Permanent(fmt.Errorf("%w: %s", ErrNoEndpoint, channel))
+	}
+	skip := make(map[string]bool, len(msg.Delivered))
+	for _, id := range msg.Delivered {
+		skip[id] = true
+	}
+	var errs []error
+	var delivered []string
+	permanent := true
+	for _, ep := range list {
+		if skip[ep.ID.Hex()] {
+			continue
+		}
+		if err := send(ctx, ep); err != nil {
+			errs = append(errs, fmt.Errorf("endpoint %s: %w", ep.ID.Hex(), err))
+			permanent = permanent && IsPermanent(err)
+			continue
+		}
+		delivered = append(delivered, ep.ID.Hex())
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	joined := errors.Join(errs...)
+	if permanent {
+		return Permanent(joined)
+	}
+	// 混合失败时去掉不可重试标记，保证整体会被重试
+	return &DeliveryError{Delivered: delivered, Err: errors.New(joined.Error())}
+}
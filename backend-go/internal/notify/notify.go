@@ -0,0 +1,140 @@
+// Package notify 可插拔的提醒投递渠道：启动时注册 Channel，调度器按提醒的渠道 ID 列表查表发送
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// ErrUnknownChannel 渠道 ID 未注册
+var ErrUnknownChannel = errors.New("unknown notification channel")
+
+// ErrNoEndpoint 用户未配置该渠道的投递目标
+var ErrNoEndpoint = errors.New("no endpoint configured for channel")
+
+// Message 渠道无关的提醒内容
+type Message struct {
+	UserID     primitive.ObjectID
+	ReminderID primitive.ObjectID
+	Subject    string // 标题 (邮件主题 / 推送标题)
+	Text       string // 提醒正文
+	Event      models.Event
+	Urgent     bool // 紧急提醒 (不受免打扰限制)
+	// Delivered 本次提醒在之前的尝试中已投递成功的目标 ID，多目标渠道重试时跳过，避免重复发送
+	Delivered []string
+}
+
+// Channel 投递渠道
+type Channel interface {
+	ID() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// EndpointValidator 需要用户配置投递目标的渠道实现此接口，在保存目标前校验
+type EndpointValidator interface {
+	ValidateEndpoint(e *models.NotificationEndpoint) error
+}
+
+// EndpointStore 读取用户的投递目标 (repository.NotificationEndpointRepository 满足此接口)
+type EndpointStore interface {
+	ListByUser(ctx context.Context, userID primitive.ObjectID, channel string) ([]models.NotificationEndpoint, error)
+	Delete(ctx context.Context, userID, id primitive.ObjectID) error
+}
+
+// Registry 渠道注册表
+type Registry struct {
+	mu       sync.RWMutex
+	channels map[string]Channel
+}
+
+func NewRegistry() *Registry {
+	return &Registry{channels: make(map[string]Channel)}
+}
+
+// Default 进程级注册表，main 启动时注册内置渠道
+var Default = NewRegistry()
+
+// Register 注册渠道，ID 重复时覆盖
+func (r *Registry) Register(ch Channel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.channels[ch.ID()] = ch
+}
+
+// Get 按 ID 查找渠道
+func (r *Registry) Get(id string) (Channel, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ch, ok := r.channels[id]
+	return ch, ok
+}
+
+// IDs 已注册的渠道 ID (排序)
+func (r *Registry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]string, 0, len(r.channels))
+	for id := range r.channels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Validate 校验渠道 ID 列表非空且均已注册
+func (r *Registry) Validate(ids []string) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("%w: empty channel list", ErrUnknownChannel)
+	}
+	for _, id := range ids {
+		if _, ok := r.Get(id); !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownChannel, id)
+		}
+	}
+	return nil
+}
+
+// permanentError 重试无法恢复的失败 (目标未配置、被对方拒绝等)
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 标记为不可重试的失败，调度器记录后不再重试该渠道
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// DeliveryError 多目标渠道部分失败 (可重试)：Delivered 为本次已投递成功的目标 ID
+type DeliveryError struct {
+	Delivered []string
+	Err       error
+}
+
+func (e *DeliveryError) Error() string { return e.Err.Error() }
+func (e *DeliveryError) Unwrap() error { return e.Err }
+
+// DeliveredEndpoints 失败时已投递成功的目标 ID，调度器记录后在重试时通过 Message.Delivered 传回
+func DeliveredEndpoints(err error) []string {
+	var d *DeliveryError
+	if errors.As(err, &d) {
+		return d.Delivered
+	}
+	return nil
+}
+
+// IsPermanent 是否为不可重试的失败
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
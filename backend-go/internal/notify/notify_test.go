@@ -0,0 +1,309 @@
+package notify
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+type memStore struct {
+	endpoints []models.NotificationEndpoint
+	deleted   []primitive.ObjectID
+}
+
+func (m *memStore) ListByUser(_ context.Context, userID primitive.ObjectID, channel string) ([]models.NotificationEndpoint, error) {
+	var out []models.NotificationEndpoint
+	for _, e := range m.endpoints {
+		if e.UserID == userID && e.Channel == channel {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memStore) Delete(_ context.Context, _, id primitive.ObjectID) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+// allowLoopback 测试期间允许投递到 httptest 的回环地址
+func allowLoopback(t *testing.T) {
+	AllowPrivateTargets = true
+	t.Cleanup(func() { AllowPrivateTargets = false })
+}
+
+// stubResolver 测试期间以固定映射替换 DNS 解析
+func stubResolver(t *testing.T, hosts map[string]string) {
+	orig := lookupIPAddr
+	lookupIPAddr = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		if ip := net.ParseIP(host); ip != nil {
+			return []net.IPAddr{{IP: ip}}, nil
+		}
+		if ip, ok := hosts[host]; ok {
+			return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	t.Cleanup(func() { lookupIPAddr = orig })
+}
+
+type stubChannel string
+
+func (s stubChannel) ID() string                          { return string(s) }
+func (s stubChannel) Send(context.Context, Message) error { return nil }
+
+func TestRegistryValidate(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(stubChannel("app"))
+	reg.Register(stubChannel("email"))
+	require.Equal(t, []string{"app", "email"}, reg.IDs())
+	require.NoError(t, reg.Validate([]string{"email", "app"}))
+	require.ErrorIs(t, reg.Validate([]string{"app", "sms"}), ErrUnknownChannel)
+	require.ErrorIs(t, reg.Validate(nil), ErrUnknownChannel)
+}
+
+func TestWebhookSignsPayload(t *testing.T) {
+	allowLoopback(t)
+	user := primitive.NewObjectID()
+	var got WebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, Sign("s3cret", ts, body), r.Header.Get(HeaderSignature))
+		require.NoError(t, json.Unmarshal(body, &got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	store := &memStore{endpoints: []models.NotificationEndpoint{{ID: primitive.NewObjectID(), UserID: user, Channel: "webhook", URL: srv.URL, Secret: "s3cret"}}}
+	ch := NewWebhookChannel(store)
+	ev := models.Event{ID: primitive.NewObjectID(), Title: "standup", EventType: "meeting"}
+	require.NoError(t, ch.Send(context.Background(), Message{UserID: user, Subject: "提醒", Text: "standup 今天", Event: ev}))
+	require.Equal(t, "reminder", got.Type)
+	require.Equal(t, ev.ID.Hex(), got.EventID)
+	require.Equal(t, "standup 今天", got.Message)
+
+	// 未配置目标：不可重试
+	err := ch.Send(context.Background(), Message{UserID: primitive.NewObjectID()})
+	require.ErrorIs(t, err, ErrNoEndpoint)
+	require.True(t, IsPermanent(err))
+}
+
+func TestFanOutPermanence(t *testing.T) {
+	allowLoopback(t)
+	user := primitive.NewObjectID()
+	rejected := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusForbidden) }))
+	defer rejected.Close()
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusBadGateway) }))
+	defer flaky.Close()
+
+	store := &memStore{endpoints: []models.NotificationEndpoint{{UserID: user, Channel: "webhook", URL: rejected.URL, Secret: "x"}}}
+	ch := NewWebhookChannel(store)
+	err := ch.Send(context.Background(), Message{UserID: user})
+	require.Error(t, err)
+	require.True(t, IsPermanent(err))
+
+	// 含可重试失败时整体可重试
+	store.endpoints = append(store.endpoints, models.NotificationEndpoint{UserID: user, Channel: "webhook", URL: flaky.URL, Secret: "x"})
+	err = ch.Send(context.Background(), Message{UserID: user})
+	require.Error(t, err)
+	require.False(t, IsPermanent(err))
+}
+
+func TestFanOutSkipsDeliveredEndpoints(t *testing.T) {
+	allowLoopback(t)
+	user := primitive.NewObjectID()
+	hits := map[string]int{}
+	flakyUp := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.URL.Path]++
+		if r.URL.Path == "/flaky" && !flakyUp {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+	ok := models.NotificationEndpoint{ID: primitive.NewObjectID(), UserID: user, Channel: "webhook", URL: srv.URL + "/ok", Secret: "x"}
+	flaky := models.NotificationEndpoint{ID: primitive.NewObjectID(), UserID: user, Channel: "webhook", URL: srv.URL + "/flaky", Secret: "x"}
+	ch := NewWebhookChannel(&memStore{endpoints: []models.NotificationEndpoint{ok, flaky}})
+
+	err := ch.Send(context.Background(), Message{UserID: user})
+	require.Error(t, err)
+	require.False(t, IsPermanent(err))
+	require.Equal(t, []string{ok.ID.Hex()}, DeliveredEndpoints(err))
+
+	// 重试只发送之前失败的目标
+	flakyUp = true
+	require.NoError(t, ch.Send(context.Background(), Message{UserID: user, Delivered: DeliveredEndpoints(err)}))
+	require.Equal(t, map[string]int{"/ok": 1, "/flaky": 2}, hits)
+}
+
+func TestChatRequestFormats(t *testing.T) {
+	stubResolver(t, map[string]string{"hooks.slack.com": "203.0.113.10"})
+	now := time.Unix(1700000000, 0)
+	_, body, err := chatRequest(models.NotificationEndpoint{URL: "https://hooks.slack.com/x", Format: ChatFormatSlack}, "hi", now)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"text":"hi"}`, string(body))
+
+	_, body, err = chatRequest(models.NotificationEndpoint{URL: "https://open.feishu.cn/x", Format: ChatFormatFeishu, Secret: "k"}, "hi", now)
+	require.NoError(t, err)
+	var feishu map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &feishu))
+	require.Equal(t, "1700000000", feishu["timestamp"])
+	require.NotEmpty(t, feishu["sign"])
+
+	target, body, err := chatRequest(models.NotificationEndpoint{URL: "https://oapi.dingtalk.com/robot/send?access_token=t", Format: ChatFormatDingTalk, Secret: "k"}, "hi", now)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"msgtype":"text","text":{"content":"hi"}}`, string(body))
+	u, _ := url.Parse(target)
+	require.Equal(t, "t", u.Query().Get("access_token"))
+	require.Equal(t, "1700000000000", u.Query().Get("timestamp"))
+	require.NotEmpty(t, u.Query().Get("sign"))
+
+	require.NoError(t, chatResponseError([]byte(`{"code":0,"msg":"success"}`)))
+	require.NoError(t, chatResponseError([]byte(`ok`)))
+	err = chatResponseError([]byte(`{"errcode":310000,"errmsg":"sign not match"}`))
+	require.True(t, IsPermanent(err))
+	require.Equal(t, "chat webhook error 310000", err.Error())
+
+	ch := NewChatChannel(nil)
+	ep := &models.NotificationEndpoint{URL: "https://hooks.slack.com/x"}
+	require.NoError(t, ch.ValidateEndpoint(ep))
+	require.Equal(t, ChatFormatSlack, ep.Format)
+	require.Error(t, ch.ValidateEndpoint(&models.NotificationEndpoint{URL: "ftp://x", Format: "slack"}))
+}
+
+// decryptWebPush 浏览器侧解密 (RFC 8291)，用于验证加密实现
+func decryptWebPush(t *testing.T, body []byte, ua *ecdh.PrivateKey, authSecret []byte) []byte {
+	salt := body[:16]
+	require.Equal(t, uint32(webPushRecordSize), binary.BigEndian.Uint32(body[16:20]))
+	idlen := int(body[20])
+	asPublic := body[21 : 21+idlen]
+	asKey, err := ecdh.P256().NewPublicKey(asPublic)
+	require.NoError(t, err)
+	shared, err := ua.ECDH(asKey)
+	require.NoError(t, err)
+	cek, nonce, err := webPushKeys(shared, authSecret, salt, ua.PublicKey().Bytes(), asPublic)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plain, err := gcm.Open(nil, nonce, body[21+idlen:], nil)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x02), plain[len(plain)-1])
+	return plain[:len(plain)-1]
+}
+
+func TestWebPushEncryptsAndAuthorizes(t *testing.T) {
+	allowLoopback(t)
+	ua, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, _ = rand.Read(authSecret)
+	vapid, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	var gotBody []byte
+	var gotAuth string
+	status := http.StatusCreated
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotAuth = r.Header.Get("Authorization")
+		require.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	user := primitive.NewObjectID()
+	epID := primitive.NewObjectID()
+	store := &memStore{endpoints: []models.NotificationEndpoint{{ID: epID, UserID: user, Channel: "webpush", URL: srv.URL + "/push/abc", P256dh: b64(ua.PublicKey().Bytes()), Auth: b64(authSecret)}}}
+	ch, err := NewWebPushChannel(b64(vapid.PublicKey().Bytes()), b64(vapid.Bytes()), "mailto:ops@example.com", store)
+	require.NoError(t, err)
+	require.NoError(t, ch.ValidateEndpoint(&store.endpoints[0]))
+
+	require.NoError(t, ch.Send(context.Background(), Message{UserID: user, Subject: "TodoIng 提醒", Text: "standup 今天"}))
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(decryptWebPush(t, gotBody, ua, authSecret), &payload))
+	require.Equal(t, "standup 今天", payload["body"])
+	require.True(t, strings.HasPrefix(gotAuth, "vapid t="))
+	require.True(t, strings.HasSuffix(gotAuth, ", k="+ch.PublicKey()))
+
+	// 订阅失效：删除目标且不再重试
+	status = http.StatusGone
+	err = ch.Send(context.Background(), Message{UserID: user, Text: "x"})
+	require.True(t, IsPermanent(err))
+	require.Equal(t, []primitive.ObjectID{epID}, store.deleted)
+
+	_, err = NewWebPushChannel(b64(ua.PublicKey().Bytes()), b64(vapid.Bytes()), "", nil)
+	require.Error(t, err)
+}
+
+func TestBlocksPrivateTargets(t *testing.T) {
+	stubResolver(t, map[string]string{"hooks.example.com": "203.0.113.10", "internal.example.com": "10.0.0.5"})
+	require.NoError(t, validateURL("https://hooks.example.com/x"))
+	for _, target := range []string{
+		"http://127.0.0.1:8080/", "http://localhost.localdomain/", "http://169.254.169.254/latest/meta-data/",
+		"http://10.1.2.3/", "http://[::1]/", "http://100.100.100.200/", "http://0.0.0.0/", "https://internal.example.com/hook",
+	} {
+		err := validateURL(target)
+		require.Error(t, err, target)
+	}
+	require.ErrorIs(t, validateURL("http://169.254.169.254/"), ErrBlockedTarget)
+
+	// 连接时再次校验 (DNS 重绑定)：保存时合法的目标解析到回环地址也会被拒绝
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { hits++ }))
+	defer srv.Close()
+	user := primitive.NewObjectID()
+	store := &memStore{endpoints: []models.NotificationEndpoint{{ID: primitive.NewObjectID(), UserID: user, Channel: "webhook", URL: srv.URL, Secret: "x"}}}
+	err := NewWebhookChannel(store).Send(context.Background(), Message{UserID: user})
+	require.ErrorIs(t, err, ErrBlockedTarget)
+	require.True(t, IsPermanent(err))
+	require.Zero(t, hits)
+}
+
+func TestPostRefusesRedirectsAndHidesBody(t *testing.T) {
+	allowLoopback(t)
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { _, _ = w.Write([]byte("secret")) }))
+	defer internal.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("db password=hunter2"))
+	}))
+	defer failing.Close()
+
+	client := newHTTPClient()
+	_, err := post(context.Background(), client, redirect.URL, "application/json", []byte("{}"), nil)
+	require.ErrorIs(t, err, errRedirect)
+	require.True(t, IsPermanent(err))
+
+	_, err = post(context.Background(), client, failing.URL, "application/json", []byte("{}"), nil)
+	require.EqualError(t, err, "http 500")
+	require.False(t, IsPermanent(err))
+}
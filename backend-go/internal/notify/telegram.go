@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// telegramAPIBase Bot API 地址 (测试可替换)
+const telegramAPIBase = "https://api.telegram.org"
+
+// TelegramChannel Telegram Bot：使用 TELEGRAM_BOT_TOKEN，目标为用户配置的 chat_id
+type TelegramChannel struct {
+	token   string
+	apiBase string
+	store   EndpointStore
+	client  *http.Client
+}
+
+func NewTelegramChannel(token string, store EndpointStore) *TelegramChannel {
+	return &TelegramChannel{token: token, apiBase: telegramAPIBase, store: store, client: newHTTPClient()}
+}
+
+func (c *TelegramChannel) ID() string { return "telegram" }
+
+func (c *TelegramChannel) ValidateEndpoint(e *models.NotificationEndpoint) error {
+	if e.ChatID == "" {
+		return errors.New("chat_id required")
+	}
+	return nil
+}
+
+func (c *TelegramChannel) Send(ctx context.Context, msg Message) error {
+	text := chatText(msg)
+	return fanOut(ctx, c.store, msg, c.ID(), func(ctx context.Context, ep models.NotificationEndpoint) error {
+		body, err := json.Marshal(map[string]interface{}{"chat_id": ep.ChatID, "text": text, "disable_web_page_preview": true})
+		if err != nil {
+			return Permanent(err)
+		}
+		_, err = post(ctx, c.client, c.apiBase+"/bot"+c.token+"/sendMessage", "application/json", body, nil)
+		return c.redact(err)
+	})
+}
+
+// redact 网络错误中包含请求 URL，避免 bot token 写入投递记录与日志
+func (c *TelegramChannel) redact(err error) error {
+	if err == nil || c.token == "" || !strings.Contains(err.Error(), c.token) {
+		return err
+	}
+	redacted := errors.New(strings.ReplaceAll(err.Error(), c.token, "<token>"))
+	if IsPermanent(err) {
+		return Permanent(redacted)
+	}
+	return redacted
+}
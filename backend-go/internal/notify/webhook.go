@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// 签名请求头：X-Todoing-Signature = "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+const (
+	HeaderSignature = "X-Todoing-Signature"
+	HeaderTimestamp = "X-Todoing-Timestamp"
+)
+
+// WebhookPayload 通用 webhook 请求体
+type WebhookPayload struct {
+	Type       string    `json:"type"` // 固定为 reminder
+	UserID     string    `json:"user_id"`
+	ReminderID string    `json:"reminder_id,omitempty"`
+	EventID    string    `json:"event_id,omitempty"`
+	Title      string    `json:"title"`
+	Message    string    `json:"message"`
+	EventType  string    `json:"event_type,omitempty"`
+	EventDate  time.Time `json:"event_date"`
+	TimeZone   string    `json:"time_zone,omitempty"`
+	SentAt     time.Time `json:"sent_at"`
+}
+
+// Sign 计算 webhook 签名，接收方以同样方式校验 (并检查时间戳防重放)
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookChannel 通用 HTTP webhook，带 HMAC 签名
+type WebhookChannel struct {
+	store  EndpointStore
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookChannel(store EndpointStore) *WebhookChannel {
+	return &WebhookChannel{store: store, client: newHTTPClient(), now: time.Now}
+}
+
+func (c *WebhookChannel) ID() string { return "webhook" }
+
+func (c *WebhookChannel) ValidateEndpoint(e *models.NotificationEndpoint) error {
+	if err := validateURL(e.URL); err != nil {
+		return err
+	}
+	if e.Secret == "" {
+		return errors.New("secret required")
+	}
+	return nil
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, msg Message) error {
+	now := c.now()
+	p := WebhookPayload{
+		Type: "reminder", UserID: msg.UserID.Hex(), Title: msg.Subject, Message: msg.Text,
+		EventType: msg.Event.EventType, EventDate: msg.Event.EventDate, TimeZone: msg.Event.TimeZone, SentAt: now.UTC(),
+	}
+	if !msg.ReminderID.IsZero() {
+		p.ReminderID = msg.ReminderID.Hex()
+	}
+	if !msg.Event.ID.IsZero() {
+		p.EventID = msg.Event.ID.Hex()
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return Permanent(err)
+	}
+	return fanOut(ctx, c.store, msg, c.ID(), func(ctx context.Context, ep models.NotificationEndpoint) error {
+		h := http.Header{}
+		h.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+		h.Set(HeaderSignature, Sign(ep.Secret, now.Unix(), body))
+		_, err := post(ctx, c.client, ep.URL, "application/json", body, h)
+		return err
+	})
+}
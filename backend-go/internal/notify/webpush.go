@@ -0,0 +1,235 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// webPushRecordSize aes128gcm 单记录大小，负载需放入一条记录
+const webPushRecordSize = 4096
+
+// maxWebPushPayload 明文上限 (记录大小 - 16 字节 tag - 1 字节分隔符)
+const maxWebPushPayload = webPushRecordSize - 16 - 1
+
+// WebPushChannel 浏览器推送 (RFC 8030)：VAPID (RFC 8292) 鉴权，负载按 RFC 8291 aes128gcm 加密
+type WebPushChannel struct {
+	priv      *ecdsa.PrivateKey
+	publicKey string // base64url 未压缩公钥，前端 PushManager.subscribe 的 applicationServerKey
+	subject   string
+	store     EndpointStore
+	client    *http.Client
+}
+
+// NewWebPushChannel publicKey / privateKey 为 base64url 编码的 P-256 VAPID 密钥；subject 为 mailto: 或 https: 联系方式
+func NewWebPushChannel(publicKey, privateKey, subject string, store EndpointStore) (*WebPushChannel, error) {
+	d, err := decodeB64URL(privateKey)
+	if err != nil || len(d) != 32 {
+		return nil, errors.New("invalid VAPID private key")
+	}
+	ecdhPriv, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	pub := ecdhPriv.PublicKey().Bytes()
+	if publicKey != "" {
+		given, err := decodeB64URL(publicKey)
+		if err != nil || !bytes.Equal(given, pub) {
+			return nil, errors.New("VAPID public key does not match private key")
+		}
+	}
+	priv := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(pub[1:33]), Y: new(big.Int).SetBytes(pub[33:])},
+		D:         new(big.Int).SetBytes(d),
+	}
+	return &WebPushChannel{
+		priv: priv, publicKey: base64.RawURLEncoding.EncodeToString(pub), subject: subject,
+		store: store, client: newHTTPClient(),
+	}, nil
+}
+
+func (c *WebPushChannel) ID() string { return "webpush" }
+
+// PublicKey VAPID 公钥 (base64url)
+func (c *WebPushChannel) PublicKey() string { return c.publicKey }
+
+func (c *WebPushChannel) ValidateEndpoint(e *models.NotificationEndpoint) error {
+	if err := validateURL(e.URL); err != nil {
+		return err
+	}
+	if p, err := decodeB64URL(e.P256dh); err != nil || len(p) != 65 {
+		return errors.New("p256dh must be a base64url P-256 public key")
+	}
+	if a, err := decodeB64URL(e.Auth); err != nil || len(a) != 16 {
+		return errors.New("auth must be a base64url 16-byte secret")
+	}
+	return nil
+}
+
+func (c *WebPushChannel) Send(ctx context.Context, msg Message) error {
+	data := map[string]string{}
+	if !msg.ReminderID.IsZero() {
+		data["reminder_id"] = msg.ReminderID.Hex()
+	}
+	if !msg.Event.ID.IsZero() {
+		data["event_id"] = msg.Event.ID.Hex()
+	}
+	payload, err := json.Marshal(map[string]interface{}{"title": msg.Subject, "body": truncateUTF8(msg.Text, 1024), "data": data})
+	if err != nil {
+		return Permanent(err)
+	}
+	return fanOut(ctx, c.store, msg, c.ID(), func(ctx context.Context, ep models.NotificationEndpoint) error {
+		err := c.push(ctx, ep, payload, msg.Urgent)
+		var serr *StatusError
+		if errors.As(err, &serr) && (serr.Code == http.StatusNotFound || serr.Code == http.StatusGone) {
+			// 订阅已失效，删除目标
+			_ = c.store.Delete(ctx, ep.UserID, ep.ID)
+		}
+		return err
+	})
+}
+
+func (c *WebPushChannel) push(ctx context.Context, ep models.NotificationEndpoint, payload []byte, urgent bool) error {
+	uaPublic, err := decodeB64URL(ep.P256dh)
+	if err != nil {
+		return Permanent(err)
+	}
+	authSecret, err := decodeB64URL(ep.Auth)
+	if err != nil {
+		return Permanent(err)
+	}
+	body, err := EncryptWebPush(payload, uaPublic, authSecret)
+	if err != nil {
+		return Permanent(err)
+	}
+	token, err := c.vapidToken(ep.URL)
+	if err != nil {
+		return Permanent(err)
+	}
+	h := http.Header{}
+	h.Set("Content-Encoding", "aes128gcm")
+	h.Set("TTL", "86400")
+	h.Set("Urgency", "normal")
+	if urgent {
+		h.Set("Urgency", "high")
+	}
+	h.Set("Authorization", "vapid t="+token+", k="+c.publicKey)
+	_, err = post(ctx, c.client, ep.URL, "application/octet-stream", body, h)
+	return err
+}
+
+// vapidToken 以推送服务 origin 为 aud 的 ES256 JWT
+func (c *WebPushChannel) vapidToken(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": c.subject,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(c.priv)
+}
+
+// EncryptWebPush 按 RFC 8291 加密负载 (单记录 aes128gcm)，返回完整请求体
+func EncryptWebPush(payload, uaPublic, authSecret []byte) ([]byte, error) {
+	if len(payload) > maxWebPushPayload {
+		return nil, errors.New("web push payload too large")
+	}
+	curve := ecdh.P256()
+	uaKey, err := curve.NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	asKey, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := asKey.ECDH(uaKey)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	asPublic := asKey.PublicKey().Bytes()
+	cek, nonce, err := webPushKeys(shared, authSecret, salt, uaPublic, asPublic)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// 头部：salt(16) | rs(4) | idlen(1) | keyid(应用服务器公钥)
+	header := make([]byte, 0, 21+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+	plain := append(append([]byte{}, payload...), 0x02) // 最后一条记录的分隔符
+	return gcm.Seal(header, nonce, plain, nil), nil
+}
+
+// webPushKeys RFC 8291 §3.4 / RFC 8188 §2.2 密钥派生
+func webPushKeys(shared, authSecret, salt, uaPublic, asPublic []byte) (cek, nonce []byte, err error) {
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublic...), asPublic...)
+	ikm := make([]byte, 32)
+	if _, err = io.ReadFull(hkdf.New(sha256.New, shared, authSecret, keyInfo), ikm); err != nil {
+		return nil, nil, err
+	}
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek = make([]byte, 16)
+	if _, err = io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: aes128gcm\x00")), cek); err != nil {
+		return nil, nil, err
+	}
+	nonce = make([]byte, 12)
+	if _, err = io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: nonce\x00")), nonce); err != nil {
+		return nil, nil, err
+	}
+	return cek, nonce, nil
+}
+
+// decodeB64URL 兼容带 / 不带填充的 base64url
+func decodeB64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
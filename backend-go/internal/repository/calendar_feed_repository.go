@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CalendarFeedRepository iCalendar 订阅令牌仓储
+type CalendarFeedRepository interface {
+	Insert(ctx context.Context, t *models.CalendarFeedToken) error
+	FindActiveByHash(ctx context.Context, hash string) (*models.CalendarFeedToken, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.CalendarFeedToken, error)
+	Revoke(ctx context.Context, userID, id primitive.ObjectID) error
+	Touch(ctx context.Context, id primitive.ObjectID, ts time.Time) error
+}
+
+// ErrFeedNotFound 订阅令牌不存在或已撤销
+var ErrFeedNotFound = errors.New("feed not found")
+
+type mongoCalendarFeedRepo struct{ db *mongo.Database }
+
+func NewCalendarFeedRepository(db *mongo.Database) CalendarFeedRepository {
+	return &mongoCalendarFeedRepo{db: db}
+}
+
+func (r *mongoCalendarFeedRepo) coll() *mongo.Collection {
+	return r.db.Collection("calendar_feed_tokens")
+}
+
+func (r *mongoCalendarFeedRepo) Insert(ctx context.Context, t *models.CalendarFeedToken) error {
+	if t == nil {
+		return errors.New("nil feed token")
+	}
+	if t.ID.IsZero() {
+		t.ID = primitive.NewObjectID()
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	_, err := r.coll().InsertOne(ctx, t)
+	return err
+}
+
+func (r *mongoCalendarFeedRepo) FindActiveByHash(ctx context.Context, hash string) (*models.CalendarFeedToken, error) {
+	var t models.CalendarFeedToken
+	err := r.coll().FindOne(ctx, bson.M{"token_hash": hash, "revoked_at": bson.M{"$exists": false}}).Decode(&t)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrFeedNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *mongoCalendarFeedRepo) ListByUser(ctx context.Context, userID primitive.ObjectID) ([]models.CalendarFeedToken, error) {
+	cur, err := r.coll().Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.CalendarFeedToken{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoCalendarFeedRepo) Revoke(ctx context.Context, userID, id primitive.ObjectID) error {
+	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": id, "user_id": userID, "revoked_at": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrFeedNotFound
+	}
+	return nil
+}
+
+func (r *mongoCalendarFeedRepo) Touch(ctx context.Context, id primitive.ObjectID, ts time.Time) error {
+	_, err := r.coll().UpdateByID(ctx, id, bson.M{"$set": bson.M{"last_used_at": ts}})
+	return err
+}
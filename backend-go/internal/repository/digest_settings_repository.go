@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DigestSettingsRepository 邮件汇总订阅仓储
+type DigestSettingsRepository interface {
+	// Get 未订阅时返回 nil, nil
+	Get(ctx context.Context, userID primitive.ObjectID) (*models.DigestSettings, error)
+	Upsert(ctx context.Context, d *models.DigestSettings) error
+	// ListDue 已开启且到期 (next_send_at <= now) 的订阅
+	ListDue(ctx context.Context, now time.Time, limit int) ([]models.DigestSettings, error)
+	// Advance 以 prev 为条件原子推进 next_send_at 并记录 last_sent_at；返回 false 表示已被其他实例推进
+	Advance(ctx context.Context, userID primitive.ObjectID, prev, next, sentAt time.Time) (bool, error)
+}
+
+type mongoDigestSettingsRepo struct{ db *mongo.Database }
+
+func NewDigestSettingsRepository(db *mongo.Database) DigestSettingsRepository {
+	return &mongoDigestSettingsRepo{db: db}
+}
+
+func (r *mongoDigestSettingsRepo) coll() *mongo.Collection { return r.db.Collection("digest_settings") }
+
+func (r *mongoDigestSettingsRepo) Get(ctx context.Context, userID primitive.ObjectID) (*models.DigestSettings, error) {
+	var d models.DigestSettings
+	if err := r.coll().FindOne(ctx, bson.M{"user_id": userID}).Decode(&d); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *mongoDigestSettingsRepo) Upsert(ctx context.Context, d *models.DigestSettings) error {
+	if d == nil || d.UserID.IsZero() {
+		return errors.New("nil digest settings")
+	}
+	d.UpdatedAt = time.Now()
+	_, err := r.coll().ReplaceOne(ctx, bson.M{"user_id": d.UserID}, d, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *mongoDigestSettingsRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]models.DigestSettings, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "next_send_at", Value: 1}}).SetLimit(int64(limit))
+	cur, err := r.coll().Find(ctx, bson.M{"enabled": true, "next_send_at": bson.M{"$lte": now}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.DigestSettings{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoDigestSettingsRepo) Advance(ctx context.Context, userID primitive.ObjectID, prev, next, sentAt time.Time) (bool, error) {
+	res, err := r.coll().UpdateOne(ctx,
+		bson.M{"user_id": userID, "enabled": true, "next_send_at": prev},
+		bson.M{"$set": bson.M{"next_send_at": next, "last_sent_at": sentAt}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 单次发生例外 (event_exceptions)：与事件同属 EventRepository，展开 / 提醒重算时按需加载
+
+func (r *mongoEventRepo) exceptions() *mongo.Collection {
+	return r.db.Collection("event_exceptions")
+}
+
+// loadEventExceptions 为事件列表批量挂载例外 (失败时静默忽略，退化为无例外展开)
+func loadEventExceptions(ctx context.Context, db *mongo.Database, events []models.Event) {
+	if len(events) == 0 {
+		return
+	}
+	ids := make([]primitive.ObjectID, 0, len(events))
+	for _, ev := range events {
+		ids = append(ids, ev.ID)
+	}
+	cur, err := db.Collection("event_exceptions").Find(ctx, bson.M{"event_id": bson.M{"$in": ids}})
+	if err != nil {
+		return
+	}
+	defer cur.Close(ctx)
+	var list []models.EventException
+	if err = cur.All(ctx, &list); err != nil {
+		return
+	}
+	byEvent := make(map[primitive.ObjectID][]models.EventException, len(events))
+	for _, ex := range list {
+		byEvent[ex.EventID] = append(byEvent[ex.EventID], ex)
+	}
+	for i := range events {
+		events[i].Exceptions = byEvent[events[i].ID]
+	}
+}
+
+// movedIntoWindow 返回改期后落在 [from, to] 内的例外所属事件 ID (原系列起始可能晚于窗口)。
+// 例外由任意有权限的成员创建，不按用户过滤；调用方查询事件时再应用访问范围
+func (r *mongoEventRepo) movedIntoWindow(ctx context.Context, from, to time.Time) []primitive.ObjectID {
+	f := bson.M{"cancelled": false, "new_date": bson.M{"$gte": from, "$lte": to}}
+	ids, err := r.exceptions().Distinct(ctx, "event_id", f)
+	if err != nil {
+		return nil
+	}
+	out := make([]primitive.ObjectID, 0, len(ids))
+	for _, v := range ids {
+		if id, ok := v.(primitive.ObjectID); ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func (r *mongoEventRepo) UpsertException(ctx context.Context, ex *models.EventException) (*models.EventException, error) {
+	if ex == nil {
+		return nil, errors.New("nil exception")
+	}
+	if err := r.writable(ctx, ex.UserID, ex.EventID, errors.New("event not found")); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	set := bson.M{"user_id": ex.UserID, "cancelled": ex.Cancelled, "updated_at": now}
+	unset := bson.M{}
+	if ex.NewDate != nil {
+		set["new_date"] = *ex.NewDate
+	} else {
+		unset["new_date"] = ""
+	}
+	if ex.Title != nil {
+		set["title"] = *ex.Title
+	} else {
+		unset["title"] = ""
+	}
+	if ex.Location != nil {
+		set["location"] = *ex.Location
+	} else {
+		unset["location"] = ""
+	}
+	update := bson.M{"$set": set, "$setOnInsert": bson.M{"_id": primitive.NewObjectID(), "created_at": now}}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	filter := bson.M{"event_id": ex.EventID, "occurrence_date": ex.OccurrenceDate}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var saved models.EventException
+	if err := r.exceptions().FindOneAndUpdate(ctx, filter, update, opts).Decode(&saved); err != nil {
+		return nil, err
+	}
+	r.resetNextStart(ctx, saved.EventID)
+	r.afterExceptionChange(saved.EventID, saved.UserID, "occurrence_"+exceptionAction(&saved), saved.OccurrenceKey())
+	return &saved, nil
+}
+
+func (r *mongoEventRepo) ListExceptions(ctx context.Context, userID, eventID primitive.ObjectID) ([]models.EventException, error) {
+	if _, err := r.FindByID(ctx, userID, eventID); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return []models.EventException{}, nil
+		}
+		return nil, err
+	}
+	cur, err := r.exceptions().Find(ctx, bson.M{"event_id": eventID}, options.Find().SetSort(bson.D{{Key: "occurrence_date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.EventException{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoEventRepo) DeleteException(ctx context.Context, userID, exceptionID primitive.ObjectID) error {
+	notFound := errors.New("exception not found")
+	var ex models.EventException
+	if err := r.exceptions().FindOne(ctx, bson.M{"_id": exceptionID}).Decode(&ex); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return notFound
+		}
+		return err
+	}
+	if err := r.writable(ctx, userID, ex.EventID, notFound); err != nil {
+		return err
+	}
+	if _, err := r.exceptions().DeleteOne(ctx, bson.M{"_id": exceptionID}); err != nil {
+		return err
+	}
+	r.resetNextStart(ctx, ex.EventID)
+	r.afterExceptionChange(ex.EventID, ex.UserID, "occurrence_restore", ex.OccurrenceKey())
+	return nil
+}
+
+// SplitSeries "此次及之后"：将原系列截断到 at 之前，并 (tail 非空时) 从 at 起创建新系列。
+// at 之后的例外迁移到新系列 (新系列改期时丢弃，避免原始时间不再对齐)。
+func (r *mongoEventRepo) SplitSeries(ctx context.Context, userID, eventID primitive.ObjectID, at time.Time, tail *models.Event) (*models.Event, *models.Event, error) {
+	if err := r.writable(ctx, userID, eventID, errors.New("event not found")); err != nil {
+		return nil, nil, err
+	}
+	ev, err := r.FindByID(ctx, userID, eventID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil, errors.New("event not found")
+		}
+		return nil, nil, err
+	}
+	rule := ev.EffectiveRecurrenceRule()
+	if rule == nil {
+		return nil, nil, errors.New("event is not recurring")
+	}
+	now := time.Now()
+	raw := *rule
+	raw.ExDates = nil
+	before := len(raw.Between(ev.LocalEventDate(), ev.EventDate, at.Add(-time.Nanosecond), 0))
+	set := bson.M{"updated_at": now}
+	cfg := map[string]interface{}{}
+	for k, v := range ev.RecurrenceConfig {
+		cfg[k] = v
+	}
+	if before == 0 { // 从首次发生开始截断：原系列整体结束
+		set["is_active"] = false
+		ev.IsActive = false
+	} else if rule.Count > 0 {
+		cfg["count"] = before
+		set["recurrence_config"] = cfg
+	} else {
+		delete(cfg, "count")
+		cfg["until"] = at.Add(-time.Second).UTC().Format("20060102T150405Z")
+		set["recurrence_config"] = cfg
+	}
+	if _, err = r.coll().UpdateOne(ctx, bson.M{"_id": ev.ID}, bson.M{"$set": set, "$unset": bson.M{"next_start": ""}}); err != nil {
+		return nil, nil, err
+	}
+	if v, ok := set["recurrence_config"]; ok {
+		ev.RecurrenceConfig = v.(map[string]interface{})
+	}
+	ev.UpdatedAt = now
+
+	var newEv *models.Event
+	if tail != nil {
+		tail.ID = primitive.NewObjectID()
+		tail.UserID = userID
+		tail.IsActive = true
+		tail.CreatedAt = time.Time{}
+		tailCfg := map[string]interface{}{}
+		for k, v := range ev.RecurrenceConfig {
+			tailCfg[k] = v
+		}
+		delete(tailCfg, "until")
+		delete(tailCfg, "count")
+		if rule.Until != nil {
+			tailCfg["until"] = rule.Until.UTC().Format("20060102T150405Z")
+		}
+		if rule.Count > 0 {
+			tailCfg["count"] = rule.Count - before
+		}
+		tail.RecurrenceConfig = tailCfg
+		if err = r.Insert(ctx, tail); err != nil {
+			return nil, nil, err
+		}
+		newEv = tail
+		moved := tail.EventDate.Equal(at)
+		exFilter := bson.M{"event_id": ev.ID, "occurrence_date": bson.M{"$gte": at}}
+		if moved {
+			_, _ = r.exceptions().UpdateMany(ctx, exFilter, bson.M{"$set": bson.M{"event_id": tail.ID, "updated_at": now}})
+		} else {
+			_, _ = r.exceptions().DeleteMany(ctx, exFilter)
+		}
+		r.cloneReminders(ctx, ev.ID, tail.ID)
+	} else {
+		_, _ = r.exceptions().DeleteMany(ctx, bson.M{"event_id": ev.ID, "occurrence_date": bson.M{"$gte": at}})
+	}
+	r.afterExceptionChange(ev.ID, userID, "split_following", models.BuildOccurrenceKey(ev.ID, at))
+	if newEv != nil {
+		r.afterExceptionChange(newEv.ID, userID, "", "")
+	}
+	return ev, newEv, nil
+}
+
+// cloneReminders 拆分系列时把原系列的有效提醒复制到新系列
+func (r *mongoEventRepo) cloneReminders(ctx context.Context, fromID, toID primitive.ObjectID) {
+	cur, err := r.reminders().Find(ctx, bson.M{"event_id": fromID, "is_active": true})
+	if err != nil {
+		return
+	}
+	defer cur.Close(ctx)
+	now := time.Now()
+	for cur.Next(ctx) {
+		var rm models.Reminder
+		if cur.Decode(&rm) != nil {
+			continue
+		}
+		rm.ID = primitive.NewObjectID()
+		rm.EventID = toID
+		rm.LastSent = nil
+		rm.NextSend = nil
+		rm.CreatedAt, rm.UpdatedAt = now, now
+		_, _ = r.reminders().InsertOne(ctx, rm)
+	}
+}
+
+// afterExceptionChange 异步重算提醒并写系统时间线 (action 为空时只重算)
+func (r *mongoEventRepo) afterExceptionChange(eventID, userID primitive.ObjectID, action, occurrenceKey string) {
+	go func() {
+		defer func() { recover() }()
+		bg := context.Background()
+		var ev models.Event
+		if r.coll().FindOne(bg, bson.M{"_id": eventID}).Decode(&ev) != nil {
+			return
+		}
+		r.recomputeReminders(bg, ev)
+		if action == "" {
+			return
+		}
+		meta := bson.M{"action": action, "occurrence_key": occurrenceKey}
+		_, _ = r.comments().InsertOne(bg, bson.M{"_id": primitive.NewObjectID(), "event_id": eventID, "user_id": userID, "type": "system", "content": action, "meta": meta, "created_at": time.Now(), "updated_at": time.Now()})
+	}()
+}
+
+// recomputeReminders 重算事件下相对时间提醒的 next_send (已考虑例外)
+func (r *mongoEventRepo) recomputeReminders(ctx context.Context, ev models.Event) {
+	evs := []models.Event{ev}
+	loadEventExceptions(ctx, r.db, evs)
+	ev = evs[0]
+	cur, err := r.reminders().Find(ctx, bson.M{"event_id": ev.ID, "is_active": true})
+	if err != nil {
+		return
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		var rm models.Reminder
+		if cur.Decode(&rm) == nil && len(rm.AbsoluteTimes) == 0 { // 相对事件时间
+			upd := bson.M{"updated_at": time.Now()}
+			if nx := rm.CalculateNextSendTime(ev); nx != nil {
+				upd["next_send"] = *nx
+			} else {
+				upd["next_send"] = nil
+			}
+			_, _ = r.reminders().UpdateByID(ctx, rm.ID, bson.M{"$set": upd})
+		}
+	}
+}
+
+func exceptionAction(ex *models.EventException) string {
+	switch {
+	case ex.Cancelled:
+		return "cancel"
+	case ex.NewDate != nil:
+		return "reschedule"
+	}
+	return "override"
+}
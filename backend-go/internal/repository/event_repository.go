@@ -25,16 +25,25 @@ import (
 type EventRepository interface {
 	Insert(ctx context.Context, e *models.Event) error
 	FindByID(ctx context.Context, userID, id primitive.ObjectID) (*models.Event, error)
+	// FindByICalUID 按导入 UID 查找事件，不存在时返回 nil, nil
+	FindByICalUID(ctx context.Context, userID primitive.ObjectID, uid string) (*models.Event, error)
 	UpdateFields(ctx context.Context, userID, id primitive.ObjectID, set map[string]interface{}) (*models.Event, error)
 	Delete(ctx context.Context, userID, id primitive.ObjectID) error
 	Count(ctx context.Context, userID primitive.ObjectID, eventType string) (int64, error)
 	ListPaged(ctx context.Context, userID primitive.ObjectID, page, pageSize int, eventType string, startDate, endDate *time.Time) (*models.EventListResponse, error)
 	ListUpcoming(ctx context.Context, userID primitive.ObjectID, days int) ([]models.Event, error)
-	CalendarRange(ctx context.Context, userID primitive.ObjectID, year, month int) ([]models.Event, error)
+	CalendarRange(ctx context.Context, userID primitive.ObjectID, year, month int, loc *time.Location) ([]models.Event, error)
 	Search(ctx context.Context, userID primitive.ObjectID, keyword string, limit int) ([]models.Event, error)
+	ListActive(ctx context.Context, userID primitive.ObjectID) ([]models.Event, error)
 	Advance(ctx context.Context, userID, eventID primitive.ObjectID, reason string) (*models.Event, error)
 	ListStartingWindow(ctx context.Context, from, to time.Time) ([]models.Event, error)
-	MarkTriggered(ctx context.Context, id primitive.ObjectID, ts time.Time) error
+	// ClaimTrigger 原子标记某次发生已触发 (last_triggered_at = 发生时间)，多实例下仅首个调用者返回 true
+	ClaimTrigger(ctx context.Context, id primitive.ObjectID, occurrence time.Time) (bool, error)
+	// 单次发生例外
+	UpsertException(ctx context.Context, ex *models.EventException) (*models.EventException, error)
+	ListExceptions(ctx context.Context, userID, eventID primitive.ObjectID) ([]models.EventException, error)
+	DeleteException(ctx context.Context, userID, exceptionID primitive.ObjectID) error
+	SplitSeries(ctx context.Context, userID, eventID primitive.ObjectID, at time.Time, tail *models.Event) (*models.Event, *models.Event, error)
 }
 
 type mongoEventRepo struct{ db *mongo.Database }
@@ -47,6 +56,27 @@ func (r *mongoEventRepo) reminders() *mongo.Collection { return r.db.Collection(
 
 func (r *mongoEventRepo) comments() *mongo.Collection { return r.db.Collection("event_comments") }
 
+// scope 本人个人事件与角色不低于 minRole 的工作区事件
+func (r *mongoEventRepo) scope(ctx context.Context, userID primitive.ObjectID, minRole string) (bson.M, error) {
+	return ownerAccess.filter(ctx, r.db, userID, minRole)
+}
+
+// writable 校验事件可写 (工作区事件需要 editor 角色)：角色不足返回 ErrWorkspaceForbidden，不可见返回 notFound
+func (r *mongoEventRepo) writable(ctx context.Context, userID, id primitive.ObjectID, notFound error) error {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return err
+	}
+	n, err := r.coll().CountDocuments(ctx, Scoped(scope, bson.M{"_id": id}), options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ownerAccess.denied(ctx, r.db, r.coll(), userID, bson.M{"_id": id}, notFound)
+	}
+	return nil
+}
+
 func (r *mongoEventRepo) Insert(ctx context.Context, e *models.Event) error {
 	if e == nil {
 		return errors.New("nil event")
@@ -68,6 +98,12 @@ func (r *mongoEventRepo) Insert(ctx context.Context, e *models.Event) error {
 	if e.Tags == nil {
 		e.Tags = []string{}
 	}
+	e.NextStart = nil
+	if e.WorkspaceID != nil {
+		if err := RequireWorkspaceRole(ctx, r.db, *e.WorkspaceID, e.UserID, models.WorkspaceRoleEditor); err != nil {
+			return err
+		}
+	}
 	_, err := r.coll().InsertOne(ctx, e)
 	if err != nil {
 		return err
@@ -90,8 +126,25 @@ func (r *mongoEventRepo) Insert(ctx context.Context, e *models.Event) error {
 }
 
 func (r *mongoEventRepo) FindByID(ctx context.Context, userID, id primitive.ObjectID) (*models.Event, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	var ev models.Event
+	if err := r.coll().FindOne(ctx, Scoped(scope, bson.M{"_id": id})).Decode(&ev); err != nil {
+		return nil, err
+	}
+	evs := []models.Event{ev}
+	loadEventExceptions(ctx, r.db, evs)
+	return &evs[0], nil
+}
+
+func (r *mongoEventRepo) FindByICalUID(ctx context.Context, userID primitive.ObjectID, uid string) (*models.Event, error) {
 	var ev models.Event
-	err := r.coll().FindOne(ctx, bson.M{"_id": id, "user_id": userID}).Decode(&ev)
+	err := r.coll().FindOne(ctx, bson.M{"user_id": userID, "ical_uid": uid}).Decode(&ev)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -107,35 +160,50 @@ func (r *mongoEventRepo) UpdateFields(ctx context.Context, userID, id primitive.
 	for k, v := range set {
 		bset[k] = v
 	}
-	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": id, "user_id": userID}, bson.M{"$set": bset})
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.coll().UpdateOne(ctx, Scoped(scope, bson.M{"_id": id}), bson.M{"$set": bset, "$unset": bson.M{"next_start": ""}})
 	if err != nil {
 		return nil, err
 	}
 	if res.MatchedCount == 0 {
-		return nil, errors.New("not found")
+		return nil, ownerAccess.denied(ctx, r.db, r.coll(), userID, bson.M{"_id": id}, errors.New("not found"))
 	}
 	return r.FindByID(ctx, userID, id)
 }
 
 func (r *mongoEventRepo) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
-	res, err := r.coll().DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return err
+	}
+	res, err := r.coll().DeleteOne(ctx, Scoped(scope, bson.M{"_id": id}))
 	if err != nil {
 		return err
 	}
 	if res.DeletedCount == 0 {
-		return errors.New("not found")
+		return ownerAccess.denied(ctx, r.db, r.coll(), userID, bson.M{"_id": id}, errors.New("not found"))
 	}
-	// 级联删除提醒（忽略错误）
-	go func() { _, _ = r.reminders().DeleteMany(context.Background(), bson.M{"event_id": id}) }()
+	// 级联删除提醒与例外（忽略错误）
+	go func() {
+		_, _ = r.reminders().DeleteMany(context.Background(), bson.M{"event_id": id})
+		_, _ = r.exceptions().DeleteMany(context.Background(), bson.M{"event_id": id})
+	}()
 	return nil
 }
 
 func (r *mongoEventRepo) Count(ctx context.Context, userID primitive.ObjectID, eventType string) (int64, error) {
-	filter := bson.M{"user_id": userID, "is_active": true}
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return 0, err
+	}
+	filter := bson.M{"is_active": true}
 	if eventType != "" {
 		filter["event_type"] = eventType
 	}
-	return r.coll().CountDocuments(ctx, filter)
+	return r.coll().CountDocuments(ctx, Scoped(scope, filter))
 }
 
 func (r *mongoEventRepo) ListPaged(ctx context.Context, userID primitive.ObjectID, page, pageSize int, eventType string, startDate, endDate *time.Time) (*models.EventListResponse, error) {
@@ -145,7 +213,11 @@ func (r *mongoEventRepo) ListPaged(ctx context.Context, userID primitive.ObjectI
 	if pageSize < 1 || pageSize > 100 {
 		pageSize = 20
 	}
-	filter := bson.M{"user_id": userID, "is_active": true}
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{"is_active": true}
 	if eventType != "" {
 		filter["event_type"] = eventType
 	}
@@ -159,6 +231,7 @@ func (r *mongoEventRepo) ListPaged(ctx context.Context, userID primitive.ObjectI
 		}
 		filter["event_date"] = dateFilter
 	}
+	filter = Scoped(scope, filter)
 	total, err := r.coll().CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -178,45 +251,51 @@ func (r *mongoEventRepo) ListPaged(ctx context.Context, userID primitive.ObjectI
 	return &models.EventListResponse{Events: events, Total: total, Page: page, PageSize: pageSize, TotalPages: pages}, nil
 }
 
+// ListUpcoming 返回未来 days 天内的全部发生 (循环事件已展开)，最多 50 条
 func (r *mongoEventRepo) ListUpcoming(ctx context.Context, userID primitive.ObjectID, days int) ([]models.Event, error) {
 	if days <= 0 {
 		days = 7
 	}
 	now := time.Now()
 	endDate := now.AddDate(0, 0, days)
-	filter := bson.M{"user_id": userID, "is_active": true, "event_date": bson.M{"$gte": now, "$lte": endDate}}
-	cur, err := r.coll().Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "event_date", Value: 1}}).SetLimit(50))
+	list, err := r.expandWindow(ctx, userID, now, endDate)
 	if err != nil {
 		return nil, err
 	}
-	defer cur.Close(ctx)
-	var list []models.Event
-	if err = cur.All(ctx, &list); err != nil {
-		return nil, err
+	if len(list) > 50 {
+		list = list[:50]
 	}
-	// 处理循环，将下一次实例化（保持与原逻辑一致）
-	var out []models.Event
-	for _, ev := range list {
-		if ev.RecurrenceType != "none" {
-			if next := ev.GetNextOccurrence(now); next != nil && next.Before(endDate) {
-				cpy := ev
-				cpy.EventDate = *next
-				out = append(out, cpy)
-			} else {
-				out = append(out, ev)
-			}
-		} else {
-			out = append(out, ev)
-		}
+	return list, nil
+}
+
+// CalendarRange 返回月份内的全部发生 (循环事件已展开)，月份边界按 loc 计算 (nil 为 UTC)
+func (r *mongoEventRepo) CalendarRange(ctx context.Context, userID primitive.ObjectID, year, month int, loc *time.Location) ([]models.Event, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	start, end := models.MonthRange(year, month, loc)
+	return r.expandWindow(ctx, userID, start, end.Add(-time.Nanosecond))
+}
+
+// expandWindow 查询可能落在 [from, to] 的事件 (一次性事件按日期过滤，循环事件只要起始不晚于 to，
+// 以及有发生被改期进窗口的事件) 并结合例外展开为发生
+func (r *mongoEventRepo) expandWindow(ctx context.Context, userID primitive.ObjectID, from, to time.Time) ([]models.Event, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
 	}
-	return out, nil
+	return r.expandFiltered(ctx, scope, from, to)
 }
 
-func (r *mongoEventRepo) CalendarRange(ctx context.Context, userID primitive.ObjectID, year, month int) ([]models.Event, error) {
-	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	end := start.AddDate(0, 1, -1).Add(24*time.Hour - time.Nanosecond)
-	filter := bson.M{"user_id": userID, "is_active": true, "event_date": bson.M{"$gte": start, "$lte": end}}
-	cur, err := r.coll().Find(ctx, filter)
+func (r *mongoEventRepo) expandFiltered(ctx context.Context, base bson.M, from, to time.Time) ([]models.Event, error) {
+	or := []bson.M{
+		{"event_date": bson.M{"$gte": from, "$lte": to}},
+		{"recurrence_type": bson.M{"$nin": []string{"", "none"}}, "event_date": bson.M{"$lte": to}},
+	}
+	if moved := r.movedIntoWindow(ctx, from, to); len(moved) > 0 {
+		or = append(or, bson.M{"_id": bson.M{"$in": moved}})
+	}
+	cur, err := r.coll().Find(ctx, Scoped(base, bson.M{"is_active": true, "$or": or}))
 	if err != nil {
 		return nil, err
 	}
@@ -225,7 +304,8 @@ func (r *mongoEventRepo) CalendarRange(ctx context.Context, userID primitive.Obj
 	if err = cur.All(ctx, &list); err != nil {
 		return nil, err
 	}
-	return list, nil
+	loadEventExceptions(ctx, r.db, list)
+	return models.ExpandOccurrences(list, from, to, 0), nil
 }
 
 func (r *mongoEventRepo) Search(ctx context.Context, userID primitive.ObjectID, keyword string, limit int) ([]models.Event, error) {
@@ -235,8 +315,12 @@ func (r *mongoEventRepo) Search(ctx context.Context, userID primitive.ObjectID,
 	if limit <= 0 {
 		limit = 20
 	}
-	filter := bson.M{"user_id": userID, "is_active": true, "$or": []bson.M{{"title": bson.M{"$regex": keyword, "$options": "i"}}, {"description": bson.M{"$regex": keyword, "$options": "i"}}, {"tags": bson.M{"$in": []string{keyword}}}}}
-	cur, err := r.coll().Find(ctx, filter, options.Find().SetLimit(int64(limit)).SetSort(bson.D{{Key: "event_date", Value: 1}}))
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{"is_active": true, "$or": []bson.M{{"title": bson.M{"$regex": keyword, "$options": "i"}}, {"description": bson.M{"$regex": keyword, "$options": "i"}}, {"tags": bson.M{"$in": []string{keyword}}}}}
+	cur, err := r.coll().Find(ctx, Scoped(scope, filter), options.Find().SetLimit(int64(limit)).SetSort(bson.D{{Key: "event_date", Value: 1}}))
 	if err != nil {
 		return nil, err
 	}
@@ -248,12 +332,35 @@ func (r *mongoEventRepo) Search(ctx context.Context, userID primitive.ObjectID,
 	return list, nil
 }
 
+// ListActive 返回用户全部有效事件 (循环事件为系列本身，已挂载例外)，供订阅导出
+func (r *mongoEventRepo) ListActive(ctx context.Context, userID primitive.ObjectID) ([]models.Event, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := r.coll().Find(ctx, Scoped(scope, bson.M{"is_active": true}), options.Find().SetSort(bson.D{{Key: "event_date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.Event{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	loadEventExceptions(ctx, r.db, list)
+	return list, nil
+}
+
 func (r *mongoEventRepo) Advance(ctx context.Context, userID, eventID primitive.ObjectID, reason string) (*models.Event, error) {
-	// 读取当前
+	// 读取当前 (工作区事件需要 editor 角色)
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return nil, err
+	}
 	var ev models.Event
-	if err := r.coll().FindOne(ctx, bson.M{"_id": eventID, "user_id": userID}).Decode(&ev); err != nil {
+	if err := r.coll().FindOne(ctx, Scoped(scope, bson.M{"_id": eventID})).Decode(&ev); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("event not found")
+			return nil, ownerAccess.denied(ctx, r.db, r.coll(), userID, bson.M{"_id": eventID}, errors.New("event not found"))
 		}
 		return nil, err
 	}
@@ -264,65 +371,46 @@ func (r *mongoEventRepo) Advance(ctx context.Context, userID, eventID primitive.
 		set["is_active"] = false
 		set["last_triggered_at"] = now
 	} else {
-		var next time.Time
-		switch ev.RecurrenceType {
-		case "daily":
-			next = ev.EventDate.AddDate(0, 0, 1)
-		case "weekly":
-			next = ev.EventDate.AddDate(0, 0, 7)
-		case "monthly":
-			next = ev.EventDate.AddDate(0, 1, 0)
-		case "yearly":
-			next = ev.EventDate.AddDate(1, 0, 0)
-		default:
-			set["is_active"] = false
-		}
-		for !next.IsZero() && next.Before(now) { // 防御推进
-			switch ev.RecurrenceType {
-			case "daily":
-				next = next.AddDate(0, 0, 1)
-			case "weekly":
-				next = next.AddDate(0, 0, 7)
-			case "monthly":
-				next = next.AddDate(0, 1, 0)
-			case "yearly":
-				next = next.AddDate(1, 0, 0)
-			default:
-				break
+		// 推进到晚于原日期且不早于当前时间的下一次发生
+		rule := ev.EffectiveRecurrenceRule()
+		var next *time.Time
+		if rule != nil {
+			after := ev.EventDate
+			if now.After(after) {
+				after = now
 			}
+			next = rule.Next(ev.LocalEventDate(), after)
 		}
-		if !next.IsZero() {
-			set["event_date"] = next
+		if next == nil { // 未知类型 / UNTIL / COUNT 已耗尽：系列结束
+			set["is_active"] = false
+			set["last_triggered_at"] = now
+			ev.IsActive = false
+		} else {
+			if rule.Count > 0 {
+				// event_date 即系列锚点，推进后 COUNT 需扣除已消耗的发生次数 (EXDATE 同样计数)
+				raw := *rule
+				raw.ExDates = nil
+				consumed := len(raw.Between(ev.LocalEventDate(), ev.EventDate, next.Add(-time.Nanosecond), 0))
+				cfg := map[string]interface{}{}
+				for k, v := range ev.RecurrenceConfig {
+					cfg[k] = v
+				}
+				cfg["count"] = rule.Count - consumed
+				set["recurrence_config"] = cfg
+				ev.RecurrenceConfig = cfg
+			}
+			set["event_date"] = *next
 			set["last_triggered_at"] = now
-			ev.EventDate = next
+			ev.EventDate = *next
 		}
 	}
-	_, err := r.coll().UpdateOne(ctx, bson.M{"_id": ev.ID, "user_id": userID}, bson.M{"$set": set})
-	if err != nil {
+	if _, err := r.coll().UpdateOne(ctx, bson.M{"_id": ev.ID}, bson.M{"$set": set, "$unset": bson.M{"next_start": ""}}); err != nil {
 		return nil, fmt.Errorf("advance update err: %w", err)
 	}
 	// 异步重算提醒
 	go func(e models.Event) {
 		defer func() { recover() }()
-		cur, err2 := r.reminders().Find(context.Background(), bson.M{"event_id": e.ID, "is_active": true})
-		if err2 != nil {
-			return
-		}
-		defer cur.Close(context.Background())
-		for cur.Next(context.Background()) {
-			var rm models.Reminder
-			if cur.Decode(&rm) == nil {
-				if len(rm.AbsoluteTimes) == 0 { // 相对事件时间
-					upd := bson.M{"updated_at": time.Now()}
-					if nx := rm.CalculateNextSendTime(e); nx != nil {
-						upd["next_send"] = *nx
-					} else {
-						upd["next_send"] = nil
-					}
-					_, _ = r.reminders().UpdateByID(context.Background(), rm.ID, bson.M{"$set": upd})
-				}
-			}
-		}
+		r.recomputeReminders(context.Background(), e)
 	}(ev)
 	// 写时间线
 	go func(old time.Time, newEv models.Event) {
@@ -343,9 +431,14 @@ func (r *mongoEventRepo) Advance(ctx context.Context, userID, eventID primitive.
 	return &ev, nil
 }
 
+// ListStartingWindow 返回 [from, to] 内开始的发生 (全部用户；循环事件展开，已应用例外)。
+// 候选按索引字段 next_start 过滤，只展开下次发生不晚于 to 的事件；next_start 缺失 (新建 / 修改后) 的事件展开后回填
 func (r *mongoEventRepo) ListStartingWindow(ctx context.Context, from, to time.Time) ([]models.Event, error) {
-	filter := bson.M{"is_active": true, "event_date": bson.M{"$gte": from, "$lte": to}}
-	cur, err := r.coll().Find(ctx, filter)
+	or := []bson.M{{"next_start": bson.M{"$lte": to}}, {"next_start": bson.M{"$exists": false}}}
+	if moved := r.movedIntoWindow(ctx, from, to); len(moved) > 0 {
+		or = append(or, bson.M{"_id": bson.M{"$in": moved}})
+	}
+	cur, err := r.coll().Find(ctx, bson.M{"is_active": true, "$or": or})
 	if err != nil {
 		return nil, err
 	}
@@ -354,10 +447,48 @@ func (r *mongoEventRepo) ListStartingWindow(ctx context.Context, from, to time.T
 	if err = cur.All(ctx, &list); err != nil {
 		return nil, err
 	}
-	return list, nil
+	loadEventExceptions(ctx, r.db, list)
+	for i := range list {
+		r.storeNextStart(ctx, &list[i], from)
+	}
+	return models.ExpandOccurrences(list, from, to, 0), nil
+}
+
+// storeNextStart 将 next_start 推进到晚于 from 的下次发生 (窗口内的发生在窗口起点越过前保持为候选)；
+// 系列已结束时写入 null，不再参与扫描。按 updated_at 条件写入，避免覆盖期间的修改
+func (r *mongoEventRepo) storeNextStart(ctx context.Context, ev *models.Event, from time.Time) {
+	next := ev.GetNextOccurrence(from)
+	if next != nil && ev.NextStart != nil && next.Equal(*ev.NextStart) {
+		return
+	}
+	_, _ = r.coll().UpdateOne(ctx, bson.M{"_id": ev.ID, "updated_at": ev.UpdatedAt}, bson.M{"$set": bson.M{"next_start": next}})
+}
+
+// resetNextStart 事件日期 / 例外变化后清除 next_start，由下次扫描重新计算
+func (r *mongoEventRepo) resetNextStart(ctx context.Context, id primitive.ObjectID) {
+	_, _ = r.coll().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"updated_at": time.Now()}, "$unset": bson.M{"next_start": ""}})
 }
 
-func (r *mongoEventRepo) MarkTriggered(ctx context.Context, id primitive.ObjectID, ts time.Time) error {
-	_, err := r.coll().UpdateByID(ctx, id, bson.M{"$set": bson.M{"last_triggered_at": ts}})
+// EnsureEventIndexes 创建调度扫描用的 next_start 索引，以及单次发生例外的 (event_id, occurrence_date) 唯一索引
+func EnsureEventIndexes(ctx context.Context, db *mongo.Database) error {
+	if _, err := db.Collection("events").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "is_active", Value: 1}, {Key: "next_start", Value: 1}},
+	}); err != nil {
+		return err
+	}
+	_, err := db.Collection("event_exceptions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "occurrence_date", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
 	return err
 }
+
+func (r *mongoEventRepo) ClaimTrigger(ctx context.Context, id primitive.ObjectID, occurrence time.Time) (bool, error) {
+	res, err := r.coll().UpdateOne(ctx,
+		bson.M{"_id": id, "last_triggered_at": bson.M{"$ne": occurrence}},
+		bson.M{"$set": bson.M{"last_triggered_at": occurrence}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
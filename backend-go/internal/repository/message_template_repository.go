@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MessageTemplateRepository 用户自定义提醒模板仓储
+type MessageTemplateRepository interface {
+	// Find 先按精确事件类型查找，再回退到适用全部类型 (event_type 为空) 的模板；都没有时返回 nil, nil
+	Find(ctx context.Context, userID primitive.ObjectID, eventType, kind string) (*models.MessageTemplate, error)
+	List(ctx context.Context, userID primitive.ObjectID) ([]models.MessageTemplate, error)
+	Upsert(ctx context.Context, t *models.MessageTemplate) error
+	// Delete 不存在时返回 false
+	Delete(ctx context.Context, userID primitive.ObjectID, eventType, kind string) (bool, error)
+}
+
+type mongoMessageTemplateRepo struct{ db *mongo.Database }
+
+func NewMessageTemplateRepository(db *mongo.Database) MessageTemplateRepository {
+	return &mongoMessageTemplateRepo{db: db}
+}
+
+func (r *mongoMessageTemplateRepo) coll() *mongo.Collection {
+	return r.db.Collection("message_templates")
+}
+
+func (r *mongoMessageTemplateRepo) Find(ctx context.Context, userID primitive.ObjectID, eventType, kind string) (*models.MessageTemplate, error) {
+	types := bson.A{""}
+	if eventType != "" {
+		types = bson.A{eventType, ""}
+	}
+	// 精确类型排在前面 (降序时非空串在空串之前)
+	opts := options.FindOne().SetSort(bson.D{{Key: "event_type", Value: -1}})
+	var t models.MessageTemplate
+	err := r.coll().FindOne(ctx, bson.M{"user_id": userID, "kind": kind, "event_type": bson.M{"$in": types}}, opts).Decode(&t)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *mongoMessageTemplateRepo) List(ctx context.Context, userID primitive.ObjectID) ([]models.MessageTemplate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "event_type", Value: 1}, {Key: "kind", Value: 1}})
+	cur, err := r.coll().Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.MessageTemplate{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoMessageTemplateRepo) Upsert(ctx context.Context, t *models.MessageTemplate) error {
+	if t == nil || t.UserID.IsZero() {
+		return errors.New("nil message template")
+	}
+	t.UpdatedAt = time.Now()
+	filter := bson.M{"user_id": t.UserID, "event_type": t.EventType, "kind": t.Kind}
+	update := bson.M{"$set": bson.M{"body": t.Body, "updated_at": t.UpdatedAt}}
+	res, err := r.coll().UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+	if id, ok := res.UpsertedID.(primitive.ObjectID); ok {
+		t.ID = id
+	} else if t.ID.IsZero() {
+		var cur models.MessageTemplate
+		if err := r.coll().FindOne(ctx, filter).Decode(&cur); err == nil {
+			t.ID = cur.ID
+		}
+	}
+	return nil
+}
+
+func (r *mongoMessageTemplateRepo) Delete(ctx context.Context, userID primitive.ObjectID, eventType, kind string) (bool, error) {
+	res, err := r.coll().DeleteOne(ctx, bson.M{"user_id": userID, "event_type": eventType, "kind": kind})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
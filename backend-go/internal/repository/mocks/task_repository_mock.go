@@ -10,10 +10,16 @@ import (
 
 type TaskRepositoryMock struct {
 	InsertFn        func(ctx context.Context, t *models.Task) error
-	ListFn          func(ctx context.Context, userID string, status string, page, limit int64) ([]models.Task, int64, error)
+	ListFn          func(ctx context.Context, userID string, q models.TaskQuery) ([]models.Task, int64, error)
 	FindByIDFn      func(ctx context.Context, userID, id string) (*models.Task, error)
 	UpdatePartialFn func(ctx context.Context, userID, id string, set bson.M) (*models.Task, error)
 	DeleteFn        func(ctx context.Context, userID, id string) error
+	ListChildrenFn  func(ctx context.Context, userID, parentID string) ([]models.Task, error)
+	ListBlockedByFn func(ctx context.Context, userID, blockerID string) ([]models.Task, error)
+	ListSeriesFn    func(ctx context.Context, userID, seriesID string) ([]models.Task, error)
+	ClaimNextFn     func(ctx context.Context, userID, id, nextID string) (bool, error)
+	AddToSetFn      func(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error)
+	PullFn          func(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error)
 }
 
 var _ repository.TaskRepository = (*TaskRepositoryMock)(nil)
@@ -21,8 +27,8 @@ var _ repository.TaskRepository = (*TaskRepositoryMock)(nil)
 func (m *TaskRepositoryMock) Insert(ctx context.Context, t *models.Task) error {
 	return m.callInsert(ctx, t)
 }
-func (m *TaskRepositoryMock) List(ctx context.Context, userID string, status string, page, limit int64) ([]models.Task, int64, error) {
-	return m.callList(ctx, userID, status, page, limit)
+func (m *TaskRepositoryMock) List(ctx context.Context, userID string, q models.TaskQuery) ([]models.Task, int64, error) {
+	return m.callList(ctx, userID, q)
 }
 func (m *TaskRepositoryMock) FindByID(ctx context.Context, userID, id string) (*models.Task, error) {
 	return m.callFindByID(ctx, userID, id)
@@ -33,6 +39,42 @@ func (m *TaskRepositoryMock) UpdatePartial(ctx context.Context, userID, id strin
 func (m *TaskRepositoryMock) Delete(ctx context.Context, userID, id string) error {
 	return m.callDelete(ctx, userID, id)
 }
+func (m *TaskRepositoryMock) ListChildren(ctx context.Context, userID, parentID string) ([]models.Task, error) {
+	if m.ListChildrenFn != nil {
+		return m.ListChildrenFn(ctx, userID, parentID)
+	}
+	return nil, nil
+}
+func (m *TaskRepositoryMock) ListBlockedBy(ctx context.Context, userID, blockerID string) ([]models.Task, error) {
+	if m.ListBlockedByFn != nil {
+		return m.ListBlockedByFn(ctx, userID, blockerID)
+	}
+	return nil, nil
+}
+func (m *TaskRepositoryMock) ListSeries(ctx context.Context, userID, seriesID string) ([]models.Task, error) {
+	if m.ListSeriesFn != nil {
+		return m.ListSeriesFn(ctx, userID, seriesID)
+	}
+	return nil, nil
+}
+func (m *TaskRepositoryMock) ClaimNext(ctx context.Context, userID, id, nextID string) (bool, error) {
+	if m.ClaimNextFn != nil {
+		return m.ClaimNextFn(ctx, userID, id, nextID)
+	}
+	return true, nil
+}
+func (m *TaskRepositoryMock) AddToSet(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error) {
+	if m.AddToSetFn != nil {
+		return m.AddToSetFn(ctx, userID, id, field, value)
+	}
+	return nil, nil
+}
+func (m *TaskRepositoryMock) Pull(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error) {
+	if m.PullFn != nil {
+		return m.PullFn(ctx, userID, id, field, value)
+	}
+	return nil, nil
+}
 
 // internal wrappers with nil checks
 func (m *TaskRepositoryMock) callInsert(ctx context.Context, t *models.Task) error {
@@ -41,9 +83,9 @@ func (m *TaskRepositoryMock) callInsert(ctx context.Context, t *models.Task) err
 	}
 	return nil
 }
-func (m *TaskRepositoryMock) callList(ctx context.Context, userID, status string, page, limit int64) ([]models.Task, int64, error) {
+func (m *TaskRepositoryMock) callList(ctx context.Context, userID string, q models.TaskQuery) ([]models.Task, int64, error) {
 	if m.ListFn != nil {
-		return m.ListFn(ctx, userID, status, page, limit)
+		return m.ListFn(ctx, userID, q)
 	}
 	return nil, 0, nil
 }
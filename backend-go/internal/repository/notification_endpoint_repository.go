@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotificationEndpointRepository 外部渠道投递目标仓储
+type NotificationEndpointRepository interface {
+	Insert(ctx context.Context, e *models.NotificationEndpoint) error
+	// ListByUser channel 为空时返回全部渠道
+	ListByUser(ctx context.Context, userID primitive.ObjectID, channel string) ([]models.NotificationEndpoint, error)
+	Delete(ctx context.Context, userID, id primitive.ObjectID) error
+}
+
+type mongoNotificationEndpointRepo struct{ db *mongo.Database }
+
+func NewNotificationEndpointRepository(db *mongo.Database) NotificationEndpointRepository {
+	return &mongoNotificationEndpointRepo{db: db}
+}
+
+func (r *mongoNotificationEndpointRepo) coll() *mongo.Collection {
+	return r.db.Collection("notification_endpoints")
+}
+
+func (r *mongoNotificationEndpointRepo) Insert(ctx context.Context, e *models.NotificationEndpoint) error {
+	if e == nil {
+		return errors.New("nil endpoint")
+	}
+	if e.ID.IsZero() {
+		e.ID = primitive.NewObjectID()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	_, err := r.coll().InsertOne(ctx, e)
+	return err
+}
+
+func (r *mongoNotificationEndpointRepo) ListByUser(ctx context.Context, userID primitive.ObjectID, channel string) ([]models.NotificationEndpoint, error) {
+	filter := bson.M{"user_id": userID}
+	if channel != "" {
+		filter["channel"] = channel
+	}
+	cur, err := r.coll().Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.NotificationEndpoint{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoNotificationEndpointRepo) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	res, err := r.coll().DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return errors.New("endpoint not found")
+	}
+	return nil
+}
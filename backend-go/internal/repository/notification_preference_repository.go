@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// NotificationPreferenceRepository 用户通知偏好仓储 (每个用户一条)
+type NotificationPreferenceRepository interface {
+	// Get 未设置时返回 nil, nil
+	Get(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error)
+	Upsert(ctx context.Context, p *models.NotificationPreferences) error
+}
+
+type mongoNotificationPreferenceRepo struct{ db *mongo.Database }
+
+func NewNotificationPreferenceRepository(db *mongo.Database) NotificationPreferenceRepository {
+	return &mongoNotificationPreferenceRepo{db: db}
+}
+
+func (r *mongoNotificationPreferenceRepo) coll() *mongo.Collection {
+	return r.db.Collection("notification_preferences")
+}
+
+func (r *mongoNotificationPreferenceRepo) Get(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error) {
+	var p models.NotificationPreferences
+	if err := r.coll().FindOne(ctx, bson.M{"user_id": userID}).Decode(&p); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *mongoNotificationPreferenceRepo) Upsert(ctx context.Context, p *models.NotificationPreferences) error {
+	if p == nil || p.UserID.IsZero() {
+		return errors.New("nil preferences")
+	}
+	p.UpdatedAt = time.Now()
+	_, err := r.coll().ReplaceOne(ctx, bson.M{"user_id": p.UserID}, p, options.Replace().SetUpsert(true))
+	return err
+}
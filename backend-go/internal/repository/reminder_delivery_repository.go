@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// ReminderDeliveryRepository 提醒投递记录 (集合 reminder_deliveries)
+type ReminderDeliveryRepository interface {
+	Insert(ctx context.Context, d *models.ReminderDelivery) error
+	// ListByReminder 按时间倒序列出某提醒的投递记录，before 为上一页最后一条的 ID
+	ListByReminder(ctx context.Context, userID, reminderID primitive.ObjectID, limit int, before *primitive.ObjectID) ([]models.ReminderDelivery, error)
+}
+
+type mongoReminderDeliveryRepo struct{ db *mongo.Database }
+
+func NewReminderDeliveryRepository(db *mongo.Database) ReminderDeliveryRepository {
+	return &mongoReminderDeliveryRepo{db: db}
+}
+
+func (r *mongoReminderDeliveryRepo) coll() *mongo.Collection {
+	return r.db.Collection("reminder_deliveries")
+}
+
+func (r *mongoReminderDeliveryRepo) Insert(ctx context.Context, d *models.ReminderDelivery) error {
+	if d == nil {
+		return errors.New("nil delivery")
+	}
+	if d.ID.IsZero() {
+		d.ID = primitive.NewObjectID()
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	_, err := r.coll().InsertOne(ctx, d)
+	return err
+}
+
+func (r *mongoReminderDeliveryRepo) ListByReminder(ctx context.Context, userID, reminderID primitive.ObjectID, limit int, before *primitive.ObjectID) ([]models.ReminderDelivery, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	filter := bson.M{"reminder_id": reminderID, "user_id": userID}
+	if before != nil {
+		filter["_id"] = bson.M{"$lt": *before}
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(int64(limit))
+	cur, err := r.coll().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	out := []models.ReminderDelivery{}
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
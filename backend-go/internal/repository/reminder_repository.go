@@ -12,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // ReminderRepository 提醒仓储，聚合逻辑集中此处
@@ -25,11 +26,24 @@ type ReminderRepository interface {
 	Delete(ctx context.Context, userID, reminderID primitive.ObjectID) error
 	ListPagedWithEvent(ctx context.Context, userID primitive.ObjectID, page, pageSize int, activeOnly bool) (*models.ReminderListResponse, error)
 	ListSimple(ctx context.Context, userID primitive.ObjectID, activeOnly bool, limit int) ([]SimpleReminderDTO, error)
+	ListByUser(ctx context.Context, userID primitive.ObjectID, activeOnly bool) ([]models.Reminder, error)
 	Upcoming(ctx context.Context, userID primitive.ObjectID, hours int) ([]models.UpcomingReminder, error)
 	Pending(ctx context.Context) ([]models.ReminderWithEvent, error)
 	ToggleActive(ctx context.Context, userID, reminderID primitive.ObjectID) (bool, error)
 	Snooze(ctx context.Context, userID, reminderID primitive.ObjectID, minutes int) error
 	MarkSent(ctx context.Context, reminderID primitive.ObjectID) error
+	// ClaimDue 原子领取一条到期且未被租用 (或租约已过期) 的提醒，无可领取时返回 nil, nil
+	ClaimDue(ctx context.Context, owner string, lease time.Duration) (*models.ReminderClaim, error)
+	// RenewClaim 续约：按令牌把租约延长到 until；租约已被接管时返回 ErrLeaseLost
+	RenewClaim(ctx context.Context, reminderID primitive.ObjectID, token string, until time.Time) error
+	// CompleteClaim 发送成功：写入 last_sent / next_send 并释放租约；租约已被接管时返回 ErrLeaseLost
+	CompleteClaim(ctx context.Context, reminderID primitive.ObjectID, token string) error
+	// RetryClaim 发送失败：记录重试状态并释放租约，RetryAt 之前不会被再次领取
+	RetryClaim(ctx context.Context, reminderID primitive.ObjectID, token string, retry models.ReminderRetry) error
+	// DeferClaim 免打扰延后：释放租约，until 之前不会被再次领取，不计入投递次数
+	DeferClaim(ctx context.Context, reminderID primitive.ObjectID, token string, until time.Time) error
+	// DeadLetterClaim 达到最大重试次数：放弃本次提醒，推进到下一次 next_send 并释放租约
+	DeadLetterClaim(ctx context.Context, reminderID primitive.ObjectID, token string, lastErr string) error
 	CreateImmediateTest(ctx context.Context, userID, eventID primitive.ObjectID, message string, delaySeconds int) (*models.Reminder, *models.Event, error)
 	// Preview 预览提醒下一次发送时间（供服务层直接使用）
 	Preview(ctx context.Context, userID, eventID primitive.ObjectID, advanceDays int, times []string) (*PreviewReminderOutput, error)
@@ -42,21 +56,51 @@ func NewReminderRepository(db *mongo.Database) ReminderRepository { return &mong
 func (r *mongoReminderRepo) coll() *mongo.Collection   { return r.db.Collection("reminders") }
 func (r *mongoReminderRepo) events() *mongo.Collection { return r.db.Collection("events") }
 
+// scope 本人个人提醒与角色不低于 minRole 的工作区提醒 (提醒继承所属事件的工作区)
+func (r *mongoReminderRepo) scope(ctx context.Context, userID primitive.ObjectID, minRole string) (bson.M, error) {
+	return ownerAccess.filter(ctx, r.db, userID, minRole)
+}
+
+// eventScope 用户可读的事件范围
+func (r *mongoReminderRepo) eventScope(ctx context.Context, userID, eventID primitive.ObjectID) (bson.M, error) {
+	scope, err := ownerAccess.filter(ctx, r.db, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return Scoped(scope, bson.M{"_id": eventID}), nil
+}
+
+// denied 写操作未命中时的原因 (ErrWorkspaceForbidden 或 reminder not found)
+func (r *mongoReminderRepo) denied(ctx context.Context, userID, reminderID primitive.ObjectID) error {
+	return ownerAccess.denied(ctx, r.db, r.coll(), userID, bson.M{"_id": reminderID}, errors.New("reminder not found"))
+}
+
+// findEvent 读取事件并挂载单次发生例外，供 next_send 计算
+func (r *mongoReminderRepo) findEvent(ctx context.Context, filter bson.M, ev *models.Event) error {
+	if err := r.events().FindOne(ctx, filter).Decode(ev); err != nil {
+		return err
+	}
+	evs := []models.Event{*ev}
+	loadEventExceptions(ctx, r.db, evs)
+	*ev = evs[0]
+	return nil
+}
+
 // SimpleReminderDTO 精简数据
 type SimpleReminderDTO struct {
-	ID            primitive.ObjectID `bson:"_id" json:"id"`
-	EventID       primitive.ObjectID `bson:"event_id" json:"event_id"`
-	EventTitle    string             `bson:"event_title" json:"event_title"`
-	EventDate     time.Time          `bson:"event_date" json:"event_date"`
-	AdvanceDays   int                `bson:"advance_days" json:"advance_days"`
-	ReminderTimes []string           `bson:"reminder_times" json:"reminder_times"`
-	ReminderType  string             `bson:"reminder_type" json:"reminder_type"`
-	CustomMessage string             `bson:"custom_message" json:"custom_message,omitempty"`
-	IsActive      bool               `bson:"is_active" json:"is_active"`
-	NextSend      *time.Time         `bson:"next_send" json:"next_send,omitempty"`
-	LastSent      *time.Time         `bson:"last_sent" json:"last_sent,omitempty"`
-	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+	ID            primitive.ObjectID      `bson:"_id" json:"id"`
+	EventID       primitive.ObjectID      `bson:"event_id" json:"event_id"`
+	EventTitle    string                  `bson:"event_title" json:"event_title"`
+	EventDate     time.Time               `bson:"event_date" json:"event_date"`
+	AdvanceDays   int                     `bson:"advance_days" json:"advance_days"`
+	ReminderTimes []string                `bson:"reminder_times" json:"reminder_times"`
+	ReminderType  models.ReminderChannels `bson:"reminder_type" json:"reminder_type"`
+	CustomMessage string                  `bson:"custom_message" json:"custom_message,omitempty"`
+	IsActive      bool                    `bson:"is_active" json:"is_active"`
+	NextSend      *time.Time              `bson:"next_send" json:"next_send,omitempty"`
+	LastSent      *time.Time              `bson:"last_sent" json:"last_sent,omitempty"`
+	CreatedAt     time.Time               `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time               `bson:"updated_at" json:"updated_at"`
 }
 
 func (r *mongoReminderRepo) Insert(ctx context.Context, m *models.Reminder) error {
@@ -71,21 +115,39 @@ func (r *mongoReminderRepo) Insert(ctx context.Context, m *models.Reminder) erro
 		m.CreatedAt = now
 	}
 	m.UpdatedAt = now
-	// 计算 next_send
+	// 事件须可见，提醒继承其工作区 (工作区事件需要 editor 角色)；并计算 next_send
+	filter, err := r.eventScope(ctx, m.UserID, m.EventID)
+	if err != nil {
+		return err
+	}
 	var ev models.Event
-	if err := r.events().FindOne(ctx, bson.M{"_id": m.EventID, "user_id": m.UserID}).Decode(&ev); err == nil {
-		m.NextSend = m.CalculateNextSendTime(ev)
+	if err := r.findEvent(ctx, filter, &ev); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return errors.New("event not found")
+		}
+		return err
+	}
+	m.WorkspaceID = ev.WorkspaceID
+	if ev.WorkspaceID != nil {
+		if err := RequireWorkspaceRole(ctx, r.db, *ev.WorkspaceID, m.UserID, models.WorkspaceRoleEditor); err != nil {
+			return err
+		}
 	}
-	_, err := r.coll().InsertOne(ctx, m)
+	m.NextSend = m.CalculateNextSendTime(ev)
+	_, err = r.coll().InsertOne(ctx, m)
 	return err
 }
 
 func (r *mongoReminderRepo) GetWithEvent(ctx context.Context, userID, reminderID primitive.ObjectID) (*models.ReminderWithEvent, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
 	pipeline := []bson.M{
-		{"$match": bson.M{"_id": reminderID, "user_id": userID}},
+		{"$match": Scoped(scope, bson.M{"_id": reminderID})},
 		{"$lookup": bson.M{"from": "events", "localField": "event_id", "foreignField": "_id", "as": "event"}},
 		{"$unwind": "$event"},
-		{"$project": bson.M{"_id": 1, "event_id": 1, "user_id": 1, "advance_days": 1, "reminder_times": 1, "reminder_type": 1, "custom_message": 1, "is_active": 1, "last_sent": 1, "next_send": 1, "created_at": 1, "updated_at": 1, "event": "$event"}},
+		{"$project": bson.M{"_id": 1, "event_id": 1, "user_id": 1, "workspace_id": 1, "advance_days": 1, "reminder_times": 1, "reminder_type": 1, "custom_message": 1, "is_active": 1, "last_sent": 1, "next_send": 1, "created_at": 1, "updated_at": 1, "event": "$event"}},
 	}
 	cur, err := r.coll().Aggregate(ctx, pipeline)
 	if err != nil {
@@ -111,12 +173,16 @@ func (r *mongoReminderRepo) UpdateFields(ctx context.Context, userID, reminderID
 	for k, v := range set {
 		bset[k] = v
 	}
-	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": reminderID, "user_id": userID}, bson.M{"$set": bset})
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.coll().UpdateOne(ctx, Scoped(scope, bson.M{"_id": reminderID}), bson.M{"$set": bset})
 	if err != nil {
 		return nil, err
 	}
 	if res.MatchedCount == 0 {
-		return nil, errors.New("reminder not found")
+		return nil, r.denied(ctx, userID, reminderID)
 	}
 	var rm models.Reminder
 	if err = r.coll().FindOne(ctx, bson.M{"_id": reminderID}).Decode(&rm); err != nil {
@@ -124,7 +190,7 @@ func (r *mongoReminderRepo) UpdateFields(ctx context.Context, userID, reminderID
 	}
 	if recomputeNext {
 		var ev models.Event
-		if err := r.events().FindOne(ctx, bson.M{"_id": rm.EventID}).Decode(&ev); err == nil {
+		if err := r.findEvent(ctx, bson.M{"_id": rm.EventID}, &ev); err == nil {
 			if nx := rm.CalculateNextSendTime(ev); true {
 				_, _ = r.coll().UpdateOne(ctx, bson.M{"_id": rm.ID}, bson.M{"$set": bson.M{"next_send": nx, "updated_at": time.Now()}})
 				rm.NextSend = nx
@@ -135,12 +201,16 @@ func (r *mongoReminderRepo) UpdateFields(ctx context.Context, userID, reminderID
 }
 
 func (r *mongoReminderRepo) Delete(ctx context.Context, userID, reminderID primitive.ObjectID) error {
-	res, err := r.coll().DeleteOne(ctx, bson.M{"_id": reminderID, "user_id": userID})
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return err
+	}
+	res, err := r.coll().DeleteOne(ctx, Scoped(scope, bson.M{"_id": reminderID}))
 	if err != nil {
 		return err
 	}
 	if res.DeletedCount == 0 {
-		return errors.New("reminder not found")
+		return r.denied(ctx, userID, reminderID)
 	}
 	return nil
 }
@@ -152,15 +222,19 @@ func (r *mongoReminderRepo) ListPagedWithEvent(ctx context.Context, userID primi
 	if pageSize < 1 || pageSize > 100 {
 		pageSize = 20
 	}
-	match := bson.M{"user_id": userID}
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	match := bson.M{}
 	if activeOnly {
 		match["is_active"] = true
 	}
 	pipeline := []bson.M{
-		{"$match": match},
+		{"$match": Scoped(scope, match)},
 		{"$lookup": bson.M{"from": "events", "localField": "event_id", "foreignField": "_id", "as": "event"}},
 		{"$unwind": "$event"},
-		{"$project": bson.M{"_id": 1, "event_id": 1, "user_id": 1, "advance_days": 1, "reminder_times": 1, "reminder_type": 1, "custom_message": 1, "is_active": 1, "last_sent": 1, "next_send": 1, "created_at": 1, "updated_at": 1, "event": "$event"}},
+		{"$project": bson.M{"_id": 1, "event_id": 1, "user_id": 1, "workspace_id": 1, "advance_days": 1, "reminder_times": 1, "reminder_type": 1, "custom_message": 1, "is_active": 1, "last_sent": 1, "next_send": 1, "created_at": 1, "updated_at": 1, "event": "$event"}},
 	}
 	// count
 	countPipe := append(append([]bson.M{}, pipeline[:1]...), bson.M{"$count": "total"})
@@ -195,16 +269,42 @@ func (r *mongoReminderRepo) ListPagedWithEvent(ctx context.Context, userID primi
 	return &models.ReminderListResponse{Reminders: list, Total: total, Page: page, PageSize: pageSize, TotalPages: pages}, nil
 }
 
+// ListByUser 返回用户的全部提醒 (不关联事件)
+func (r *mongoReminderRepo) ListByUser(ctx context.Context, userID primitive.ObjectID, activeOnly bool) ([]models.Reminder, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{}
+	if activeOnly {
+		filter["is_active"] = true
+	}
+	cur, err := r.coll().Find(ctx, Scoped(scope, filter))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.Reminder{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
 func (r *mongoReminderRepo) ListSimple(ctx context.Context, userID primitive.ObjectID, activeOnly bool, limit int) ([]SimpleReminderDTO, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 50
 	}
-	match := bson.M{"user_id": userID}
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	match := bson.M{}
 	if activeOnly {
 		match["is_active"] = true
 	}
 	pipeline := []bson.M{
-		{"$match": match},
+		{"$match": Scoped(scope, match)},
 		{"$lookup": bson.M{"from": "events", "localField": "event_id", "foreignField": "_id", "as": "event"}},
 		{"$unwind": "$event"},
 		{"$project": bson.M{"_id": 1, "event_id": 1, "event_title": "$event.title", "event_date": "$event.event_date", "advance_days": 1, "reminder_times": 1, "reminder_type": 1, "custom_message": 1, "is_active": 1, "next_send": 1, "last_sent": 1, "created_at": 1, "updated_at": 1}},
@@ -229,7 +329,11 @@ func (r *mongoReminderRepo) Upcoming(ctx context.Context, userID primitive.Objec
 	}
 	now := time.Now()
 	end := now.Add(time.Duration(hours) * time.Hour)
-	pipeline := []bson.M{{"$match": bson.M{"user_id": userID, "is_active": true, "next_send": bson.M{"$gte": now, "$lte": end}}}, {"$lookup": bson.M{"from": "events", "localField": "event_id", "foreignField": "_id", "as": "event"}}, {"$unwind": "$event"}, {"$project": bson.M{"_id": 1, "custom_message": 1, "event_title": "$event.title", "event_date": "$event.event_date", "importance_level": "$event.importance_level", "next_send": 1}}, {"$sort": bson.M{"next_send": 1}}}
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := []bson.M{{"$match": Scoped(scope, bson.M{"is_active": true, "next_send": bson.M{"$gte": now, "$lte": end}})}, {"$lookup": bson.M{"from": "events", "localField": "event_id", "foreignField": "_id", "as": "event"}}, {"$unwind": "$event"}, {"$project": bson.M{"_id": 1, "custom_message": 1, "event_title": "$event.title", "event_date": "$event.event_date", "importance_level": "$event.importance_level", "next_send": 1}}, {"$sort": bson.M{"next_send": 1}}}
 	cur, err := r.coll().Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, err
@@ -320,15 +424,19 @@ func (r *mongoReminderRepo) Pending(ctx context.Context) ([]models.ReminderWithE
 }
 
 func (r *mongoReminderRepo) ToggleActive(ctx context.Context, userID, reminderID primitive.ObjectID) (bool, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return false, err
+	}
 	var rm models.Reminder
-	if err := r.coll().FindOne(ctx, bson.M{"_id": reminderID, "user_id": userID}).Decode(&rm); err != nil {
+	if err := r.coll().FindOne(ctx, Scoped(scope, bson.M{"_id": reminderID})).Decode(&rm); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return false, errors.New("reminder not found")
+			return false, r.denied(ctx, userID, reminderID)
 		}
 		return false, err
 	}
 	newVal := !rm.IsActive
-	_, err := r.coll().UpdateOne(ctx, bson.M{"_id": rm.ID}, bson.M{"$set": bson.M{"is_active": newVal, "updated_at": time.Now()}})
+	_, err = r.coll().UpdateOne(ctx, bson.M{"_id": rm.ID}, bson.M{"$set": bson.M{"is_active": newVal, "updated_at": time.Now()}})
 	return newVal, err
 }
 
@@ -336,12 +444,16 @@ func (r *mongoReminderRepo) Snooze(ctx context.Context, userID, reminderID primi
 	if minutes <= 0 {
 		minutes = 60
 	}
-	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": reminderID, "user_id": userID}, bson.M{"$set": bson.M{"next_send": time.Now().Add(time.Duration(minutes) * time.Minute), "updated_at": time.Now()}})
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return err
+	}
+	res, err := r.coll().UpdateOne(ctx, Scoped(scope, bson.M{"_id": reminderID}), bson.M{"$set": bson.M{"next_send": time.Now().Add(time.Duration(minutes) * time.Minute), "updated_at": time.Now()}})
 	if err != nil {
 		return err
 	}
 	if res.MatchedCount == 0 {
-		return errors.New("reminder not found")
+		return r.denied(ctx, userID, reminderID)
 	}
 	return nil
 }
@@ -352,7 +464,7 @@ func (r *mongoReminderRepo) MarkSent(ctx context.Context, reminderID primitive.O
 		return err
 	}
 	var ev models.Event
-	if err := r.events().FindOne(ctx, bson.M{"_id": rm.EventID}).Decode(&ev); err != nil {
+	if err := r.findEvent(ctx, bson.M{"_id": rm.EventID}, &ev); err != nil {
 		return err
 	}
 	now := time.Now()
@@ -361,6 +473,162 @@ func (r *mongoReminderRepo) MarkSent(ctx context.Context, reminderID primitive.O
 	return err
 }
 
+// ErrLeaseLost 租约已过期并被其他实例接管
+var ErrLeaseLost = errors.New("reminder lease lost")
+
+func (r *mongoReminderRepo) ClaimDue(ctx context.Context, owner string, lease time.Duration) (*models.ReminderClaim, error) {
+	for {
+		now := time.Now()
+		until := now.Add(lease)
+		token := primitive.NewObjectID().Hex()
+		filter := bson.M{
+			"is_active": true,
+			"next_send": bson.M{"$lte": now},
+			"$or":       []bson.M{{"lease_until": nil}, {"lease_until": bson.M{"$lte": now}}},
+		}
+		update := bson.M{"$set": bson.M{"lease_owner": owner, "lease_token": token, "lease_until": until}}
+		// 返回领取前的文档，以便识别接管的过期租约
+		opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_send", Value: 1}}).SetReturnDocument(options.Before)
+		var rm models.Reminder
+		if err := r.coll().FindOneAndUpdate(ctx, filter, update, opts).Decode(&rm); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return nil, nil
+			}
+			return nil, err
+		}
+		claim := &models.ReminderClaim{Token: token, RecoveredFrom: rm.LeaseOwner}
+		rm.LeaseOwner, rm.LeaseToken, rm.LeaseUntil = owner, token, &until
+		claim.Reminder = rm
+		err := r.findEvent(ctx, bson.M{"_id": rm.EventID}, &claim.Event)
+		if err != nil && err != mongo.ErrNoDocuments {
+			_ = r.releaseLease(ctx, rm.ID, token)
+			return nil, err
+		}
+		visible := err == nil
+		if visible {
+			if visible, err = r.eventVisible(ctx, &claim.Event, rm.UserID); err != nil {
+				_ = r.releaseLease(ctx, rm.ID, token)
+				return nil, err
+			}
+		}
+		if !visible {
+			// 事件已删除或提醒所有者已看不到事件 (退出工作区 / 工作区删除)：停用提醒，继续领取下一条
+			_, _ = r.coll().UpdateOne(ctx, bson.M{"_id": rm.ID, "lease_token": token}, bson.M{
+				"$set":   bson.M{"is_active": false, "updated_at": now},
+				"$unset": bson.M{"lease_owner": "", "lease_token": "", "lease_until": ""},
+			})
+			continue
+		}
+		return claim, nil
+	}
+}
+
+// eventVisible 用户能否看到事件：个人事件仅创建者，工作区事件需为成员
+func (r *mongoReminderRepo) eventVisible(ctx context.Context, ev *models.Event, userID primitive.ObjectID) (bool, error) {
+	if ev.WorkspaceID == nil {
+		return ev.UserID == userID, nil
+	}
+	switch err := RequireWorkspaceRole(ctx, r.db, *ev.WorkspaceID, userID, models.WorkspaceRoleViewer); {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrWorkspaceNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (r *mongoReminderRepo) RenewClaim(ctx context.Context, reminderID primitive.ObjectID, token string, until time.Time) error {
+	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": reminderID, "lease_token": token}, bson.M{"$set": bson.M{"lease_until": until}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *mongoReminderRepo) CompleteClaim(ctx context.Context, reminderID primitive.ObjectID, token string) error {
+	return r.finishClaim(ctx, reminderID, token, bson.M{"last_sent": time.Now()}, "last_error")
+}
+
+func (r *mongoReminderRepo) DeadLetterClaim(ctx context.Context, reminderID primitive.ObjectID, token string, lastErr string) error {
+	return r.finishClaim(ctx, reminderID, token, bson.M{"dead_letter_at": time.Now(), "last_error": lastErr})
+}
+
+// finishClaim 按租约令牌推进 next_send、重置重试状态并释放租约；unset 为额外清除的字段
+func (r *mongoReminderRepo) finishClaim(ctx context.Context, reminderID primitive.ObjectID, token string, set bson.M, unset ...string) error {
+	var rm models.Reminder
+	if err := r.coll().FindOne(ctx, bson.M{"_id": reminderID, "lease_token": token}).Decode(&rm); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return ErrLeaseLost
+		}
+		return err
+	}
+	var ev models.Event
+	if err := r.findEvent(ctx, bson.M{"_id": rm.EventID}, &ev); err != nil {
+		return err
+	}
+	set["next_send"] = rm.CalculateNextSendTime(ev)
+	set["updated_at"] = time.Now()
+	uns := bson.M{"lease_owner": "", "lease_token": "", "lease_until": "", "delivery_attempts": "", "retry_channels": "", "delivered_endpoints": ""}
+	for _, k := range unset {
+		uns[k] = ""
+	}
+	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": reminderID, "lease_token": token}, bson.M{"$set": set, "$unset": uns})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *mongoReminderRepo) RetryClaim(ctx context.Context, reminderID primitive.ObjectID, token string, retry models.ReminderRetry) error {
+	// lease_until 保留为重试时间，避免在退避期内被再次领取
+	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": reminderID, "lease_token": token}, bson.M{
+		"$set": bson.M{
+			"lease_until":         retry.RetryAt,
+			"delivery_attempts":   retry.Attempts,
+			"retry_channels":      retry.Channels,
+			"delivered_endpoints": retry.Endpoints,
+			"last_error":          retry.Error,
+			"updated_at":          time.Now(),
+		},
+		"$unset": bson.M{"lease_owner": "", "lease_token": ""},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *mongoReminderRepo) DeferClaim(ctx context.Context, reminderID primitive.ObjectID, token string, until time.Time) error {
+	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": reminderID, "lease_token": token}, bson.M{
+		"$set":   bson.M{"lease_until": until, "updated_at": time.Now()},
+		"$unset": bson.M{"lease_owner": "", "lease_token": ""},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *mongoReminderRepo) releaseLease(ctx context.Context, reminderID primitive.ObjectID, token string) error {
+	_, err := r.coll().UpdateOne(ctx, bson.M{"_id": reminderID, "lease_token": token}, bson.M{
+		"$unset": bson.M{"lease_owner": "", "lease_token": "", "lease_until": ""},
+	})
+	return err
+}
+
 func (r *mongoReminderRepo) CreateImmediateTest(ctx context.Context, userID, eventID primitive.ObjectID, message string, delaySeconds int) (*models.Reminder, *models.Event, error) {
 	if delaySeconds < 0 {
 		delaySeconds = 0
@@ -368,15 +636,24 @@ func (r *mongoReminderRepo) CreateImmediateTest(ctx context.Context, userID, eve
 	if delaySeconds > 3600 {
 		delaySeconds = 3600
 	}
+	filter, err := r.eventScope(ctx, userID, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
 	var ev models.Event
-	if err := r.events().FindOne(ctx, bson.M{"_id": eventID, "user_id": userID}).Decode(&ev); err != nil {
+	if err := r.findEvent(ctx, filter, &ev); err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil, errors.New("event not found")
 		}
 		return nil, nil, err
 	}
+	if ev.WorkspaceID != nil {
+		if err := RequireWorkspaceRole(ctx, r.db, *ev.WorkspaceID, userID, models.WorkspaceRoleEditor); err != nil {
+			return nil, nil, err
+		}
+	}
 	now := time.Now()
-	rm := &models.Reminder{ID: primitive.NewObjectID(), EventID: eventID, UserID: userID, AdvanceDays: 0, ReminderTimes: []string{now.Add(time.Duration(delaySeconds) * time.Second).Format("15:04")}, ReminderType: "app", CustomMessage: message, IsActive: true, CreatedAt: now, UpdatedAt: now}
+	rm := &models.Reminder{ID: primitive.NewObjectID(), EventID: eventID, UserID: userID, WorkspaceID: ev.WorkspaceID, AdvanceDays: 0, ReminderTimes: []string{now.Add(time.Duration(delaySeconds) * time.Second).Format("15:04")}, ReminderType: models.ReminderChannels{"app"}, CustomMessage: message, IsActive: true, CreatedAt: now, UpdatedAt: now}
 	ns := now.Add(time.Duration(delaySeconds) * time.Second)
 	rm.NextSend = &ns
 	if _, err := r.coll().InsertOne(ctx, rm); err != nil {
@@ -412,15 +689,18 @@ func BuildPreview(event *models.Event, eventFound bool, advanceDays int, times [
 
 // 预览计算（供 service 使用）
 func (r *mongoReminderRepo) computePreview(ctx context.Context, userID, eventID primitive.ObjectID, advanceDays int, times []string) (*models.Event, *time.Time, error) {
-	var ev models.Event
-	err := r.events().FindOne(ctx, bson.M{"_id": eventID, "user_id": userID}).Decode(&ev)
+	filter, err := r.eventScope(ctx, userID, eventID)
 	if err != nil {
+		return nil, nil, err
+	}
+	var ev models.Event
+	if err := r.findEvent(ctx, filter, &ev); err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil, nil
 		}
 		return nil, nil, err
 	}
-	temp := models.Reminder{EventID: eventID, AdvanceDays: advanceDays, ReminderTimes: times, ReminderType: "app", IsActive: true}
+	temp := models.Reminder{EventID: eventID, AdvanceDays: advanceDays, ReminderTimes: times, ReminderType: models.ReminderChannels{"app"}, IsActive: true}
 	return &ev, temp.CalculateNextSendTime(ev), nil
 }
 
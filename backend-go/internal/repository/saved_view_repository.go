@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SavedViewRepository 用户保存的任务视图仓储，所有操作限定在 userID 名下
+type SavedViewRepository interface {
+	Insert(ctx context.Context, v *models.SavedView) error
+	List(ctx context.Context, userID primitive.ObjectID) ([]models.SavedView, error)
+	Get(ctx context.Context, userID, id primitive.ObjectID) (*models.SavedView, error)
+	// FindByName 不存在时返回 nil, nil
+	FindByName(ctx context.Context, userID primitive.ObjectID, name string) (*models.SavedView, error)
+	Update(ctx context.Context, v *models.SavedView) error
+	Delete(ctx context.Context, userID, id primitive.ObjectID) error
+}
+
+// ErrSavedViewNotFound 视图不存在或不属于当前用户
+var ErrSavedViewNotFound = errors.New("saved view not found")
+
+type mongoSavedViewRepo struct{ db *mongo.Database }
+
+func NewSavedViewRepository(db *mongo.Database) SavedViewRepository {
+	return &mongoSavedViewRepo{db: db}
+}
+
+func (r *mongoSavedViewRepo) coll() *mongo.Collection { return r.db.Collection("saved_views") }
+
+func (r *mongoSavedViewRepo) Insert(ctx context.Context, v *models.SavedView) error {
+	if v == nil {
+		return errors.New("nil saved view")
+	}
+	if v.ID.IsZero() {
+		v.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	v.CreatedAt, v.UpdatedAt = now, now
+	_, err := r.coll().InsertOne(ctx, v)
+	return err
+}
+
+func (r *mongoSavedViewRepo) List(ctx context.Context, userID primitive.ObjectID) ([]models.SavedView, error) {
+	cur, err := r.coll().Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.SavedView{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoSavedViewRepo) Get(ctx context.Context, userID, id primitive.ObjectID) (*models.SavedView, error) {
+	var v models.SavedView
+	if err := r.coll().FindOne(ctx, bson.M{"_id": id, "user_id": userID}).Decode(&v); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrSavedViewNotFound
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *mongoSavedViewRepo) FindByName(ctx context.Context, userID primitive.ObjectID, name string) (*models.SavedView, error) {
+	var v models.SavedView
+	if err := r.coll().FindOne(ctx, bson.M{"user_id": userID, "name": name}).Decode(&v); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *mongoSavedViewRepo) Update(ctx context.Context, v *models.SavedView) error {
+	if v == nil {
+		return errors.New("nil saved view")
+	}
+	v.UpdatedAt = time.Now()
+	set := bson.M{"name": v.Name, "query": v.Query, "sort": v.Sort, "updated_at": v.UpdatedAt}
+	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": v.ID, "user_id": v.UserID}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrSavedViewNotFound
+	}
+	return nil
+}
+
+func (r *mongoSavedViewRepo) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	res, err := r.coll().DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrSavedViewNotFound
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SearchRepository 搜索候选集 (排序与片段由服务层完成)。
+// 有关键词时优先走文本索引 ($text，按 textScore 取前 limit 条)；文本索引缺失或无命中
+// (如中文短语未按空格分词) 时退化为不区分大小写的正则匹配。
+type SearchRepository interface {
+	Tasks(ctx context.Context, userID string, q models.SearchQuery, limit int) ([]models.Task, error)
+	Events(ctx context.Context, userID primitive.ObjectID, q models.SearchQuery, limit int) ([]models.Event, error)
+	// Comments 事件评论及其所属事件 (只返回用户可见事件下的评论)
+	Comments(ctx context.Context, userID primitive.ObjectID, q models.SearchQuery, limit int) ([]models.EventComment, map[primitive.ObjectID]models.Event, error)
+}
+
+// 文本索引名称 (每个集合只能有一个文本索引)
+const (
+	taskSearchIndex    = "tasks_search"
+	eventSearchIndex   = "events_search"
+	commentSearchIndex = "event_comments_search"
+)
+
+// 各集合参与搜索的字段
+var (
+	taskSearchFields    = []string{"title", "description", "comments.text", "tags"}
+	eventSearchFields   = []string{"title", "description", "location", "tags"}
+	commentSearchFields = []string{"content"}
+)
+
+type mongoSearchRepo struct{ db *mongo.Database }
+
+func NewSearchRepository(db *mongo.Database) SearchRepository { return &mongoSearchRepo{db: db} }
+
+// EnsureSearchIndexes 创建搜索用文本索引；language 为 none 不做词干与停用词处理，便于中英文混排。
+// 同名索引的字段或权重变化时 (IndexOptionsConflict / IndexKeySpecsConflict) 删除旧索引后重建
+func EnsureSearchIndexes(ctx context.Context, db *mongo.Database) error {
+	specs := []struct {
+		coll, name string
+		weights    bson.D
+	}{
+		{"tasks", taskSearchIndex, bson.D{{Key: "title", Value: 10}, {Key: "tags", Value: 5}, {Key: "description", Value: 3}, {Key: "comments.text", Value: 1}}},
+		{"events", eventSearchIndex, bson.D{{Key: "title", Value: 10}, {Key: "tags", Value: 5}, {Key: "location", Value: 3}, {Key: "description", Value: 3}}},
+		{"event_comments", commentSearchIndex, bson.D{{Key: "content", Value: 1}}},
+	}
+	for _, s := range specs {
+		keys := bson.D{}
+		for _, w := range s.weights {
+			keys = append(keys, bson.E{Key: w.Key, Value: "text"})
+		}
+		model := mongo.IndexModel{
+			Keys:    keys,
+			Options: options.Index().SetName(s.name).SetWeights(s.weights).SetDefaultLanguage("none"),
+		}
+		indexes := db.Collection(s.coll).Indexes()
+		_, err := indexes.CreateOne(ctx, model)
+		var se mongo.ServerError
+		if errors.As(err, &se) && (se.HasErrorCode(85) || se.HasErrorCode(86)) {
+			if _, err = indexes.DropOne(ctx, s.name); err == nil {
+				_, err = indexes.CreateOne(ctx, model)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mongoSearchRepo) Tasks(ctx context.Context, userID string, q models.SearchQuery, limit int) ([]models.Task, error) {
+	scope, err := TaskAccessFilter(ctx, r.db, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{}
+	if len(q.Status) > 0 {
+		var in []string
+		for _, s := range q.Status {
+			in = append(in, models.TaskStatusVariants(s)...)
+		}
+		filter["status"] = bson.M{"$in": in}
+	}
+	if len(q.Priority) > 0 {
+		filter["priority"] = bson.M{"$in": q.Priority}
+	}
+	if len(q.Tags) > 0 {
+		filter["tags"] = bson.M{"$all": q.Tags}
+	}
+	if rng := dateRange(q); rng != nil {
+		filter["$or"] = []bson.M{{"deadline": rng}, {"scheduledDate": rng}}
+	}
+	raws, err := r.find(ctx, r.db.Collection("tasks"), Scoped(scope, filter), taskSearchFields, q.Text, "createdAt", limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]models.Task, 0, len(raws))
+	for _, raw := range raws {
+		var t models.Task
+		if bson.Unmarshal(raw, &t) == nil {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (r *mongoSearchRepo) Events(ctx context.Context, userID primitive.ObjectID, q models.SearchQuery, limit int) ([]models.Event, error) {
+	scope, err := ownerAccess.filter(ctx, r.db, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{"is_active": true}
+	if len(q.Tags) > 0 {
+		filter["tags"] = bson.M{"$all": q.Tags}
+	}
+	if rng := dateRange(q); rng != nil {
+		filter["event_date"] = rng
+	}
+	raws, err := r.find(ctx, r.db.Collection("events"), Scoped(scope, filter), eventSearchFields, q.Text, "event_date", limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]models.Event, 0, len(raws))
+	for _, raw := range raws {
+		var e models.Event
+		if bson.Unmarshal(raw, &e) == nil {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *mongoSearchRepo) Comments(ctx context.Context, userID primitive.ObjectID, q models.SearchQuery, limit int) ([]models.EventComment, map[primitive.ObjectID]models.Event, error) {
+	// 评论本身不带归属：先取可见事件，再在搜索条件中限定 event_id
+	scope, err := ownerAccess.filter(ctx, r.db, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	visible, err := r.db.Collection("events").Distinct(ctx, "_id", Scoped(scope, bson.M{"is_active": true}))
+	if err != nil {
+		return nil, nil, err
+	}
+	comments := []models.EventComment{}
+	events := map[primitive.ObjectID]models.Event{}
+	if len(visible) == 0 {
+		return comments, events, nil
+	}
+	filter := bson.M{"event_id": bson.M{"$in": visible}}
+	if rng := dateRange(q); rng != nil {
+		filter["created_at"] = rng
+	}
+	raws, err := r.find(ctx, r.db.Collection("event_comments"), filter, commentSearchFields, q.Text, "created_at", limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(raws))
+	for _, raw := range raws {
+		var c models.EventComment
+		if bson.Unmarshal(raw, &c) == nil {
+			comments = append(comments, c)
+			ids = append(ids, c.EventID)
+		}
+	}
+	if len(ids) == 0 {
+		return comments, events, nil
+	}
+	cur, err := r.db.Collection("events").Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		var e models.Event
+		if cur.Decode(&e) == nil {
+			events[e.ID] = e
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return nil, nil, err
+	}
+	return comments, events, nil
+}
+
+// find 按关键词查找候选：先用文本索引，缺失 (IndexNotFound) 或无结果时退化为正则；无关键词时按 dateField 倒序
+func (r *mongoSearchRepo) find(ctx context.Context, coll *mongo.Collection, filter bson.M, fields []string, text string, dateField string, limit int) ([]bson.Raw, error) {
+	text = strings.TrimSpace(text)
+	if text != "" {
+		textFilter := bson.M{"$text": bson.M{"$search": text}}
+		for k, v := range filter {
+			textFilter[k] = v
+		}
+		score := bson.M{"score": bson.M{"$meta": "textScore"}}
+		raws, err := r.all(ctx, coll, textFilter, options.Find().SetProjection(score).SetSort(score).SetLimit(int64(limit)))
+		var se mongo.ServerError
+		switch {
+		case errors.As(err, &se) && se.HasErrorCode(27): // 未建文本索引
+		case err != nil:
+			return nil, err
+		case len(raws) > 0:
+			return raws, nil
+		}
+		filter = Scoped(filter, regexFilter(fields, strings.Fields(text)))
+	}
+	return r.all(ctx, coll, filter, options.Find().SetSort(bson.D{{Key: dateField, Value: -1}}).SetLimit(int64(limit)))
+}
+
+func (r *mongoSearchRepo) all(ctx context.Context, coll *mongo.Collection, filter bson.M, opts *options.FindOptions) ([]bson.Raw, error) {
+	cur, err := coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var out []bson.Raw
+	for cur.Next(ctx) {
+		out = append(out, append(bson.Raw(nil), cur.Current...))
+	}
+	return out, cur.Err()
+}
+
+// regexFilter 任一字段包含任一关键词 (不区分大小写)
+func regexFilter(fields, terms []string) bson.M {
+	or := make([]bson.M, 0, len(fields)*len(terms))
+	for _, t := range terms {
+		re := primitive.Regex{Pattern: regexp.QuoteMeta(t), Options: "i"}
+		for _, f := range fields {
+			or = append(or, bson.M{f: re})
+		}
+	}
+	return bson.M{"$or": or}
+}
+
+// dateRange 搜索日期范围条件，未设置时返回 nil
+func dateRange(q models.SearchQuery) bson.M {
+	if q.From == nil && q.To == nil {
+		return nil
+	}
+	rng := bson.M{}
+	if q.From != nil {
+		rng["$gte"] = *q.From
+	}
+	if q.To != nil {
+		rng["$lte"] = *q.To
+	}
+	return rng
+}
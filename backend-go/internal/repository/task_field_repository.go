@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TaskFieldRepository 任务自定义字段定义仓储。
+// 定义归属于用户 (个人任务) 或工作区 (工作区任务)：workspaceID 非零时按工作区，否则按 userID
+type TaskFieldRepository interface {
+	Insert(ctx context.Context, f *models.TaskFieldDefinition) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.TaskFieldDefinition, error)
+	List(ctx context.Context, userID, workspaceID primitive.ObjectID) ([]models.TaskFieldDefinition, error)
+	// FindByKey 不存在时返回 nil, nil
+	FindByKey(ctx context.Context, userID, workspaceID primitive.ObjectID, key string) (*models.TaskFieldDefinition, error)
+	Update(ctx context.Context, f *models.TaskFieldDefinition) error
+	// Delete 删除定义，并清除所属范围内任务上该字段的取值
+	Delete(ctx context.Context, f *models.TaskFieldDefinition) error
+}
+
+// ErrTaskFieldNotFound 字段定义不存在
+var ErrTaskFieldNotFound = errors.New("task field not found")
+
+type mongoTaskFieldRepo struct{ db *mongo.Database }
+
+func NewTaskFieldRepository(db *mongo.Database) TaskFieldRepository {
+	return &mongoTaskFieldRepo{db: db}
+}
+
+func (r *mongoTaskFieldRepo) coll() *mongo.Collection {
+	return r.db.Collection("task_field_definitions")
+}
+
+// fieldOwner 定义所属范围条件
+func fieldOwner(userID, workspaceID primitive.ObjectID) bson.M {
+	if !workspaceID.IsZero() {
+		return bson.M{"workspace_id": workspaceID}
+	}
+	return bson.M{"user_id": userID, "workspace_id": bson.M{"$exists": false}}
+}
+
+func (r *mongoTaskFieldRepo) Insert(ctx context.Context, f *models.TaskFieldDefinition) error {
+	if f == nil {
+		return errors.New("nil task field")
+	}
+	if f.ID.IsZero() {
+		f.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	f.CreatedAt, f.UpdatedAt = now, now
+	_, err := r.coll().InsertOne(ctx, f)
+	return err
+}
+
+func (r *mongoTaskFieldRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*models.TaskFieldDefinition, error) {
+	var f models.TaskFieldDefinition
+	if err := r.coll().FindOne(ctx, bson.M{"_id": id}).Decode(&f); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrTaskFieldNotFound
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *mongoTaskFieldRepo) List(ctx context.Context, userID, workspaceID primitive.ObjectID) ([]models.TaskFieldDefinition, error) {
+	cur, err := r.coll().Find(ctx, fieldOwner(userID, workspaceID), options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.TaskFieldDefinition{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoTaskFieldRepo) FindByKey(ctx context.Context, userID, workspaceID primitive.ObjectID, key string) (*models.TaskFieldDefinition, error) {
+	filter := fieldOwner(userID, workspaceID)
+	filter["key"] = key
+	var f models.TaskFieldDefinition
+	if err := r.coll().FindOne(ctx, filter).Decode(&f); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *mongoTaskFieldRepo) Update(ctx context.Context, f *models.TaskFieldDefinition) error {
+	if f == nil {
+		return errors.New("nil task field")
+	}
+	f.UpdatedAt = time.Now()
+	set := bson.M{"name": f.Name, "options": f.Options, "updated_at": f.UpdatedAt}
+	res, err := r.coll().UpdateByID(ctx, f.ID, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrTaskFieldNotFound
+	}
+	return nil
+}
+
+func (r *mongoTaskFieldRepo) Delete(ctx context.Context, f *models.TaskFieldDefinition) error {
+	res, err := r.coll().DeleteOne(ctx, bson.M{"_id": f.ID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrTaskFieldNotFound
+	}
+	tasks := bson.M{"workspaceId": f.WorkspaceID.Hex()}
+	if f.WorkspaceID.IsZero() {
+		tasks = bson.M{"createdBy": f.UserID.Hex(), "$or": []bson.M{{"workspaceId": bson.M{"$exists": false}}, {"workspaceId": ""}}}
+	}
+	_, err = r.db.Collection("tasks").UpdateMany(ctx, tasks, bson.M{"$unset": bson.M{"customFields." + f.Key: ""}})
+	return err
+}
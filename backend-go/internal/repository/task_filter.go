@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// taskFilterFields 过滤 / 排序字段对应的任务文档字段
+var taskFilterFields = map[string]string{
+	models.FilterFieldStatus:    "status",
+	models.FilterFieldPriority:  "priority",
+	models.FilterFieldAssignee:  "assignee",
+	models.FilterFieldCreator:   "createdBy",
+	models.FilterFieldDue:       "deadline",
+	models.FilterFieldScheduled: "scheduledDate",
+	models.FilterFieldCreated:   "createdAt",
+	models.FilterFieldUpdated:   "updatedAt",
+	models.FilterFieldTag:       "tags",
+	models.FilterFieldBlocked:   "blocked",
+	models.FilterFieldRecurring: "recurrence",
+	models.FilterFieldParent:    "parentId",
+	models.FilterFieldWorkspace: "workspaceId",
+	"title":                     "title",
+}
+
+// TaskFilterBSON 将过滤表达式转换为 Mongo 条件；me 解析为 userID，日期按 loc 时区与 now 计算
+func TaskFilterBSON(f models.TaskFilter, userID string, now time.Time, loc *time.Location) (bson.M, error) {
+	conds := make([]bson.M, 0, len(f.Terms))
+	for _, t := range f.Terms {
+		c, err := taskTermBSON(t, userID, now, loc)
+		if err != nil {
+			return nil, err
+		}
+		if t.Negate {
+			c = bson.M{"$nor": []bson.M{c}}
+		}
+		conds = append(conds, c)
+	}
+	switch len(conds) {
+	case 0:
+		return bson.M{}, nil
+	case 1:
+		return conds[0], nil
+	}
+	return bson.M{"$and": conds}, nil
+}
+
+// taskFilterField 过滤 / 排序字段对应的文档字段，自定义字段为 customFields.<key>
+func taskFilterField(name string) string {
+	if key := strings.TrimPrefix(name, models.FilterFieldCustomPrefix); key != name {
+		return "customFields." + key
+	}
+	return taskFilterFields[name]
+}
+
+func taskTermBSON(t models.TaskFilterTerm, userID string, now time.Time, loc *time.Location) (bson.M, error) {
+	field := taskFilterField(t.Field)
+	if strings.HasPrefix(t.Field, models.FilterFieldCustomPrefix) {
+		return customTermBSON(field, t.Op, t.Values, now, loc)
+	}
+	switch t.Field {
+	case models.FilterFieldText:
+		var or []bson.M
+		for _, v := range t.Values {
+			re := primitive.Regex{Pattern: regexp.QuoteMeta(v), Options: "i"}
+			or = append(or, bson.M{"title": re}, bson.M{"description": re})
+		}
+		return bson.M{"$or": or}, nil
+	case models.FilterFieldStatus:
+		var in []string
+		for _, v := range t.Values {
+			in = append(in, models.TaskStatusVariants(v)...)
+		}
+		return bson.M{field: bson.M{"$in": in}}, nil
+	case models.FilterFieldDue, models.FilterFieldScheduled, models.FilterFieldCreated, models.FilterFieldUpdated:
+		return dateTermBSON(field, t.Op, t.Values[0], now, loc)
+	case models.FilterFieldBlocked:
+		if t.Values[0] == "true" {
+			return bson.M{field: true}, nil
+		}
+		return bson.M{field: bson.M{"$ne": true}}, nil
+	case models.FilterFieldRecurring:
+		empty := bson.M{"$or": []bson.M{{field: nil}, {field: ""}}}
+		if t.Values[0] == "true" {
+			return bson.M{"$nor": []bson.M{empty}}, nil
+		}
+		return empty, nil
+	}
+	var or []bson.M
+	var in []string
+	for _, v := range t.Values {
+		switch {
+		case v == models.FilterValueMe:
+			in = append(in, userID)
+		case v == models.FilterValueNone:
+			or = append(or, bson.M{field: nil}, bson.M{field: ""})
+		default:
+			in = append(in, v)
+		}
+	}
+	if len(in) > 0 {
+		or = append(or, bson.M{field: bson.M{"$in": in}})
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return bson.M{"$or": or}, nil
+}
+
+// customTermBSON 自定义字段条件：取值可解析为数字时按数字比较，可解析为日期时按日期比较，否则按文本；
+// 不带比较符时各取值为「或」，文本不区分大小写完整匹配
+func customTermBSON(field, op string, values []string, now time.Time, loc *time.Location) (bson.M, error) {
+	if values[0] == models.FilterValueNone {
+		return bson.M{field: nil}, nil
+	}
+	if op != "" && op != "=" {
+		v := values[0]
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return bson.M{field: bson.M{compareOps[op]: n}}, nil
+		}
+		if _, _, err := models.ResolveFilterDate(strings.ToLower(v), now, loc); err == nil {
+			return dateTermBSON(field, op, strings.ToLower(v), now, loc)
+		}
+		return bson.M{field: bson.M{compareOps[op]: v}}, nil
+	}
+	var or []bson.M
+	for _, v := range values {
+		or = append(or, bson.M{field: primitive.Regex{Pattern: "^" + regexp.QuoteMeta(v) + "$", Options: "i"}})
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			or = append(or, bson.M{field: n})
+		} else if c, err := dateTermBSON(field, "=", strings.ToLower(v), now, loc); err == nil {
+			or = append(or, c)
+		}
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return bson.M{"$or": or}, nil
+}
+
+var compareOps = map[string]string{"<": "$lt", "<=": "$lte", ">": "$gt", ">=": "$gte"}
+
+// dateTermBSON 日期比较：整天取值 (today / YYYY-MM-DD) 的 = 匹配当天，< 早于当天，<= 不晚于当天，> 晚于当天；
+// 相对时间为时间点，= 匹配该时间点所在的整天
+func dateTermBSON(field, op, v string, now time.Time, loc *time.Location) (bson.M, error) {
+	if v == models.FilterValueNone {
+		return bson.M{field: nil}, nil
+	}
+	start, end, err := models.ResolveFilterDate(v, now, loc)
+	if err != nil {
+		return nil, err
+	}
+	if start.Equal(end) && (op == "" || op == "=") {
+		if loc == nil {
+			loc = time.UTC
+		}
+		local := start.In(loc)
+		start = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		end = start.AddDate(0, 0, 1)
+	}
+	point := start.Equal(end)
+	switch op {
+	case "", "=":
+		return bson.M{field: bson.M{"$gte": start, "$lt": end}}, nil
+	case "<":
+		return bson.M{field: bson.M{"$lt": start}}, nil
+	case "<=":
+		if point {
+			return bson.M{field: bson.M{"$lte": start}}, nil
+		}
+		return bson.M{field: bson.M{"$lt": end}}, nil
+	case ">":
+		if point {
+			return bson.M{field: bson.M{"$gt": start}}, nil
+		}
+		return bson.M{field: bson.M{"$gte": end}}, nil
+	case ">=":
+		return bson.M{field: bson.M{"$gte": start}}, nil
+	}
+	return nil, fmt.Errorf("%w: bad operator %q", models.ErrInvalidTaskFilter, op)
+}
+
+// TaskListPipeline 按 sort 排序并分页 (limit 为 0 不分页)；未指定排序时按创建时间倒序。
+// 优先级按 High > Medium > Low、状态按 待办 < 进行中 < 完成 排序，日期与自定义字段为空的任务总在最后。
+func TaskListPipeline(filter bson.M, sort []models.TaskSortField, skip, limit int64) mongo.Pipeline {
+	if len(sort) == 0 {
+		sort = []models.TaskSortField{{Field: models.FilterFieldCreated, Desc: true}}
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filter}}}
+	added := bson.D{}
+	keys := bson.D{}
+	for _, s := range sort {
+		dir := 1
+		if s.Desc {
+			dir = -1
+		}
+		field := taskFilterField(s.Field)
+		switch s.Field {
+		case models.FilterFieldPriority:
+			added = append(added, bson.E{Key: "_priorityRank", Value: rankSwitch("$priority", map[string]int{"Low": 1, "Medium": 2, "High": 3})})
+			field = "_priorityRank"
+		case models.FilterFieldStatus:
+			added = append(added, bson.E{Key: "_statusRank", Value: rankSwitch("$status", map[string]int{"To Do": 1, "Todo": 1, "In Progress": 2, "InProgress": 2, "Done": 3})})
+			field = "_statusRank"
+		}
+		if nullsLast(s.Field) {
+			missing := "_missing_" + strings.ReplaceAll(s.Field, ".", "_")
+			added = append(added, bson.E{Key: missing, Value: bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$" + field, nil}}, nil}}, 1, 0}}})
+			keys = append(keys, bson.E{Key: missing, Value: 1})
+		}
+		keys = append(keys, bson.E{Key: field, Value: dir})
+	}
+	keys = append(keys, bson.E{Key: "_id", Value: -1})
+	if len(added) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: added}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: keys}})
+	if skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+	if len(added) > 0 {
+		unset := bson.A{}
+		for _, e := range added {
+			unset = append(unset, e.Key)
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$unset", Value: unset}})
+	}
+	return pipeline
+}
+
+// nullsLast 排序时空值排在最后的字段：日期与自定义字段
+func nullsLast(field string) bool {
+	switch field {
+	case models.FilterFieldDue, models.FilterFieldScheduled, models.FilterFieldCreated, models.FilterFieldUpdated:
+		return true
+	}
+	return strings.HasPrefix(field, models.FilterFieldCustomPrefix)
+}
+
+// rankSwitch 按取值映射排序权重，未知值为 0
+func rankSwitch(expr string, ranks map[string]int) bson.M {
+	branches := bson.A{}
+	for v, rank := range ranks {
+		branches = append(branches, bson.M{"case": bson.M{"$eq": bson.A{expr, v}}, "then": rank})
+	}
+	return bson.M{"$switch": bson.M{"branches": branches, "default": 0}}
+}
@@ -15,10 +15,18 @@ import (
 
 type TaskRepository interface {
 	Insert(ctx context.Context, t *models.Task) error
-	List(ctx context.Context, userID string, status string, page, limit int64) ([]models.Task, int64, error)
+	List(ctx context.Context, userID string, q models.TaskQuery) ([]models.Task, int64, error)
 	FindByID(ctx context.Context, userID, id string) (*models.Task, error)
 	UpdatePartial(ctx context.Context, userID, id string, set bson.M) (*models.Task, error)
 	Delete(ctx context.Context, userID, id string) error
+	ListChildren(ctx context.Context, userID, parentID string) ([]models.Task, error)
+	ListBlockedBy(ctx context.Context, userID, blockerID string) ([]models.Task, error)
+	ListSeries(ctx context.Context, userID, seriesID string) ([]models.Task, error)
+	// ClaimNext 原子占用循环任务的下一个实例：nextId 为空时写入 nextID，返回是否占用成功
+	ClaimNext(ctx context.Context, userID, id, nextID string) (bool, error)
+	// AddToSet / Pull 原子增删数组字段中的元素，返回更新后的任务
+	AddToSet(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error)
+	Pull(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error)
 }
 
 type mongoTaskRepo struct{ db *mongo.Database }
@@ -27,14 +35,85 @@ func NewTaskRepository(db *mongo.Database) TaskRepository { return &mongoTaskRep
 
 func (r *mongoTaskRepo) coll() *mongo.Collection { return r.db.Collection("tasks") }
 
+// scope 本人个人任务与角色不低于 minRole 的工作区任务
+func (r *mongoTaskRepo) scope(ctx context.Context, userID, minRole string) (bson.M, error) {
+	return TaskAccessFilter(ctx, r.db, userID, minRole)
+}
+
+// find 在访问范围内查询
+func (r *mongoTaskRepo) find(ctx context.Context, userID string, filter bson.M, opts ...*options.FindOptions) ([]models.Task, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := r.coll().Find(ctx, Scoped(scope, filter), opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var list []models.Task
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// TaskViewFilter 列表视图条件：分配给我 / 我创建的 / 未分配，全部视图返回空条件
+func TaskViewFilter(view, userID string) bson.M {
+	switch view {
+	case models.TaskViewAssignedToMe:
+		return bson.M{"assignee": userID}
+	case models.TaskViewCreatedByMe:
+		return bson.M{"createdBy": userID}
+	case models.TaskViewUnassigned:
+		return bson.M{"$or": []bson.M{{"assignee": nil}, {"assignee": ""}}}
+	}
+	return bson.M{}
+}
+
+func taskIDFilter(id string) bson.M {
+	filter := bson.M{"$or": []bson.M{{"_id": id}}}
+	if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+		filter["$or"] = append(filter["$or"].([]bson.M), bson.M{"_id": oid})
+	}
+	return filter
+}
+
 func (r *mongoTaskRepo) Insert(ctx context.Context, t *models.Task) error {
 	if t == nil {
 		return errors.New("nil task")
 	}
+	if t.WorkspaceID != "" {
+		wid, err := primitive.ObjectIDFromHex(t.WorkspaceID)
+		if err != nil {
+			return ErrWorkspaceNotFound
+		}
+		uid, _ := primitive.ObjectIDFromHex(t.CreatedBy)
+		if err := RequireWorkspaceRole(ctx, r.db, wid, uid, models.WorkspaceRoleEditor); err != nil {
+			return err
+		}
+	}
 	now := time.Now()
 	t.CreatedAt = now
 	t.UpdatedAt = now
-	res, err := r.coll().InsertOne(ctx, t)
+	var doc interface{} = t
+	if oid, err := primitive.ObjectIDFromHex(t.ID); err == nil { // 预分配的 ID (如循环任务的下一个实例) 以 ObjectID 保存
+		raw, err := bson.Marshal(t)
+		if err != nil {
+			return err
+		}
+		var d bson.D
+		if err := bson.Unmarshal(raw, &d); err != nil {
+			return err
+		}
+		for i := range d {
+			if d[i].Key == "_id" {
+				d[i].Value = oid
+			}
+		}
+		doc = d
+	}
+	res, err := r.coll().InsertOne(ctx, doc)
 	if err != nil {
 		return err
 	}
@@ -44,14 +123,33 @@ func (r *mongoTaskRepo) Insert(ctx context.Context, t *models.Task) error {
 	return nil
 }
 
-func (r *mongoTaskRepo) List(ctx context.Context, userID, status string, page, limit int64) ([]models.Task, int64, error) {
-	filter := bson.M{"createdBy": userID}
-	if status != "" {
-		filter["status"] = status
+func (r *mongoTaskRepo) List(ctx context.Context, userID string, q models.TaskQuery) ([]models.Task, int64, error) {
+	filter, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, 0, err
+	}
+	if q.Status != "" {
+		filter = Scoped(filter, bson.M{"status": q.Status})
+	}
+	if view := TaskViewFilter(q.View, userID); len(view) > 0 {
+		filter = Scoped(filter, view)
+	}
+	if len(q.Tags) > 0 {
+		filter = Scoped(filter, bson.M{"tags": bson.M{"$all": q.Tags}})
+	}
+	if len(q.Filter.Terms) > 0 {
+		match, err := TaskFilterBSON(q.Filter, userID, time.Now(), q.Loc)
+		if err != nil {
+			return nil, 0, err
+		}
+		filter = Scoped(filter, match)
+	}
+	total, err := r.coll().CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
 	}
-	page, limit = common.Normalize(page, limit, 200)
-	opts := options.Find().SetLimit(limit).SetSkip((page - 1) * limit)
-	cur, err := r.coll().Find(ctx, filter, opts)
+	page, limit := common.Normalize(q.Page, q.Limit, 200)
+	cur, err := r.coll().Aggregate(ctx, TaskListPipeline(filter, q.Sort, (page-1)*limit, limit))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -63,38 +161,92 @@ func (r *mongoTaskRepo) List(ctx context.Context, userID, status string, page, l
 			list = append(list, m)
 		}
 	}
-	return list, int64(len(list)), cur.Err()
+	return list, total, cur.Err()
 }
 
 func (r *mongoTaskRepo) FindByID(ctx context.Context, userID, id string) (*models.Task, error) {
-	filter := bson.M{"createdBy": userID, "$or": []bson.M{{"_id": id}}}
-	if oid, err := primitive.ObjectIDFromHex(id); err == nil {
-		filter["$or"] = append(filter["$or"].([]bson.M), bson.M{"_id": oid})
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
 	}
 	var m models.Task
-	if err := r.coll().FindOne(ctx, filter).Decode(&m); err != nil {
+	if err := r.coll().FindOne(ctx, Scoped(scope, taskIDFilter(id))).Decode(&m); err != nil {
 		return nil, err
 	}
 	return &m, nil
 }
 
+// UpdatePartial 工作区任务需要 editor 角色，只读成员返回 ErrWorkspaceForbidden
 func (r *mongoTaskRepo) UpdatePartial(ctx context.Context, userID, id string, set bson.M) (*models.Task, error) {
 	set["updatedAt"] = time.Now()
-	filter := bson.M{"createdBy": userID, "$or": []bson.M{{"_id": id}}}
-	if oid, err := primitive.ObjectIDFromHex(id); err == nil {
-		filter["$or"] = append(filter["$or"].([]bson.M), bson.M{"_id": oid})
+	return r.update(ctx, userID, id, bson.M{"$set": set})
+}
+
+func (r *mongoTaskRepo) AddToSet(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error) {
+	return r.update(ctx, userID, id, bson.M{"$addToSet": bson.M{field: value}, "$set": bson.M{"updatedAt": time.Now()}})
+}
+
+func (r *mongoTaskRepo) Pull(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error) {
+	return r.update(ctx, userID, id, bson.M{"$pull": bson.M{field: value}, "$set": bson.M{"updatedAt": time.Now()}})
+}
+
+// update 按可写范围更新单个任务并返回更新后的任务
+func (r *mongoTaskRepo) update(ctx context.Context, userID, id string, update bson.M) (*models.Task, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return nil, err
 	}
-	if _, err := r.coll().UpdateOne(ctx, filter, bson.M{"$set": set}); err != nil {
+	res, err := r.coll().UpdateOne(ctx, Scoped(scope, taskIDFilter(id)), update)
+	if err != nil {
 		return nil, err
 	}
+	if res.MatchedCount == 0 {
+		return nil, TaskAccessDenied(ctx, r.db, userID, taskIDFilter(id))
+	}
 	return r.FindByID(ctx, userID, id)
 }
 
 func (r *mongoTaskRepo) Delete(ctx context.Context, userID, id string) error {
-	filter := bson.M{"createdBy": userID, "$or": []bson.M{{"_id": id}}}
-	if oid, err := primitive.ObjectIDFromHex(id); err == nil {
-		filter["$or"] = append(filter["$or"].([]bson.M), bson.M{"_id": oid})
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return err
+	}
+	res, err := r.coll().DeleteOne(ctx, Scoped(scope, taskIDFilter(id)))
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		if err := TaskAccessDenied(ctx, r.db, userID, taskIDFilter(id)); errors.Is(err, ErrWorkspaceForbidden) {
+			return err
+		}
 	}
-	_, err := r.coll().DeleteOne(ctx, filter)
-	return err
+	return nil
+}
+
+func (r *mongoTaskRepo) ClaimNext(ctx context.Context, userID, id, nextID string) (bool, error) {
+	scope, err := r.scope(ctx, userID, models.WorkspaceRoleEditor)
+	if err != nil {
+		return false, err
+	}
+	filter := Scoped(scope, bson.M{"$and": []bson.M{taskIDFilter(id), {"nextId": bson.M{"$in": []interface{}{nil, ""}}}}})
+	res, err := r.coll().UpdateOne(ctx, filter, bson.M{"$set": bson.M{"nextId": nextID, "updatedAt": time.Now()}})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount == 1, nil
+}
+
+// ListChildren 直接子任务 (按创建时间正序)
+func (r *mongoTaskRepo) ListChildren(ctx context.Context, userID, parentID string) ([]models.Task, error) {
+	return r.find(ctx, userID, bson.M{"parentId": parentID}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+}
+
+// ListBlockedBy 依赖 blockerID 的任务
+func (r *mongoTaskRepo) ListBlockedBy(ctx context.Context, userID, blockerID string) ([]models.Task, error) {
+	return r.find(ctx, userID, bson.M{"blockedBy": blockerID})
+}
+
+// ListSeries 循环任务系列中的全部实例，按创建时间排序
+func (r *mongoTaskRepo) ListSeries(ctx context.Context, userID, seriesID string) ([]models.Task, error) {
+	return r.find(ctx, userID, bson.M{"seriesId": seriesID}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
 }
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UserRepository 用户资料读写 (注册 / 登录仍由 auth 处理器直接访问 users 集合)
+type UserRepository interface {
+	FindByID(ctx context.Context, userID primitive.ObjectID) (*models.User, error)
+	TimeZone(ctx context.Context, userID primitive.ObjectID) string
+	Locale(ctx context.Context, userID primitive.ObjectID) string
+	UpdateFields(ctx context.Context, userID primitive.ObjectID, set map[string]interface{}) (*models.User, error)
+	// FindByLogin 按用户 ID、用户名或邮箱查找用户
+	FindByLogin(ctx context.Context, login string) (*models.User, error)
+}
+
+// ErrUserNotFound 用户不存在
+var ErrUserNotFound = errors.New("user not found")
+
+type mongoUserRepo struct{ db *mongo.Database }
+
+func NewUserRepository(db *mongo.Database) UserRepository { return &mongoUserRepo{db: db} }
+
+func (r *mongoUserRepo) coll() *mongo.Collection { return r.db.Collection("users") }
+
+func (r *mongoUserRepo) FindByID(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	var u models.User
+	if err := r.coll().FindOne(ctx, bson.M{"_id": userID}, options.FindOne().SetProjection(bson.M{"password": 0})).Decode(&u); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+// TimeZone 读取用户时区 (未设置或查询失败返回空串)
+func (r *mongoUserRepo) TimeZone(ctx context.Context, userID primitive.ObjectID) string {
+	var doc struct {
+		TimeZone string `bson:"timeZone"`
+	}
+	if err := r.coll().FindOne(ctx, bson.M{"_id": userID}, options.FindOne().SetProjection(bson.M{"timeZone": 1})).Decode(&doc); err != nil {
+		return ""
+	}
+	return doc.TimeZone
+}
+
+// Locale 读取用户语言 (未设置或查询失败返回空串)
+func (r *mongoUserRepo) Locale(ctx context.Context, userID primitive.ObjectID) string {
+	var doc struct {
+		Locale string `bson:"locale"`
+	}
+	if err := r.coll().FindOne(ctx, bson.M{"_id": userID}, options.FindOne().SetProjection(bson.M{"locale": 1})).Decode(&doc); err != nil {
+		return ""
+	}
+	return doc.Locale
+}
+
+func (r *mongoUserRepo) UpdateFields(ctx context.Context, userID primitive.ObjectID, set map[string]interface{}) (*models.User, error) {
+	if len(set) > 0 {
+		res, err := r.coll().UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": set})
+		if err != nil {
+			return nil, err
+		}
+		if res.MatchedCount == 0 {
+			return nil, ErrUserNotFound
+		}
+	}
+	return r.FindByID(ctx, userID)
+}
+
+func (r *mongoUserRepo) FindByLogin(ctx context.Context, login string) (*models.User, error) {
+	if oid, err := primitive.ObjectIDFromHex(login); err == nil {
+		return r.FindByID(ctx, oid)
+	}
+	var u models.User
+	filter := bson.M{"$or": []bson.M{{"username": login}, {"email": login}}}
+	if err := r.coll().FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"password": 0})).Decode(&u); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &u, nil
+}
@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WorkspaceRepository 工作区仓储；成员内嵌在工作区文档中，成员变更以带条件的原子更新写入
+type WorkspaceRepository interface {
+	Insert(ctx context.Context, w *models.Workspace) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Workspace, error)
+	ListByMember(ctx context.Context, userID primitive.ObjectID) ([]models.Workspace, error)
+	UpdateFields(ctx context.Context, id primitive.ObjectID, set map[string]interface{}) (*models.Workspace, error)
+	// AddMember 追加成员 (要求尚不是成员)
+	AddMember(ctx context.Context, id primitive.ObjectID, m models.WorkspaceMember) (*models.Workspace, error)
+	// SetMemberRole 修改成员角色 (降为非 owner 时要求另有 owner)
+	SetMemberRole(ctx context.Context, id, memberID primitive.ObjectID, role string) (*models.Workspace, error)
+	// RemoveMember 移除成员 (要求另有 owner)，并停用其在工作区事件上的提醒
+	RemoveMember(ctx context.Context, id, memberID primitive.ObjectID) (*models.Workspace, error)
+	// Delete 删除工作区，其中的任务 / 事件 / 提醒转为各自创建者的个人数据；他人事件上的提醒随之停用
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// 工作区相关错误
+var (
+	ErrWorkspaceNotFound       = errors.New("workspace not found")
+	ErrWorkspaceForbidden      = errors.New("workspace permission denied")      // 可见但角色不足
+	ErrWorkspaceMembersChanged = errors.New("workspace members changed, retry") // 成员变更条件未命中 (工作区已被并发修改或删除)
+)
+
+type mongoWorkspaceRepo struct{ db *mongo.Database }
+
+func NewWorkspaceRepository(db *mongo.Database) WorkspaceRepository {
+	return &mongoWorkspaceRepo{db: db}
+}
+
+func (r *mongoWorkspaceRepo) coll() *mongo.Collection { return r.db.Collection("workspaces") }
+
+func (r *mongoWorkspaceRepo) Insert(ctx context.Context, w *models.Workspace) error {
+	if w == nil {
+		return errors.New("nil workspace")
+	}
+	if w.ID.IsZero() {
+		w.ID = primitive.NewObjectID()
+	}
+	now := time.Now()
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = now
+	}
+	w.UpdatedAt = now
+	_, err := r.coll().InsertOne(ctx, w)
+	return err
+}
+
+func (r *mongoWorkspaceRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Workspace, error) {
+	var w models.Workspace
+	if err := r.coll().FindOne(ctx, bson.M{"_id": id}).Decode(&w); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *mongoWorkspaceRepo) ListByMember(ctx context.Context, userID primitive.ObjectID) ([]models.Workspace, error) {
+	cur, err := r.coll().Find(ctx, bson.M{"members.user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	list := []models.Workspace{}
+	if err = cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *mongoWorkspaceRepo) UpdateFields(ctx context.Context, id primitive.ObjectID, set map[string]interface{}) (*models.Workspace, error) {
+	bset := bson.M{"updated_at": time.Now()}
+	for k, v := range set {
+		bset[k] = v
+	}
+	res, err := r.coll().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bset})
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, ErrWorkspaceNotFound
+	}
+	return r.FindByID(ctx, id)
+}
+
+func (r *mongoWorkspaceRepo) AddMember(ctx context.Context, id primitive.ObjectID, m models.WorkspaceMember) (*models.Workspace, error) {
+	filter := bson.M{"_id": id, "members.user_id": bson.M{"$ne": m.UserID}}
+	return r.updateMembers(ctx, id, filter, bson.M{"$push": bson.M{"members": m}, "$set": bson.M{"updated_at": time.Now()}})
+}
+
+func (r *mongoWorkspaceRepo) SetMemberRole(ctx context.Context, id, memberID primitive.ObjectID, role string) (*models.Workspace, error) {
+	filter := bson.M{"_id": id, "members.user_id": memberID}
+	if role != models.WorkspaceRoleOwner {
+		filter["members"] = otherOwner(memberID)
+	}
+	update := bson.M{"$set": bson.M{"members.$[m].role": role, "updated_at": time.Now()}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"m.user_id": memberID}}})
+	return r.updateMembers(ctx, id, filter, update, opts)
+}
+
+func (r *mongoWorkspaceRepo) RemoveMember(ctx context.Context, id, memberID primitive.ObjectID) (*models.Workspace, error) {
+	filter := bson.M{"_id": id, "members.user_id": memberID, "members": otherOwner(memberID)}
+	w, err := r.updateMembers(ctx, id, filter, bson.M{"$pull": bson.M{"members": bson.M{"user_id": memberID}}, "$set": bson.M{"updated_at": time.Now()}})
+	if err != nil {
+		return nil, err
+	}
+	// 退出后看不到工作区事件，停用其在工作区内的提醒
+	if _, err := r.db.Collection("reminders").UpdateMany(ctx, bson.M{"workspace_id": id, "user_id": memberID}, bson.M{"$set": bson.M{"is_active": false, "updated_at": time.Now()}}); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// otherOwner 除 memberID 外至少还有一名 owner，保证变更后工作区不会失去 owner
+func otherOwner(memberID primitive.ObjectID) bson.M {
+	return bson.M{"$elemMatch": bson.M{"user_id": bson.M{"$ne": memberID}, "role": models.WorkspaceRoleOwner}}
+}
+
+// updateMembers 条件更新成员列表，未命中返回 ErrWorkspaceMembersChanged
+func (r *mongoWorkspaceRepo) updateMembers(ctx context.Context, id primitive.ObjectID, filter, update bson.M, opts ...*options.UpdateOptions) (*models.Workspace, error) {
+	res, err := r.coll().UpdateOne(ctx, filter, update, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if res.MatchedCount == 0 {
+		return nil, ErrWorkspaceMembersChanged
+	}
+	return r.FindByID(ctx, id)
+}
+
+func (r *mongoWorkspaceRepo) Delete(ctx context.Context, id primitive.ObjectID) error {
+	res, err := r.coll().DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrWorkspaceNotFound
+	}
+	if _, err := r.db.Collection("tasks").UpdateMany(ctx, bson.M{"workspaceId": id.Hex()}, bson.M{"$unset": bson.M{"workspaceId": ""}}); err != nil {
+		return err
+	}
+	if err := r.retireForeignReminders(ctx, id); err != nil {
+		return err
+	}
+	for _, name := range []string{"events", "reminders"} {
+		if _, err := r.db.Collection(name).UpdateMany(ctx, bson.M{"workspace_id": id}, bson.M{"$unset": bson.M{"workspace_id": ""}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retireForeignReminders 工作区删除后事件归各自创建者所有：停用其他成员在这些事件上的提醒，避免转为个人提醒后继续发送
+func (r *mongoWorkspaceRepo) retireForeignReminders(ctx context.Context, id primitive.ObjectID) error {
+	cur, err := r.db.Collection("events").Find(ctx, bson.M{"workspace_id": id}, options.Find().SetProjection(bson.M{"_id": 1, "user_id": 1}))
+	if err != nil {
+		return err
+	}
+	var events []struct {
+		ID     primitive.ObjectID `bson:"_id"`
+		UserID primitive.ObjectID `bson:"user_id"`
+	}
+	if err := cur.All(ctx, &events); err != nil {
+		return err
+	}
+	byCreator := map[primitive.ObjectID][]primitive.ObjectID{}
+	for _, ev := range events {
+		byCreator[ev.UserID] = append(byCreator[ev.UserID], ev.ID)
+	}
+	for creator, ids := range byCreator {
+		filter := bson.M{"workspace_id": id, "event_id": bson.M{"$in": ids}, "user_id": bson.M{"$ne": creator}}
+		if _, err := r.db.Collection("reminders").UpdateMany(ctx, filter, bson.M{"$set": bson.M{"is_active": false, "updated_at": time.Now()}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ---- 访问范围 ----
+
+// accessScope 数据归属字段：个人数据 (未归属工作区) 仅创建者可访问，工作区数据按成员角色授权
+type accessScope struct {
+	owner     string // 创建者字段
+	workspace string // 所属工作区字段
+	hexIDs    bool   // 任务集合以十六进制字符串保存用户 / 工作区 ID
+}
+
+var (
+	taskAccess  = accessScope{owner: "createdBy", workspace: "workspaceId", hexIDs: true}
+	ownerAccess = accessScope{owner: "user_id", workspace: "workspace_id"} // 事件 / 提醒
+)
+
+// memberWorkspaces 用户角色不低于 minRole 的工作区 ID
+func memberWorkspaces(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, minRole string) ([]primitive.ObjectID, error) {
+	filter := bson.M{"members": bson.M{"$elemMatch": bson.M{"user_id": userID, "role": bson.M{"$in": models.WorkspaceRolesAtLeast(minRole)}}}}
+	cur, err := db.Collection("workspaces").Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var ids []primitive.ObjectID
+	for cur.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if cur.Decode(&doc) == nil {
+			ids = append(ids, doc.ID)
+		}
+	}
+	return ids, cur.Err()
+}
+
+// filter userID 以 minRole 身份可访问的数据条件
+func (s accessScope) filter(ctx context.Context, db *mongo.Database, userID primitive.ObjectID, minRole string) (bson.M, error) {
+	ids, err := memberWorkspaces(ctx, db, userID, minRole)
+	if err != nil {
+		return nil, err
+	}
+	var owner interface{} = userID
+	var in interface{} = ids
+	if s.hexIDs {
+		owner = userID.Hex()
+		hex := make([]string, 0, len(ids))
+		for _, id := range ids {
+			hex = append(hex, id.Hex())
+		}
+		in = hex
+	}
+	personal := bson.M{s.owner: owner, s.workspace: nil}
+	if len(ids) == 0 {
+		return personal, nil
+	}
+	return bson.M{"$or": []bson.M{personal, {s.workspace: bson.M{"$in": in}}}}, nil
+}
+
+// denied 写操作未命中时区分原因：数据可读 (角色不足) 返回 ErrWorkspaceForbidden，否则返回 notFound
+func (s accessScope) denied(ctx context.Context, db *mongo.Database, coll *mongo.Collection, userID primitive.ObjectID, match bson.M, notFound error) error {
+	read, err := s.filter(ctx, db, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return err
+	}
+	n, err := coll.CountDocuments(ctx, Scoped(read, match), options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrWorkspaceForbidden
+	}
+	return notFound
+}
+
+// Scoped 合并访问范围与查询条件，键冲突 (如双方都有 $or) 时改用 $and
+func Scoped(scope, filter bson.M) bson.M {
+	out := bson.M{}
+	for k, v := range filter {
+		out[k] = v
+	}
+	for k, v := range scope {
+		if _, clash := out[k]; clash {
+			return bson.M{"$and": []bson.M{scope, filter}}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// RequireWorkspaceRole 校验用户在工作区中的角色不低于 minRole：非成员返回 ErrWorkspaceNotFound，角色不足返回 ErrWorkspaceForbidden
+func RequireWorkspaceRole(ctx context.Context, db *mongo.Database, workspaceID, userID primitive.ObjectID, minRole string) error {
+	w, err := NewWorkspaceRepository(db).FindByID(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	role := w.RoleOf(userID)
+	if role == "" {
+		return ErrWorkspaceNotFound
+	}
+	if !models.WorkspaceRoleAtLeast(role, minRole) {
+		return ErrWorkspaceForbidden
+	}
+	return nil
+}
+
+// TaskAccessFilter 任务访问条件，供直接读写 tasks 集合的处理器使用；userID 非 ObjectID 时只匹配本人的个人任务
+func TaskAccessFilter(ctx context.Context, db *mongo.Database, userID, minRole string) (bson.M, error) {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return bson.M{"createdBy": userID, "workspaceId": nil}, nil
+	}
+	return taskAccess.filter(ctx, db, oid, minRole)
+}
+
+// TaskAccessDenied 任务写操作未命中时的原因 (ErrWorkspaceForbidden 或 mongo.ErrNoDocuments)
+func TaskAccessDenied(ctx context.Context, db *mongo.Database, userID string, match bson.M) error {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+	return taskAccess.denied(ctx, db, db.Collection("tasks"), oid, match, mongo.ErrNoDocuments)
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/ical"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CalendarFeedPath 订阅地址前缀 (令牌即凭证，无需 JWT)
+const CalendarFeedPath = "/api/calendar/feed/"
+
+// feedUIDDomain 导出组件 UID 的域部分
+const feedUIDDomain = "todoing"
+
+// CalendarFeedService iCalendar 订阅：令牌管理 + 基于 UnifiedService 数据渲染 .ics
+type CalendarFeedService struct {
+	feeds   repository.CalendarFeedRepository
+	unified *UnifiedService
+}
+
+func NewCalendarFeedService(db *mongo.Database) *CalendarFeedService {
+	return &CalendarFeedService{feeds: repository.NewCalendarFeedRepository(db), unified: NewUnifiedService(db)}
+}
+
+// CreateFeed 生成新的订阅令牌，明文仅此次返回
+func (s *CalendarFeedService) CreateFeed(ctx context.Context, userID primitive.ObjectID, name string) (*models.CalendarFeedCreated, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	if strings.TrimSpace(name) == "" {
+		name = "TodoIng"
+	}
+	feed := models.CalendarFeedToken{
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		TokenHash: hashFeedToken(token),
+		TokenHint: token[len(token)-4:],
+		CreatedAt: time.Now(),
+	}
+	if err := s.feeds.Insert(ctx, &feed); err != nil {
+		return nil, err
+	}
+	return &models.CalendarFeedCreated{Feed: feed, Token: token, URL: CalendarFeedPath + token + ".ics"}, nil
+}
+
+func (s *CalendarFeedService) ListFeeds(ctx context.Context, userID primitive.ObjectID) ([]models.CalendarFeedToken, error) {
+	return s.feeds.ListByUser(ctx, userID)
+}
+
+func (s *CalendarFeedService) RevokeFeed(ctx context.Context, userID, feedID primitive.ObjectID) error {
+	return s.feeds.Revoke(ctx, userID, feedID)
+}
+
+// RenderFeed 校验令牌并渲染该用户的 .ics 内容；令牌无效或已撤销返回 repository.ErrFeedNotFound
+func (s *CalendarFeedService) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	token = strings.TrimSuffix(token, ".ics")
+	if token == "" {
+		return nil, repository.ErrFeedNotFound
+	}
+	feed, err := s.feeds.FindActiveByHash(ctx, hashFeedToken(token))
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.unified.GetFeedData(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	_ = s.feeds.Touch(ctx, feed.ID, now)
+	var buf bytes.Buffer
+	if err := buildFeedCalendar(feed.Name, data, now).Encode(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// buildFeedCalendar 将聚合数据编码为 VCALENDAR：事件 -> VEVENT(+RRULE/EXDATE/RECURRENCE-ID)，提醒 -> VALARM，任务 -> VTODO
+func buildFeedCalendar(name string, data *models.CalendarFeedData, now time.Time) *ical.Component {
+	cal := ical.NewComponent("VCALENDAR").
+		Add("VERSION", "2.0").
+		Add("PRODID", "-//TodoIng//Calendar Feed//CN").
+		Add("CALSCALE", "GREGORIAN").
+		Add("METHOD", "PUBLISH").
+		AddText("X-WR-CALNAME", name)
+	if data.TimeZone != "" {
+		cal.Add("X-WR-TIMEZONE", data.TimeZone)
+	}
+	remindersByEvent := make(map[primitive.ObjectID][]models.Reminder)
+	for _, r := range data.Reminders {
+		remindersByEvent[r.EventID] = append(remindersByEvent[r.EventID], r)
+	}
+	zones := map[string]*time.Location{}
+	var comps []*ical.Component
+	for i := range data.Events {
+		ev := &data.Events[i]
+		if ev.TimeZone != "" && !ev.IsAllDay {
+			zones[ev.TimeZone] = ev.TimeLocation()
+		}
+		comps = append(comps, eventComponents(ev, remindersByEvent[ev.ID], now)...)
+	}
+	for i := range data.Tasks {
+		comps = append(comps, taskComponent(&data.Tasks[i], now))
+	}
+	names := make([]string, 0, len(zones))
+	for n := range zones {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		cal.AddChild(ical.VTimezone(zones[n], now.AddDate(-1, 0, 0), now.AddDate(2, 0, 0)))
+	}
+	for _, c := range comps {
+		cal.AddChild(c)
+	}
+	return cal
+}
+
+// feedTime 按事件形态格式化时间：全天 -> DATE；有时区 -> TZID 本地时间；否则 UTC
+func feedTime(ev *models.Event, t time.Time) (string, [][2]string) {
+	loc := ev.TimeLocation()
+	switch {
+	case ev.IsAllDay:
+		return t.In(loc).Format(ical.DateLayout), [][2]string{{"VALUE", "DATE"}}
+	case ev.TimeZone != "":
+		return t.In(loc).Format(ical.DateTimeLayout), [][2]string{{"TZID", ev.TimeZone}}
+	}
+	return ical.FormatUTC(t), nil
+}
+
+// eventUID 导入的事件沿用原 UID，便于外部日历与再次导入对齐
+func eventUID(ev *models.Event) string {
+	if ev.ICalUID != "" {
+		return ev.ICalUID
+	}
+	return ev.ID.Hex() + "@" + feedUIDDomain
+}
+
+func eventComponents(ev *models.Event, reminders []models.Reminder, now time.Time) []*ical.Component {
+	loc := ev.TimeLocation()
+	start := ev.LocalEventDate()
+	base := ical.NewComponent("VEVENT").
+		Add("UID", eventUID(ev)).
+		Add("DTSTAMP", ical.FormatUTC(now))
+	v, p := feedTime(ev, start)
+	base.Add("DTSTART", v, p...)
+	if ev.IsAllDay {
+		v, p = feedTime(ev, start.AddDate(0, 0, 1))
+		base.Add("DTEND", v, p...)
+	}
+	base.AddText("SUMMARY", ev.Title).
+		AddText("DESCRIPTION", ev.Description).
+		AddText("LOCATION", ev.Location)
+	cats := append([]string{ev.EventType}, ev.Tags...)
+	escaped := make([]string, 0, len(cats))
+	for _, c := range cats {
+		if c != "" {
+			escaped = append(escaped, ical.EscapeText(c))
+		}
+	}
+	if len(escaped) > 0 {
+		base.Add("CATEGORIES", strings.Join(escaped, ","))
+	}
+	base.Add("PRIORITY", fmt.Sprintf("%d", importanceToICalPriority(ev.ImportanceLevel)))
+	if !ev.CreatedAt.IsZero() {
+		base.Add("CREATED", ical.FormatUTC(ev.CreatedAt))
+	}
+	if !ev.UpdatedAt.IsZero() {
+		base.Add("LAST-MODIFIED", ical.FormatUTC(ev.UpdatedAt))
+	}
+
+	var overrides []*ical.Component
+	if rule := ev.EffectiveRecurrenceRule(); rule != nil {
+		rr := rule.String()
+		if ev.IsAllDay && rule.Until != nil { // DATE 型 DTSTART 的 UNTIL 也需为 DATE
+			rr = strings.Replace(rr, "UNTIL="+ical.FormatUTC(*rule.Until), "UNTIL="+rule.Until.In(loc).Format(ical.DateLayout), 1)
+		}
+		base.Add("RRULE", rr)
+		for _, ex := range rule.ExDates {
+			if h, m, sec := ex.Clock(); h == 0 && m == 0 && sec == 0 && !ev.IsAllDay { // 纯日期 EXDATE：补齐为当天的发生时刻
+				y, mo, d := ex.Date()
+				ex = time.Date(y, mo, d, start.Hour(), start.Minute(), start.Second(), 0, loc)
+			}
+			v, p := feedTime(ev, ex)
+			base.Add("EXDATE", v, p...)
+		}
+		for i := range ev.Exceptions {
+			x := &ev.Exceptions[i]
+			if x.Cancelled {
+				v, p := feedTime(ev, x.OccurrenceDate)
+				base.Add("EXDATE", v, p...)
+				continue
+			}
+			overrides = append(overrides, overrideComponent(ev, x, now))
+		}
+	}
+	for _, r := range reminders {
+		for _, a := range reminderAlarms(ev, r, start) {
+			base.AddChild(a)
+		}
+	}
+	return append([]*ical.Component{base}, overrides...)
+}
+
+// overrideComponent 单次发生改期 / 覆盖 -> 带 RECURRENCE-ID 的 VEVENT
+func overrideComponent(ev *models.Event, x *models.EventException, now time.Time) *ical.Component {
+	occ := ev.Occurrence(x.OccurrenceDate)
+	if x.NewDate != nil {
+		occ.EventDate = *x.NewDate
+	}
+	if x.Title != nil {
+		occ.Title = *x.Title
+	}
+	if x.Location != nil {
+		occ.Location = *x.Location
+	}
+	c := ical.NewComponent("VEVENT").
+		Add("UID", eventUID(ev)).
+		Add("DTSTAMP", ical.FormatUTC(now))
+	v, p := feedTime(ev, x.OccurrenceDate)
+	c.Add("RECURRENCE-ID", v, p...)
+	v, p = feedTime(ev, occ.EventDate)
+	c.Add("DTSTART", v, p...)
+	if ev.IsAllDay {
+		v, p = feedTime(ev, occ.EventDate.In(ev.TimeLocation()).AddDate(0, 0, 1))
+		c.Add("DTEND", v, p...)
+	}
+	c.AddText("SUMMARY", occ.Title).
+		AddText("DESCRIPTION", occ.Description).
+		AddText("LOCATION", occ.Location)
+	if !x.UpdatedAt.IsZero() {
+		c.Add("LAST-MODIFIED", ical.FormatUTC(x.UpdatedAt))
+	}
+	return c
+}
+
+// reminderAlarms 提醒 -> VALARM：HH:MM + AdvanceDays 转为相对 DTSTART 的 TRIGGER；绝对时间直接使用 DATE-TIME
+func reminderAlarms(ev *models.Event, r models.Reminder, start time.Time) []*ical.Component {
+	desc := r.CustomMessage
+	if desc == "" {
+		desc = ev.Title
+	}
+	alarm := func(trigger string, params ...[2]string) *ical.Component {
+		return ical.NewComponent("VALARM").
+			Add("ACTION", "DISPLAY").
+			Add("TRIGGER", trigger, params...).
+			AddText("DESCRIPTION", desc)
+	}
+	var out []*ical.Component
+	if len(r.AbsoluteTimes) > 0 {
+		for _, t := range r.AbsoluteTimes {
+			out = append(out, alarm(ical.FormatUTC(t), [2]string{"VALUE", "DATE-TIME"}))
+		}
+		return out
+	}
+	for _, hm := range r.ReminderTimes {
+		at, err := time.Parse("15:04", hm)
+		if err != nil {
+			continue
+		}
+		day := start.AddDate(0, 0, -r.AdvanceDays)
+		target := time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), 0, 0, start.Location())
+		out = append(out, alarm(ical.FormatDuration(target.Sub(start)), [2]string{"RELATED", "START"}))
+	}
+	return out
+}
+
+func taskComponent(t *models.Task, now time.Time) *ical.Component {
+	c := ical.NewComponent("VTODO").
+		Add("UID", "task-"+t.ID+"@"+feedUIDDomain).
+		Add("DTSTAMP", ical.FormatUTC(now)).
+		AddText("SUMMARY", t.Title).
+		AddText("DESCRIPTION", t.Description)
+	// DUE 不得早于 DTSTART：计划时间晚于截止时间时只保留 DUE
+	if t.ScheduledDate != nil && (t.Deadline == nil || !t.ScheduledDate.After(*t.Deadline)) {
+		c.Add("DTSTART", ical.FormatUTC(*t.ScheduledDate))
+	}
+	if t.Deadline != nil {
+		c.Add("DUE", ical.FormatUTC(*t.Deadline))
+	}
+	switch t.Status {
+	case "Done", "done", "DONE", "已完成":
+		c.Add("STATUS", "COMPLETED")
+		if !t.UpdatedAt.IsZero() {
+			c.Add("COMPLETED", ical.FormatUTC(t.UpdatedAt))
+		}
+	case "In Progress", "InProgress", "in_progress":
+		c.Add("STATUS", "IN-PROCESS")
+	default:
+		c.Add("STATUS", "NEEDS-ACTION")
+	}
+	switch t.Priority {
+	case "High", "high":
+		c.Add("PRIORITY", "1")
+	case "Medium", "medium":
+		c.Add("PRIORITY", "5")
+	case "Low", "low":
+		c.Add("PRIORITY", "9")
+	}
+	if !t.UpdatedAt.IsZero() {
+		c.Add("LAST-MODIFIED", ical.FormatUTC(t.UpdatedAt))
+	}
+	return c
+}
+
+// importanceToICalPriority 重要程度 1-5 -> iCalendar PRIORITY (1 最高, 9 最低)
+func importanceToICalPriority(level int) int {
+	if level < 1 || level > 5 {
+		return 0
+	}
+	return 11 - 2*level
+}
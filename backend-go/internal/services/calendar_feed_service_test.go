@@ -0,0 +1,74 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+func renderFeed(t *testing.T, data *models.CalendarFeedData) string {
+	t.Helper()
+	var buf bytes.Buffer
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, buildFeedCalendar("测试", data, now).Encode(&buf))
+	// 去掉折行便于断言
+	return strings.ReplaceAll(buf.String(), "\r\n ", "")
+}
+
+func TestBuildFeedCalendarEvents(t *testing.T) {
+	sh, _ := time.LoadLocation("Asia/Shanghai")
+	weekly := models.Event{
+		ID:               primitive.NewObjectID(),
+		Title:            "周会, 例行",
+		EventType:        "meeting",
+		EventDate:        time.Date(2025, 1, 6, 9, 0, 0, 0, sh),
+		TimeZone:         "Asia/Shanghai",
+		RecurrenceType:   "weekly",
+		RecurrenceConfig: map[string]interface{}{"exdates": []interface{}{"2025-01-13"}},
+		ImportanceLevel:  5,
+	}
+	allDay := models.Event{
+		ID:        primitive.NewObjectID(),
+		Title:     "生日",
+		EventDate: time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+		IsAllDay:  true,
+	}
+	rem := models.Reminder{EventID: weekly.ID, AdvanceDays: 1, ReminderTimes: []string{"18:00"}, IsActive: true}
+	out := renderFeed(t, &models.CalendarFeedData{
+		TimeZone:  "Asia/Shanghai",
+		Events:    []models.Event{weekly, allDay},
+		Reminders: []models.Reminder{rem},
+	})
+
+	require.Contains(t, out, "BEGIN:VTIMEZONE\r\nTZID:Asia/Shanghai\r\n")
+	require.Contains(t, out, "UID:"+weekly.ID.Hex()+"@todoing\r\n")
+	require.Contains(t, out, "DTSTART;TZID=Asia/Shanghai:20250106T090000\r\n")
+	require.Contains(t, out, "RRULE:FREQ=WEEKLY\r\n")
+	require.Contains(t, out, "EXDATE;TZID=Asia/Shanghai:20250113T090000\r\n")
+	require.Contains(t, out, `SUMMARY:周会\, 例行`)
+	require.Contains(t, out, "PRIORITY:1\r\n")
+	// 提前 1 天 18:00 -> 开始前 15 小时
+	require.Contains(t, out, "TRIGGER;RELATED=START:-PT15H\r\n")
+
+	require.Contains(t, out, "DTSTART;VALUE=DATE:20250308\r\n")
+	require.Contains(t, out, "DTEND;VALUE=DATE:20250309\r\n")
+}
+
+func TestBuildFeedCalendarTasks(t *testing.T) {
+	due := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+	late := due.Add(48 * time.Hour)
+	out := renderFeed(t, &models.CalendarFeedData{Tasks: []models.Task{
+		{ID: "t1", Title: "写报告", Status: "Done", Priority: "High", Deadline: &due, UpdatedAt: due},
+		{ID: "t2", Title: "计划晚于截止", Status: "To Do", Deadline: &due, ScheduledDate: &late},
+	}})
+	require.Contains(t, out, "UID:task-t1@todoing\r\nDTSTAMP:20250101T000000Z\r\nSUMMARY:写报告\r\nDUE:20250201T100000Z\r\nSTATUS:COMPLETED\r\n")
+	require.Contains(t, out, "PRIORITY:1\r\n")
+	require.NotContains(t, out, "DTSTART:20250203T100000Z")
+	require.Contains(t, out, "STATUS:NEEDS-ACTION\r\n")
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DigestScheduler 定时发送邮件汇总 (每分钟检查到期订阅)
+type DigestScheduler struct {
+	digests  *DigestService
+	ticker   *time.Ticker
+	stopChan chan bool
+	running  bool
+}
+
+func NewDigestScheduler(db *mongo.Database) *DigestScheduler {
+	return &DigestScheduler{digests: NewDigestService(db), stopChan: make(chan bool)}
+}
+
+// Start 启动调度器
+func (s *DigestScheduler) Start() {
+	if s.running {
+		return
+	}
+	s.running = true
+	s.ticker = time.NewTicker(1 * time.Minute)
+	log.Println("Digest scheduler started")
+	go func() {
+		for {
+			select {
+			case <-s.ticker.C:
+				s.check()
+			case <-s.stopChan:
+				s.ticker.Stop()
+				s.running = false
+				log.Println("Digest scheduler stopped")
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止调度器
+func (s *DigestScheduler) Stop() {
+	if !s.running {
+		return
+	}
+	s.stopChan <- true
+}
+
+func (s *DigestScheduler) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second)
+	defer cancel()
+	sent, err := s.digests.SendDue(ctx, time.Now())
+	if err != nil {
+		log.Printf("Digest scan failed: %v", err)
+		return
+	}
+	if sent > 0 {
+		log.Printf("Digest scan: sent=%d", sent)
+	}
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/email"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/templates"
+)
+
+// digestPriorityLimit 汇总中展示的优先任务数
+const digestPriorityLimit = 5
+
+// DigestService 每日 / 每周邮件汇总：逾期任务、窗口内事件与提醒、优先任务及汇总模式暂存的通知
+type DigestService struct {
+	settings      repository.DigestSettingsRepository
+	unified       *UnifiedService
+	tasks         *TaskSortService
+	notifications *NotificationService
+	users         *UserService
+	send          func(to, subject, htmlBody string) error
+}
+
+func NewDigestService(db *mongo.Database) *DigestService {
+	return &DigestService{
+		settings:      repository.NewDigestSettingsRepository(db),
+		unified:       NewUnifiedService(db),
+		tasks:         NewTaskSortService(db),
+		notifications: NewNotificationService(db),
+		users:         NewUserService(repository.NewUserRepository(db)),
+		send:          email.SendGeneric,
+	}
+}
+
+// GetSettings 汇总订阅，未设置时返回默认值 (关闭)
+func (s *DigestService) GetSettings(ctx context.Context, userID primitive.ObjectID) (*models.DigestSettings, error) {
+	d, err := s.settings.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		d = models.DefaultDigestSettings(userID)
+	}
+	return d, nil
+}
+
+// UpdateSettings 更新订阅并重新计算下一次发送时间 (关闭时清空)
+func (s *DigestService) UpdateSettings(ctx context.Context, userID primitive.ObjectID, req models.UpdateDigestSettingsRequest) (*models.DigestSettings, error) {
+	d, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Enabled != nil {
+		d.Enabled = *req.Enabled
+	}
+	if req.Frequency != nil {
+		d.Frequency = *req.Frequency
+	}
+	if req.SendAt != nil {
+		d.SendAt = *req.SendAt
+	}
+	if req.Weekday != nil {
+		d.Weekday = *req.Weekday
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	d.NextSendAt = nil
+	if d.Enabled {
+		next := d.NextAfter(time.Now(), s.users.Location(ctx, userID))
+		d.NextSendAt = &next
+	}
+	if err := s.settings.Upsert(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DigestPreview 预览结果
+type DigestPreview struct {
+	Subject string         `json:"subject"`
+	HTML    string         `json:"html"`
+	Digest  *models.Digest `json:"digest"`
+}
+
+// Preview 按当前数据生成汇总但不发送；frequency 为空时使用订阅设置
+func (s *DigestService) Preview(ctx context.Context, userID primitive.ObjectID, frequency string) (*DigestPreview, error) {
+	d, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if frequency != "" {
+		d.Frequency = frequency
+		if err := d.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	loc := s.users.Location(ctx, userID)
+	digest, err := s.Build(ctx, d, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	subject, body, err := renderDigest(digest, loc, s.users.Locale(ctx, userID))
+	if err != nil {
+		return nil, err
+	}
+	return &DigestPreview{Subject: subject, HTML: body, Digest: digest}, nil
+}
+
+// Build 汇总 UnifiedService.GetUpcoming 与 TaskSortService.GetPriorityTasks 的结果
+func (s *DigestService) Build(ctx context.Context, d *models.DigestSettings, now time.Time) (*models.Digest, error) {
+	items, _, err := s.unified.GetUpcoming(ctx, d.UserID, int(d.Window().Hours()), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	priority, err := s.tasks.GetPriorityTasks(ctx, d.UserID)
+	if err != nil {
+		log.Printf("digest: priority tasks for %s: %v", d.UserID.Hex(), err)
+	}
+	since := now.Add(-d.Window())
+	if d.LastSentAt != nil {
+		since = *d.LastSentAt
+	}
+	held, err := s.notifications.ListDigest(ctx, d.UserID, since, 50)
+	if err != nil {
+		log.Printf("digest: held notifications for %s: %v", d.UserID.Hex(), err)
+	}
+	return buildDigest(d, now, items, priority, held), nil
+}
+
+// buildDigest 按来源拆分聚合条目：计划时间已过的任务归为逾期
+func buildDigest(d *models.DigestSettings, now time.Time, items []models.UnifiedItem, priority []models.PriorityTask, held []models.Notification) *models.Digest {
+	out := &models.Digest{
+		UserID: d.UserID, Frequency: d.Frequency, GeneratedAt: now, WindowEnd: now.Add(d.Window()),
+		OverdueTasks: []models.UnifiedItem{}, Events: []models.UnifiedItem{}, Reminders: []models.UnifiedItem{},
+		UpcomingTasks: []models.UnifiedItem{}, PriorityTasks: []models.PriorityTask{}, Notifications: []models.Notification{},
+	}
+	for _, it := range items {
+		switch it.Source {
+		case "task":
+			if it.ScheduledAt.Before(now) {
+				out.OverdueTasks = append(out.OverdueTasks, it)
+			} else {
+				out.UpcomingTasks = append(out.UpcomingTasks, it)
+			}
+		case "event":
+			out.Events = append(out.Events, it)
+		case "reminder":
+			out.Reminders = append(out.Reminders, it)
+		}
+	}
+	if len(priority) > digestPriorityLimit {
+		priority = priority[:digestPriorityLimit]
+	}
+	out.PriorityTasks = append(out.PriorityTasks, priority...)
+	out.Notifications = append(out.Notifications, held...)
+	return out
+}
+
+// renderDigest 按用户语言渲染 digest_* 模板，生成邮件主题与 HTML 正文 (时间按用户时区显示)
+func renderDigest(d *models.Digest, loc *time.Location, locale string) (string, string, error) {
+	data := templates.DigestData{Digest: d, Weekly: d.Frequency == models.DigestWeekly, Loc: loc}
+	subject, err := templates.Default.Render(locale, templates.DigestSubject, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := templates.Default.Render(locale, templates.DigestEmail, data)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+// SendDue 发送所有到期的汇总：先以 next_send_at 为条件推进下一次时间 (多实例只有一个实例发送)，再构建并发送
+func (s *DigestService) SendDue(ctx context.Context, now time.Time) (sent int, err error) {
+	due, err := s.settings.ListDue(ctx, now, 100)
+	if err != nil {
+		return 0, err
+	}
+	for i := range due {
+		d := &due[i]
+		loc := s.users.Location(ctx, d.UserID)
+		ok, err := s.settings.Advance(ctx, d.UserID, *d.NextSendAt, d.NextAfter(now, loc), now)
+		if err != nil || !ok {
+			continue
+		}
+		delivered, err := s.sendOne(ctx, d, now, loc)
+		if err != nil {
+			log.Printf("digest: send to %s failed: %v", d.UserID.Hex(), err)
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// sendOne 构建并发送单个用户的汇总，没有任何条目时跳过
+func (s *DigestService) sendOne(ctx context.Context, d *models.DigestSettings, now time.Time, loc *time.Location) (bool, error) {
+	digest, err := s.Build(ctx, d, now)
+	if err != nil {
+		return false, err
+	}
+	if digest.IsEmpty() {
+		return false, nil
+	}
+	user, err := s.users.GetProfile(ctx, d.UserID)
+	if err != nil {
+		return false, err
+	}
+	if user.Email == "" {
+		return false, errors.New("user email not found")
+	}
+	subject, body, err := renderDigest(digest, loc, user.Locale)
+	if err != nil {
+		return false, err
+	}
+	return true, s.send(user.Email, subject, body)
+}
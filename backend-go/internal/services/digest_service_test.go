@@ -0,0 +1,55 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+func TestBuildAndRenderDigest(t *testing.T) {
+	now := time.Date(2030, 1, 9, 0, 0, 0, 0, time.UTC)
+	settings := models.DefaultDigestSettings(primitive.NewObjectID())
+	items := []models.UnifiedItem{
+		{Source: "task", Title: "写周报", ScheduledAt: now.Add(-2 * time.Hour)},
+		{Source: "task", Title: "发布", ScheduledAt: now.Add(5 * time.Hour)},
+		{Source: "event", Title: "<b>站会</b>", ScheduledAt: now.Add(time.Hour)},
+		{Source: "reminder", Title: "交房租", ScheduledAt: now.Add(3 * time.Hour)},
+	}
+	priority := make([]models.PriorityTask, 8)
+	for i := range priority {
+		priority[i].Title = "p"
+	}
+	held := []models.Notification{{Message: "评论了你的事件", Digest: true}}
+
+	d := buildDigest(settings, now, items, priority, held)
+	require.Len(t, d.OverdueTasks, 1)
+	require.Equal(t, "写周报", d.OverdueTasks[0].Title)
+	require.Len(t, d.UpcomingTasks, 1)
+	require.Len(t, d.Events, 1)
+	require.Len(t, d.Reminders, 1)
+	require.Len(t, d.PriorityTasks, digestPriorityLimit)
+	require.False(t, d.IsEmpty())
+
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	subject, body, err := renderDigest(d, loc, "zh-CN")
+	require.NoError(t, err)
+	require.Equal(t, "TodoIng 每日汇总 2030-01-09", subject)
+	require.Contains(t, body, "逾期任务 (1)")
+	require.Contains(t, body, "09:00 &lt;b&gt;站会&lt;/b&gt;") // 按用户时区显示并转义
+	require.Contains(t, body, "评论了你的事件")
+	require.False(t, strings.Contains(body, "<b>站会"))
+
+	d.Frequency = models.DigestWeekly
+	subject, body, err = renderDigest(d, loc, "en-US")
+	require.NoError(t, err)
+	require.Equal(t, "TodoIng weekly digest 2030-01-09", subject)
+	require.Contains(t, body, "Overdue tasks (1)")
+
+	empty := buildDigest(settings, now, nil, nil, nil)
+	require.True(t, empty.IsEmpty())
+}
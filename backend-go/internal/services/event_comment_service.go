@@ -87,24 +87,26 @@ func (s *EventCommentService) UpdateComment(ctx context.Context, userID, comment
 	return &ec, nil
 }
 
-// DeleteComment 仅作者或事件拥有者可删除
-func (s *EventCommentService) DeleteComment(ctx context.Context, userID primitive.ObjectID, commentID primitive.ObjectID) error {
+// DeleteComment 仅作者或事件拥有者可删除，返回被删除的评论
+func (s *EventCommentService) DeleteComment(ctx context.Context, userID primitive.ObjectID, commentID primitive.ObjectID) (*models.EventComment, error) {
 	var ec models.EventComment
 	if err := s.coll.FindOne(ctx, bson.M{"_id": commentID}).Decode(&ec); err != nil {
 		if err == mongo.ErrNoDocuments {
-			return errors.New("comment not found")
+			return nil, errors.New("comment not found")
 		}
-		return err
+		return nil, err
 	}
 	var ev models.Event
 	if err := s.eventCo.FindOne(ctx, bson.M{"_id": ec.EventID}).Decode(&ev); err != nil {
-		return err
+		return nil, err
 	}
 	if ec.UserID != userID && ev.UserID != userID {
-		return errors.New("no permission")
+		return nil, errors.New("no permission")
 	}
-	_, err := s.coll.DeleteOne(ctx, bson.M{"_id": ec.ID})
-	return err
+	if _, err := s.coll.DeleteOne(ctx, bson.M{"_id": ec.ID}); err != nil {
+		return nil, err
+	}
+	return &ec, nil
 }
 
 // ListTimeline 列出事件时间线（按创建时间升序）
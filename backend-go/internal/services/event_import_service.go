@@ -0,0 +1,513 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/ical"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// MaxImportEvents 单次导入的 VEVENT 上限
+const MaxImportEvents = 5000
+
+// ErrInvalidImport 导入文件无法解析或内容不符合要求
+var ErrInvalidImport = errors.New("invalid ics file")
+
+// EventImportService .ics 导入：VEVENT -> Event，RRULE/EXDATE -> 循环配置，RECURRENCE-ID -> 单次例外，VALARM -> Reminder。
+// 以 UID 去重：同一用户再次导入同一 UID 时更新原事件及其提醒，而不是新建。
+type EventImportService struct {
+	events    repository.EventRepository
+	reminders repository.ReminderRepository
+}
+
+func NewEventImportService(events repository.EventRepository, reminders repository.ReminderRepository) *EventImportService {
+	return &EventImportService{events: events, reminders: reminders}
+}
+
+// Import 解析并导入 .ics；defaultZone 用于无 TZID 的浮动时间 (一般为用户时区)。
+// 文件无法解析时返回 error；单个 VEVENT 的失败记录在报告中。
+func (s *EventImportService) Import(ctx context.Context, userID primitive.ObjectID, r io.Reader, defaultZone string) (*models.EventImportResult, error) {
+	if s.events == nil || s.reminders == nil {
+		return nil, errors.New("event import repos nil")
+	}
+	roots, err := ical.Parse(r)
+	if errors.Is(err, ical.ErrMalformed) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var vevents []*ical.Component
+	for _, root := range roots {
+		vevents = append(vevents, root.Components("VEVENT")...)
+	}
+	if len(vevents) == 0 {
+		return nil, fmt.Errorf("%w: no VEVENT found", ErrInvalidImport)
+	}
+	if len(vevents) > MaxImportEvents {
+		return nil, fmt.Errorf("%w: too many events (max %d)", ErrInvalidImport, MaxImportEvents)
+	}
+	existing, err := s.reminders.ListByUser(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	remindersByEvent := make(map[primitive.ObjectID][]models.Reminder)
+	for _, rm := range existing {
+		remindersByEvent[rm.EventID] = append(remindersByEvent[rm.EventID], rm)
+	}
+
+	res := &models.EventImportResult{Items: []models.EventImportItem{}}
+	items := make([]models.EventImportItem, len(vevents))
+	imported := make(map[string]*models.Event) // UID -> 已导入的主事件
+	var overrides []int
+	// 先导入主事件，再处理 RECURRENCE-ID 覆盖，保证主事件已存在
+	for i, comp := range vevents {
+		if comp.Get("RECURRENCE-ID") != nil {
+			overrides = append(overrides, i)
+			continue
+		}
+		items[i] = s.importEvent(ctx, userID, i, comp, defaultZone, remindersByEvent, imported)
+	}
+	for _, i := range overrides {
+		items[i] = s.importOverride(ctx, userID, i, vevents[i], defaultZone, imported)
+	}
+	for _, it := range items {
+		res.Add(it)
+	}
+	return res, nil
+}
+
+func (s *EventImportService) importEvent(ctx context.Context, userID primitive.ObjectID, index int, comp *ical.Component, defaultZone string, remindersByEvent map[primitive.ObjectID][]models.Reminder, imported map[string]*models.Event) models.EventImportItem {
+	item := models.EventImportItem{Index: index, UID: comp.Text("UID"), Title: comp.Text("SUMMARY")}
+	if strings.EqualFold(comp.Text("STATUS"), "CANCELLED") {
+		item.Status, item.Error = models.ImportStatusSkipped, "cancelled"
+		return item
+	}
+	ev, alarms, warnings, err := mapVEvent(comp, defaultZone)
+	item.Warnings = warnings
+	if err != nil {
+		item.Status, item.Error = models.ImportStatusError, err.Error()
+		return item
+	}
+	item.Title = ev.Title
+	cur, err := s.findImported(ctx, userID, item.UID)
+	if err != nil {
+		item.Status, item.Error = models.ImportStatusError, err.Error()
+		return item
+	}
+	if cur == nil {
+		ev.ID = primitive.NewObjectID()
+		ev.UserID = userID
+		ev.IsActive = true
+		if err := s.events.Insert(ctx, ev); err != nil {
+			item.Status, item.Error = models.ImportStatusError, err.Error()
+			return item
+		}
+		item.Status = models.ImportStatusCreated
+	} else {
+		set := map[string]interface{}{
+			"title":             ev.Title,
+			"description":       ev.Description,
+			"event_type":        ev.EventType,
+			"event_date":        ev.EventDate,
+			"recurrence_type":   ev.RecurrenceType,
+			"recurrence_config": ev.RecurrenceConfig,
+			"importance_level":  ev.ImportanceLevel,
+			"tags":              ev.Tags,
+			"location":          ev.Location,
+			"is_all_day":        ev.IsAllDay,
+			"time_zone":         ev.TimeZone,
+		}
+		updated, err := s.events.UpdateFields(ctx, userID, cur.ID, set)
+		if err != nil {
+			item.Status, item.Error = models.ImportStatusError, err.Error()
+			return item
+		}
+		ev = updated
+		item.Status = models.ImportStatusUpdated
+	}
+	item.EventID = ev.ID.Hex()
+	if item.UID != "" {
+		imported[item.UID] = ev
+	}
+	n, err := s.syncReminders(ctx, userID, ev.ID, alarms, remindersByEvent[ev.ID])
+	item.Reminders = n
+	if err != nil {
+		item.Warnings = append(item.Warnings, "reminders: "+err.Error())
+	}
+	return item
+}
+
+// findImported 按 UID 查找已导入事件；本系统导出的 UID (<id>@todoing) 直接对应事件 ID
+func (s *EventImportService) findImported(ctx context.Context, userID primitive.ObjectID, uid string) (*models.Event, error) {
+	if uid == "" {
+		return nil, nil
+	}
+	ev, err := s.events.FindByICalUID(ctx, userID, uid)
+	if err != nil || ev != nil {
+		return ev, err
+	}
+	if hex, ok := strings.CutSuffix(uid, "@"+feedUIDDomain); ok {
+		if id, err := primitive.ObjectIDFromHex(hex); err == nil {
+			if ev, err := s.events.FindByID(ctx, userID, id); err == nil {
+				return ev, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// importOverride RECURRENCE-ID 覆盖 -> 主事件的单次发生例外
+func (s *EventImportService) importOverride(ctx context.Context, userID primitive.ObjectID, index int, comp *ical.Component, defaultZone string, imported map[string]*models.Event) models.EventImportItem {
+	item := models.EventImportItem{Index: index, UID: comp.Text("UID"), Title: comp.Text("SUMMARY")}
+	master := imported[item.UID]
+	if master == nil {
+		cur, err := s.findImported(ctx, userID, item.UID)
+		if err != nil || cur == nil {
+			item.Status, item.Error = models.ImportStatusError, "recurring master event not found"
+			return item
+		}
+		master = cur
+	}
+	ex, err := mapOverride(comp, master, defaultZone)
+	if err != nil {
+		item.Status, item.Error = models.ImportStatusError, err.Error()
+		return item
+	}
+	ex.UserID = userID
+	if _, err := s.events.UpsertException(ctx, ex); err != nil {
+		item.Status, item.Error = models.ImportStatusError, err.Error()
+		return item
+	}
+	item.Status = models.ImportStatusUpdated
+	item.EventID = master.ID.Hex()
+	return item
+}
+
+// syncReminders 按 (提前天数, 是否绝对时间) 与事件已有提醒对齐：已有则更新，否则新建
+func (s *EventImportService) syncReminders(ctx context.Context, userID, eventID primitive.ObjectID, alarms []models.CreateReminderRequest, current []models.Reminder) (int, error) {
+	n := 0
+	used := make(map[primitive.ObjectID]bool)
+	for _, a := range alarms {
+		var match *models.Reminder
+		for i := range current {
+			c := &current[i]
+			if !used[c.ID] && c.AdvanceDays == a.AdvanceDays && (len(c.AbsoluteTimes) > 0) == (len(a.AbsoluteTimes) > 0) {
+				match = c
+				break
+			}
+		}
+		if match != nil {
+			used[match.ID] = true
+			set := map[string]interface{}{
+				"reminder_times": a.ReminderTimes,
+				"absolute_times": a.AbsoluteTimes,
+				"reminder_type":  a.ReminderType,
+				"custom_message": a.CustomMessage,
+				"is_active":      true,
+			}
+			if _, err := s.reminders.UpdateFields(ctx, userID, match.ID, set, true); err != nil {
+				return n, err
+			}
+			n++
+			continue
+		}
+		rm := &models.Reminder{
+			ID:            primitive.NewObjectID(),
+			EventID:       eventID,
+			UserID:        userID,
+			AdvanceDays:   a.AdvanceDays,
+			ReminderTimes: a.ReminderTimes,
+			AbsoluteTimes: a.AbsoluteTimes,
+			ReminderType:  a.ReminderType,
+			CustomMessage: a.CustomMessage,
+			IsActive:      true,
+		}
+		if err := s.reminders.Insert(ctx, rm); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// mapVEvent VEVENT -> Event (未落库) + 提醒请求；warnings 记录降级处理的字段
+func mapVEvent(comp *ical.Component, defaultZone string) (*models.Event, []models.CreateReminderRequest, []string, error) {
+	var warnings []string
+	floating := models.LoadLocation(defaultZone)
+	dtstart := comp.Get("DTSTART")
+	if dtstart == nil {
+		return nil, nil, nil, errors.New("missing DTSTART")
+	}
+	ts, allDay, tzid, err := dtstart.Times(floating)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if id := dtstart.Param("TZID"); id != "" && tzid == "" {
+		warnings = append(warnings, fmt.Sprintf("unknown TZID %q, using %s", id, floating.String()))
+	}
+	ev := &models.Event{
+		Title:           truncateRunes(strings.TrimSpace(comp.Text("SUMMARY")), 100),
+		Description:     comp.Text("DESCRIPTION"),
+		Location:        comp.Text("LOCATION"),
+		EventDate:       ts[0],
+		IsAllDay:        allDay,
+		RecurrenceType:  "none",
+		ImportanceLevel: icalPriorityToImportance(comp.Text("PRIORITY")),
+		Tags:            []string{},
+		EventType:       "custom",
+	}
+	if ev.Title == "" {
+		ev.Title = "(untitled)"
+	}
+	switch {
+	case tzid != "":
+		ev.TimeZone = tzid
+	case strings.HasSuffix(dtstart.Value, "Z"):
+		ev.TimeZone = ""
+	default: // 浮动时间 / 全天
+		ev.TimeZone = defaultZone
+	}
+	if ev.TimeZone != "" && models.ValidateTimeZone(ev.TimeZone) != nil {
+		ev.TimeZone = ""
+	}
+	if ev.IsAllDay {
+		ev.EventDate = models.DateIn(ev.EventDate, ev.TimeLocation())
+	}
+	for _, p := range comp.All("CATEGORIES") {
+		for _, c := range splitICalList(p.Value) {
+			if isEventType(strings.ToLower(c)) && ev.EventType == "custom" {
+				ev.EventType = strings.ToLower(c)
+				continue
+			}
+			ev.Tags = append(ev.Tags, c)
+		}
+	}
+
+	if rr := comp.Get("RRULE"); rr != nil {
+		rule, err := models.ParseRRule(rr.Value)
+		if err != nil {
+			return nil, nil, warnings, err
+		}
+		if len(comp.All("RRULE")) > 1 {
+			warnings = append(warnings, "multiple RRULE, only the first is used")
+		}
+		ev.RecurrenceType = models.FreqToRecurrenceType(rule.Freq)
+		ev.RecurrenceConfig = map[string]interface{}{"rrule": rule.String()}
+		var exdates []string
+		for _, p := range comp.All("EXDATE") {
+			exs, exAllDay, _, err := p.Times(ev.TimeLocation())
+			if err != nil {
+				warnings = append(warnings, err.Error())
+				continue
+			}
+			for _, t := range exs {
+				if exAllDay {
+					exdates = append(exdates, t.Format("2006-01-02"))
+				} else {
+					exdates = append(exdates, t.UTC().Format(time.RFC3339))
+				}
+			}
+		}
+		if len(exdates) > 0 {
+			ev.RecurrenceConfig["exdates"] = exdates
+		}
+		if comp.Get("RDATE") != nil {
+			warnings = append(warnings, "RDATE is not supported and was ignored")
+		}
+		if _, err := ev.RecurrenceRule(); err != nil {
+			return nil, nil, warnings, err
+		}
+	}
+	if ev.EventType == "custom" && ev.IsAllDay && ev.RecurrenceType == "yearly" {
+		ev.EventType = "anniversary"
+	}
+	if uid := comp.Text("UID"); uid != "" && !strings.HasSuffix(uid, "@"+feedUIDDomain) {
+		ev.ICalUID = uid
+	}
+	alarms, aw := mapAlarms(comp, ev)
+	return ev, alarms, append(warnings, aw...), nil
+}
+
+// mapAlarms VALARM -> 提醒：相对 TRIGGER 换算为 提前天数 + HH:MM (同一提前天数合并为一条)，DATE-TIME TRIGGER 作为绝对时间
+func mapAlarms(comp *ical.Component, ev *models.Event) ([]models.CreateReminderRequest, []string) {
+	var warnings []string
+	loc := ev.TimeLocation()
+	start := ev.LocalEventDate()
+	byDays := map[int]*models.CreateReminderRequest{}
+	var absolute *models.CreateReminderRequest
+	for _, a := range comp.Components("VALARM") {
+		trig := a.Get("TRIGGER")
+		if trig == nil {
+			continue
+		}
+		kind := "app"
+		if strings.EqualFold(a.Text("ACTION"), "EMAIL") {
+			kind = "email"
+		}
+		msg := a.Text("DESCRIPTION")
+		if msg == ev.Title {
+			msg = ""
+		}
+		if strings.EqualFold(trig.Param("VALUE"), "DATE-TIME") {
+			ts, _, _, err := trig.Times(loc)
+			if err != nil {
+				warnings = append(warnings, err.Error())
+				continue
+			}
+			if absolute == nil {
+				absolute = &models.CreateReminderRequest{CustomMessage: msg}
+			}
+			absolute.ReminderType = appendUnique(absolute.ReminderType, kind)
+			absolute.AbsoluteTimes = append(absolute.AbsoluteTimes, ts[0].UTC())
+			absolute.ReminderTimes = appendUnique(absolute.ReminderTimes, ts[0].In(loc).Format("15:04"))
+			continue
+		}
+		d, err := ical.ParseDuration(trig.Value)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+			continue
+		}
+		if strings.EqualFold(trig.Param("RELATED"), "END") {
+			warnings = append(warnings, "TRIGGER RELATED=END treated as START")
+		}
+		target := start.Add(d)
+		days := models.CalendarDaysBetween(target, start, loc)
+		if days < 0 || days > 365 {
+			warnings = append(warnings, fmt.Sprintf("alarm %s out of range, skipped", trig.Value))
+			continue
+		}
+		req := byDays[days]
+		if req == nil {
+			req = &models.CreateReminderRequest{AdvanceDays: days, CustomMessage: msg}
+			byDays[days] = req
+		}
+		req.ReminderType = appendUnique(req.ReminderType, kind)
+		req.ReminderTimes = appendUnique(req.ReminderTimes, target.In(loc).Format("15:04"))
+	}
+	keys := make([]int, 0, len(byDays))
+	for k := range byDays {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	out := make([]models.CreateReminderRequest, 0, len(keys)+1)
+	for _, k := range keys {
+		req := byDays[k]
+		sort.Strings(req.ReminderTimes)
+		out = append(out, *req)
+	}
+	if absolute != nil {
+		out = append(out, *absolute)
+	}
+	return out, warnings
+}
+
+// mapOverride RECURRENCE-ID VEVENT -> 单次发生例外
+func mapOverride(comp *ical.Component, master *models.Event, defaultZone string) (*models.EventException, error) {
+	floating := master.TimeLocation()
+	if master.TimeZone == "" && defaultZone != "" {
+		floating = models.LoadLocation(defaultZone)
+	}
+	rid, _, _, err := comp.Get("RECURRENCE-ID").Times(floating)
+	if err != nil {
+		return nil, err
+	}
+	ex := &models.EventException{EventID: master.ID, OccurrenceDate: rid[0]}
+	if master.IsAllDay {
+		ex.OccurrenceDate = models.DateIn(rid[0], master.TimeLocation())
+	}
+	if strings.EqualFold(comp.Text("STATUS"), "CANCELLED") {
+		ex.Cancelled = true
+		return ex, nil
+	}
+	if p := comp.Get("DTSTART"); p != nil {
+		ts, _, _, err := p.Times(floating)
+		if err != nil {
+			return nil, err
+		}
+		start := ts[0]
+		if master.IsAllDay {
+			start = models.DateIn(start, master.TimeLocation())
+		}
+		if !start.Equal(ex.OccurrenceDate) {
+			ex.NewDate = &start
+		}
+	}
+	if title := strings.TrimSpace(comp.Text("SUMMARY")); title != "" && title != master.Title {
+		title = truncateRunes(title, 100)
+		ex.Title = &title
+	}
+	if loc := comp.Text("LOCATION"); loc != "" && loc != master.Location {
+		ex.Location = &loc
+	}
+	return ex, nil
+}
+
+// icalPriorityToImportance iCalendar PRIORITY (1 最高, 9 最低, 0 未定义) -> 重要程度 1-5
+func icalPriorityToImportance(s string) int {
+	p, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || p < 1 || p > 9 {
+		return 3
+	}
+	return (12 - p) / 2
+}
+
+func isEventType(t string) bool {
+	switch t {
+	case "birthday", "anniversary", "holiday", "custom", "meeting", "deadline":
+		return true
+	}
+	return false
+}
+
+// splitICalList 按未转义的逗号拆分 TEXT 列表
+func splitICalList(v string) []string {
+	var out []string
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		switch {
+		case v[i] == '\\' && i+1 < len(v):
+			b.WriteByte(v[i])
+			i++
+			b.WriteByte(v[i])
+		case v[i] == ',':
+			if s := strings.TrimSpace(ical.UnescapeText(b.String())); s != "" {
+				out = append(out, s)
+			}
+			b.Reset()
+		default:
+			b.WriteByte(v[i])
+		}
+	}
+	if s := strings.TrimSpace(ical.UnescapeText(b.String())); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, x := range list {
+		if x == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
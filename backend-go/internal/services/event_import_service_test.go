@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/ical"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+func parseVEvents(t *testing.T, s string) []*ical.Component {
+	t.Helper()
+	roots, err := ical.Parse(strings.NewReader(s))
+	require.NoError(t, err)
+	return roots[0].Components("VEVENT")
+}
+
+func TestMapVEvent(t *testing.T) {
+	evs := parseVEvents(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup-1@example.com",
+		"SUMMARY:Standup",
+		"DTSTART;TZID=America/New_York:20250106T093000",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20250331T235959Z",
+		"EXDATE;TZID=America/New_York:20250108T093000",
+		"CATEGORIES:meeting,team\\, core",
+		"PRIORITY:1",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-PT15M",
+		"END:VALARM",
+		"BEGIN:VALARM",
+		"ACTION:EMAIL",
+		"TRIGGER:-PT15H30M",
+		"END:VALARM",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER;RELATED=START:-PT30M",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:bday@example.com",
+		"SUMMARY:Mum",
+		"DTSTART;VALUE=DATE:19700315",
+		"RRULE:FREQ=YEARLY",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n"))
+	require.Len(t, evs, 2)
+
+	ev, alarms, warnings, err := mapVEvent(evs[0], "Asia/Shanghai")
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Equal(t, "standup-1@example.com", ev.ICalUID)
+	require.Equal(t, "America/New_York", ev.TimeZone)
+	require.Equal(t, "meeting", ev.EventType)
+	require.Equal(t, []string{"team, core"}, ev.Tags)
+	require.Equal(t, 5, ev.ImportanceLevel)
+	require.Equal(t, "weekly", ev.RecurrenceType)
+	ny, _ := time.LoadLocation("America/New_York")
+	occ := ev.OccurrencesBetween(time.Date(2025, 1, 6, 0, 0, 0, 0, ny), time.Date(2025, 1, 14, 0, 0, 0, 0, ny), 0)
+	require.Equal(t, []time.Time{time.Date(2025, 1, 6, 9, 30, 0, 0, ny), time.Date(2025, 1, 13, 9, 30, 0, 0, ny)}, occ)
+
+	require.Len(t, alarms, 2)
+	require.Equal(t, 0, alarms[0].AdvanceDays)
+	require.Equal(t, []string{"09:00", "09:15"}, alarms[0].ReminderTimes)
+	require.Equal(t, models.ReminderChannels{"app"}, alarms[0].ReminderType)
+	require.Equal(t, 1, alarms[1].AdvanceDays)
+	require.Equal(t, []string{"18:00"}, alarms[1].ReminderTimes)
+	require.Equal(t, models.ReminderChannels{"email"}, alarms[1].ReminderType)
+
+	bday, _, _, err := mapVEvent(evs[1], "Asia/Shanghai")
+	require.NoError(t, err)
+	require.True(t, bday.IsAllDay)
+	require.Equal(t, "anniversary", bday.EventType)
+	require.Equal(t, "Asia/Shanghai", bday.TimeZone)
+	require.Equal(t, "1970-03-15", bday.LocalEventDate().Format("2006-01-02"))
+}
+
+func TestImportMapsExportedFeed(t *testing.T) {
+	sh, _ := time.LoadLocation("Asia/Shanghai")
+	src := models.Event{
+		ID:              primitive.NewObjectID(),
+		Title:           "周会",
+		EventType:       "meeting",
+		EventDate:       time.Date(2025, 1, 6, 9, 0, 0, 0, sh),
+		TimeZone:        "Asia/Shanghai",
+		RecurrenceType:  "weekly",
+		ImportanceLevel: 4,
+		Tags:            []string{"ops"},
+	}
+	rem := models.Reminder{EventID: src.ID, AdvanceDays: 1, ReminderTimes: []string{"09:00", "18:00"}, IsActive: true}
+	var buf bytes.Buffer
+	require.NoError(t, buildFeedCalendar("t", &models.CalendarFeedData{Events: []models.Event{src}, Reminders: []models.Reminder{rem}}, time.Now()).Encode(&buf))
+
+	evs := parseVEvents(t, buf.String())
+	require.Len(t, evs, 1)
+	ev, alarms, _, err := mapVEvent(evs[0], "")
+	require.NoError(t, err)
+	require.Empty(t, ev.ICalUID) // 本系统导出的 UID 直接对应事件 ID
+	require.Equal(t, src.ID.Hex()+"@todoing", evs[0].Text("UID"))
+	require.True(t, src.EventDate.Equal(ev.EventDate))
+	require.Equal(t, src.TimeZone, ev.TimeZone)
+	require.Equal(t, src.EventType, ev.EventType)
+	require.Equal(t, src.Tags, ev.Tags)
+	require.Equal(t, src.ImportanceLevel, ev.ImportanceLevel)
+	require.Equal(t, "weekly", ev.RecurrenceType)
+	require.Len(t, alarms, 1)
+	require.Equal(t, 1, alarms[0].AdvanceDays)
+	require.Equal(t, []string{"09:00", "18:00"}, alarms[0].ReminderTimes)
+}
@@ -35,6 +35,8 @@ func (s *EventService) CreateEvent(ctx context.Context, userID primitive.ObjectI
 		Tags:             req.Tags,
 		Location:         req.Location,
 		IsAllDay:         req.IsAllDay,
+		TimeZone:         req.TimeZone,
+		WorkspaceID:      req.WorkspaceID,
 		IsActive:         true,
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
@@ -51,6 +53,15 @@ func (s *EventService) CreateEvent(ctx context.Context, userID primitive.ObjectI
 	if e.Tags == nil {
 		e.Tags = []string{}
 	}
+	if err := models.ValidateTimeZone(e.TimeZone); err != nil {
+		return nil, err
+	}
+	if e.IsAllDay { // 全天事件落在事件时区当天 00:00
+		e.EventDate = models.DateIn(e.EventDate, e.TimeLocation())
+	}
+	if _, err := e.RecurrenceRule(); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Insert(ctx, e); err != nil {
 		return nil, err
 	}
@@ -93,12 +104,54 @@ func (s *EventService) UpdateEvent(ctx context.Context, userID, eventID primitiv
 	if req.IsAllDay != nil {
 		set["is_all_day"] = *req.IsAllDay
 	}
+	if req.TimeZone != nil {
+		if err := models.ValidateTimeZone(*req.TimeZone); err != nil {
+			return nil, err
+		}
+		set["time_zone"] = *req.TimeZone
+	}
 	if req.IsActive != nil {
 		set["is_active"] = *req.IsActive
 	}
 	if len(set) == 0 {
 		return s.GetEvent(ctx, userID, eventID)
 	}
+	if req.EventDate != nil || req.IsAllDay != nil || req.TimeZone != nil {
+		// 全天事件的日期按 (合并后的) 事件时区落到当天 00:00；仅改时区时保留原来的日历日期
+		cur, err := s.repo.FindByID(ctx, userID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		base := cur.LocalEventDate()
+		if req.EventDate != nil {
+			base = *req.EventDate
+		}
+		if req.IsAllDay != nil {
+			cur.IsAllDay = *req.IsAllDay
+		}
+		if req.TimeZone != nil {
+			cur.TimeZone = *req.TimeZone
+		}
+		if cur.IsAllDay {
+			set["event_date"] = models.DateIn(base, cur.TimeLocation())
+		}
+	}
+	if req.RecurrenceType != nil || req.RecurrenceConfig != nil {
+		// 校验合并后的循环规则
+		cur, err := s.repo.FindByID(ctx, userID, eventID)
+		if err != nil {
+			return nil, err
+		}
+		if req.RecurrenceType != nil {
+			cur.RecurrenceType = *req.RecurrenceType
+		}
+		if req.RecurrenceConfig != nil {
+			cur.RecurrenceConfig = req.RecurrenceConfig
+		}
+		if _, err := cur.RecurrenceRule(); err != nil {
+			return nil, err
+		}
+	}
 	return s.repo.UpdateFields(ctx, userID, eventID, set)
 }
 
@@ -118,14 +171,18 @@ func (s *EventService) GetUpcomingEvents(ctx context.Context, userID primitive.O
 	return s.repo.ListUpcoming(ctx, userID, days)
 }
 
-func (s *EventService) GetCalendarEvents(ctx context.Context, userID primitive.ObjectID, year, month int) (map[string][]models.Event, error) {
-	list, err := s.repo.CalendarRange(ctx, userID, year, month)
+// GetCalendarEvents 按 loc (通常为用户时区) 的月份边界与日期分桶
+func (s *EventService) GetCalendarEvents(ctx context.Context, userID primitive.ObjectID, year, month int, loc *time.Location) (map[string][]models.Event, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	list, err := s.repo.CalendarRange(ctx, userID, year, month, loc)
 	if err != nil {
 		return nil, err
 	}
 	m := make(map[string][]models.Event)
 	for _, ev := range list {
-		k := ev.EventDate.Format("2006-01-02")
+		k := models.DayKey(ev.EventDate, loc)
 		m[k] = append(m[k], ev)
 	}
 	return m, nil
@@ -134,3 +191,93 @@ func (s *EventService) GetCalendarEvents(ctx context.Context, userID primitive.O
 func (s *EventService) SearchEvents(ctx context.Context, userID primitive.ObjectID, keyword string, limit int) ([]models.Event, error) {
 	return s.repo.Search(ctx, userID, keyword, limit)
 }
+
+// EditOccurrence 编辑循环事件的单次发生：scope=this 写入例外记录；scope=following 拆分系列
+func (s *EventService) EditOccurrence(ctx context.Context, userID, eventID primitive.ObjectID, req models.EditOccurrenceRequest) (*models.EditOccurrenceResult, error) {
+	ev, err := s.repo.FindByID(ctx, userID, eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+	if ev.EffectiveRecurrenceRule() == nil {
+		return nil, errors.New("event is not recurring")
+	}
+	var at time.Time
+	switch {
+	case req.OccurrenceKey != "":
+		id, t, perr := models.ParseOccurrenceKey(req.OccurrenceKey)
+		if perr != nil {
+			return nil, perr
+		}
+		if id != eventID {
+			return nil, errors.New("occurrence key does not belong to event")
+		}
+		at = t
+	case req.OccurrenceDate != nil:
+		at = *req.OccurrenceDate
+	default:
+		return nil, errors.New("occurrence_key or occurrence_date required")
+	}
+	if len(ev.OccurrencesBetween(at, at, 1)) == 0 {
+		return nil, errors.New("occurrence not found in series")
+	}
+	if req.Action == "" {
+		req.Action = models.OccurrenceActionModify
+	}
+	if req.Action != models.OccurrenceActionCancel && req.Action != models.OccurrenceActionModify {
+		return nil, errors.New("invalid action")
+	}
+	if req.Action == models.OccurrenceActionModify && req.NewDate == nil && req.Title == nil && req.Location == nil {
+		return nil, errors.New("nothing to modify")
+	}
+	if req.Title != nil && *req.Title == "" {
+		return nil, errors.New("title empty")
+	}
+
+	switch req.Scope {
+	case "", models.OccurrenceScopeThis:
+		ex := &models.EventException{EventID: eventID, UserID: userID, OccurrenceDate: at}
+		if req.Action == models.OccurrenceActionCancel {
+			ex.Cancelled = true
+		} else {
+			ex.NewDate, ex.Title, ex.Location = req.NewDate, req.Title, req.Location
+		}
+		saved, err := s.repo.UpsertException(ctx, ex)
+		if err != nil {
+			return nil, err
+		}
+		return &models.EditOccurrenceResult{Scope: models.OccurrenceScopeThis, Exception: saved}, nil
+	case models.OccurrenceScopeFollowing:
+		var tail *models.Event
+		if req.Action == models.OccurrenceActionModify {
+			cp := *ev
+			cp.Exceptions = nil
+			cp.EventDate = at
+			if req.NewDate != nil {
+				cp.EventDate = *req.NewDate
+			}
+			if req.Title != nil {
+				cp.Title = *req.Title
+			}
+			if req.Location != nil {
+				cp.Location = *req.Location
+			}
+			tail = &cp
+		}
+		series, newSeries, err := s.repo.SplitSeries(ctx, userID, eventID, at, tail)
+		if err != nil {
+			return nil, err
+		}
+		return &models.EditOccurrenceResult{Scope: models.OccurrenceScopeFollowing, Series: series, NewSeries: newSeries}, nil
+	}
+	return nil, errors.New("invalid scope")
+}
+
+// ListExceptions 列出事件的单次发生例外
+func (s *EventService) ListExceptions(ctx context.Context, userID, eventID primitive.ObjectID) ([]models.EventException, error) {
+	return s.repo.ListExceptions(ctx, userID, eventID)
+}
+
+// RestoreOccurrence 删除例外，恢复该次发生为规则默认
+func (s *EventService) RestoreOccurrence(ctx context.Context, userID, exceptionID primitive.ObjectID) error {
+	return s.repo.DeleteException(ctx, userID, exceptionID)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/templates"
+)
+
+// ErrInvalidEventType 模板的事件类型不合法
+var ErrInvalidEventType = errors.New("invalid event type")
+
+// messageTemplateEventTypes 可单独设置模板的事件类型
+var messageTemplateEventTypes = map[string]bool{
+	"birthday": true, "anniversary": true, "holiday": true, "custom": true, "meeting": true, "deadline": true,
+}
+
+// MessageTemplateService 用户按事件类型自定义提醒模板
+type MessageTemplateService struct {
+	repo repository.MessageTemplateRepository
+}
+
+func NewMessageTemplateService(db *mongo.Database) *MessageTemplateService {
+	return &MessageTemplateService{repo: repository.NewMessageTemplateRepository(db)}
+}
+
+func (s *MessageTemplateService) List(ctx context.Context, userID primitive.ObjectID) ([]models.MessageTemplate, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// Upsert 校验后保存模板；eventType 为 all 表示全部事件类型
+func (s *MessageTemplateService) Upsert(ctx context.Context, userID primitive.ObjectID, eventType, kind, body string) (*models.MessageTemplate, error) {
+	eventType, err := normalizeTemplateEventType(eventType)
+	if err != nil {
+		return nil, err
+	}
+	if err := templates.Default.ValidateOverride(kind, body); err != nil {
+		return nil, err
+	}
+	t := &models.MessageTemplate{UserID: userID, EventType: eventType, Kind: kind, Body: body}
+	if err := s.repo.Upsert(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Delete 删除模板，恢复内置模板
+func (s *MessageTemplateService) Delete(ctx context.Context, userID primitive.ObjectID, eventType, kind string) (bool, error) {
+	eventType, err := normalizeTemplateEventType(eventType)
+	if err != nil {
+		return false, err
+	}
+	return s.repo.Delete(ctx, userID, eventType, kind)
+}
+
+// Defaults 某语言下可覆盖模板的内置源码
+func (s *MessageTemplateService) Defaults(locale string) map[string]string {
+	out := map[string]string{}
+	for _, kind := range templates.OverridableKinds {
+		if src, ok := templates.Default.Source(locale, kind); ok {
+			out[kind] = src
+		}
+	}
+	return out
+}
+
+func normalizeTemplateEventType(eventType string) (string, error) {
+	if eventType == "" || eventType == models.MessageTemplateAllTypes {
+		return "", nil
+	}
+	if !messageTemplateEventTypes[eventType] {
+		return "", fmt.Errorf("%w: %s", ErrInvalidEventType, eventType)
+	}
+	return eventType, nil
+}
+
+// MessageRenderer 按用户语言与自定义模板生成提醒文案；nil 时使用默认语言的内置模板
+type MessageRenderer struct {
+	users     *UserService
+	overrides repository.MessageTemplateRepository
+}
+
+func NewMessageRenderer(db *mongo.Database) *MessageRenderer {
+	return &MessageRenderer{
+		users:     NewUserService(repository.NewUserRepository(db)),
+		overrides: repository.NewMessageTemplateRepository(db),
+	}
+}
+
+// Reminder 提醒标题与正文；提醒设置了 CustomMessage 时正文直接使用它
+func (m *MessageRenderer) Reminder(ctx context.Context, reminder models.Reminder, event models.Event) (subject, text string) {
+	data := templates.ReminderData{
+		Event:    event,
+		Reminder: reminder,
+		DaysLeft: models.CalendarDaysBetween(time.Now(), event.EventDate, event.TimeLocation()),
+		Loc:      event.TimeLocation(),
+	}
+	locale := m.locale(ctx, reminder.UserID)
+	subject = m.render(ctx, locale, reminder.UserID, event.EventType, templates.ReminderSubject, data)
+	if reminder.CustomMessage != "" {
+		return subject, reminder.CustomMessage
+	}
+	return subject, m.render(ctx, locale, reminder.UserID, event.EventType, templates.ReminderText, data)
+}
+
+// ReminderEmail 提醒邮件 HTML 正文，message 为 Reminder 生成的正文
+func (m *MessageRenderer) ReminderEmail(ctx context.Context, userID primitive.ObjectID, message string, event models.Event) string {
+	data := templates.ReminderData{Event: event, Message: message, Loc: event.TimeLocation()}
+	return m.render(ctx, m.locale(ctx, userID), userID, event.EventType, templates.ReminderEmail, data)
+}
+
+func (m *MessageRenderer) locale(ctx context.Context, userID primitive.ObjectID) string {
+	if m == nil {
+		return templates.DefaultLocale
+	}
+	return m.users.Locale(ctx, userID)
+}
+
+// render 优先使用用户模板，用户模板出错时记录日志并回退内置模板
+func (m *MessageRenderer) render(ctx context.Context, locale string, userID primitive.ObjectID, eventType, kind string, data templates.ReminderData) string {
+	if m != nil && m.overrides != nil {
+		t, err := m.overrides.Find(ctx, userID, eventType, kind)
+		if err != nil {
+			log.Printf("message template lookup user=%s kind=%s: %v", userID.Hex(), kind, err)
+		} else if t != nil {
+			out, err := templates.Default.RenderOverride(locale, kind, t.Body, data)
+			if err == nil {
+				return out
+			}
+			log.Printf("message template %s render failed, using built-in: %v", t.ID.Hex(), err)
+		}
+	}
+	out, err := templates.Default.Render(locale, kind, data)
+	if err != nil {
+		log.Printf("render %s/%s failed: %v", locale, kind, err)
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/email"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	nHub "github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// RegisterNotificationChannels 注册内置渠道：app / email / webhook / chat 始终可用；
+// telegram 需 TELEGRAM_BOT_TOKEN，webpush 需 VAPID_PRIVATE_KEY (及 VAPID_PUBLIC_KEY / VAPID_SUBJECT)；
+// NOTIFY_ALLOW_PRIVATE_TARGETS=true 时允许投递到内网地址
+func RegisterNotificationChannels(reg *notify.Registry, db *mongo.Database, hub *nHub.Hub) {
+	notify.AllowPrivateTargets = os.Getenv("NOTIFY_ALLOW_PRIVATE_TARGETS") == "true"
+	notifications := NewNotificationService(db)
+	endpoints := repository.NewNotificationEndpointRepository(db)
+	reg.Register(&appChannel{notifications: notifications, hub: hub})
+	reg.Register(&emailChannel{db: db, notifications: notifications, hub: hub, messages: NewMessageRenderer(db)})
+	reg.Register(notify.NewWebhookChannel(endpoints))
+	reg.Register(notify.NewChatChannel(endpoints))
+	if token := os.Getenv("TELEGRAM_BOT_TOKEN"); token != "" {
+		reg.Register(notify.NewTelegramChannel(token, endpoints))
+	}
+	if key := os.Getenv("VAPID_PRIVATE_KEY"); key != "" {
+		subject := os.Getenv("VAPID_SUBJECT")
+		if subject == "" {
+			subject = "mailto:admin@todoing.local"
+		}
+		ch, err := notify.NewWebPushChannel(os.Getenv("VAPID_PUBLIC_KEY"), key, subject, endpoints)
+		if err != nil {
+			log.Printf("Web Push channel disabled: %v", err)
+		} else {
+			reg.Register(ch)
+		}
+	}
+	log.Printf("Notification channels registered: %v", reg.IDs())
+}
+
+// appChannel 站内通知 + SSE
+type appChannel struct {
+	notifications *NotificationService
+	hub           *nHub.Hub
+}
+
+func (c *appChannel) ID() string { return "app" }
+
+func (c *appChannel) Send(ctx context.Context, msg notify.Message) error {
+	log.Printf("App notification for user %s: %s", msg.UserID.Hex(), msg.Text)
+	n, err := c.notifications.Create(ctx, models.NotificationCreate{
+		UserID:   msg.UserID,
+		Type:     "reminder",
+		Message:  msg.Text,
+		EventID:  eventIDPtr(msg.Event),
+		Metadata: map[string]interface{}{"event_title": msg.Event.Title, "event_type": msg.Event.EventType},
+		Urgent:   msg.Urgent,
+	})
+	if errors.Is(err, ErrNotificationMuted) {
+		return nil
+	}
+	if err == nil && c.hub != nil {
+		c.hub.Broadcast(n)
+	}
+	return err
+}
+
+// emailChannel 邮件提醒 (与登录验证码共用 SMTP 配置)，发送后写一条站内记录
+type emailChannel struct {
+	db            *mongo.Database
+	notifications *NotificationService
+	hub           *nHub.Hub
+	messages      *MessageRenderer
+}
+
+func (c *emailChannel) ID() string { return "email" }
+
+func (c *emailChannel) Send(ctx context.Context, msg notify.Message) error {
+	userEmail, err := c.userEmail(ctx, msg.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user email: %w", err)
+	}
+	if userEmail == "" {
+		return notify.Permanent(fmt.Errorf("user email not found"))
+	}
+	body := c.messages.ReminderEmail(ctx, msg.UserID, msg.Text, msg.Event)
+	if err := email.SendGeneric(userEmail, msg.Subject, body); err != nil {
+		log.Printf("Email send failed %s: %v", userEmail, err)
+		return err
+	}
+	log.Printf("Email sent to %s subject=%s", userEmail, msg.Subject)
+	if c.notifications != nil && c.hub != nil {
+		n, err := c.notifications.Create(ctx, models.NotificationCreate{UserID: msg.UserID, Type: "email", Message: fmt.Sprintf("Email sent: %s", msg.Subject), EventID: eventIDPtr(msg.Event)})
+		if err == nil {
+			c.hub.Broadcast(n)
+		}
+	}
+	return nil
+}
+
+func (c *emailChannel) userEmail(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	var doc struct {
+		Email string `bson:"email"`
+	}
+	if err := c.db.Collection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&doc); err != nil {
+		return "", err
+	}
+	return doc.Email, nil
+}
+
+func eventIDPtr(event models.Event) *primitive.ObjectID {
+	if event.ID.IsZero() {
+		return nil
+	}
+	id := event.ID
+	return &id
+}
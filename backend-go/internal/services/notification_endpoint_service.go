@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// ErrInvalidEndpoint 投递目标配置不合法 (渠道未注册、渠道无需配置目标或字段校验失败)
+var ErrInvalidEndpoint = errors.New("invalid notification endpoint")
+
+// NotificationEndpointService 管理用户在外部渠道 (webhook / chat / telegram / webpush) 的投递目标
+type NotificationEndpointService struct {
+	endpoints repository.NotificationEndpointRepository
+	channels  *notify.Registry
+}
+
+func NewNotificationEndpointService(db *mongo.Database) *NotificationEndpointService {
+	return &NotificationEndpointService{endpoints: repository.NewNotificationEndpointRepository(db), channels: notify.Default}
+}
+
+// Channels 已注册的渠道 ID 与 Web Push 公钥 (未启用时为空)
+func (s *NotificationEndpointService) Channels() ([]string, string) {
+	var vapid string
+	if ch, ok := s.channels.Get("webpush"); ok {
+		if wp, ok := ch.(interface{ PublicKey() string }); ok {
+			vapid = wp.PublicKey()
+		}
+	}
+	return s.channels.IDs(), vapid
+}
+
+func (s *NotificationEndpointService) Create(ctx context.Context, userID primitive.ObjectID, req models.CreateNotificationEndpointRequest) (*models.NotificationEndpointCreated, error) {
+	channel := strings.ToLower(strings.TrimSpace(req.Channel))
+	ch, ok := s.channels.Get(channel)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", notify.ErrUnknownChannel, channel)
+	}
+	v, ok := ch.(notify.EndpointValidator)
+	if !ok {
+		return nil, fmt.Errorf("%w: channel %s does not use endpoints", ErrInvalidEndpoint, channel)
+	}
+	ep := &models.NotificationEndpoint{
+		UserID: userID, Channel: channel, Name: strings.TrimSpace(req.Name),
+		URL: strings.TrimSpace(req.URL), Format: strings.ToLower(strings.TrimSpace(req.Format)), Secret: req.Secret,
+		ChatID: strings.TrimSpace(req.ChatID), P256dh: req.P256dh, Auth: req.Auth, CreatedAt: time.Now(),
+	}
+	generated := ""
+	if channel == "webhook" && ep.Secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		ep.Secret = hex.EncodeToString(buf)
+		generated = ep.Secret
+	}
+	if err := v.ValidateEndpoint(ep); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEndpoint, err)
+	}
+	if err := s.endpoints.Insert(ctx, ep); err != nil {
+		return nil, err
+	}
+	return &models.NotificationEndpointCreated{Endpoint: *ep, Secret: generated}, nil
+}
+
+func (s *NotificationEndpointService) List(ctx context.Context, userID primitive.ObjectID, channel string) ([]models.NotificationEndpoint, error) {
+	return s.endpoints.ListByUser(ctx, userID, channel)
+}
+
+func (s *NotificationEndpointService) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	return s.endpoints.Delete(ctx, userID, id)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// NotificationPreferenceService 用户通知偏好：按类型启用的渠道、免打扰时段、周末静音、汇总 / 立即投递
+type NotificationPreferenceService struct {
+	repo     repository.NotificationPreferenceRepository
+	users    *UserService
+	channels *notify.Registry
+}
+
+func NewNotificationPreferenceService(db *mongo.Database) *NotificationPreferenceService {
+	return &NotificationPreferenceService{
+		repo:     repository.NewNotificationPreferenceRepository(db),
+		users:    NewUserService(repository.NewUserRepository(db)),
+		channels: notify.Default,
+	}
+}
+
+// Get 用户偏好，未设置时返回默认值 (全部渠道、立即投递、无免打扰)
+func (s *NotificationPreferenceService) Get(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error) {
+	p, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		p = &models.NotificationPreferences{UserID: userID, Mode: models.NotificationModeImmediate}
+	}
+	return p, nil
+}
+
+// Update 整体替换用户偏好
+func (s *NotificationPreferenceService) Update(ctx context.Context, userID primitive.ObjectID, p models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	p.UserID = userID
+	if p.Mode == "" {
+		p.Mode = models.NotificationModeImmediate
+	}
+	for t, tp := range p.Types {
+		if tp.Channels != nil {
+			tp.Channels = models.ReminderChannels(tp.Channels).Normalize()
+		}
+		for _, c := range tp.Channels {
+			if _, ok := s.channels.Get(c); !ok {
+				return nil, fmt.Errorf("%w: %s", notify.ErrUnknownChannel, c)
+			}
+		}
+		p.Types[t] = tp
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Upsert(ctx, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// NotificationPolicy 用户偏好与时区，投递前据此判断渠道、汇总与延后
+type NotificationPolicy struct {
+	Prefs    *models.NotificationPreferences // nil 表示未设置
+	Location *time.Location
+}
+
+// Policy 读取偏好失败时按默认策略 (立即投递) 处理，不阻断通知
+func (s *NotificationPreferenceService) Policy(ctx context.Context, userID primitive.ObjectID) NotificationPolicy {
+	if s == nil {
+		return NotificationPolicy{Location: time.UTC}
+	}
+	p, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to load notification preferences for %s: %v", userID.Hex(), err)
+		p = nil
+	}
+	if p == nil {
+		return NotificationPolicy{Location: time.UTC}
+	}
+	return NotificationPolicy{Prefs: p, Location: s.users.Location(ctx, userID)}
+}
+
+// AllowsChannel 该类型通知是否允许经 channel 投递
+func (p NotificationPolicy) AllowsChannel(notificationType, channel string) bool {
+	return p.Prefs.AllowsChannel(notificationType, channel)
+}
+
+// Digest 该类型通知是否走汇总 (不实时推送)
+func (p NotificationPolicy) Digest(notificationType string) bool {
+	return p.Prefs.ModeFor(notificationType) == models.NotificationModeDigest
+}
+
+// DeferUntil 非紧急通知在免打扰期间应延后到的时间，可立即投递时为零值
+func (p NotificationPolicy) DeferUntil(notificationType string, urgent bool, now time.Time) time.Time {
+	return p.Prefs.DeferUntil(notificationType, urgent, now, p.Location)
+}
+
+// Channels 过滤出该类型允许的渠道；汇总模式只保留站内通知 (app)
+func (p NotificationPolicy) Channels(notificationType string, channels []string) []string {
+	out := make([]string, 0, len(channels))
+	for _, c := range channels {
+		if !p.AllowsChannel(notificationType, c) {
+			continue
+		}
+		if p.Digest(notificationType) && c != "app" {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// Admit 实现 notifications.Gate：关闭站内渠道或汇总模式的通知不实时推送，免打扰期间延后推送
+func (s *NotificationPreferenceService) Admit(n models.Notification) (time.Time, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	p := s.Policy(ctx, n.UserID)
+	if !p.AllowsChannel(n.Type, "app") || p.Digest(n.Type) {
+		return time.Time{}, false
+	}
+	at := p.DeferUntil(n.Type, n.Urgent, time.Now())
+	if n.DeliverAt != nil && n.DeliverAt.After(at) {
+		at = *n.DeliverAt
+	}
+	return at, true
+}
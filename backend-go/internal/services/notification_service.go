@@ -11,6 +11,8 @@ package services
 import (
 	"context"
 	"errors"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
@@ -20,15 +22,25 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type NotificationService struct{ db *mongo.Database }
+// ErrNotificationMuted 用户已关闭该类型的站内通知
+var ErrNotificationMuted = errors.New("notification muted by preferences")
+
+// ErrNotificationNotFound 通知不存在、不属于该用户或状态不满足 (如已读)
+var ErrNotificationNotFound = errors.New("not_found")
+
+type NotificationService struct {
+	db    *mongo.Database
+	prefs *NotificationPreferenceService
+}
 
 func NewNotificationService(db *mongo.Database) *NotificationService {
-	return &NotificationService{db: db}
+	return &NotificationService{db: db, prefs: NewNotificationPreferenceService(db)}
 }
 
 func (s *NotificationService) collection() *mongo.Collection { return s.db.Collection("notifications") }
 
-// Create 插入通知
+// Create 插入通知；按用户偏好标记汇总，免打扰期间的非紧急通知延后到时段结束 (deliver_at)。
+// 同一线程 (ThreadKey) 已有未读、未归档通知时合并：新通知继承计数与首条时间并替换旧通知，线程随之移到列表最前
 func (s *NotificationService) Create(ctx context.Context, in models.NotificationCreate) (models.Notification, error) {
 	n := models.Notification{
 		ID:        primitive.NewObjectID(),
@@ -38,38 +50,179 @@ func (s *NotificationService) Create(ctx context.Context, in models.Notification
 		EventID:   in.EventID,
 		CreatedAt: time.Now(),
 		Metadata:  in.Metadata,
+		Urgent:    in.Urgent,
+		GroupKey:  in.ThreadKey(),
+	}
+	policy := s.prefs.Policy(ctx, in.UserID)
+	if !policy.AllowsChannel(in.Type, "app") {
+		return models.Notification{}, ErrNotificationMuted
+	}
+	n.Digest = policy.Digest(in.Type)
+	if at := policy.DeferUntil(in.Type, in.Urgent, n.CreatedAt); !at.IsZero() {
+		n.DeliverAt = &at
+	}
+	prev, err := s.openThread(ctx, in.UserID, n.GroupKey)
+	if err != nil {
+		return models.Notification{}, err
+	}
+	if prev != nil {
+		n.Count = prev.Count + 1
+		if prev.Count == 0 {
+			n.Count = 2
+		}
+		first := prev.CreatedAt
+		if prev.FirstAt != nil {
+			first = *prev.FirstAt
+		}
+		n.FirstAt = &first
+		n.Replaces = &prev.ID
 	}
 	if _, err := s.collection().InsertOne(ctx, n); err != nil {
 		return models.Notification{}, err
 	}
+	if prev != nil {
+		if _, err := s.collection().DeleteOne(ctx, bson.M{"_id": prev.ID, "user_id": in.UserID}); err != nil {
+			return n, err
+		}
+	}
 	return n, nil
 }
 
-// List 列出通知 (倒序) 支持未读过滤
-func (s *NotificationService) List(ctx context.Context, userID primitive.ObjectID, unreadOnly bool, limit int) ([]models.Notification, error) {
+// openThread 同一线程中最新的未读、未归档通知，不存在时返回 nil
+func (s *NotificationService) openThread(ctx context.Context, userID primitive.ObjectID, key string) (*models.Notification, error) {
+	if key == "" {
+		return nil, nil
+	}
+	filter := bson.M{"user_id": userID, "group_key": key, "read_at": bson.M{"$exists": false}, "archived_at": bson.M{"$exists": false}}
+	var prev models.Notification
+	err := s.collection().FindOne(ctx, filter, options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})).Decode(&prev)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &prev, nil
+}
+
+// visibleFilter 用户可见的通知：已到期推送 (非免打扰延后中)，按归档状态区分
+func visibleFilter(userID primitive.ObjectID, archived bool) bson.M {
+	filter := bson.M{"user_id": userID, "$or": bson.A{
+		bson.M{"deliver_at": bson.M{"$exists": false}},
+		bson.M{"deliver_at": bson.M{"$lte": time.Now()}},
+	}}
+	filter["archived_at"] = bson.M{"$exists": archived}
+	return filter
+}
+
+// List 按 ID 倒序分页列出通知，支持未读 / 类型 / 归档过滤；尚在免打扰延后中的通知不返回
+func (s *NotificationService) List(ctx context.Context, userID primitive.ObjectID, q models.NotificationQuery) (models.NotificationPage, error) {
+	limit := q.Limit
 	if limit <= 0 || limit > 200 {
 		limit = 50
 	}
-	filter := bson.M{"user_id": userID}
-	if unreadOnly {
+	filter := visibleFilter(userID, q.Archived)
+	if q.UnreadOnly {
 		filter["read_at"] = bson.M{"$exists": false}
 	}
-	findOpts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(int64(limit))
+	if q.Type != "" {
+		filter["type"] = q.Type
+	}
+	if !q.Cursor.IsZero() {
+		filter["_id"] = bson.M{"$lt": q.Cursor}
+	}
+	// 多取一条判断是否还有下一页
+	findOpts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(int64(limit + 1))
 	cur, err := s.collection().Find(ctx, filter, findOpts)
 	if err != nil {
-		return nil, err
+		return models.NotificationPage{}, err
 	}
 	defer cur.Close(ctx)
-	var out []models.Notification
+	page := models.NotificationPage{Notifications: []models.Notification{}}
 	for cur.Next(ctx) {
 		var n models.Notification
 		if err := cur.Decode(&n); err == nil {
-			out = append(out, n)
+			page.Notifications = append(page.Notifications, n)
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return models.NotificationPage{}, err
+	}
+	if len(page.Notifications) > limit {
+		page.Notifications = page.Notifications[:limit]
+		page.NextCursor = page.Notifications[limit-1].ID.Hex()
+	}
+	return page, nil
+}
+
+// UnreadCounts 按类型统计未读、未归档且已到期推送的通知
+func (s *NotificationService) UnreadCounts(ctx context.Context, userID primitive.ObjectID) (models.UnreadCounts, error) {
+	match := visibleFilter(userID, false)
+	match["read_at"] = bson.M{"$exists": false}
+	cur, err := s.collection().Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$type", "n": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return models.UnreadCounts{}, err
+	}
+	defer cur.Close(ctx)
+	out := models.UnreadCounts{ByType: map[string]int64{}}
+	for cur.Next(ctx) {
+		var row struct {
+			Type string `bson:"_id"`
+			N    int64  `bson:"n"`
+		}
+		if err := cur.Decode(&row); err == nil {
+			out.ByType[row.Type] = row.N
+			out.Total += row.N
 		}
 	}
 	return out, cur.Err()
 }
 
+// ListAfter SSE 断线重连补发：after 之后创建的通知，以及 after 之后才到期推送的延后通知 (按 ID 正序)；
+// 汇总模式与尚在延后中的通知不返回，与实时推送一致
+func (s *NotificationService) ListAfter(ctx context.Context, userID, after primitive.ObjectID, limit int) ([]models.Notification, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 100
+	}
+	now := time.Now()
+	filter := bson.M{"user_id": userID, "digest": bson.M{"$ne": true}, "$or": bson.A{
+		bson.M{"_id": bson.M{"$gt": after}, "deliver_at": bson.M{"$exists": false}},
+		bson.M{"_id": bson.M{"$gt": after}, "deliver_at": bson.M{"$lte": now}},
+		bson.M{"deliver_at": bson.M{"$gt": after.Timestamp(), "$lte": now}},
+	}}
+	cur, err := s.collection().Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	out := []models.Notification{}
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListDigest 汇总模式下 since 之后暂存且未读的通知 (正序)
+func (s *NotificationService) ListDigest(ctx context.Context, userID primitive.ObjectID, since time.Time, limit int) ([]models.Notification, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	filter := bson.M{"user_id": userID, "digest": true, "created_at": bson.M{"$gt": since}, "read_at": bson.M{"$exists": false}, "archived_at": bson.M{"$exists": false}}
+	cur, err := s.collection().Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	out := []models.Notification{}
+	if err := cur.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MarkRead 标记某条通知已读
 func (s *NotificationService) MarkRead(ctx context.Context, userID, id primitive.ObjectID) error {
 	now := time.Now()
@@ -78,7 +231,7 @@ func (s *NotificationService) MarkRead(ctx context.Context, userID, id primitive
 		return err
 	}
 	if res.MatchedCount == 0 {
-		return errors.New("not_found")
+		return ErrNotificationNotFound
 	}
 	return nil
 }
@@ -93,4 +246,103 @@ func (s *NotificationService) MarkAllRead(ctx context.Context, userID primitive.
 	return res.ModifiedCount, nil
 }
 
+// Archive 归档 / 取消归档
+func (s *NotificationService) Archive(ctx context.Context, userID, id primitive.ObjectID, archived bool) error {
+	update := bson.M{"$unset": bson.M{"archived_at": ""}}
+	if archived {
+		update = bson.M{"$set": bson.M{"archived_at": time.Now()}}
+	}
+	res, err := s.collection().UpdateOne(ctx, bson.M{"_id": id, "user_id": userID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// Delete 删除单条通知
+func (s *NotificationService) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	res, err := s.collection().DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// NotificationRetention 通知保留期 (天)，由 Mongo TTL 索引自动删除；0 表示不自动删除
+type NotificationRetention struct {
+	Days         int // 自创建起
+	ArchivedDays int // 自归档起
+}
+
+// 默认保留期，可用 NOTIFICATION_RETENTION_DAYS / NOTIFICATION_ARCHIVE_RETENTION_DAYS 覆盖
+const (
+	defaultNotificationRetentionDays = 180
+	defaultArchivedRetentionDays     = 30
+)
+
+func NotificationRetentionFromEnv() NotificationRetention {
+	return NotificationRetention{
+		Days:         retentionDaysFromEnv("NOTIFICATION_RETENTION_DAYS", defaultNotificationRetentionDays),
+		ArchivedDays: retentionDaysFromEnv("NOTIFICATION_ARCHIVE_RETENTION_DAYS", defaultArchivedRetentionDays),
+	}
+}
+
+func retentionDaysFromEnv(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil && v >= 0 {
+		return v
+	}
+	return def
+}
+
+// TTL 索引名 (修改保留期时按名称 collMod)
+const (
+	notificationTTLIndex         = "notifications_created_ttl"
+	archivedNotificationTTLIndex = "notifications_archived_ttl"
+)
+
+// EnsureIndexes 创建列表 / 线程索引与 TTL 保留索引；保留期变化时更新已有 TTL 索引，设为 0 时删除
+func (s *NotificationService) EnsureIndexes(ctx context.Context, r NotificationRetention) error {
+	_, err := s.collection().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "group_key", Value: 1}}, Options: options.Index().SetSparse(true)},
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.ensureTTL(ctx, notificationTTLIndex, "created_at", r.Days); err != nil {
+		return err
+	}
+	return s.ensureTTL(ctx, archivedNotificationTTLIndex, "archived_at", r.ArchivedDays)
+}
+
+func (s *NotificationService) ensureTTL(ctx context.Context, name, field string, days int) error {
+	if days <= 0 {
+		_, err := s.collection().Indexes().DropOne(ctx, name)
+		var se mongo.ServerError
+		if errors.As(err, &se) && se.HasErrorCode(27) { // IndexNotFound
+			return nil
+		}
+		return err
+	}
+	seconds := int32(days * 24 * 3600)
+	_, err := s.collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: options.Index().SetName(name).SetExpireAfterSeconds(seconds),
+	})
+	var se mongo.ServerError
+	if errors.As(err, &se) && se.HasErrorCode(85) { // IndexOptionsConflict：保留期已变更
+		return s.db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: s.collection().Name()},
+			{Key: "index", Value: bson.M{"name": name, "expireAfterSeconds": seconds}},
+		}).Err()
+	}
+	return err
+}
+
 func int64Ptr(v int64) *int64 { return &v }
@@ -4,13 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
 	"time"
 
-	"github.com/axfinn/todoIngPlus/backend-go/internal/email"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
 	nHub "github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -23,11 +24,33 @@ type ReminderScheduler struct {
 	ticker          *time.Ticker
 	stopChan        chan bool
 	running         bool
-	notificationSvc *NotificationService
 	hub             *nHub.Hub
 	eventRepo       repository.EventRepository
+	reminderRepo    repository.ReminderRepository
+	deliveries      repository.ReminderDeliveryRepository
+	channels        *notify.Registry
+	preferences     *NotificationPreferenceService // 用户通知偏好 (nil 表示全部立即发送)
+	messages        *MessageRenderer               // 按用户语言与自定义模板生成文案 (nil 使用默认语言内置模板)
+	instanceID      string                         // 租约持有者标识 (INSTANCE_ID 或 hostname-pid)
+	lease           time.Duration                  // 单条提醒的租约时长，超时未完成视为实例崩溃，可被接管
+	maxAttempts     int                            // 达到后进入死信
 }
 
+// maxClaimsPerScan 单轮最多处理的提醒数，余下的留到下一轮
+const maxClaimsPerScan = 500
+
+// 发送失败的指数退避：首次重试间隔略短于扫描周期，保证下一轮可重新领取
+const (
+	reminderRetryBase = 50 * time.Second
+	reminderRetryMax  = time.Hour
+)
+
+// defaultReminderMaxAttempts 单次提醒的最大投递尝试次数，可用 REMINDER_MAX_ATTEMPTS 覆盖
+const defaultReminderMaxAttempts = 5
+
+// defaultReminderLease 默认租约时长，可用 REMINDER_LEASE_SECONDS 覆盖；每个渠道发送前续约，发送 ctx 以租约到期为截止时间
+const defaultReminderLease = 2 * time.Minute
+
 // NewReminderScheduler 创建提醒调度器
 func NewReminderScheduler(db *mongo.Database, hub *nHub.Hub) *ReminderScheduler {
 	eventRepo := repository.NewEventRepository(db)
@@ -37,13 +60,46 @@ func NewReminderScheduler(db *mongo.Database, hub *nHub.Hub) *ReminderScheduler
 		reminderService: NewReminderService(reminderRepo),
 		eventService:    NewEventService(eventRepo),
 		eventRepo:       eventRepo,
+		reminderRepo:    reminderRepo,
+		instanceID:      schedulerInstanceID(),
+		lease:           reminderLeaseFromEnv(),
+		maxAttempts:     reminderMaxAttemptsFromEnv(),
+		deliveries:      repository.NewReminderDeliveryRepository(db),
+		channels:        notify.Default,
+		preferences:     NewNotificationPreferenceService(db),
+		messages:        NewMessageRenderer(db),
 		stopChan:        make(chan bool),
 		running:         false,
-		notificationSvc: NewNotificationService(db),
 		hub:             hub,
 	}
 }
 
+// schedulerInstanceID 优先使用 INSTANCE_ID，否则使用 hostname-pid
+func schedulerInstanceID() string {
+	if id := os.Getenv("INSTANCE_ID"); id != "" {
+		return id
+	}
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+func reminderLeaseFromEnv() time.Duration {
+	if v, err := strconv.Atoi(os.Getenv("REMINDER_LEASE_SECONDS")); err == nil && v > 0 {
+		return time.Duration(v) * time.Second
+	}
+	return defaultReminderLease
+}
+
+func reminderMaxAttemptsFromEnv() int {
+	if v, err := strconv.Atoi(os.Getenv("REMINDER_MAX_ATTEMPTS")); err == nil && v > 0 {
+		return v
+	}
+	return defaultReminderMaxAttempts
+}
+
 // Start 启动调度器
 func (s *ReminderScheduler) Start() {
 	if s.running {
@@ -89,6 +145,7 @@ func (s *ReminderScheduler) Stop() {
 }
 
 // checkAndSendReminders 检查并发送提醒
+// 多实例部署时每个实例都会运行：事件开始时间线按发生时间原子去重，到期提醒逐条领取租约后再发送
 func (s *ReminderScheduler) checkAndSendReminders() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -101,220 +158,250 @@ func (s *ReminderScheduler) checkAndSendReminders() {
 	if errEv == nil {
 		ecs := NewEventCommentService(s.db)
 		for _, ev := range events {
-			if ev.LastTriggeredAt != nil && now.Sub(*ev.LastTriggeredAt) < 55*time.Second {
-				continue
+			if ok, err := s.eventRepo.ClaimTrigger(ctx, ev.ID, ev.EventDate); err != nil || !ok {
+				continue // 已由本实例或其他实例记录
 			}
 			content := fmt.Sprintf("系统: 事件开始触发 - %s", ev.Title)
-			if c, err := ecs.AddComment(ctx, ev.UserID, ev.ID, models.CreateEventCommentRequest{Content: content, Type: "system", Meta: map[string]string{"kind": "event_start"}}); err != nil {
+			if c, err := ecs.AddComment(ctx, ev.UserID, ev.ID, models.CreateEventCommentRequest{Content: content, Type: "system", Meta: map[string]string{"kind": "event_start", "occurrence_key": ev.OccurrenceKey}}); err != nil {
 				log.Printf("timeline event_start comment err: %v", err)
 			} else if s.hub != nil {
 				// 推送通知用于前端实时刷新（事件时间线）
 				n := models.Notification{UserID: ev.UserID, Type: "timeline_event", Message: content, CreatedAt: time.Now(), EventID: &ev.ID, Metadata: map[string]interface{}{"comment_id": c.ID.Hex(), "kind": "event_start"}}
 				s.hub.Broadcast(n)
+				s.hub.Publish(nHub.NewChange(ev.UserID, nHub.EventTimelineTopic(ev.ID), nHub.ActionCreated, c.ID.Hex(), c))
 			}
-			_ = s.eventRepo.MarkTriggered(ctx, ev.ID, now)
 		}
 	}
 
-	// 2) 获取待发送的提醒
-	pendingReminders, err := s.reminderService.GetPendingReminders(ctx)
-	if err != nil {
-		log.Printf("Failed to get pending reminders: %v", err)
-		return
-	}
-
-	if len(pendingReminders) == 0 {
-		return
+	// 2) 逐条领取并发送到期提醒
+	sent, failed := s.drainDue(ctx, s.sendChannel)
+	if sent+failed > 0 {
+		log.Printf("Reminder scan on %s: sent=%d failed=%d", s.instanceID, sent, failed)
 	}
+}
 
-	log.Printf("Found %d pending reminders", len(pendingReminders))
+// channelSender 按渠道发送单条提醒
+type channelSender func(ctx context.Context, channel string, reminderWithEvent models.ReminderWithEvent) error
 
-	for _, reminderWithEvent := range pendingReminders {
-		if err := s.sendReminder(ctx, reminderWithEvent); err != nil {
-			log.Printf("Failed to send reminder %s: %v", reminderWithEvent.ID.Hex(), err)
+// drainDue 循环领取到期提醒并投递，返回本轮成功 / 失败的提醒数
+func (s *ReminderScheduler) drainDue(ctx context.Context, send channelSender) (sent, failed int) {
+	for i := 0; i < maxClaimsPerScan && ctx.Err() == nil; i++ {
+		claim, err := s.reminderRepo.ClaimDue(ctx, s.instanceID, s.lease)
+		if err != nil {
+			log.Printf("Failed to claim reminder: %v", err)
+			return
+		}
+		if claim == nil {
+			return
+		}
+		if claim.RecoveredFrom != "" {
+			log.Printf("Recovered reminder %s from expired lease of %s", claim.ID.Hex(), claim.RecoveredFrom)
+		}
+		policy := s.preferences.Policy(ctx, claim.UserID)
+		if s.deferForQuietHours(ctx, claim, policy) {
 			continue
 		}
-
-		// 发送成功后写入事件时间线系统记录
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("timeline comment panic: %v", r)
-				}
-			}()
-			ecs := NewEventCommentService(s.db)
-			content := fmt.Sprintf("系统: 已发送提醒 (%s) - %s", reminderWithEvent.Reminder.ReminderType, reminderWithEvent.Event.Title)
-			if c, err := ecs.AddComment(ctx, reminderWithEvent.Reminder.UserID, reminderWithEvent.Event.ID, models.CreateEventCommentRequest{Content: content, Type: "system", Meta: map[string]string{"reminder_id": reminderWithEvent.ID.Hex()}}); err != nil {
-				log.Printf("failed to append system comment for event %s: %v", reminderWithEvent.Event.ID.Hex(), err)
-			} else if s.hub != nil {
-				n := models.Notification{UserID: reminderWithEvent.Reminder.UserID, Type: "timeline_event", Message: content, CreatedAt: time.Now(), EventID: &reminderWithEvent.Event.ID, Metadata: map[string]interface{}{"comment_id": c.ID.Hex(), "reminder_id": reminderWithEvent.ID.Hex(), "kind": "reminder_sent"}}
-				s.hub.Broadcast(n)
-			}
-		}()
-
-		// 标记提醒已发送
-		if err := s.reminderService.MarkReminderSent(ctx, reminderWithEvent.ID); err != nil {
-			log.Printf("Failed to mark reminder as sent %s: %v", reminderWithEvent.ID.Hex(), err)
+		if s.attempt(ctx, claim, policy, send) {
+			sent++
+		} else {
+			failed++
 		}
 	}
+	return
 }
 
-// sendReminder 发送提醒
-func (s *ReminderScheduler) sendReminder(ctx context.Context, reminderWithEvent models.ReminderWithEvent) error {
-	reminder := reminderWithEvent.Reminder
-	event := reminderWithEvent.Event
-
-	// 生成提醒消息
-	message := s.generateReminderMessage(reminder, event)
-
-	// 根据提醒类型发送
-	switch reminder.ReminderType {
-	case "app":
-		return s.sendAppNotification(ctx, reminder.UserID, message, event)
-	case "email":
-		return s.sendEmailReminder(ctx, reminder.UserID, message, event)
-	case "both":
-		// 发送应用内通知
-		if err := s.sendAppNotification(ctx, reminder.UserID, message, event); err != nil {
-			log.Printf("Failed to send app notification: %v", err)
+// isUrgentReminder 高重要程度事件的提醒不受免打扰限制
+func isUrgentReminder(rw models.ReminderWithEvent) bool {
+	return rw.Event.ImportanceLevel >= models.UrgentImportanceLevel
+}
+
+// deferForQuietHours 非紧急提醒落在用户免打扰时段 / 周末静音时，释放租约延后到时段结束 (不计入投递次数)
+func (s *ReminderScheduler) deferForQuietHours(ctx context.Context, claim *models.ReminderClaim, policy NotificationPolicy) bool {
+	rm := claim.Reminder
+	until := policy.DeferUntil("reminder", isUrgentReminder(claim.ReminderWithEvent), time.Now())
+	if until.IsZero() {
+		return false
+	}
+	if err := s.reminderRepo.DeferClaim(ctx, rm.ID, claim.Token, until); err != nil {
+		log.Printf("Failed to defer reminder %s: %v", rm.ID.Hex(), err)
+		return true
+	}
+	log.Printf("Reminder %s deferred to %s (quiet hours)", rm.ID.Hex(), until.Format(time.RFC3339))
+	if s.deliveries == nil {
+		return true
+	}
+	for _, ch := range rm.Channels() {
+		d := models.ReminderDelivery{
+			ReminderID: rm.ID, EventID: rm.EventID, UserID: rm.UserID, Channel: ch,
+			Status: models.DeliveryStatusDefer, Attempt: rm.DeliveryAttempts, ScheduledFor: rm.NextSend, DeferredTo: &until, Instance: s.instanceID,
+		}
+		if err := s.deliveries.Insert(ctx, &d); err != nil {
+			log.Printf("Failed to record reminder delivery %s: %v", rm.ID.Hex(), err)
 		}
-		// 发送邮件提醒
-		return s.sendEmailReminder(ctx, reminder.UserID, message, event)
-	default:
-		return fmt.Errorf("unknown reminder type: %s", reminder.ReminderType)
 	}
+	return true
 }
 
-// generateReminderMessage 生成提醒消息
-func (s *ReminderScheduler) generateReminderMessage(reminder models.Reminder, event models.Event) string {
-	if reminder.CustomMessage != "" {
-		return reminder.CustomMessage
+// attempt 对领取到的提醒做一次投递尝试，每个渠道写一条 reminder_deliveries：
+// 渠道先按用户偏好过滤 (关闭的渠道跳过，汇总模式只写站内通知)；每个渠道发送前续约，续约失败 (已被接管) 立即停止；
+// 全部成功则完成租约 (写 next_send)；有渠道失败则按指数退避延后重试 (仅重试失败渠道，多目标渠道跳过已成功的目标，
+// 不可重试的失败直接放弃)；
+// 达到最大次数进入死信，放弃本次提醒并推进到下一次 next_send
+func (s *ReminderScheduler) attempt(ctx context.Context, claim *models.ReminderClaim, policy NotificationPolicy, send channelSender) bool {
+	rm := claim.Reminder
+	channels := rm.Channels()
+	if len(rm.RetryChannels) > 0 {
+		channels = rm.RetryChannels
 	}
-
-	// 计算距离事件的时间
-	now := time.Now()
-	daysLeft := int(event.EventDate.Sub(now).Hours() / 24)
-
-	var timeDesc string
-	if daysLeft == 0 {
-		timeDesc = "今天"
-	} else if daysLeft == 1 {
-		timeDesc = "明天"
-	} else if daysLeft > 0 {
-		timeDesc = fmt.Sprintf("%d天后", daysLeft)
-	} else {
-		timeDesc = "已过期"
+	channels = policy.Channels("reminder", channels)
+	if len(channels) == 0 {
+		log.Printf("Reminder %s skipped: all channels disabled by preferences", rm.ID.Hex())
+	}
+	attempt := rm.DeliveryAttempts + 1
+	maxAttempts := s.maxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultReminderMaxAttempts
+	}
+	dead := attempt >= maxAttempts
+	var failedChannels []string
+	deliveredEndpoints := append([]string(nil), rm.DeliveredEndpoints...)
+	var lastErr error
+	delivered := 0
+	for _, ch := range channels {
+		// 每个渠道发送前续约，且发送不超过租约期限：持有租约期间其他实例无法接管，避免重复发送
+		until := time.Now().Add(s.lease)
+		if err := s.reminderRepo.RenewClaim(ctx, rm.ID, claim.Token, until); err != nil {
+			log.Printf("Reminder %s stopped before %s: lease renew failed: %v", rm.ID.Hex(), ch, err)
+			return false
+		}
+		start := time.Now()
+		sendCtx, cancel := context.WithDeadline(ctx, until)
+		err := send(sendCtx, ch, claim.ReminderWithEvent)
+		cancel()
+		d := models.ReminderDelivery{
+			ReminderID: rm.ID, EventID: rm.EventID, UserID: rm.UserID,
+			Channel: ch, Status: models.DeliveryStatusSent, Attempt: attempt,
+			LatencyMs: time.Since(start).Milliseconds(), ScheduledFor: rm.NextSend, Instance: s.instanceID,
+		}
+		switch {
+		case err == nil:
+			delivered++
+		case notify.IsPermanent(err): // 重试无法恢复 (未配置目标、被对方拒绝)，直接放弃该渠道
+			log.Printf("Reminder %s via %s failed permanently: %v", rm.ID.Hex(), ch, err)
+			lastErr = err
+			d.Status, d.Error = models.DeliveryStatusDead, err.Error()
+		default:
+			log.Printf("Failed to send reminder %s via %s (attempt %d): %v", rm.ID.Hex(), ch, attempt, err)
+			failedChannels = append(failedChannels, ch)
+			deliveredEndpoints = append(deliveredEndpoints, notify.DeliveredEndpoints(err)...)
+			lastErr = err
+			d.Status, d.Error = models.DeliveryStatusFailed, err.Error()
+			if dead {
+				d.Status = models.DeliveryStatusDead
+			}
+		}
+		if s.deliveries != nil {
+			if err := s.deliveries.Insert(ctx, &d); err != nil {
+				log.Printf("Failed to record reminder delivery %s: %v", rm.ID.Hex(), err)
+			}
+		}
 	}
 
-	eventTypeDesc := s.getEventTypeDescription(event.EventType)
-
-	return fmt.Sprintf("🔔 %s提醒：%s (%s)", eventTypeDesc, event.Title, timeDesc)
-}
-
-// getEventTypeDescription 获取事件类型描述
-func (s *ReminderScheduler) getEventTypeDescription(eventType string) string {
-	switch eventType {
-	case "birthday":
-		return "生日"
-	case "anniversary":
-		return "纪念日"
-	case "holiday":
-		return "节日"
-	case "meeting":
-		return "会议"
-	case "deadline":
-		return "截止日期"
-	case "custom":
-		return "自定义事件"
+	switch {
+	case len(failedChannels) == 0:
+		if err := s.reminderRepo.CompleteClaim(ctx, rm.ID, claim.Token); err != nil {
+			log.Printf("Failed to mark reminder as sent %s: %v", rm.ID.Hex(), err)
+		}
+		if delivered > 0 {
+			s.appendSentTimeline(ctx, claim.ReminderWithEvent)
+		}
+		return lastErr == nil
+	case dead:
+		log.Printf("Reminder %s dead-lettered after %d attempts: %v", rm.ID.Hex(), attempt, lastErr)
+		if err := s.reminderRepo.DeadLetterClaim(ctx, rm.ID, claim.Token, lastErr.Error()); err != nil {
+			log.Printf("Failed to dead-letter reminder %s: %v", rm.ID.Hex(), err)
+		}
 	default:
-		return "事件"
+		retry := models.ReminderRetry{Attempts: attempt, Channels: failedChannels, Endpoints: deliveredEndpoints, Error: lastErr.Error(), RetryAt: time.Now().Add(reminderBackoff(attempt))}
+		if err := s.reminderRepo.RetryClaim(ctx, rm.ID, claim.Token, retry); err != nil {
+			log.Printf("Failed to schedule reminder retry %s: %v", rm.ID.Hex(), err)
+		}
 	}
+	return false
 }
 
-// sendAppNotification 发送应用内通知
-func (s *ReminderScheduler) sendAppNotification(ctx context.Context, userID primitive.ObjectID, message string, event models.Event) error {
-	log.Printf("App notification for user %s: %s", userID.Hex(), message)
-	var eventID *primitive.ObjectID
-	if !event.ID.IsZero() {
-		eid := event.ID
-		eventID = &eid
+// reminderBackoff 第 attempt 次失败后的等待时间：50s, 100s, 200s ... 封顶 1 小时
+func reminderBackoff(attempt int) time.Duration {
+	d := reminderRetryBase
+	for i := 1; i < attempt && d < reminderRetryMax; i++ {
+		d *= 2
 	}
-	n, err := s.notificationSvc.Create(ctx, models.NotificationCreate{
-		UserID:   userID,
-		Type:     "reminder",
-		Message:  message,
-		EventID:  eventID,
-		Metadata: map[string]interface{}{"event_title": event.Title, "event_type": event.EventType},
-	})
-	if err == nil && s.hub != nil {
-		s.hub.Broadcast(n)
+	if d > reminderRetryMax {
+		d = reminderRetryMax
 	}
-	return err
+	return d
 }
 
-// sendEmailReminder 发送邮件提醒
-func (s *ReminderScheduler) sendEmailReminder(ctx context.Context, userID primitive.ObjectID, message string, event models.Event) error {
-	userEmail, err := s.getUserEmail(ctx, userID)
-	if err != nil {
-		return fmt.Errorf("failed to get user email: %w", err)
-	}
-	if userEmail == "" {
-		return fmt.Errorf("user email not found")
-	}
-	subject := fmt.Sprintf("TodoIng 提醒：%s", event.Title)
-	body := s.generateEmailBody(message, event)
-	if err := email.SendGeneric(userEmail, subject, body); err != nil {
-		// 不再模拟，直接返回错误（使用登录同一邮箱配置）
-		log.Printf("Email send failed %s: %v", userEmail, err)
-		return err
+// appendSentTimeline 发送成功后写入事件时间线系统记录
+func (s *ReminderScheduler) appendSentTimeline(ctx context.Context, reminderWithEvent models.ReminderWithEvent) {
+	if s.db == nil {
+		return
 	}
-	log.Printf("Email sent to %s subject=%s", userEmail, subject)
-	if s.notificationSvc != nil && s.hub != nil {
-		var eventID *primitive.ObjectID
-		if !event.ID.IsZero() {
-			eid := event.ID
-			eventID = &eid
-		}
-		n, err2 := s.notificationSvc.Create(ctx, models.NotificationCreate{UserID: userID, Type: "email", Message: fmt.Sprintf("Email sent: %s", subject), EventID: eventID})
-		if err2 == nil {
-			s.hub.Broadcast(n)
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("timeline comment panic: %v", r)
 		}
+	}()
+	ecs := NewEventCommentService(s.db)
+	content := fmt.Sprintf("系统: 已发送提醒 (%s) - %s", reminderWithEvent.Reminder.ReminderType, reminderWithEvent.Event.Title)
+	if c, err := ecs.AddComment(ctx, reminderWithEvent.Reminder.UserID, reminderWithEvent.Event.ID, models.CreateEventCommentRequest{Content: content, Type: "system", Meta: map[string]string{"reminder_id": reminderWithEvent.ID.Hex()}}); err != nil {
+		log.Printf("failed to append system comment for event %s: %v", reminderWithEvent.Event.ID.Hex(), err)
+	} else if s.hub != nil {
+		n := models.Notification{UserID: reminderWithEvent.Reminder.UserID, Type: "timeline_event", Message: content, CreatedAt: time.Now(), EventID: &reminderWithEvent.Event.ID, Metadata: map[string]interface{}{"comment_id": c.ID.Hex(), "reminder_id": reminderWithEvent.ID.Hex(), "kind": "reminder_sent"}}
+		s.hub.Broadcast(n)
+		s.hub.Publish(nHub.NewChange(reminderWithEvent.Reminder.UserID, nHub.EventTimelineTopic(reminderWithEvent.Event.ID), nHub.ActionCreated, c.ID.Hex(), c))
 	}
-	return nil
 }
 
-// getUserEmail 获取用户邮箱
-func (s *ReminderScheduler) getUserEmail(ctx context.Context, userID primitive.ObjectID) (string, error) {
-	users := s.db.Collection("users")
-	var doc struct {
-		Email string `bson:"email"`
-	}
-	err := users.FindOne(ctx, bson.M{"_id": userID}).Decode(&doc)
-	if err != nil {
-		return "", err
+// sendChannel 通过注册表中的渠道发送提醒
+func (s *ReminderScheduler) sendChannel(ctx context.Context, channel string, reminderWithEvent models.ReminderWithEvent) error {
+	ch, ok := s.channels.Get(channel)
+	if !ok {
+		return notify.Permanent(fmt.Errorf("%w: %s", notify.ErrUnknownChannel, channel))
 	}
-	return doc.Email, nil
+	reminder := reminderWithEvent.Reminder
+	event := s.occurrenceForReminder(ctx, reminder, reminderWithEvent.Event)
+	subject, text := s.messages.Reminder(ctx, reminder, event)
+	return ch.Send(ctx, notify.Message{
+		UserID:     reminder.UserID,
+		ReminderID: reminder.ID,
+		Subject:    subject,
+		Text:       text,
+		Event:      event,
+		Urgent:     isUrgentReminder(reminderWithEvent),
+		Delivered:  reminder.DeliveredEndpoints,
+	})
 }
 
-// generateEmailBody 生成邮件正文
-func (s *ReminderScheduler) generateEmailBody(message string, event models.Event) string {
-	return fmt.Sprintf(`
-亲爱的用户，
-
-%s
-
-事件详情：
-- 标题：%s
-- 时间：%s
-- 类型：%s
-- 重要程度：%d/5
-
-请及时关注相关事项。
-
----
-TodoIng 任务管理系统
-`, message, event.Title, event.EventDate.Format("2006-01-02 15:04"),
-		s.getEventTypeDescription(event.EventType), event.ImportanceLevel)
+// occurrenceForReminder 找到本次提醒对应的事件发生 (已应用单次例外：改期/覆盖标题地点)，找不到时返回原事件。
+// 以 next_send (而非当前时间) 在事件时区中的日期 + AdvanceDays 定位发生：重试与免打扰延后只推迟租约，不改 next_send
+func (s *ReminderScheduler) occurrenceForReminder(ctx context.Context, reminder models.Reminder, event models.Event) models.Event {
+	if s.eventRepo == nil || event.RecurrenceType == "" || event.RecurrenceType == "none" {
+		return event
+	}
+	ev, err := s.eventRepo.FindByID(ctx, event.UserID, event.ID)
+	if err != nil {
+		return event
+	}
+	at := time.Now()
+	if reminder.NextSend != nil {
+		at = *reminder.NextSend
+	}
+	day := models.StartOfDay(at, ev.TimeLocation()).AddDate(0, 0, reminder.AdvanceDays)
+	if occ := models.ExpandOccurrences([]models.Event{*ev}, day, day.AddDate(0, 0, 1).Add(-time.Nanosecond), 0); len(occ) > 0 {
+		return occ[0]
+	}
+	return event
 }
 
 // UpdateEventReminders 更新事件的提醒时间（当事件变更时调用）
@@ -340,7 +427,10 @@ func (s *ReminderScheduler) IsRunning() bool {
 // GetStatus 获取调度器状态
 func (s *ReminderScheduler) GetStatus() map[string]interface{} {
 	return map[string]interface{}{
-		"running":    s.running,
-		"last_check": time.Now().Format("2006-01-02 15:04:05"),
+		"running":       s.running,
+		"last_check":    time.Now().Format("2006-01-02 15:04:05"),
+		"instance_id":   s.instanceID,
+		"lease_seconds": int(s.lease.Seconds()),
+		"max_attempts":  s.maxAttempts,
 	}
 }
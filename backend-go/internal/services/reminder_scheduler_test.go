@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// leaseStore 模拟 reminders 集合上的租约语义 (原子领取 / 令牌校验)
+type leaseStore struct {
+	repository.ReminderRepository
+	mu        sync.Mutex
+	reminders map[primitive.ObjectID]*models.Reminder
+	events    map[primitive.ObjectID]models.Event // 按提醒 ID 附带的事件
+	sent      map[primitive.ObjectID]int
+}
+
+func newLeaseStore(n int) *leaseStore {
+	st := &leaseStore{reminders: map[primitive.ObjectID]*models.Reminder{}, sent: map[primitive.ObjectID]int{}}
+	due := time.Now().Add(-time.Minute)
+	for i := 0; i < n; i++ {
+		id := primitive.NewObjectID()
+		st.reminders[id] = &models.Reminder{ID: id, IsActive: true, NextSend: &due}
+	}
+	return st
+}
+
+func (st *leaseStore) ClaimDue(_ context.Context, owner string, lease time.Duration) (*models.ReminderClaim, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	now := time.Now()
+	for _, rm := range st.reminders {
+		if rm.NextSend == nil || rm.NextSend.After(now) || (rm.LeaseUntil != nil && rm.LeaseUntil.After(now)) {
+			continue
+		}
+		claim := &models.ReminderClaim{Token: primitive.NewObjectID().Hex(), RecoveredFrom: rm.LeaseOwner}
+		until := now.Add(lease)
+		rm.LeaseOwner, rm.LeaseToken, rm.LeaseUntil = owner, claim.Token, &until
+		claim.Reminder = *rm
+		claim.Event = st.events[rm.ID]
+		return claim, nil
+	}
+	return nil, nil
+}
+
+func (st *leaseStore) RenewClaim(_ context.Context, id primitive.ObjectID, token string, until time.Time) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	rm := st.reminders[id]
+	if rm.LeaseToken != token {
+		return repository.ErrLeaseLost
+	}
+	rm.LeaseUntil = &until
+	return nil
+}
+
+func (st *leaseStore) CompleteClaim(_ context.Context, id primitive.ObjectID, token string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	rm := st.reminders[id]
+	if rm.LeaseToken != token {
+		return repository.ErrLeaseLost
+	}
+	next := time.Now().Add(24 * time.Hour)
+	rm.NextSend, rm.LeaseOwner, rm.LeaseToken, rm.LeaseUntil = &next, "", "", nil
+	return nil
+}
+
+func (st *leaseStore) RetryClaim(_ context.Context, id primitive.ObjectID, token string, retry models.ReminderRetry) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	rm := st.reminders[id]
+	if rm.LeaseToken != token {
+		return repository.ErrLeaseLost
+	}
+	rm.LeaseOwner, rm.LeaseToken, rm.LeaseUntil = "", "", &retry.RetryAt
+	rm.DeliveryAttempts, rm.RetryChannels, rm.LastError = retry.Attempts, retry.Channels, retry.Error
+	rm.DeliveredEndpoints = retry.Endpoints
+	return nil
+}
+
+func (st *leaseStore) DeadLetterClaim(_ context.Context, id primitive.ObjectID, token string, lastErr string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	rm := st.reminders[id]
+	if rm.LeaseToken != token {
+		return repository.ErrLeaseLost
+	}
+	now := time.Now()
+	next := now.Add(24 * time.Hour)
+	rm.NextSend, rm.LeaseOwner, rm.LeaseToken, rm.LeaseUntil = &next, "", "", nil
+	rm.DeliveryAttempts, rm.RetryChannels, rm.LastError, rm.DeadLetterAt = 0, nil, lastErr, &now
+	return nil
+}
+
+func (st *leaseStore) DeferClaim(_ context.Context, id primitive.ObjectID, token string, until time.Time) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	rm := st.reminders[id]
+	if rm.LeaseToken != token {
+		return repository.ErrLeaseLost
+	}
+	rm.LeaseOwner, rm.LeaseToken, rm.LeaseUntil = "", "", &until
+	return nil
+}
+
+// expireRetries 跳过退避等待，使所有待重试提醒立即可领取
+func (st *leaseStore) expireRetries() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	past := time.Now().Add(-time.Second)
+	for _, rm := range st.reminders {
+		if rm.LeaseUntil != nil {
+			rm.LeaseUntil = &past
+		}
+	}
+}
+
+func (st *leaseStore) record(_ context.Context, _ string, rw models.ReminderWithEvent) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.sent[rw.ID]++
+	return nil
+}
+
+// deliveryLog 内存版 reminder_deliveries
+type deliveryLog struct {
+	repository.ReminderDeliveryRepository
+	mu   sync.Mutex
+	rows []models.ReminderDelivery
+}
+
+func (l *deliveryLog) Insert(_ context.Context, d *models.ReminderDelivery) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rows = append(l.rows, *d)
+	return nil
+}
+
+func TestDrainDueDeliversOnceAcrossInstances(t *testing.T) {
+	st := newLeaseStore(50)
+	var wg sync.WaitGroup
+	for _, id := range []string{"api-1", "api-2", "api-3"} {
+		s := &ReminderScheduler{reminderRepo: st, instanceID: id, lease: time.Minute}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.drainDue(context.Background(), st.record)
+		}()
+	}
+	wg.Wait()
+	require.Len(t, st.sent, 50)
+	for id, n := range st.sent {
+		require.Equal(t, 1, n, id.Hex())
+	}
+}
+
+func TestDrainDueReleasesFailedAndRecoversExpired(t *testing.T) {
+	st := newLeaseStore(2)
+	s := &ReminderScheduler{reminderRepo: st, instanceID: "api-1", lease: time.Minute}
+	sent, failed := s.drainDue(context.Background(), func(context.Context, string, models.ReminderWithEvent) error {
+		return errors.New("smtp down")
+	})
+	require.Equal(t, 0, sent)
+	require.Equal(t, 2, failed)
+	for _, rm := range st.reminders {
+		require.Empty(t, rm.LeaseToken) // 失败后释放租约，重试时间到后可再次领取
+		require.True(t, rm.LeaseUntil.After(time.Now()))
+		past := time.Now().Add(-time.Second)
+		rm.LeaseUntil = &past
+	}
+
+	// 模拟实例崩溃：领取后未完成，租约过期后被其他实例接管
+	crashed, err := st.ClaimDue(context.Background(), "api-crashed", -time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, crashed)
+	other := &ReminderScheduler{reminderRepo: st, instanceID: "api-2", lease: time.Minute}
+	sent, failed = other.drainDue(context.Background(), st.record)
+	require.Equal(t, 2, sent)
+	require.Equal(t, 0, failed)
+	require.ErrorIs(t, st.CompleteClaim(context.Background(), crashed.ID, crashed.Token), repository.ErrLeaseLost)
+}
+
+func TestDrainDueBacksOffThenDeadLetters(t *testing.T) {
+	st := newLeaseStore(1)
+	for _, rm := range st.reminders {
+		rm.ReminderType = models.ReminderChannels{"app", "email"}
+	}
+	history := &deliveryLog{}
+	s := &ReminderScheduler{reminderRepo: st, deliveries: history, instanceID: "api-1", lease: time.Minute, maxAttempts: 3}
+	// app 正常，email 持续失败：只重试 email
+	send := func(_ context.Context, ch string, _ models.ReminderWithEvent) error {
+		if ch == "email" {
+			return errors.New("smtp down")
+		}
+		return nil
+	}
+	for attempt := 1; attempt <= 3; attempt++ {
+		sent, failed := s.drainDue(context.Background(), send)
+		require.Equal(t, 0, sent)
+		require.Equal(t, 1, failed)
+		st.expireRetries()
+	}
+	sent, failed := s.drainDue(context.Background(), send)
+	require.Zero(t, sent+failed) // 死信后推进到下一次提醒，不再重试
+
+	require.Len(t, history.rows, 4)
+	require.Equal(t, []string{"app", "email"}, []string{history.rows[0].Channel, history.rows[1].Channel})
+	require.Equal(t, models.DeliveryStatusSent, history.rows[0].Status)
+	for i, row := range history.rows[1:] {
+		require.Equal(t, "email", row.Channel)
+		require.Equal(t, i+1, row.Attempt)
+		require.Equal(t, "smtp down", row.Error)
+	}
+	require.Equal(t, models.DeliveryStatusFailed, history.rows[2].Status)
+	require.Equal(t, models.DeliveryStatusDead, history.rows[3].Status)
+	for _, rm := range st.reminders {
+		require.NotNil(t, rm.DeadLetterAt)
+		require.Zero(t, rm.DeliveryAttempts)
+		require.True(t, rm.NextSend.After(time.Now()))
+	}
+}
+
+func TestDrainDueRenewsLeasePerChannel(t *testing.T) {
+	st := newLeaseStore(1)
+	for _, rm := range st.reminders {
+		rm.ReminderType = models.ReminderChannels{"app", "email", "webhook"}
+	}
+	s := &ReminderScheduler{reminderRepo: st, instanceID: "api-1", lease: time.Minute}
+	var got []string
+	sent, failed := s.drainDue(context.Background(), func(ctx context.Context, ch string, rw models.ReminderWithEvent) error {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok) // 单个渠道发送不超过租约期限
+		require.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+		got = append(got, ch)
+		if ch == "email" { // 模拟发送期间租约被其他实例接管
+			st.mu.Lock()
+			st.reminders[rw.ID].LeaseToken = "api-2"
+			st.mu.Unlock()
+		}
+		return nil
+	})
+	require.Equal(t, 0, sent)
+	require.Equal(t, 1, failed)
+	require.Equal(t, []string{"app", "email"}, got) // 续约失败后不再发送后续渠道
+	for _, rm := range st.reminders {
+		require.Equal(t, "api-2", rm.LeaseToken)
+	}
+}
+
+func TestDrainDueRetriesOnlyFailedEndpoints(t *testing.T) {
+	st := newLeaseStore(1)
+	for _, rm := range st.reminders {
+		rm.ReminderType = models.ReminderChannels{"webhook"}
+	}
+	s := &ReminderScheduler{reminderRepo: st, instanceID: "api-1", lease: time.Minute}
+	var skipped [][]string
+	send := func(_ context.Context, _ string, rw models.ReminderWithEvent) error {
+		skipped = append(skipped, rw.Reminder.DeliveredEndpoints)
+		if len(skipped) == 1 {
+			return &notify.DeliveryError{Delivered: []string{"ep-ok"}, Err: errors.New("endpoint ep-flaky: http 502")}
+		}
+		return nil
+	}
+	_, failed := s.drainDue(context.Background(), send)
+	require.Equal(t, 1, failed)
+	st.expireRetries()
+	sent, _ := s.drainDue(context.Background(), send)
+	require.Equal(t, 1, sent)
+	require.Equal(t, [][]string{nil, {"ep-ok"}}, skipped)
+}
+
+func TestReminderBackoff(t *testing.T) {
+	require.Equal(t, 50*time.Second, reminderBackoff(1))
+	require.Equal(t, 100*time.Second, reminderBackoff(2))
+	require.Equal(t, 400*time.Second, reminderBackoff(4))
+	require.Equal(t, time.Hour, reminderBackoff(20))
+}
+
+func TestDrainDueSkipsRetryForPermanentFailure(t *testing.T) {
+	st := newLeaseStore(1)
+	for _, rm := range st.reminders {
+		rm.ReminderType = models.ReminderChannels{"app", "webhook"}
+	}
+	history := &deliveryLog{}
+	s := &ReminderScheduler{reminderRepo: st, deliveries: history, instanceID: "api-1", lease: time.Minute}
+	sent, failed := s.drainDue(context.Background(), func(_ context.Context, ch string, _ models.ReminderWithEvent) error {
+		if ch == "webhook" {
+			return notify.Permanent(notify.ErrNoEndpoint)
+		}
+		return nil
+	})
+	require.Equal(t, 0, sent)
+	require.Equal(t, 1, failed)
+	require.Len(t, history.rows, 2)
+	require.Equal(t, models.DeliveryStatusDead, history.rows[1].Status)
+	for _, rm := range st.reminders {
+		require.Zero(t, rm.DeliveryAttempts) // 直接完成本次提醒，不进入重试
+		require.Nil(t, rm.LeaseUntil)
+		require.True(t, rm.NextSend.After(time.Now()))
+	}
+}
+
+// fixedPreferences 所有用户共用同一份通知偏好
+type fixedPreferences struct {
+	p *models.NotificationPreferences
+}
+
+func (f fixedPreferences) Get(context.Context, primitive.ObjectID) (*models.NotificationPreferences, error) {
+	return f.p, nil
+}
+
+func (f fixedPreferences) Upsert(context.Context, *models.NotificationPreferences) error { return nil }
+
+func TestDrainDueHonorsNotificationPreferences(t *testing.T) {
+	st := newLeaseStore(2)
+	var urgent, normal primitive.ObjectID
+	for id, rm := range st.reminders {
+		rm.ReminderType = models.ReminderChannels{"app", "email", "webhook"}
+		if urgent.IsZero() {
+			urgent = id
+		} else {
+			normal = id
+		}
+	}
+	st.events = map[primitive.ObjectID]models.Event{urgent: {ImportanceLevel: models.UrgentImportanceLevel}}
+	// 当前处于免打扰时段 (未设置时区按 UTC)；提醒关闭 email 渠道
+	now := time.Now().UTC()
+	prefs := &models.NotificationPreferences{
+		QuietHours: &models.QuietHours{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")},
+		Types:      map[string]models.NotificationTypePreference{"reminder": {Channels: []string{"app", "webhook"}}},
+	}
+	history := &deliveryLog{}
+	s := &ReminderScheduler{reminderRepo: st, deliveries: history, instanceID: "api-1", lease: time.Minute,
+		preferences: &NotificationPreferenceService{repo: fixedPreferences{prefs}}}
+	var got []string
+	send := func(_ context.Context, ch string, rw models.ReminderWithEvent) error {
+		got = append(got, rw.ID.Hex()+"/"+ch)
+		return nil
+	}
+
+	// 紧急提醒照常发送 (仅允许的渠道)，普通提醒延后到时段结束且不计入投递次数
+	sent, failed := s.drainDue(context.Background(), send)
+	require.Equal(t, 1, sent)
+	require.Zero(t, failed)
+	require.Equal(t, []string{urgent.Hex() + "/app", urgent.Hex() + "/webhook"}, got)
+	rm := st.reminders[normal]
+	require.True(t, rm.LeaseUntil.After(time.Now()))
+	require.Zero(t, rm.DeliveryAttempts)
+	deferred := 0
+	for _, row := range history.rows {
+		if row.Status == models.DeliveryStatusDefer {
+			deferred++
+			require.Equal(t, normal, row.ReminderID)
+			require.NotNil(t, row.DeferredTo)
+		}
+	}
+	require.Equal(t, 3, deferred)
+
+	// 时段结束后发送；汇总模式只写站内通知
+	prefs.QuietHours = nil
+	prefs.Mode = models.NotificationModeDigest
+	st.expireRetries()
+	got = nil
+	sent, _ = s.drainDue(context.Background(), send)
+	require.Equal(t, 1, sent)
+	require.Equal(t, []string{normal.Hex() + "/app"}, got)
+}
+
+// eventByID 仅实现 FindByID 的事件仓库
+type eventByID struct {
+	repository.EventRepository
+	ev models.Event
+}
+
+func (f eventByID) FindByID(context.Context, primitive.ObjectID, primitive.ObjectID) (*models.Event, error) {
+	ev := f.ev
+	return &ev, nil
+}
+
+func TestOccurrenceForReminderUsesNextSendInEventZone(t *testing.T) {
+	// 每天 08:00 (美东) 的事件，提前 1 天 22:00 提醒；按 UTC 计算日期会错位到后一天
+	ev := models.Event{ID: primitive.NewObjectID(), EventDate: time.Date(2030, 1, 1, 13, 0, 0, 0, time.UTC), RecurrenceType: "daily", TimeZone: "America/New_York"}
+	s := &ReminderScheduler{eventRepo: eventByID{ev: ev}}
+	// next_send 早于当前时间 (重试 / 免打扰延后后才发送)，仍应对应 next_send 那次发生
+	next := time.Date(2030, 1, 10, 3, 0, 0, 0, time.UTC) // 2030-01-09 22:00 美东
+	occ := s.occurrenceForReminder(context.Background(), models.Reminder{AdvanceDays: 1, NextSend: &next}, ev)
+	require.True(t, occ.EventDate.Equal(time.Date(2030, 1, 10, 13, 0, 0, 0, time.UTC)), "got %s", occ.EventDate)
+}
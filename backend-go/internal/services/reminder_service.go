@@ -8,18 +8,20 @@ import (
 	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/notify"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // ReminderService 精简：只组合仓储层
 type ReminderService struct {
-	repo repository.ReminderRepository
+	repo     repository.ReminderRepository
+	channels *notify.Registry // 校验提醒渠道
 }
 
 // NewReminderService 创建提醒服务
 func NewReminderService(repo repository.ReminderRepository) *ReminderService {
-	return &ReminderService{repo: repo}
+	return &ReminderService{repo: repo, channels: notify.Default}
 }
 
 // CreateReminder 创建提醒
@@ -27,6 +29,10 @@ func (s *ReminderService) CreateReminder(ctx context.Context, userID primitive.O
 	if s.repo == nil {
 		return nil, errors.New("reminder repo nil")
 	}
+	req.ReminderType = req.ReminderType.Normalize()
+	if err := s.channels.Validate(req.ReminderType); err != nil {
+		return nil, err
+	}
 	r := &models.Reminder{
 		ID:            primitive.NewObjectID(),
 		EventID:       req.EventID,
@@ -61,7 +67,11 @@ func (s *ReminderService) UpdateReminder(ctx context.Context, userID, reminderID
 		set["reminder_times"] = req.ReminderTimes
 	}
 	if req.ReminderType != nil {
-		set["reminder_type"] = *req.ReminderType
+		channels := req.ReminderType.Normalize()
+		if err := s.channels.Validate(channels); err != nil {
+			return nil, err
+		}
+		set["reminder_type"] = channels
 	}
 	if req.CustomMessage != nil {
 		set["custom_message"] = *req.CustomMessage
@@ -32,21 +32,19 @@ func (s *ReportService) Generate(ctx context.Context, userID string, in models.R
 	if in.Title == "" {
 		return nil, errors.New("title required")
 	}
-	// 选取时间范围
+	// 选取时间范围 (按用户时区计算周期起点)
 	if start.IsZero() || end.IsZero() || end.Before(start) {
-		end = time.Now()
-		switch in.Type {
-		case "weekly":
-			start = end.AddDate(0, 0, -7)
-		case "monthly":
-			start = end.AddDate(0, -1, 0)
-		default:
-			start = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
-		}
+		loc := NewUserService(repository.NewUserRepository(s.db)).LocationByHex(ctx, userID)
+		end = time.Now().In(loc)
+		start = models.ReportPeriodStart(in.Type, end, loc)
 	}
 	// Tasks
 	taskColl := s.db.Collection("tasks")
-	taskFilter := bson.M{"createdBy": userID, "createdAt": bson.M{"$gte": start, "$lte": end}}
+	scope, err := repository.TaskAccessFilter(ctx, s.db, userID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	taskFilter := repository.Scoped(scope, bson.M{"createdAt": bson.M{"$gte": start, "$lte": end}})
 	taskCur, err := taskColl.Find(ctx, taskFilter)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// 视图相关错误 (过滤表达式 / 排序错误见 models.ErrInvalidTaskFilter / models.ErrInvalidTaskSort)
+var (
+	ErrSavedViewNameRequired = errors.New("saved view name required")
+	ErrSavedViewNameTaken    = errors.New("saved view name already exists")
+)
+
+const maxSavedViewName = 100
+
+// SavedViewService 保存的任务视图：命名的过滤表达式 + 排序，列表 / 仪表盘 / 统一即将到来列表可按视图筛选任务
+type SavedViewService struct {
+	repo repository.SavedViewRepository
+}
+
+func NewSavedViewService(repo repository.SavedViewRepository) *SavedViewService {
+	return &SavedViewService{repo: repo}
+}
+
+func (s *SavedViewService) List(ctx context.Context, userID primitive.ObjectID) ([]models.SavedView, error) {
+	return s.repo.List(ctx, userID)
+}
+
+func (s *SavedViewService) Get(ctx context.Context, userID, id primitive.ObjectID) (*models.SavedView, error) {
+	return s.repo.Get(ctx, userID, id)
+}
+
+// Create 校验过滤表达式与排序后保存，名称在用户内唯一
+func (s *SavedViewService) Create(ctx context.Context, userID primitive.ObjectID, req models.SavedViewRequest) (*models.SavedView, error) {
+	v := &models.SavedView{UserID: userID}
+	if err := s.apply(ctx, v, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Insert(ctx, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Update 整体替换视图的名称、过滤表达式与排序
+func (s *SavedViewService) Update(ctx context.Context, userID, id primitive.ObjectID, req models.SavedViewRequest) (*models.SavedView, error) {
+	v, err := s.repo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, v, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (s *SavedViewService) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	return s.repo.Delete(ctx, userID, id)
+}
+
+func (s *SavedViewService) apply(ctx context.Context, v *models.SavedView, req models.SavedViewRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return ErrSavedViewNameRequired
+	}
+	if len([]rune(name)) > maxSavedViewName {
+		name = string([]rune(name)[:maxSavedViewName])
+	}
+	if _, err := models.ParseTaskFilter(req.Query); err != nil {
+		return err
+	}
+	if _, err := models.ParseTaskSort(req.Sort); err != nil {
+		return err
+	}
+	existing, err := s.repo.FindByName(ctx, v.UserID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != v.ID {
+		return ErrSavedViewNameTaken
+	}
+	v.Name, v.Query, v.Sort = name, strings.TrimSpace(req.Query), strings.TrimSpace(req.Sort)
+	return nil
+}
+
+// Resolve 组合视图与临时条件：viewID 非空时取视图的过滤表达式，与 expr 取「且」；
+// sort 非空时覆盖视图的排序
+func (s *SavedViewService) Resolve(ctx context.Context, userID primitive.ObjectID, viewID, expr, sort string) (models.TaskFilter, []models.TaskSortField, error) {
+	query, order := "", ""
+	if viewID != "" {
+		id, err := primitive.ObjectIDFromHex(viewID)
+		if err != nil {
+			return models.TaskFilter{}, nil, repository.ErrSavedViewNotFound
+		}
+		v, err := s.repo.Get(ctx, userID, id)
+		if err != nil {
+			return models.TaskFilter{}, nil, err
+		}
+		query, order = v.Query, v.Sort
+	}
+	viewFilter, err := models.ParseTaskFilter(query)
+	if err != nil {
+		return models.TaskFilter{}, nil, err
+	}
+	adHoc, err := models.ParseTaskFilter(expr)
+	if err != nil {
+		return models.TaskFilter{}, nil, err
+	}
+	if strings.TrimSpace(sort) != "" {
+		order = sort
+	}
+	fields, err := models.ParseTaskSort(order)
+	if err != nil {
+		return models.TaskFilter{}, nil, err
+	}
+	return viewFilter.And(adHoc), fields, nil
+}
+
+// TaskMatch 视图与临时条件对应的任务 Mongo 条件 (不含访问范围)，供仪表盘与统一即将到来列表使用；
+// 两者皆为空时返回 nil
+func (s *SavedViewService) TaskMatch(ctx context.Context, userID primitive.ObjectID, viewID, expr string, loc *time.Location) (bson.M, error) {
+	if viewID == "" && strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	f, _, err := s.Resolve(ctx, userID, viewID, expr, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Terms) == 0 {
+		return nil, nil
+	}
+	return repository.TaskFilterBSON(f, userID.Hex(), time.Now(), loc)
+}
+
+// IsSavedViewQueryError 过滤表达式 / 排序 / 视图错误，调用方应返回 400 (视图不存在为 404)
+func IsSavedViewQueryError(err error) bool {
+	return errors.Is(err, models.ErrInvalidTaskFilter) || errors.Is(err, models.ErrInvalidTaskSort) ||
+		errors.Is(err, ErrSavedViewNameRequired) || errors.Is(err, ErrSavedViewNameTaken)
+}
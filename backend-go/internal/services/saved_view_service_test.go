@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// memSavedViewRepo 以 map 模拟视图集合
+type memSavedViewRepo struct {
+	store map[primitive.ObjectID]models.SavedView
+}
+
+func (r *memSavedViewRepo) Insert(ctx context.Context, v *models.SavedView) error {
+	v.ID = primitive.NewObjectID()
+	r.store[v.ID] = *v
+	return nil
+}
+
+func (r *memSavedViewRepo) List(ctx context.Context, userID primitive.ObjectID) ([]models.SavedView, error) {
+	var out []models.SavedView
+	for _, v := range r.store {
+		if v.UserID == userID {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+func (r *memSavedViewRepo) Get(ctx context.Context, userID, id primitive.ObjectID) (*models.SavedView, error) {
+	v, ok := r.store[id]
+	if !ok || v.UserID != userID {
+		return nil, repository.ErrSavedViewNotFound
+	}
+	return &v, nil
+}
+
+func (r *memSavedViewRepo) FindByName(ctx context.Context, userID primitive.ObjectID, name string) (*models.SavedView, error) {
+	for _, v := range r.store {
+		if v.UserID == userID && v.Name == name {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memSavedViewRepo) Update(ctx context.Context, v *models.SavedView) error {
+	if _, err := r.Get(ctx, v.UserID, v.ID); err != nil {
+		return err
+	}
+	r.store[v.ID] = *v
+	return nil
+}
+
+func (r *memSavedViewRepo) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	if _, err := r.Get(ctx, userID, id); err != nil {
+		return err
+	}
+	delete(r.store, id)
+	return nil
+}
+
+func TestSavedViewServiceValidatesAndResolves(t *testing.T) {
+	ctx := context.Background()
+	svc := NewSavedViewService(&memSavedViewRepo{store: map[primitive.ObjectID]models.SavedView{}})
+	alice, bob := primitive.NewObjectID(), primitive.NewObjectID()
+
+	_, err := svc.Create(ctx, alice, models.SavedViewRequest{Name: "bad", Query: "priority:urgent"})
+	require.ErrorIs(t, err, models.ErrInvalidTaskFilter)
+	_, err = svc.Create(ctx, alice, models.SavedViewRequest{Name: " ", Query: "tag:ops"})
+	require.ErrorIs(t, err, ErrSavedViewNameRequired)
+
+	v, err := svc.Create(ctx, alice, models.SavedViewRequest{Name: "Urgent", Query: "priority:High -status:Done", Sort: "due"})
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, alice, models.SavedViewRequest{Name: "Urgent", Query: "tag:ops"})
+	require.ErrorIs(t, err, ErrSavedViewNameTaken)
+	_, err = svc.Create(ctx, bob, models.SavedViewRequest{Name: "Urgent", Query: "tag:ops"})
+	require.NoError(t, err, "names are unique per user only")
+
+	// 视图条件与临时条件取且，显式排序覆盖视图排序
+	f, sort, err := svc.Resolve(ctx, alice, v.ID.Hex(), "assignee:me", "")
+	require.NoError(t, err)
+	require.Len(t, f.Terms, 3)
+	require.Equal(t, []models.TaskSortField{{Field: models.FilterFieldDue}}, sort)
+	_, sort, err = svc.Resolve(ctx, alice, v.ID.Hex(), "", "-updated")
+	require.NoError(t, err)
+	require.Equal(t, []models.TaskSortField{{Field: models.FilterFieldUpdated, Desc: true}}, sort)
+
+	_, _, err = svc.Resolve(ctx, bob, v.ID.Hex(), "", "")
+	require.True(t, errors.Is(err, repository.ErrSavedViewNotFound), "other users' views are not visible")
+
+	match, err := svc.TaskMatch(ctx, alice, v.ID.Hex(), "assignee:me", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, bson.M{"$and": []bson.M{
+		{"priority": bson.M{"$in": []string{"High"}}},
+		{"$nor": []bson.M{{"status": bson.M{"$in": models.TaskStatusVariants("Done")}}}},
+		{"assignee": bson.M{"$in": []string{alice.Hex()}}},
+	}}, match)
+
+	match, err = svc.TaskMatch(ctx, alice, "", "", time.UTC)
+	require.NoError(t, err)
+	require.Nil(t, match)
+}
+
+func TestTaskFilterBSONDates(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	now := time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC)
+	today := time.Date(2025, 3, 11, 0, 0, 0, 0, loc)
+	cases := map[string]bson.M{
+		"due:today":        {"deadline": bson.M{"$gte": today, "$lt": today.AddDate(0, 0, 1)}},
+		"due:<=today":      {"deadline": bson.M{"$lt": today.AddDate(0, 0, 1)}},
+		"due:>today":       {"deadline": bson.M{"$gte": today.AddDate(0, 0, 1)}},
+		"due:<7d":          {"deadline": bson.M{"$lt": now.Add(7 * 24 * time.Hour)}},
+		"created:>=-1w":    {"createdAt": bson.M{"$gte": now.Add(-7 * 24 * time.Hour)}},
+		"scheduled:none":   {"scheduledDate": nil},
+		"cf.estimate:>3":   {"customFields.estimate": bson.M{"$gt": float64(3)}},
+		"cf.review:<today": {"customFields.review": bson.M{"$lt": today}},
+		"cf.owner:none":    {"customFields.owner": nil},
+	}
+	for expr, want := range cases {
+		f, err := models.ParseTaskFilter(expr)
+		require.NoError(t, err, expr)
+		got, err := repository.TaskFilterBSON(f, "u1", now, loc)
+		require.NoError(t, err, expr)
+		require.Equal(t, want, got, expr)
+	}
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"html"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// 搜索相关错误
+var (
+	ErrSearchQueryRequired = errors.New("search text or filter required")
+	ErrInvalidSearchSource = errors.New("invalid search source")
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	snippetRunes       = 120 // 片段长度
+)
+
+// SearchService 任务 / 事件 / 事件评论统一搜索：仓储取候选，服务层按字段权重重新打分并生成高亮片段
+type SearchService struct {
+	repo repository.SearchRepository
+}
+
+func NewSearchService(repo repository.SearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// searchField 参与打分的字段
+type searchField struct {
+	name   string
+	text   string
+	weight float64
+}
+
+// Search 按关键词与过滤条件搜索，结果按相关度降序 (无关键词时按日期倒序)
+func (s *SearchService) Search(ctx context.Context, userID primitive.ObjectID, q models.SearchQuery) (*models.SearchResponse, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("search service not init")
+	}
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" && len(q.Status) == 0 && len(q.Priority) == 0 && len(q.Tags) == 0 && q.From == nil && q.To == nil {
+		return nil, ErrSearchQueryRequired
+	}
+	sources, err := searchSources(q)
+	if err != nil {
+		return nil, err
+	}
+	if q.Limit <= 0 {
+		q.Limit = defaultSearchLimit
+	}
+	if q.Limit > maxSearchLimit {
+		q.Limit = maxSearchLimit
+	}
+	terms := searchTerms(q.Text)
+	var hits []models.SearchHit
+	if sources[models.SearchSourceTask] {
+		tasks, err := s.repo.Tasks(ctx, userID.Hex(), q, q.Limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			hits = append(hits, taskHit(t, terms))
+		}
+	}
+	if sources[models.SearchSourceEvent] {
+		events, err := s.repo.Events(ctx, userID, q, q.Limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			hits = append(hits, eventHit(e, terms))
+		}
+	}
+	if sources[models.SearchSourceComment] {
+		comments, events, err := s.repo.Comments(ctx, userID, q, q.Limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range comments {
+			hits = append(hits, commentHit(c, events[c.EventID], terms))
+		}
+	}
+	rankHits(hits)
+	if len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	if hits == nil {
+		hits = []models.SearchHit{}
+	}
+	return &models.SearchResponse{Query: q.Text, Items: hits, Total: len(hits)}, nil
+}
+
+// searchSources 参与搜索的来源：任务专属过滤 (状态 / 优先级) 只搜索任务，标签过滤排除评论
+func searchSources(q models.SearchQuery) (map[string]bool, error) {
+	all := map[string]bool{models.SearchSourceTask: true, models.SearchSourceEvent: true, models.SearchSourceComment: true}
+	out := all
+	if len(q.Sources) > 0 {
+		out = map[string]bool{}
+		for _, src := range q.Sources {
+			if !all[src] {
+				return nil, ErrInvalidSearchSource
+			}
+			out[src] = true
+		}
+	}
+	if len(q.Status) > 0 || len(q.Priority) > 0 {
+		out = map[string]bool{models.SearchSourceTask: out[models.SearchSourceTask]}
+	}
+	if len(q.Tags) > 0 {
+		delete(out, models.SearchSourceComment)
+	}
+	return out, nil
+}
+
+// searchTerms 小写去重的关键词
+func searchTerms(text string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, t := range strings.Fields(strings.ToLower(text)) {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func taskHit(t models.Task, terms []string) models.SearchHit {
+	comments := make([]string, 0, len(t.Comments))
+	for _, c := range t.Comments {
+		comments = append(comments, c.Text)
+	}
+	fields := []searchField{
+		{name: "title", text: t.Title, weight: 10},
+		{name: "tags", text: strings.Join(t.Tags, " "), weight: 5},
+		{name: "description", text: t.Description, weight: 3},
+		{name: "comments", text: strings.Join(comments, "\n"), weight: 1},
+	}
+	date := t.EffectiveDeadline()
+	if date == nil {
+		date = t.ScheduledDate
+	}
+	hit := models.SearchHit{ID: t.ID, Source: models.SearchSourceTask, Title: t.Title, Date: date, Status: t.Status, Priority: t.Priority, Tags: t.Tags, DetailURL: "/tasks"}
+	fillHit(&hit, fields, terms)
+	return hit
+}
+
+func eventHit(e models.Event, terms []string) models.SearchHit {
+	fields := []searchField{
+		{name: "title", text: e.Title, weight: 10},
+		{name: "tags", text: strings.Join(e.Tags, " "), weight: 5},
+		{name: "location", text: e.Location, weight: 3},
+		{name: "description", text: e.Description, weight: 3},
+	}
+	date := e.EventDate
+	hit := models.SearchHit{ID: e.ID.Hex(), Source: models.SearchSourceEvent, Title: e.Title, Date: &date, Tags: e.Tags, DetailURL: "/events"}
+	fillHit(&hit, fields, terms)
+	return hit
+}
+
+func commentHit(c models.EventComment, e models.Event, terms []string) models.SearchHit {
+	fields := []searchField{
+		{name: "title", text: e.Title, weight: 0}, // 只用于高亮标题，不参与打分
+		{name: "content", text: c.Content, weight: 2},
+	}
+	date := c.CreatedAt
+	hit := models.SearchHit{ID: c.ID.Hex(), Source: models.SearchSourceComment, Title: e.Title, Date: &date, EventID: c.EventID.Hex(), DetailURL: "/events"}
+	fillHit(&hit, fields, terms)
+	return hit
+}
+
+// fillHit 计算相关度，生成标题高亮与命中最多 (加权) 的正文字段片段；没有正文命中时取首个非空正文开头
+func fillHit(hit *models.SearchHit, fields []searchField, terms []string) {
+	var best *searchField
+	bestScore := 0.0
+	for i := range fields {
+		f := &fields[i]
+		score := fieldScore(f.text, terms) * f.weight
+		hit.Score += score
+		if f.name == "title" {
+			hit.Highlight = highlight(f.text, terms)
+			continue
+		}
+		if score > bestScore || (best == nil && strings.TrimSpace(f.text) != "") {
+			best, bestScore = f, score
+		}
+	}
+	if best != nil {
+		hit.Snippet = snippet(best.text, terms, snippetRunes)
+		hit.Field = best.name
+	}
+}
+
+// fieldScore 单字段得分：每个关键词首次命中计 1 分，重复命中各加 0.5 (最多 2 次)，多词完整短语命中再加 1 分
+func fieldScore(text string, terms []string) float64 {
+	if text == "" || len(terms) == 0 {
+		return 0
+	}
+	lower := strings.ToLower(text)
+	score := 0.0
+	for _, t := range terms {
+		n := strings.Count(lower, t)
+		if n == 0 {
+			continue
+		}
+		if n > 3 {
+			n = 3
+		}
+		score += 1 + 0.5*float64(n-1)
+	}
+	if len(terms) > 1 && strings.Contains(lower, strings.Join(terms, " ")) {
+		score++
+	}
+	return score
+}
+
+// rankHits 按得分降序，同分按日期倒序 (无日期在后)
+func rankHits(hits []models.SearchHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		di, dj := hits[i].Date, hits[j].Date
+		switch {
+		case di == nil:
+			return false
+		case dj == nil:
+			return true
+		}
+		return di.After(*dj)
+	})
+}
+
+// snippet 截取首个命中词附近 size 个字符并高亮，截断处以省略号标记
+func snippet(text string, terms []string, size int) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		lower = runes
+	}
+	start := 0
+	for i := range lower {
+		if matchAt(lower, i, terms) > 0 {
+			start = i - size/4
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + size
+	if end > len(runes) {
+		end = len(runes)
+		if start = end - size; start < 0 {
+			start = 0
+		}
+	}
+	out := markRange(runes, lower, start, end, terms)
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(runes) {
+		out += "…"
+	}
+	return out
+}
+
+// highlight 整段文本高亮
+func highlight(text string, terms []string) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		lower = runes
+	}
+	return markRange(runes, lower, 0, len(runes), terms)
+}
+
+// markRange 对 [start,end) 做 HTML 转义，命中词包裹 <mark>
+func markRange(runes, lower []rune, start, end int, terms []string) string {
+	var b strings.Builder
+	plain := start
+	for i := start; i < end; {
+		n := matchAt(lower, i, terms)
+		if n == 0 || i+n > end {
+			i++
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[plain:i])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[i : i+n])))
+		b.WriteString("</mark>")
+		i += n
+		plain = i
+	}
+	b.WriteString(html.EscapeString(string(runes[plain:end])))
+	return b.String()
+}
+
+// matchAt lower[i:] 起始处最长命中词的长度 (字符数)，未命中返回 0
+func matchAt(lower []rune, i int, terms []string) int {
+	best := 0
+	for _, t := range terms {
+		tr := []rune(t)
+		if len(tr) <= best || i+len(tr) > len(lower) {
+			continue
+		}
+		if string(lower[i:i+len(tr)]) == t {
+			best = len(tr)
+		}
+	}
+	return best
+}
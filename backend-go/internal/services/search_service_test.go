@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// fakeSearchRepo 直接返回固定候选，记录各来源是否被查询
+type fakeSearchRepo struct {
+	tasks    []models.Task
+	events   []models.Event
+	comments []models.EventComment
+	queried  map[string]bool
+}
+
+func (r *fakeSearchRepo) Tasks(ctx context.Context, userID string, q models.SearchQuery, limit int) ([]models.Task, error) {
+	r.queried[models.SearchSourceTask] = true
+	return r.tasks, nil
+}
+
+func (r *fakeSearchRepo) Events(ctx context.Context, userID primitive.ObjectID, q models.SearchQuery, limit int) ([]models.Event, error) {
+	r.queried[models.SearchSourceEvent] = true
+	return r.events, nil
+}
+
+func (r *fakeSearchRepo) Comments(ctx context.Context, userID primitive.ObjectID, q models.SearchQuery, limit int) ([]models.EventComment, map[primitive.ObjectID]models.Event, error) {
+	r.queried[models.SearchSourceComment] = true
+	events := map[primitive.ObjectID]models.Event{}
+	for _, e := range r.events {
+		events[e.ID] = e
+	}
+	return r.comments, events, nil
+}
+
+func TestSearchServiceRanksAndHighlights(t *testing.T) {
+	ev := models.Event{ID: primitive.NewObjectID(), Title: "Team offsite", Tags: []string{"review"}, Location: "Room <A>"}
+	repo := &fakeSearchRepo{
+		tasks: []models.Task{
+			{ID: "t1", Title: "Write notes", Description: "prepare the quarterly review deck"},
+			{ID: "t2", Title: "Quarterly review", Description: "with finance"},
+		},
+		events:   []models.Event{ev},
+		comments: []models.EventComment{{ID: primitive.NewObjectID(), EventID: ev.ID, Content: "moved the <b>review</b> to friday"}},
+		queried:  map[string]bool{},
+	}
+	res, err := NewSearchService(repo).Search(context.Background(), primitive.NewObjectID(), models.SearchQuery{Text: "Quarterly REVIEW"})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if res.Total != 4 || res.Items[0].ID != "t2" {
+		t.Fatalf("title match should rank first: %+v", res.Items)
+	}
+	if res.Items[0].Highlight != "<mark>Quarterly</mark> <mark>review</mark>" {
+		t.Fatalf("highlight = %q", res.Items[0].Highlight)
+	}
+	var comment models.SearchHit
+	for _, h := range res.Items {
+		if h.Source == models.SearchSourceComment {
+			comment = h
+		}
+	}
+	if comment.EventID != ev.ID.Hex() || comment.Title != "Team offsite" {
+		t.Fatalf("comment hit should carry its event: %+v", comment)
+	}
+	if !strings.Contains(comment.Snippet, "&lt;b&gt;<mark>review</mark>&lt;/b&gt;") {
+		t.Fatalf("snippet should be escaped and highlighted: %q", comment.Snippet)
+	}
+}
+
+func TestSearchServiceFiltersAndValidation(t *testing.T) {
+	repo := &fakeSearchRepo{queried: map[string]bool{}}
+	svc := NewSearchService(repo)
+	ctx, uid := context.Background(), primitive.NewObjectID()
+	if _, err := svc.Search(ctx, uid, models.SearchQuery{Text: "  "}); !errors.Is(err, ErrSearchQueryRequired) {
+		t.Fatalf("expected query required, got %v", err)
+	}
+	if _, err := svc.Search(ctx, uid, models.SearchQuery{Text: "x", Sources: []string{"note"}}); !errors.Is(err, ErrInvalidSearchSource) {
+		t.Fatalf("expected invalid source, got %v", err)
+	}
+	// 状态过滤只搜索任务
+	if _, err := svc.Search(ctx, uid, models.SearchQuery{Status: []string{"Done"}}); err != nil {
+		t.Fatalf("status search: %v", err)
+	}
+	if !repo.queried[models.SearchSourceTask] || repo.queried[models.SearchSourceEvent] || repo.queried[models.SearchSourceComment] {
+		t.Fatalf("status filter should only query tasks: %v", repo.queried)
+	}
+	// 标签过滤搜索任务与事件
+	repo.queried = map[string]bool{}
+	if _, err := svc.Search(ctx, uid, models.SearchQuery{Tags: []string{"work"}}); err != nil {
+		t.Fatalf("tag search: %v", err)
+	}
+	if !repo.queried[models.SearchSourceTask] || !repo.queried[models.SearchSourceEvent] || repo.queried[models.SearchSourceComment] {
+		t.Fatalf("tag filter should query tasks and events: %v", repo.queried)
+	}
+}
+
+func TestSnippetWindow(t *testing.T) {
+	text := strings.Repeat("前言", 50) + "关键字" + strings.Repeat("后文", 50)
+	got := snippet(text, []string{"关键字"}, 20)
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "<mark>关键字</mark>") {
+		t.Fatalf("snippet = %q", got)
+	}
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// 任务分配相关错误
+var (
+	ErrInvalidAssignee  = errors.New("assignee user not found")
+	ErrAssigneeNoAccess = errors.New("assignee cannot access task")
+	ErrInvalidTaskView  = errors.New("invalid task view")
+)
+
+// NotificationTypeTaskAssigned 任务分配 (或改派) 给他人时发给负责人的通知类型
+const NotificationTypeTaskAssigned = "task_assigned"
+
+// WithDirectory 配置用户与工作区仓储后，负责人会解析为真实用户并校验其能看到任务；未配置时原样保存
+func (s *TaskService) WithDirectory(users repository.UserRepository, workspaces repository.WorkspaceRepository) *TaskService {
+	s.users = users
+	s.workspaces = workspaces
+	return s
+}
+
+// ResolveAssignee 将用户 ID、用户名或邮箱解析为负责人用户 ID；空字符串表示取消分配 (返回 nil)。
+// 个人任务只能分配给创建者本人，工作区任务只能分配给工作区成员。
+func (s *TaskService) ResolveAssignee(ctx context.Context, login string, t *models.Task) (*string, error) {
+	login = strings.TrimSpace(login)
+	if login == "" {
+		return nil, nil
+	}
+	if s.users == nil {
+		return &login, nil
+	}
+	u, err := s.users.FindByLogin(ctx, login)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil, ErrInvalidAssignee
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.canSee(ctx, u.ID, t); err != nil {
+		return nil, err
+	}
+	return &u.ID, nil
+}
+
+// canSee 校验 userID 能看到任务
+func (s *TaskService) canSee(ctx context.Context, userID string, t *models.Task) error {
+	if t.WorkspaceID == "" {
+		if userID != t.CreatedBy {
+			return ErrAssigneeNoAccess
+		}
+		return nil
+	}
+	if s.workspaces == nil {
+		return nil
+	}
+	wsID, err := primitive.ObjectIDFromHex(t.WorkspaceID)
+	if err != nil {
+		return ErrAssigneeNoAccess
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrAssigneeNoAccess
+	}
+	w, err := s.workspaces.FindByID(ctx, wsID)
+	if errors.Is(err, repository.ErrWorkspaceNotFound) {
+		return ErrAssigneeNoAccess
+	}
+	if err != nil {
+		return err
+	}
+	if w.RoleOf(uid) == "" {
+		return ErrAssigneeNoAccess
+	}
+	return nil
+}
+
+// NotifyAssigned 负责人由 previous 变为他人时通知新负责人；未变化、取消分配或分配给自己时不通知
+func (s *TaskService) NotifyAssigned(ctx context.Context, actorID string, previous *string, t models.Task) {
+	if t.Assignee == nil || *t.Assignee == "" || *t.Assignee == actorID {
+		return
+	}
+	if previous != nil && *previous == *t.Assignee {
+		return
+	}
+	s.notify(ctx, *t.Assignee, models.NotificationCreate{
+		Type:     NotificationTypeTaskAssigned,
+		Message:  fmt.Sprintf("你被指派为任务「%s」的负责人", t.Title),
+		Metadata: map[string]interface{}{"task_id": t.ID, "assigned_by": actorID},
+		GroupKey: NotificationTypeTaskAssigned + ":" + t.ID,
+	})
+}
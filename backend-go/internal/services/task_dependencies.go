@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	nHub "github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
+)
+
+// 任务依赖相关错误
+var (
+	ErrInvalidDependency = errors.New("invalid task dependency")
+	ErrDependencyCycle   = errors.New("task dependency cycle")
+)
+
+// NotificationTypeTaskUnblocked 阻塞任务完成后发给被解除阻塞任务的通知类型
+const NotificationTypeTaskUnblocked = "task_unblocked"
+
+// WithNotifications 阻塞任务完成时通过站内通知 (及可选的实时推送) 告知被解除阻塞的任务
+func (s *TaskService) WithNotifications(n *NotificationService, hub *nHub.Hub) *TaskService {
+	s.notifier = n
+	s.hub = hub
+	return s
+}
+
+// AddDependency blockerID 完成前 taskID 处于阻塞状态；会形成环时返回 ErrDependencyCycle
+func (s *TaskService) AddDependency(ctx context.Context, userID, taskID, blockerID string) (*models.Task, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("task service not init")
+	}
+	t, err := s.repo.FindByID(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	blocker, err := s.repo.FindByID(ctx, userID, blockerID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrInvalidDependency
+	}
+	if err != nil {
+		return nil, err
+	}
+	if blocker.ID == t.ID {
+		return nil, ErrDependencyCycle
+	}
+	for _, id := range t.BlockedBy {
+		if id == blocker.ID {
+			return t, nil
+		}
+	}
+	cycle, err := s.dependsOn(ctx, userID, blocker.ID, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cycle {
+		return nil, ErrDependencyCycle
+	}
+	if t, err = s.repo.AddToSet(ctx, userID, t.ID, "blockedBy", blocker.ID); err != nil {
+		return nil, err
+	}
+	if _, err := s.refreshBlocked(ctx, userID, t); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, userID, t.ID)
+}
+
+// RemoveDependency 移除依赖并重新计算阻塞状态
+func (s *TaskService) RemoveDependency(ctx context.Context, userID, taskID, blockerID string) (*models.Task, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("task service not init")
+	}
+	t, err := s.repo.FindByID(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, id := range t.BlockedBy {
+		found = found || id == blockerID
+	}
+	if !found {
+		return nil, ErrInvalidDependency
+	}
+	if t, err = s.repo.Pull(ctx, userID, t.ID, "blockedBy", blockerID); err != nil {
+		return nil, err
+	}
+	if _, err := s.refreshBlocked(ctx, userID, t); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, userID, t.ID)
+}
+
+// Dependencies 阻塞 taskID 的任务与被 taskID 阻塞的任务
+func (s *TaskService) Dependencies(ctx context.Context, userID, taskID string) (blockedBy, blocks []models.Task, err error) {
+	t, err := s.repo.FindByID(ctx, userID, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, id := range t.BlockedBy {
+		b, err := s.repo.FindByID(ctx, userID, id)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		blockedBy = append(blockedBy, *b)
+	}
+	blocks, err = s.repo.ListBlockedBy(ctx, userID, t.ID)
+	return blockedBy, blocks, err
+}
+
+// dependsOn from 是否 (传递地) 依赖 target
+func (s *TaskService) dependsOn(ctx context.Context, userID, from, target string) (bool, error) {
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		t, err := s.repo.FindByID(ctx, userID, id)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		for _, next := range t.BlockedBy {
+			if next == target {
+				return true, nil
+			}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false, nil
+}
+
+// refreshBlocked 按阻塞任务的完成情况重新计算 blocked，由阻塞变为未阻塞时记录 unblockedAt 并返回 true
+func (s *TaskService) refreshBlocked(ctx context.Context, userID string, t *models.Task) (bool, error) {
+	blocked := false
+	for _, id := range t.BlockedBy {
+		b, err := s.repo.FindByID(ctx, userID, id)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue // 已删除的阻塞任务不再阻塞
+		}
+		if err != nil {
+			return false, err
+		}
+		if !models.IsTaskDone(b.Status) {
+			blocked = true
+			break
+		}
+	}
+	if blocked == t.Blocked {
+		return false, nil
+	}
+	set := bson.M{"blocked": blocked, "unblockedAt": nil}
+	if !blocked {
+		set["unblockedAt"] = time.Now()
+	}
+	if _, err := s.repo.UpdatePartial(ctx, userID, t.ID, set); err != nil {
+		return false, err
+	}
+	t.Blocked = blocked
+	return !blocked, nil
+}
+
+// ResolveDependents blockerID 状态变化后重新计算依赖它的任务；阻塞任务已完成时通知被解除阻塞的任务，返回这些任务
+func (s *TaskService) ResolveDependents(ctx context.Context, userID, blockerID string) ([]models.Task, error) {
+	blocker, err := s.repo.FindByID(ctx, userID, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	dependents, err := s.repo.ListBlockedBy(ctx, userID, blocker.ID)
+	if err != nil {
+		return nil, err
+	}
+	var unblocked []models.Task
+	for i := range dependents {
+		ok, err := s.refreshBlocked(ctx, userID, &dependents[i])
+		if err != nil {
+			return unblocked, err
+		}
+		if ok {
+			unblocked = append(unblocked, dependents[i])
+		}
+	}
+	if models.IsTaskDone(blocker.Status) {
+		for _, t := range unblocked {
+			s.notifyUnblocked(ctx, t, *blocker)
+		}
+	}
+	return unblocked, nil
+}
+
+// DetachDependents 任务删除后从依赖它的任务中移除并重新计算阻塞状态 (不发送通知)
+func (s *TaskService) DetachDependents(ctx context.Context, userID, blockerID string) error {
+	dependents, err := s.repo.ListBlockedBy(ctx, userID, blockerID)
+	if err != nil {
+		return err
+	}
+	for i := range dependents {
+		t := &dependents[i]
+		kept := make([]string, 0, len(t.BlockedBy))
+		for _, id := range t.BlockedBy {
+			if id != blockerID {
+				kept = append(kept, id)
+			}
+		}
+		t.BlockedBy = kept
+		if _, err := s.repo.UpdatePartial(ctx, userID, t.ID, bson.M{"blockedBy": kept}); err != nil {
+			return err
+		}
+		if _, err := s.refreshBlocked(ctx, userID, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TaskService) notifyUnblocked(ctx context.Context, t, blocker models.Task) {
+	s.notify(ctx, t.CreatedBy, models.NotificationCreate{
+		Type:     NotificationTypeTaskUnblocked,
+		Message:  fmt.Sprintf("「%s」已完成，任务「%s」可以开始了", blocker.Title, t.Title),
+		Metadata: map[string]interface{}{"task_id": t.ID, "blocker_id": blocker.ID},
+		GroupKey: NotificationTypeTaskUnblocked + ":" + t.ID,
+	})
+}
+
+// notify 写入站内通知并实时推送；未配置通知服务或用户已屏蔽该类型时跳过
+func (s *TaskService) notify(ctx context.Context, userID string, in models.NotificationCreate) {
+	if s.notifier == nil {
+		return
+	}
+	uid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return
+	}
+	in.UserID = uid
+	n, err := s.notifier.Create(ctx, in)
+	if errors.Is(err, ErrNotificationMuted) {
+		return
+	}
+	if err != nil {
+		log.Printf("%s notification for %v failed: %v", in.Type, in.Metadata["task_id"], err)
+		return
+	}
+	if s.hub != nil {
+		s.hub.Broadcast(n)
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// 字段定义错误
+var (
+	ErrInvalidTaskField  = errors.New("invalid task field")
+	ErrTaskFieldKeyTaken = errors.New("task field key already exists")
+)
+
+const maxTaskFieldOptions = 50
+
+// TaskFieldService 任务自定义字段定义：个人字段由本人维护，工作区字段所有成员可见、仅 owner 可修改
+type TaskFieldService struct {
+	repo       repository.TaskFieldRepository
+	workspaces repository.WorkspaceRepository
+}
+
+func NewTaskFieldService(repo repository.TaskFieldRepository, workspaces repository.WorkspaceRepository) *TaskFieldService {
+	return &TaskFieldService{repo: repo, workspaces: workspaces}
+}
+
+// List 个人字段 (workspaceID 为空) 或工作区字段
+func (s *TaskFieldService) List(ctx context.Context, userID primitive.ObjectID, workspaceID string) ([]models.TaskFieldDefinition, error) {
+	wid, err := s.scope(ctx, userID, workspaceID, models.WorkspaceRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, userID, wid)
+}
+
+// Create 新建字段定义，Key 在所属范围内唯一
+func (s *TaskFieldService) Create(ctx context.Context, userID primitive.ObjectID, req models.TaskFieldRequest) (*models.TaskFieldDefinition, error) {
+	wid, err := s.scope(ctx, userID, req.WorkspaceID, models.WorkspaceRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	key := strings.ToLower(strings.TrimSpace(req.Key))
+	if !models.ValidCustomFieldKey(key) {
+		return nil, ErrInvalidTaskField
+	}
+	if !models.ValidTaskFieldType(req.Type) {
+		return nil, ErrInvalidTaskField
+	}
+	f := &models.TaskFieldDefinition{WorkspaceID: wid, Key: key, Type: req.Type, CreatedBy: userID}
+	if wid.IsZero() {
+		f.UserID = userID
+	}
+	if err := applyTaskField(f, req); err != nil {
+		return nil, err
+	}
+	existing, err := s.repo.FindByKey(ctx, userID, wid, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrTaskFieldKeyTaken
+	}
+	if err := s.repo.Insert(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Update 修改名称与选项 (Key / 类型不可修改，已保存的取值不受影响)
+func (s *TaskFieldService) Update(ctx context.Context, userID, id primitive.ObjectID, req models.TaskFieldRequest) (*models.TaskFieldDefinition, error) {
+	f, err := s.load(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTaskField(f, req); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Delete 删除字段定义并清除任务上的取值
+func (s *TaskFieldService) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	f, err := s.load(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, f)
+}
+
+// load 读取可修改的字段定义：他人的个人字段视为不存在
+func (s *TaskFieldService) load(ctx context.Context, userID, id primitive.ObjectID) (*models.TaskFieldDefinition, error) {
+	f, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if f.WorkspaceID.IsZero() {
+		if f.UserID != userID {
+			return nil, repository.ErrTaskFieldNotFound
+		}
+		return f, nil
+	}
+	if _, err := s.scope(ctx, userID, f.WorkspaceID.Hex(), models.WorkspaceRoleOwner); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// scope 解析工作区并校验角色，个人范围返回零值
+func (s *TaskFieldService) scope(ctx context.Context, userID primitive.ObjectID, workspaceID, minRole string) (primitive.ObjectID, error) {
+	if workspaceID == "" {
+		return primitive.NilObjectID, nil
+	}
+	wid, err := primitive.ObjectIDFromHex(workspaceID)
+	if err != nil || s.workspaces == nil {
+		return primitive.NilObjectID, repository.ErrWorkspaceNotFound
+	}
+	w, err := s.workspaces.FindByID(ctx, wid)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	role := w.RoleOf(userID)
+	if role == "" {
+		return primitive.NilObjectID, repository.ErrWorkspaceNotFound
+	}
+	if !models.WorkspaceRoleAtLeast(role, minRole) {
+		return primitive.NilObjectID, repository.ErrWorkspaceForbidden
+	}
+	return wid, nil
+}
+
+// applyTaskField 写入名称与选项：名称默认为 Key；单选字段至少一个选项，选项去空白、去重
+func applyTaskField(f *models.TaskFieldDefinition, req models.TaskFieldRequest) error {
+	f.Name = strings.TrimSpace(req.Name)
+	if f.Name == "" {
+		f.Name = f.Key
+	}
+	f.Options = nil
+	if f.Type != models.TaskFieldSelect {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, o := range req.Options {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[strings.ToLower(o)] {
+			continue
+		}
+		seen[strings.ToLower(o)] = true
+		f.Options = append(f.Options, o)
+	}
+	if len(f.Options) == 0 || len(f.Options) > maxTaskFieldOptions {
+		return ErrInvalidTaskField
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// memTaskFieldRepo 以切片模拟字段定义集合
+type memTaskFieldRepo struct {
+	fields []models.TaskFieldDefinition
+}
+
+func (r *memTaskFieldRepo) owns(f models.TaskFieldDefinition, userID, workspaceID primitive.ObjectID) bool {
+	if !workspaceID.IsZero() {
+		return f.WorkspaceID == workspaceID
+	}
+	return f.WorkspaceID.IsZero() && f.UserID == userID
+}
+
+func (r *memTaskFieldRepo) Insert(ctx context.Context, f *models.TaskFieldDefinition) error {
+	f.ID = primitive.NewObjectID()
+	r.fields = append(r.fields, *f)
+	return nil
+}
+
+func (r *memTaskFieldRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*models.TaskFieldDefinition, error) {
+	for _, f := range r.fields {
+		if f.ID == id {
+			return &f, nil
+		}
+	}
+	return nil, repository.ErrTaskFieldNotFound
+}
+
+func (r *memTaskFieldRepo) List(ctx context.Context, userID, workspaceID primitive.ObjectID) ([]models.TaskFieldDefinition, error) {
+	var out []models.TaskFieldDefinition
+	for _, f := range r.fields {
+		if r.owns(f, userID, workspaceID) {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func (r *memTaskFieldRepo) FindByKey(ctx context.Context, userID, workspaceID primitive.ObjectID, key string) (*models.TaskFieldDefinition, error) {
+	for _, f := range r.fields {
+		if r.owns(f, userID, workspaceID) && f.Key == key {
+			return &f, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memTaskFieldRepo) Update(ctx context.Context, f *models.TaskFieldDefinition) error {
+	for i := range r.fields {
+		if r.fields[i].ID == f.ID {
+			r.fields[i] = *f
+			return nil
+		}
+	}
+	return repository.ErrTaskFieldNotFound
+}
+
+func (r *memTaskFieldRepo) Delete(ctx context.Context, f *models.TaskFieldDefinition) error {
+	for i := range r.fields {
+		if r.fields[i].ID == f.ID {
+			r.fields = append(r.fields[:i], r.fields[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrTaskFieldNotFound
+}
+
+func TestTaskFieldServiceDefinitions(t *testing.T) {
+	ctx := context.Background()
+	owner, bob := primitive.NewObjectID(), primitive.NewObjectID()
+	ws := models.Workspace{ID: primitive.NewObjectID(), OwnerID: owner, Members: []models.WorkspaceMember{{UserID: owner, Role: models.WorkspaceRoleOwner}, {UserID: bob, Role: models.WorkspaceRoleEditor}}}
+	workspaces := &memWorkspaceRepo{store: map[primitive.ObjectID]*models.Workspace{ws.ID: &ws}}
+	svc := NewTaskFieldService(&memTaskFieldRepo{}, workspaces)
+
+	if _, err := svc.Create(ctx, bob, models.TaskFieldRequest{Key: "Bad Key", Type: models.TaskFieldText}); !errors.Is(err, ErrInvalidTaskField) {
+		t.Fatalf("expected invalid key, got %v", err)
+	}
+	if _, err := svc.Create(ctx, bob, models.TaskFieldRequest{Key: "stage", Type: models.TaskFieldSelect, Options: []string{" ", ""}}); !errors.Is(err, ErrInvalidTaskField) {
+		t.Fatalf("select without options should be rejected, got %v", err)
+	}
+	f, err := svc.Create(ctx, bob, models.TaskFieldRequest{Key: "Estimate", Type: models.TaskFieldNumber, Options: []string{"ignored"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if f.Key != "estimate" || f.Name != "estimate" || f.UserID != bob || f.Options != nil {
+		t.Fatalf("unexpected definition %+v", f)
+	}
+	if _, err := svc.Create(ctx, bob, models.TaskFieldRequest{Key: "estimate", Type: models.TaskFieldText}); !errors.Is(err, ErrTaskFieldKeyTaken) {
+		t.Fatalf("expected key taken, got %v", err)
+	}
+	// 同名字段可存在于不同范围
+	if _, err := svc.Create(ctx, owner, models.TaskFieldRequest{Key: "estimate", Type: models.TaskFieldNumber}); err != nil {
+		t.Fatalf("other user's personal field: %v", err)
+	}
+
+	// 工作区字段仅 owner 可创建，成员可见
+	wsReq := models.TaskFieldRequest{WorkspaceID: ws.ID.Hex(), Key: "stage", Type: models.TaskFieldSelect, Options: []string{"Todo", "review", "todo"}}
+	if _, err := svc.Create(ctx, bob, wsReq); !errors.Is(err, repository.ErrWorkspaceForbidden) {
+		t.Fatalf("editor create: expected forbidden, got %v", err)
+	}
+	stage, err := svc.Create(ctx, owner, wsReq)
+	if err != nil {
+		t.Fatalf("owner create: %v", err)
+	}
+	if len(stage.Options) != 2 {
+		t.Fatalf("options should be deduplicated, got %v", stage.Options)
+	}
+	list, err := svc.List(ctx, bob, ws.ID.Hex())
+	if err != nil || len(list) != 1 {
+		t.Fatalf("member list: %d %v", len(list), err)
+	}
+	if _, err := svc.List(ctx, primitive.NewObjectID(), ws.ID.Hex()); !errors.Is(err, repository.ErrWorkspaceNotFound) {
+		t.Fatalf("non-member list: expected not found, got %v", err)
+	}
+
+	// 他人的个人字段视为不存在
+	if err := svc.Delete(ctx, owner, f.ID); !errors.Is(err, repository.ErrTaskFieldNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if err := svc.Delete(ctx, bob, f.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+}
+
+func TestTaskServiceTagsAndCustomFields(t *testing.T) {
+	ctx := context.Background()
+	uid := primitive.NewObjectID()
+	fields := &memTaskFieldRepo{fields: []models.TaskFieldDefinition{
+		{ID: primitive.NewObjectID(), UserID: uid, Key: "estimate", Type: models.TaskFieldNumber},
+		{ID: primitive.NewObjectID(), UserID: uid, Key: "review", Type: models.TaskFieldDate},
+		{ID: primitive.NewObjectID(), UserID: uid, Key: "stage", Type: models.TaskFieldSelect, Options: []string{"Draft", "Review"}},
+	}}
+	repo, store := memTaskRepo()
+	svc := NewTaskService(repo).WithFields(fields)
+
+	_, err := svc.Create(ctx, uid.Hex(), models.Task{Title: "a", CustomFields: map[string]interface{}{"owner": "bob"}})
+	if !errors.Is(err, ErrUnknownCustomField) || !IsTaskFieldValueError(err) {
+		t.Fatalf("expected unknown field, got %v", err)
+	}
+	if _, err := svc.Create(ctx, uid.Hex(), models.Task{Title: "a", CustomFields: map[string]interface{}{"estimate": "soon"}}); !errors.Is(err, ErrInvalidCustomFieldValue) {
+		t.Fatalf("expected invalid number, got %v", err)
+	}
+	if _, err := svc.Create(ctx, uid.Hex(), models.Task{Title: "a", CustomFields: map[string]interface{}{"stage": "Done"}}); !errors.Is(err, ErrInvalidCustomFieldValue) {
+		t.Fatalf("expected invalid option, got %v", err)
+	}
+
+	task, err := svc.Create(ctx, uid.Hex(), models.Task{
+		Title:        "a",
+		Tags:         []string{" backend ", "Backend", "", "urgent"},
+		CustomFields: map[string]interface{}{"estimate": "2.5", "review": "2026-03-01", "stage": "review"},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if len(task.Tags) != 2 || task.Tags[0] != "backend" || task.Tags[1] != "urgent" {
+		t.Fatalf("tags should be trimmed and deduplicated, got %v", task.Tags)
+	}
+	if task.CustomFields["estimate"] != 2.5 || task.CustomFields["stage"] != "Review" {
+		t.Fatalf("values should be normalized, got %v", task.CustomFields)
+	}
+	if d, ok := task.CustomFields["review"].(time.Time); !ok || !d.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("date should be parsed, got %v", task.CustomFields["review"])
+	}
+
+	// 更新按键合并，空字符串删除
+	tags := []string{"ops"}
+	upd, err := svc.Update(ctx, uid.Hex(), models.TaskUpdateRequest{ID: task.ID, Tags: &tags, CustomFields: map[string]interface{}{"estimate": 3, "review": ""}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(upd.Tags) != 1 || upd.CustomFields["estimate"] != float64(3) || upd.CustomFields["stage"] != "Review" {
+		t.Fatalf("unexpected update result %v %v", upd.Tags, upd.CustomFields)
+	}
+	if _, ok := store[task.ID].CustomFields["review"]; ok {
+		t.Fatalf("empty value should remove the field, got %v", store[task.ID].CustomFields)
+	}
+	if _, err := svc.Update(ctx, uid.Hex(), models.TaskUpdateRequest{ID: task.ID, CustomFields: map[string]interface{}{"stage": "Gone"}}); !IsTaskFieldValueError(err) {
+		t.Fatalf("expected invalid option on update, got %v", err)
+	}
+
+	many := make([]string, maxTaskTags+1)
+	for i := range many {
+		many[i] = string(rune('a' + i))
+	}
+	if _, err := NormalizeTags(many); !errors.Is(err, ErrTooManyTags) {
+		t.Fatalf("expected too many tags, got %v", err)
+	}
+}
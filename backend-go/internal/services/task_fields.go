@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// 标签 / 自定义字段取值错误
+var (
+	ErrUnknownCustomField      = errors.New("unknown custom field")
+	ErrInvalidCustomFieldValue = errors.New("invalid custom field value")
+	ErrTooManyTags             = errors.New("too many tags")
+)
+
+const (
+	maxTaskTags        = 20
+	maxTagLength       = 50
+	maxCustomTextRunes = 1000
+)
+
+// WithFields 启用自定义字段校验；未设置时任务不接受自定义字段
+func (s *TaskService) WithFields(fields repository.TaskFieldRepository) *TaskService {
+	s.fields = fields
+	return s
+}
+
+// NormalizeTags 去除首尾空白与空标签，按不区分大小写去重 (保留首次出现的写法)
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := map[string]bool{}
+	var out []string
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		if len([]rune(t)) > maxTagLength {
+			t = string([]rune(t)[:maxTagLength])
+		}
+		seen[strings.ToLower(t)] = true
+		out = append(out, t)
+	}
+	if len(out) > maxTaskTags {
+		return nil, ErrTooManyTags
+	}
+	return out, nil
+}
+
+// ResolveCustomFields 将 in 合并到 current 并按任务所属范围 (工作区或创建者个人) 的字段定义校验：
+// 取值为 nil 或空字符串时删除该字段，未定义的字段返回 ErrUnknownCustomField。
+// strict 为 false 时 (如循环任务生成下一个实例) 丢弃不再合法的取值而不报错。结果为空时返回 nil
+func (s *TaskService) ResolveCustomFields(ctx context.Context, t *models.Task, current, in map[string]interface{}, strict bool) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for k, v := range current {
+		out[k] = v
+	}
+	if len(in) > 0 {
+		defs, err := s.fieldDefinitions(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range in {
+			if v == nil || v == "" {
+				delete(out, k)
+				continue
+			}
+			def, ok := defs[k]
+			if !ok {
+				if !strict {
+					continue
+				}
+				return nil, fmt.Errorf("%w: %s", ErrUnknownCustomField, k)
+			}
+			val, err := NormalizeCustomFieldValue(def, v)
+			if err != nil {
+				if !strict {
+					continue
+				}
+				return nil, err
+			}
+			out[k] = val
+		}
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// fieldDefinitions 任务所属范围的字段定义 (按 Key)
+func (s *TaskService) fieldDefinitions(ctx context.Context, t *models.Task) (map[string]models.TaskFieldDefinition, error) {
+	defs := map[string]models.TaskFieldDefinition{}
+	if s.fields == nil {
+		return defs, nil
+	}
+	var userID, workspaceID primitive.ObjectID
+	if t.WorkspaceID != "" {
+		wid, err := primitive.ObjectIDFromHex(t.WorkspaceID)
+		if err != nil {
+			return defs, nil
+		}
+		workspaceID = wid
+	} else {
+		uid, err := primitive.ObjectIDFromHex(t.CreatedBy)
+		if err != nil {
+			return defs, nil
+		}
+		userID = uid
+	}
+	list, err := s.fields.List(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range list {
+		defs[d.Key] = d
+	}
+	return defs, nil
+}
+
+// NormalizeCustomFieldValue 按字段类型校验并转换取值：数字存为 float64，日期存为 UTC 时间，单选规范为选项原文
+func NormalizeCustomFieldValue(def models.TaskFieldDefinition, v interface{}) (interface{}, error) {
+	bad := fmt.Errorf("%w: %s expects %s", ErrInvalidCustomFieldValue, def.Key, def.Type)
+	switch def.Type {
+	case models.TaskFieldText:
+		s, ok := v.(string)
+		if !ok {
+			return nil, bad
+		}
+		if len([]rune(s)) > maxCustomTextRunes {
+			s = string([]rune(s)[:maxCustomTextRunes])
+		}
+		return s, nil
+	case models.TaskFieldNumber:
+		switch x := v.(type) {
+		case float64:
+			return x, nil
+		case int:
+			return float64(x), nil
+		case int32:
+			return float64(x), nil
+		case int64:
+			return float64(x), nil
+		case json.Number:
+			if n, err := x.Float64(); err == nil {
+				return n, nil
+			}
+		case string:
+			if n, err := strconv.ParseFloat(strings.TrimSpace(x), 64); err == nil {
+				return n, nil
+			}
+		}
+		return nil, bad
+	case models.TaskFieldDate:
+		switch x := v.(type) {
+		case time.Time:
+			return x.UTC(), nil
+		case primitive.DateTime:
+			return x.Time().UTC(), nil
+		case string:
+			x = strings.TrimSpace(x)
+			if t, err := time.Parse(time.RFC3339, x); err == nil {
+				return t.UTC(), nil
+			}
+			if t, err := time.Parse("2006-01-02", x); err == nil {
+				return t, nil
+			}
+		}
+		return nil, bad
+	case models.TaskFieldSelect:
+		s, ok := v.(string)
+		if !ok {
+			return nil, bad
+		}
+		for _, opt := range def.Options {
+			if strings.EqualFold(opt, strings.TrimSpace(s)) {
+				return opt, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s must be one of %s", ErrInvalidCustomFieldValue, def.Key, strings.Join(def.Options, ", "))
+	}
+	return nil, bad
+}
+
+// IsTaskFieldValueError 标签 / 自定义字段取值错误，调用方应返回 400
+func IsTaskFieldValueError(err error) bool {
+	return errors.Is(err, ErrUnknownCustomField) || errors.Is(err, ErrInvalidCustomFieldValue) || errors.Is(err, ErrTooManyTags)
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+// NormalizeRecurrence 校验任务的 RRULE，空字符串表示不循环；非法时返回 models.ErrInvalidRecurrence
+func NormalizeRecurrence(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+	if _, err := models.ParseRRule(s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// SpawnNextOccurrence 循环任务完成后生成下一个实例，并与当前实例互相关联。
+// 新实例沿用标题、描述、优先级、负责人、父任务、工作区与检查项 (重置为未完成)，日期按规则顺延；
+// 非循环、未完成、已生成过下一个实例或规则已结束时返回 nil；
+// 先以预分配的 ID 原子占用 nextId，并发完成同一任务时只有占用成功的请求生成实例。
+func (s *TaskService) SpawnNextOccurrence(ctx context.Context, userID, id string) (*models.Task, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("task service not init")
+	}
+	t, err := s.repo.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Recurrence == "" || t.NextID != "" || !models.IsTaskDone(t.Status) {
+		return nil, nil
+	}
+	scheduled, deadline, ok := t.NextRecurrence(time.Now())
+	if !ok {
+		return nil, nil
+	}
+	seriesID := t.SeriesID
+	if seriesID == "" {
+		seriesID = t.ID
+	}
+	start := t.RecurrenceStart
+	if start == nil {
+		anchor := t.RecurrenceAnchor()
+		start = &anchor
+	}
+	// 负责人已无权访问 (如已退出工作区) 时新实例不再分配
+	assignee := t.Assignee
+	if assignee != nil && s.users != nil && s.canSee(ctx, *assignee, t) != nil {
+		assignee = nil
+	}
+	checklist := make([]models.ChecklistItem, 0, len(t.Checklist))
+	for _, it := range t.Checklist {
+		checklist = append(checklist, models.ChecklistItem{Text: it.Text})
+	}
+	nextID := primitive.NewObjectID().Hex()
+	claimed, err := s.repo.ClaimNext(ctx, userID, t.ID, nextID)
+	if err != nil || !claimed {
+		return nil, err
+	}
+	next, err := s.Create(ctx, userID, models.Task{
+		ID:              nextID,
+		Title:           t.Title,
+		Description:     t.Description,
+		Status:          models.TaskStatusTodo,
+		Priority:        t.Priority,
+		Assignee:        assignee,
+		Deadline:        deadline,
+		ScheduledDate:   scheduled,
+		Comments:        []models.Comment{},
+		ParentID:        t.ParentID,
+		WorkspaceID:     t.WorkspaceID,
+		Tags:            t.Tags,
+		CustomFields:    t.CustomFields,
+		Checklist:       checklist,
+		AutoComplete:    t.AutoComplete,
+		Recurrence:      t.Recurrence,
+		RecurrenceStart: start,
+		SeriesID:        seriesID,
+		PreviousID:      t.ID,
+	})
+	if err != nil {
+		// 释放占用，之后可重新生成
+		_, _ = s.repo.UpdatePartial(ctx, userID, t.ID, bson.M{"nextId": ""})
+		return nil, err
+	}
+	if _, err := s.repo.UpdatePartial(ctx, userID, t.ID, bson.M{"seriesId": seriesID, "recurrenceStart": *start}); err != nil {
+		return next, err
+	}
+	return next, nil
+}
+
+// History 循环任务所在系列的全部实例 (按创建时间排序)；非循环任务只返回自身
+func (s *TaskService) History(ctx context.Context, userID, id string) ([]models.Task, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("task service not init")
+	}
+	t, err := s.repo.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if t.SeriesID == "" {
+		return []models.Task{*t}, nil
+	}
+	return s.repo.ListSeries(ctx, userID, t.SeriesID)
+}
@@ -3,16 +3,37 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	nHub "github.com/axfinn/todoIngPlus/backend-go/internal/notifications"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// 子任务相关错误
+var (
+	ErrInvalidParentTask     = errors.New("invalid parent task")
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrChecklistTextRequired = errors.New("checklist item text required")
+)
+
+// MaxTaskDepth 任务层级上限 (含顶层任务)，同时限制汇总 / 级联删除的递归深度
+const MaxTaskDepth = 5
+
 // TaskService 抽离出的任务领域服务
 // 使用 repository 进行数据访问
 type TaskService struct {
-	repo repository.TaskRepository
+	repo     repository.TaskRepository
+	notifier *NotificationService // 可选：依赖解除 / 任务分配通知
+	hub      *nHub.Hub
+
+	users      repository.UserRepository      // 可选：负责人解析与校验
+	workspaces repository.WorkspaceRepository // 可选：工作区任务负责人须为成员
+	fields     repository.TaskFieldRepository // 可选：自定义字段定义
 }
 
 func NewTaskService(db repository.TaskRepository) *TaskService { return &TaskService{repo: db} }
@@ -38,21 +59,185 @@ func (s *TaskService) Create(ctx context.Context, userID string, in models.Task)
 	if in.Priority == "" {
 		in.Priority = "Medium"
 	}
+	if in.ParentID != nil {
+		pid, err := s.ValidateParent(ctx, userID, *in.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		in.ParentID = &pid
+		parent, err := s.repo.FindByID(ctx, userID, pid)
+		if err != nil {
+			return nil, err
+		}
+		in.WorkspaceID = parent.WorkspaceID // 子任务与父任务属于同一工作区
+	}
+	if in.Assignee != nil {
+		assignee, err := s.ResolveAssignee(ctx, *in.Assignee, &in)
+		if err != nil {
+			return nil, err
+		}
+		in.Assignee = assignee
+	}
+	recurrence, err := NormalizeRecurrence(in.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	in.Recurrence = recurrence
+	if in.Tags, err = NormalizeTags(in.Tags); err != nil {
+		return nil, err
+	}
+	// 循环任务的后续实例丢弃不再合法的自定义字段取值，避免完成任务时失败
+	if in.CustomFields, err = s.ResolveCustomFields(ctx, &in, nil, in.CustomFields, in.PreviousID == ""); err != nil {
+		return nil, err
+	}
+	in.Checklist = NormalizeChecklist(in.Checklist, now)
+	if len(in.Checklist) > 0 {
+		p, _ := models.RollupProgress(nil, in.Checklist)
+		in.Progress = &p
+	}
 	if err := s.repo.Insert(ctx, &in); err != nil {
 		return nil, err
 	}
+	if in.PreviousID == "" { // 循环任务的后续实例沿用负责人，不重复通知
+		s.NotifyAssigned(ctx, userID, nil, in)
+	}
+	if in.ParentID != nil {
+		if err := s.Rollup(ctx, userID, *in.ParentID); err != nil {
+			return &in, err
+		}
+	}
 	return &in, nil
 }
 
-// List 任务分页
-func (s *TaskService) List(ctx context.Context, userID string, status string, page, limit int64) ([]models.Task, int64, error) {
+// ValidateParent 校验父任务存在且层级未超限，返回父任务 ID
+func (s *TaskService) ValidateParent(ctx context.Context, userID, parentID string) (string, error) {
+	if parentID == "" {
+		return "", ErrInvalidParentTask
+	}
+	id := parentID
+	for depth := 1; ; depth++ {
+		t, err := s.repo.FindByID(ctx, userID, id)
+		if errors.Is(err, mongo.ErrNoDocuments) || (err == nil && t == nil) {
+			return "", ErrInvalidParentTask
+		}
+		if err != nil {
+			return "", err
+		}
+		if t.ParentID == nil {
+			return parentID, nil
+		}
+		if depth+1 >= MaxTaskDepth {
+			return "", ErrInvalidParentTask
+		}
+		id = *t.ParentID
+	}
+}
+
+// NormalizeChecklist 去除空检查项并补全 ID / 完成时间
+func NormalizeChecklist(items []models.ChecklistItem, now time.Time) []models.ChecklistItem {
+	out := make([]models.ChecklistItem, 0, len(items))
+	for _, it := range items {
+		if strings.TrimSpace(it.Text) == "" {
+			continue
+		}
+		if it.ID == "" {
+			it.ID = primitive.NewObjectID().Hex()
+		}
+		if it.Done && it.DoneAt == nil {
+			at := now
+			it.DoneAt = &at
+		}
+		if !it.Done {
+			it.DoneAt = nil
+		}
+		out = append(out, it)
+	}
+	return out
+}
+
+// Rollup 重新汇总任务进度与子任务截止日期，开启自动完成且全部完成时将任务置为完成；逐级向上汇总父任务
+func (s *TaskService) Rollup(ctx context.Context, userID, id string) error {
+	for depth := 0; id != "" && depth < MaxTaskDepth; depth++ {
+		t, err := s.repo.FindByID(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		children, err := s.repo.ListChildren(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		p, earliest := models.RollupProgress(children, t.Checklist)
+		set := bson.M{"progress": p, "subtaskDeadline": earliest}
+		completed := t.AutoComplete && p.Complete() && !models.IsTaskDone(t.Status)
+		if completed {
+			set["status"] = models.TaskStatusDone
+		}
+		if _, err := s.repo.UpdatePartial(ctx, userID, id, set); err != nil {
+			return err
+		}
+		if completed {
+			if _, err := s.ResolveDependents(ctx, userID, id); err != nil {
+				return err
+			}
+			if _, err := s.SpawnNextOccurrence(ctx, userID, id); err != nil {
+				return err
+			}
+		}
+		if t.ParentID == nil {
+			return nil
+		}
+		id = *t.ParentID
+	}
+	return nil
+}
+
+// Tree 任务详情及其子任务层级
+func (s *TaskService) Tree(ctx context.Context, userID, id string) (*models.Task, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("task service not init")
+	}
+	if userID == "" || id == "" {
+		return nil, errors.New("invalid params")
+	}
+	t, err := s.repo.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillSubtasks(ctx, userID, t, 1); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *TaskService) fillSubtasks(ctx context.Context, userID string, t *models.Task, depth int) error {
+	if depth >= MaxTaskDepth {
+		return nil
+	}
+	children, err := s.repo.ListChildren(ctx, userID, t.ID)
+	if err != nil {
+		return err
+	}
+	for i := range children {
+		if err := s.fillSubtasks(ctx, userID, &children[i], depth+1); err != nil {
+			return err
+		}
+	}
+	t.Subtasks = children
+	return nil
+}
+
+// List 任务分页 (可按状态与视图过滤)
+func (s *TaskService) List(ctx context.Context, userID string, q models.TaskQuery) ([]models.Task, int64, error) {
 	if s == nil || s.repo == nil {
 		return nil, 0, errors.New("task service not init")
 	}
 	if userID == "" {
 		return nil, 0, errors.New("user id missing")
 	}
-	return s.repo.List(ctx, userID, status, page, limit)
+	if !models.ValidTaskView(q.View) {
+		return nil, 0, ErrInvalidTaskView
+	}
+	return s.repo.List(ctx, userID, q)
 }
 
 // Get 单条任务
@@ -81,8 +266,18 @@ func (s *TaskService) Update(ctx context.Context, userID string, req models.Task
 	if req.Description != nil {
 		set["description"] = *req.Description
 	}
+	var before *models.Task
 	if req.Assignee != nil {
-		set["assignee"] = *req.Assignee
+		current, err := s.repo.FindByID(ctx, userID, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		assignee, err := s.ResolveAssignee(ctx, *req.Assignee, current)
+		if err != nil {
+			return nil, err
+		}
+		before = current
+		set["assignee"] = assignee
 	}
 	if req.Status != nil {
 		set["status"] = *req.Status
@@ -99,13 +294,138 @@ func (s *TaskService) Update(ctx context.Context, userID string, req models.Task
 	if len(req.Comments) > 0 {
 		set["comments"] = req.Comments
 	}
+	if req.Checklist != nil {
+		set["checklist"] = NormalizeChecklist(req.Checklist, time.Now())
+	}
+	if req.AutoComplete != nil {
+		set["autoComplete"] = *req.AutoComplete
+	}
+	if req.Recurrence != nil {
+		recurrence, err := NormalizeRecurrence(*req.Recurrence)
+		if err != nil {
+			return nil, err
+		}
+		set["recurrence"] = recurrence
+	}
+	if req.Tags != nil {
+		tags, err := NormalizeTags(*req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		set["tags"] = tags
+	}
+	if len(req.CustomFields) > 0 {
+		current, err := s.repo.FindByID(ctx, userID, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := s.ResolveCustomFields(ctx, current, current.CustomFields, req.CustomFields, true)
+		if err != nil {
+			return nil, err
+		}
+		set["customFields"] = fields
+	}
 	// 类型转换
 	bset := make(map[string]interface{}, len(set))
 	for k, v := range set {
 		bset[k] = v
 	}
 	// repository UpdatePartial 需要 bson.M; 这里直接断言即可
-	return s.repo.UpdatePartial(ctx, userID, req.ID, bset)
+	t, err := s.repo.UpdatePartial(ctx, userID, req.ID, bset)
+	if err != nil || t == nil {
+		return t, err
+	}
+	if before != nil {
+		s.NotifyAssigned(ctx, userID, before.Assignee, *t)
+	}
+	// 状态变化：重新计算依赖本任务的任务；循环任务完成时生成下一个实例
+	spawned := false
+	if req.Status != nil {
+		if _, err := s.ResolveDependents(ctx, userID, t.ID); err != nil {
+			return t, err
+		}
+		next, err := s.SpawnNextOccurrence(ctx, userID, t.ID)
+		if err != nil {
+			return t, err
+		}
+		spawned = next != nil
+	}
+	// 检查项 / 自动完成变化时从自身汇总；状态或截止日期变化只影响父任务
+	switch {
+	case req.Checklist != nil || req.AutoComplete != nil:
+		err = s.Rollup(ctx, userID, t.ID)
+	case (req.Status != nil || req.Deadline != nil) && t.ParentID != nil:
+		err = s.Rollup(ctx, userID, *t.ParentID)
+	case !spawned:
+		return t, nil
+	}
+	if err != nil {
+		return t, err
+	}
+	return s.repo.FindByID(ctx, userID, req.ID)
+}
+
+// AddChecklistItem 追加检查项
+func (s *TaskService) AddChecklistItem(ctx context.Context, userID, taskID, text string) (*models.Task, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, ErrChecklistTextRequired
+	}
+	return s.editChecklist(ctx, userID, taskID, func(items []models.ChecklistItem) ([]models.ChecklistItem, error) {
+		return append(items, models.ChecklistItem{Text: text}), nil
+	})
+}
+
+// UpdateChecklistItem 修改检查项文本或完成状态
+func (s *TaskService) UpdateChecklistItem(ctx context.Context, userID, taskID, itemID string, text *string, done *bool) (*models.Task, error) {
+	return s.editChecklist(ctx, userID, taskID, func(items []models.ChecklistItem) ([]models.ChecklistItem, error) {
+		for i := range items {
+			if items[i].ID != itemID {
+				continue
+			}
+			if text != nil {
+				items[i].Text = *text
+			}
+			if done != nil {
+				items[i].Done = *done
+			}
+			return items, nil
+		}
+		return nil, ErrChecklistItemNotFound
+	})
+}
+
+// RemoveChecklistItem 删除检查项
+func (s *TaskService) RemoveChecklistItem(ctx context.Context, userID, taskID, itemID string) (*models.Task, error) {
+	return s.editChecklist(ctx, userID, taskID, func(items []models.ChecklistItem) ([]models.ChecklistItem, error) {
+		for i := range items {
+			if items[i].ID == itemID {
+				return append(items[:i], items[i+1:]...), nil
+			}
+		}
+		return nil, ErrChecklistItemNotFound
+	})
+}
+
+// editChecklist 读取-修改-写回检查项并重新汇总进度
+func (s *TaskService) editChecklist(ctx context.Context, userID, taskID string, edit func([]models.ChecklistItem) ([]models.ChecklistItem, error)) (*models.Task, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("task service not init")
+	}
+	t, err := s.repo.FindByID(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := edit(append([]models.ChecklistItem(nil), t.Checklist...))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.UpdatePartial(ctx, userID, taskID, bson.M{"checklist": NormalizeChecklist(items, time.Now())}); err != nil {
+		return nil, err
+	}
+	if err := s.Rollup(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, userID, taskID)
 }
 
 // Delete 删除任务
@@ -116,5 +436,48 @@ func (s *TaskService) Delete(ctx context.Context, userID, id string) error {
 	if userID == "" || id == "" {
 		return errors.New("invalid params")
 	}
-	return s.repo.Delete(ctx, userID, id)
+	t, err := s.repo.FindByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.deleteSubtasks(ctx, userID, t.ID, 1); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.DetachDependents(ctx, userID, t.ID); err != nil {
+		return err
+	}
+	if t.ParentID != nil {
+		return s.Rollup(ctx, userID, *t.ParentID)
+	}
+	return nil
+}
+
+// DeleteSubtasks 级联删除全部子任务 (不含自身)
+func (s *TaskService) DeleteSubtasks(ctx context.Context, userID, id string) error {
+	return s.deleteSubtasks(ctx, userID, id, 1)
+}
+
+func (s *TaskService) deleteSubtasks(ctx context.Context, userID, id string, depth int) error {
+	if depth >= MaxTaskDepth {
+		return nil
+	}
+	children, err := s.repo.ListChildren(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	for _, c := range children {
+		if err := s.deleteSubtasks(ctx, userID, c.ID, depth+1); err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, userID, c.ID); err != nil {
+			return err
+		}
+		if err := s.DetachDependents(ctx, userID, c.ID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
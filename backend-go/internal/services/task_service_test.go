@@ -2,10 +2,16 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
 	"github.com/axfinn/todoIngPlus/backend-go/internal/repository/mocks"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestTaskServiceCreate(t *testing.T) {
@@ -19,3 +25,331 @@ func TestTaskServiceCreate(t *testing.T) {
 		t.Fatalf("expected id gen123 got %s", res.ID)
 	}
 }
+
+// memTaskRepo 以 map 模拟任务集合，仅覆盖子任务汇总用到的方法
+func memTaskRepo(tasks ...models.Task) (*mocks.TaskRepositoryMock, map[string]*models.Task) {
+	store := map[string]*models.Task{}
+	for i := range tasks {
+		store[tasks[i].ID] = &tasks[i]
+	}
+	return &mocks.TaskRepositoryMock{
+		InsertFn: func(ctx context.Context, t *models.Task) error {
+			if t.ID == "" {
+				t.ID = fmt.Sprintf("gen%d", len(store))
+			}
+			cp := *t
+			store[t.ID] = &cp
+			return nil
+		},
+		FindByIDFn: func(ctx context.Context, userID, id string) (*models.Task, error) {
+			t, ok := store[id]
+			if !ok {
+				return nil, mongo.ErrNoDocuments
+			}
+			cp := *t
+			return &cp, nil
+		},
+		ListChildrenFn: func(ctx context.Context, userID, parentID string) ([]models.Task, error) {
+			var out []models.Task
+			for _, t := range store {
+				if t.ParentID != nil && *t.ParentID == parentID {
+					out = append(out, *t)
+				}
+			}
+			return out, nil
+		},
+		ListBlockedByFn: func(ctx context.Context, userID, blockerID string) ([]models.Task, error) {
+			var out []models.Task
+			for _, t := range store {
+				for _, b := range t.BlockedBy {
+					if b == blockerID {
+						out = append(out, *t)
+					}
+				}
+			}
+			return out, nil
+		},
+		ListSeriesFn: func(ctx context.Context, userID, seriesID string) ([]models.Task, error) {
+			var out []models.Task
+			for _, t := range store {
+				if t.SeriesID == seriesID {
+					out = append(out, *t)
+				}
+			}
+			sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+			return out, nil
+		},
+		ClaimNextFn: func(ctx context.Context, userID, id, nextID string) (bool, error) {
+			t, ok := store[id]
+			if !ok || t.NextID != "" {
+				return false, nil
+			}
+			t.NextID = nextID
+			return true, nil
+		},
+		AddToSetFn: func(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error) {
+			t := store[id]
+			exists := false
+			for _, b := range t.BlockedBy {
+				exists = exists || b == value
+			}
+			if field == "blockedBy" && !exists {
+				t.BlockedBy = append(t.BlockedBy, value.(string))
+			}
+			cp := *t
+			return &cp, nil
+		},
+		PullFn: func(ctx context.Context, userID, id, field string, value interface{}) (*models.Task, error) {
+			t := store[id]
+			if field == "blockedBy" {
+				var kept []string
+				for _, b := range t.BlockedBy {
+					if b != value {
+						kept = append(kept, b)
+					}
+				}
+				t.BlockedBy = kept
+			}
+			cp := *t
+			return &cp, nil
+		},
+		UpdatePartialFn: func(ctx context.Context, userID, id string, set bson.M) (*models.Task, error) {
+			t := store[id]
+			if v, ok := set["blockedBy"].([]string); ok {
+				t.BlockedBy = v
+			}
+			if v, ok := set["blocked"].(bool); ok {
+				t.Blocked = v
+			}
+			if v, ok := set["unblockedAt"].(time.Time); ok {
+				t.UnblockedAt = &v
+			}
+			if p, ok := set["progress"].(models.TaskProgress); ok {
+				t.Progress = &p
+			}
+			if d, ok := set["subtaskDeadline"].(*time.Time); ok {
+				t.SubtaskDeadline = d
+			}
+			if st, ok := set["status"].(string); ok {
+				t.Status = st
+			}
+			if cl, ok := set["checklist"].([]models.ChecklistItem); ok {
+				t.Checklist = cl
+			}
+			if v, ok := set["nextId"].(string); ok {
+				t.NextID = v
+			}
+			if v, ok := set["seriesId"].(string); ok {
+				t.SeriesID = v
+			}
+			if v, ok := set["recurrenceStart"].(time.Time); ok {
+				t.RecurrenceStart = &v
+			}
+			if _, ok := set["assignee"]; ok {
+				t.Assignee, _ = set["assignee"].(*string)
+			}
+			if _, ok := set["tags"]; ok {
+				t.Tags, _ = set["tags"].([]string)
+			}
+			if _, ok := set["customFields"]; ok {
+				t.CustomFields, _ = set["customFields"].(map[string]interface{})
+			}
+			cp := *t
+			return &cp, nil
+		},
+	}, store
+}
+
+func TestTaskServiceRollupAutoCompletesAncestors(t *testing.T) {
+	root, mid := "root", "mid"
+	due := time.Now().Add(48 * time.Hour)
+	repo, store := memTaskRepo(
+		models.Task{ID: root, Status: "To Do", AutoComplete: true},
+		models.Task{ID: mid, Status: "To Do", ParentID: &root, AutoComplete: true, Checklist: []models.ChecklistItem{{ID: "c1", Text: "a"}}},
+		models.Task{ID: "leaf", Status: "Done", ParentID: &mid},
+		models.Task{ID: "other", Status: "To Do", ParentID: &root, Deadline: &due},
+	)
+	svc := &TaskService{repo: repo}
+	ctx := context.Background()
+	done := true
+
+	if _, err := svc.UpdateChecklistItem(ctx, "u1", mid, "c1", nil, &done); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if got := store[mid]; got.Status != models.TaskStatusDone || *got.Progress != (models.TaskProgress{Done: 2, Total: 2}) {
+		t.Fatalf("mid should auto-complete with 2/2, got %s %+v", got.Status, got.Progress)
+	}
+	// 根任务还有未完成子任务：不自动完成，截止日期取未完成子任务
+	if got := store[root]; models.IsTaskDone(got.Status) || *got.Progress != (models.TaskProgress{Done: 1, Total: 2}) || !got.SubtaskDeadline.Equal(due) {
+		t.Fatalf("root rollup wrong: %s %+v %v", got.Status, got.Progress, got.SubtaskDeadline)
+	}
+}
+
+func TestTaskServiceValidateParentDepth(t *testing.T) {
+	var chain []models.Task
+	for i := 0; i < MaxTaskDepth; i++ {
+		task := models.Task{ID: string(rune('a' + i))}
+		if i > 0 {
+			p := chain[i-1].ID
+			task.ParentID = &p
+		}
+		chain = append(chain, task)
+	}
+	repo, _ := memTaskRepo(chain...)
+	svc := &TaskService{repo: repo}
+	ctx := context.Background()
+	if _, err := svc.ValidateParent(ctx, "u1", chain[MaxTaskDepth-2].ID); err != nil {
+		t.Fatalf("level %d should be allowed: %v", MaxTaskDepth, err)
+	}
+	if _, err := svc.ValidateParent(ctx, "u1", chain[MaxTaskDepth-1].ID); !errors.Is(err, ErrInvalidParentTask) {
+		t.Fatalf("expected ErrInvalidParentTask beyond max depth, got %v", err)
+	}
+	if _, err := svc.ValidateParent(ctx, "u1", "missing"); !errors.Is(err, ErrInvalidParentTask) {
+		t.Fatalf("expected ErrInvalidParentTask for missing parent, got %v", err)
+	}
+}
+
+func TestCalculateTaskPriorityUsesSubtaskDeadline(t *testing.T) {
+	cfg := models.TaskSortConfig{PriorityDays: 7, WeightUrgent: 0.7, WeightImportant: 0.3}
+	soon := time.Now().Add(24 * time.Hour)
+	s := &TaskSortService{}
+	without := s.CalculateTaskPriority(models.Task{Status: "todo"}, cfg)
+	with := s.CalculateTaskPriority(models.Task{Status: "todo", SubtaskDeadline: &soon}, cfg)
+	if without != 0.1 || with <= without {
+		t.Fatalf("subtask deadline should raise priority: without=%v with=%v", without, with)
+	}
+}
+
+func TestTaskServiceDependencies(t *testing.T) {
+	repo, store := memTaskRepo(
+		models.Task{ID: "a", Title: "A", Status: "To Do"},
+		models.Task{ID: "b", Title: "B", Status: "To Do"},
+		models.Task{ID: "c", Title: "C", Status: "To Do"},
+	)
+	svc := &TaskService{repo: repo}
+	ctx := context.Background()
+
+	// c 依赖 b，b 依赖 a
+	if _, err := svc.AddDependency(ctx, "u1", "b", "a"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if _, err := svc.AddDependency(ctx, "u1", "c", "b"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !store["b"].Blocked || !store["c"].Blocked {
+		t.Fatalf("b and c should be blocked")
+	}
+	for _, pair := range [][2]string{{"a", "c"}, {"a", "a"}} {
+		if _, err := svc.AddDependency(ctx, "u1", pair[0], pair[1]); !errors.Is(err, ErrDependencyCycle) {
+			t.Fatalf("%s blocked by %s should be a cycle, got %v", pair[0], pair[1], err)
+		}
+	}
+	if _, err := svc.AddDependency(ctx, "u1", "a", "missing"); !errors.Is(err, ErrInvalidDependency) {
+		t.Fatalf("expected ErrInvalidDependency, got %v", err)
+	}
+
+	// a 完成：只有 b 解除阻塞，c 仍被 b 阻塞
+	store["a"].Status = "Done"
+	unblocked, err := svc.ResolveDependents(ctx, "u1", "a")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(unblocked) != 1 || unblocked[0].ID != "b" || store["b"].Blocked || store["b"].UnblockedAt == nil {
+		t.Fatalf("b should be newly unblocked, got %+v", unblocked)
+	}
+	if !store["c"].Blocked {
+		t.Fatalf("c should still be blocked")
+	}
+	// a 重新打开：b 再次阻塞
+	store["a"].Status = "To Do"
+	if _, err := svc.ResolveDependents(ctx, "u1", "a"); err != nil || !store["b"].Blocked {
+		t.Fatalf("b should be blocked again: %v", err)
+	}
+}
+
+func TestCalculateTaskPriorityDependencies(t *testing.T) {
+	cfg := models.TaskSortConfig{PriorityDays: 7, WeightUrgent: 0.7, WeightImportant: 0.3}
+	soon := time.Now().Add(24 * time.Hour)
+	justNow := time.Now().Add(-time.Hour)
+	s := &TaskSortService{}
+	base := s.CalculateTaskPriority(models.Task{Status: "todo", Deadline: &soon}, cfg)
+	blocked := s.CalculateTaskPriority(models.Task{Status: "todo", Deadline: &soon, Blocked: true}, cfg)
+	unblocked := s.CalculateTaskPriority(models.Task{Status: "todo", Deadline: &soon, UnblockedAt: &justNow}, cfg)
+	if !(blocked < base && base < unblocked) {
+		t.Fatalf("expected blocked < base < unblocked, got %v %v %v", blocked, base, unblocked)
+	}
+}
+
+func TestTaskServiceRecurrenceSpawnsNextInstance(t *testing.T) {
+	// 周一计划、周三截止的每周任务，提前完成
+	scheduled := time.Now().AddDate(0, 0, 3).Truncate(24 * time.Hour)
+	deadline := scheduled.Add(48 * time.Hour)
+	assignee := "bob"
+	repo, store := memTaskRepo(models.Task{
+		ID: "a", Title: "weekly report", Description: "send it", Status: "To Do", Priority: "High", Assignee: &assignee,
+		ScheduledDate: &scheduled, Deadline: &deadline, Recurrence: "FREQ=WEEKLY",
+		Checklist: []models.ChecklistItem{{ID: "c1", Text: "draft", Done: true}},
+	})
+	svc := &TaskService{repo: repo}
+	ctx := context.Background()
+	done := "Done"
+
+	if _, err := svc.Update(ctx, "u1", models.TaskUpdateRequest{ID: "a", Status: &done}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	first := store["a"]
+	if first.NextID == "" || first.SeriesID != "a" {
+		t.Fatalf("completed instance should link to next, got next=%q series=%q", first.NextID, first.SeriesID)
+	}
+	next := store[first.NextID]
+	if next.Status != models.TaskStatusTodo || next.PreviousID != "a" || next.SeriesID != "a" || next.Description != "send it" || next.Assignee == nil || *next.Assignee != "bob" {
+		t.Fatalf("next instance wrong: %+v", next)
+	}
+	if !next.ScheduledDate.Equal(scheduled.AddDate(0, 0, 7)) || !next.Deadline.Equal(deadline.AddDate(0, 0, 7)) {
+		t.Fatalf("dates should shift one week, got %v %v", next.ScheduledDate, next.Deadline)
+	}
+	if len(next.Checklist) != 1 || next.Checklist[0].Done || next.Checklist[0].ID == "c1" {
+		t.Fatalf("checklist should be reset, got %+v", next.Checklist)
+	}
+
+	// 再次标记完成不会重复生成
+	if _, err := svc.Update(ctx, "u1", models.TaskUpdateRequest{ID: "a", Status: &done}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	// 并发请求读到生成前的状态：nextId 占用失败，不生成实例
+	stale := *first
+	stale.NextID = ""
+	find := repo.FindByIDFn
+	repo.FindByIDFn = func(ctx context.Context, userID, id string) (*models.Task, error) { cp := stale; return &cp, nil }
+	if got, err := svc.SpawnNextOccurrence(ctx, "u1", "a"); err != nil || got != nil {
+		t.Fatalf("stale spawn should be a no-op, got %v %v", got, err)
+	}
+	repo.FindByIDFn = find
+	if len(store) != 2 || store["a"].NextID != next.ID {
+		t.Fatalf("expected no duplicate instance, got %d tasks", len(store))
+	}
+	history, err := svc.History(ctx, "u1", next.ID)
+	if err != nil || len(history) != 2 {
+		t.Fatalf("history should contain both instances, got %d %v", len(history), err)
+	}
+}
+
+func TestTaskNextRecurrenceSkipsMissedOccurrences(t *testing.T) {
+	now := time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	task := models.Task{Deadline: &due, Recurrence: "FREQ=DAILY;INTERVAL=7"}
+	scheduled, deadline, ok := task.NextRecurrence(now)
+	if !ok || scheduled != nil || !deadline.Equal(time.Date(2026, 3, 23, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("late completion should jump past now, got %v %v %v", scheduled, deadline, ok)
+	}
+	// COUNT 从系列起点计数
+	task.Recurrence = "FREQ=WEEKLY;COUNT=2"
+	start := due.AddDate(0, 0, -7)
+	task.RecurrenceStart = &start
+	if _, _, ok := task.NextRecurrence(now); ok {
+		t.Fatalf("series with COUNT=2 should have ended")
+	}
+	if _, err := NormalizeRecurrence("FREQ=SOMETIMES"); !errors.Is(err, models.ErrInvalidRecurrence) {
+		t.Fatalf("expected ErrInvalidRecurrence, got %v", err)
+	}
+}
@@ -101,17 +101,36 @@ func (s *TaskSortService) UpdateTaskSortConfig(ctx context.Context, userID primi
 	return &config, nil
 }
 
-// CalculateTaskPriority 计算任务优先级分数
+// 依赖状态对优先级的调整：被阻塞的任务降权，刚解除阻塞的任务加权
+const (
+	blockedPriorityFactor  = 0.3
+	unblockedPriorityBoost = 0.3
+)
+
+// CalculateTaskPriority 计算任务优先级分数；被未完成依赖阻塞的任务降权，刚解除阻塞的任务前置
 func (s *TaskSortService) CalculateTaskPriority(task models.Task, config models.TaskSortConfig) float64 {
+	score := s.basePriority(task, config)
+	switch {
+	case task.Blocked:
+		score *= blockedPriorityFactor
+	case task.RecentlyUnblocked(time.Now()):
+		score = math.Min(1, score+unblockedPriorityBoost)
+	}
+	return score
+}
+
+// basePriority 按截止时间、状态与优先级计算的分数 (不考虑依赖)
+func (s *TaskSortService) basePriority(task models.Task, config models.TaskSortConfig) float64 {
 	now := time.Now()
 
-	// 如果任务没有截止时间，给一个默认的低优先级
-	if task.Deadline == nil {
+	// 没有截止时间时取未完成子任务中最早的截止时间；都没有则给一个默认的低优先级
+	deadline := task.EffectiveDeadline()
+	if deadline == nil {
 		return 0.1
 	}
 
 	// 计算距离截止时间的天数
-	daysUntilDue := task.Deadline.Sub(now).Hours() / 24
+	daysUntilDue := deadline.Sub(now).Hours() / 24
 
 	// 紧急程度计算（越接近截止时间分数越高）
 	var urgencyScore float64
@@ -162,16 +181,27 @@ func (s *TaskSortService) CalculateTaskPriority(task models.Task, config models.
 
 // GetPriorityTasks 获取按优先级排序的任务
 func (s *TaskSortService) GetPriorityTasks(ctx context.Context, userID primitive.ObjectID) ([]models.PriorityTask, error) {
+	return s.GetPriorityTasksMatching(ctx, userID, nil)
+}
+
+// GetPriorityTasksMatching 同 GetPriorityTasks，只保留同时满足 match 的任务 (保存的视图 / 过滤表达式)
+func (s *TaskSortService) GetPriorityTasksMatching(ctx context.Context, userID primitive.ObjectID, match bson.M) ([]models.PriorityTask, error) {
 	// 获取排序配置
 	config, err := s.GetTaskSortConfig(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sort config: %w", err)
 	}
 
-	// 查询用户的活跃任务
-	filter := bson.M{
-		"createdBy": userID.Hex(), // 使用字符串类型的用户ID
-		"status":    bson.M{"$in": []string{"pending", "in_progress", "todo", "doing"}},
+	// 查询用户可见的活跃任务 (个人任务 + 所在工作区的任务)
+	scope, err := s.taskScope(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := repository.Scoped(scope, bson.M{
+		"status": bson.M{"$in": []string{"pending", "in_progress", "todo", "doing"}},
+	})
+	if len(match) > 0 {
+		filter = repository.Scoped(filter, match)
 	}
 
 	cursor, err := s.taskColl.Find(ctx, filter)
@@ -193,17 +223,18 @@ func (s *TaskSortService) GetPriorityTasks(ctx context.Context, userID primitive
 		priorityScore := s.CalculateTaskPriority(task, *config)
 
 		var daysLeft int
-		if task.Deadline != nil {
-			daysLeft = int(task.Deadline.Sub(now).Hours() / 24)
+		if deadline := task.EffectiveDeadline(); deadline != nil {
+			daysLeft = int(deadline.Sub(now).Hours() / 24)
 		}
 
 		isUrgent := daysLeft <= config.PriorityDays && daysLeft >= 0
 
 		priorityTask := models.PriorityTask{
-			Task:          task,
-			PriorityScore: priorityScore,
-			DaysLeft:      daysLeft,
-			IsUrgent:      isUrgent,
+			Task:           task,
+			PriorityScore:  priorityScore,
+			DaysLeft:       daysLeft,
+			IsUrgent:       isUrgent && !task.Blocked,
+			NewlyUnblocked: task.RecentlyUnblocked(now),
 		}
 
 		priorityTasks = append(priorityTasks, priorityTask)
@@ -391,10 +422,16 @@ func (s *TaskSortService) getEventSummary(ctx context.Context, userID primitive.
 	}, nil
 }
 
+// taskScope 用户可见的任务范围，与任务仓储一致
+func (s *TaskSortService) taskScope(ctx context.Context, userID primitive.ObjectID) (bson.M, error) {
+	return repository.TaskAccessFilter(ctx, s.db, userID.Hex(), models.WorkspaceRoleViewer)
+}
+
 // getTaskSummary 获取任务统计
 func (s *TaskSortService) getTaskSummary(ctx context.Context, userID primitive.ObjectID) (models.TaskSummary, error) {
-	filter := bson.M{
-		"createdBy": userID.Hex(),
+	filter, err := s.taskScope(ctx, userID)
+	if err != nil {
+		return models.TaskSummary{}, err
 	}
 
 	// 总任务数
@@ -404,10 +441,9 @@ func (s *TaskSortService) getTaskSummary(ctx context.Context, userID primitive.O
 	}
 
 	// 已完成任务数
-	completedFilter := bson.M{
-		"createdBy": userID.Hex(),
-		"status":    bson.M{"$in": []string{"completed", "done"}},
-	}
+	completedFilter := repository.Scoped(filter, bson.M{
+		"status": bson.M{"$in": []string{"completed", "done"}},
+	})
 	completedTasks, err := s.taskColl.CountDocuments(ctx, completedFilter)
 	if err != nil {
 		return models.TaskSummary{}, fmt.Errorf("failed to count completed tasks: %w", err)
@@ -421,11 +457,10 @@ func (s *TaskSortService) getTaskSummary(ctx context.Context, userID primitive.O
 
 	// 过期任务数
 	now := time.Now()
-	overdueFilter := bson.M{
-		"createdBy": userID.Hex(),
-		"status":    bson.M{"$in": []string{"pending", "in_progress", "todo", "doing"}},
-		"deadline":  bson.M{"$lt": now},
-	}
+	overdueFilter := repository.Scoped(filter, bson.M{
+		"status":   bson.M{"$in": []string{"pending", "in_progress", "todo", "doing"}},
+		"deadline": bson.M{"$lt": now},
+	})
 	overdueCount, err := s.taskColl.CountDocuments(ctx, overdueFilter)
 	if err != nil {
 		return models.TaskSummary{}, fmt.Errorf("failed to count overdue tasks: %w", err)
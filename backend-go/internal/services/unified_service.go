@@ -34,6 +34,8 @@ type simpleTask struct {
 	Deadline      *time.Time
 	ScheduledDate *time.Time
 	Unscheduled   bool // 标记是否是临时无日期任务（使用 now 占位）
+	Blocked       bool
+	UnblockedAt   *time.Time
 }
 
 // buildUpcomingItems 抽取出的纯构建 & 过滤 & 排序逻辑，便于单元测试
@@ -56,7 +58,7 @@ func buildUpcomingItems(now, end time.Time, sources []string, limit int, events
 		if useFilter && !sourceFilter["event"] {
 			continue
 		}
-		items = append(items, models.UnifiedItem{ID: ev.ID.Hex(), Source: "event", Title: ev.Title, ScheduledAt: ev.EventDate, CountdownSeconds: secs, DaysLeft: daysLeft, Importance: ev.ImportanceLevel, DetailURL: "/events", SourceID: ev.ID.Hex()})
+		items = append(items, models.UnifiedItem{ID: ev.ID.Hex(), Source: "event", Title: ev.Title, ScheduledAt: ev.EventDate, CountdownSeconds: secs, DaysLeft: daysLeft, Importance: ev.ImportanceLevel, DetailURL: "/events", SourceID: ev.ID.Hex(), OccurrenceKey: ev.OccurrenceKey})
 	}
 	// 提醒
 	for _, r := range reminders {
@@ -85,7 +87,7 @@ func buildUpcomingItems(now, end time.Time, sources []string, limit int, events
 		}
 		secs := int(sched.Sub(now).Seconds())
 		daysLeft := int(sched.Sub(now).Hours() / 24)
-		items = append(items, models.UnifiedItem{ID: t.ID, Source: "task", Title: t.Title, ScheduledAt: *sched, CountdownSeconds: secs, DaysLeft: daysLeft, PriorityScore: t.PriorityScore, DetailURL: "/dashboard", SourceID: t.ID})
+		items = append(items, models.UnifiedItem{ID: t.ID, Source: "task", Title: t.Title, ScheduledAt: *sched, CountdownSeconds: secs, DaysLeft: daysLeft, PriorityScore: t.PriorityScore, DetailURL: "/dashboard", SourceID: t.ID, Blocked: t.Blocked, NewlyUnblocked: t.RecentlyUnblocked(now)})
 		prioritySet[t.ID] = struct{}{}
 	}
 	// 普通任务
@@ -111,10 +113,14 @@ func buildUpcomingItems(now, end time.Time, sources []string, limit int, events
 		}
 		secs := int(sched.Sub(now).Seconds())
 		daysLeft := int(sched.Sub(now).Hours() / 24)
-		items = append(items, models.UnifiedItem{ID: t.ID, Source: "task", Title: t.Title, ScheduledAt: *sched, CountdownSeconds: secs, DaysLeft: daysLeft, DetailURL: "/dashboard", SourceID: t.ID, IsUnscheduled: t.Unscheduled})
+		newlyUnblocked := models.Task{Blocked: t.Blocked, UnblockedAt: t.UnblockedAt}.RecentlyUnblocked(now) // 查询已排除完成任务
+		items = append(items, models.UnifiedItem{ID: t.ID, Source: "task", Title: t.Title, ScheduledAt: *sched, CountdownSeconds: secs, DaysLeft: daysLeft, DetailURL: "/dashboard", SourceID: t.ID, IsUnscheduled: t.Unscheduled, Blocked: t.Blocked, NewlyUnblocked: newlyUnblocked})
 	}
-	// 排序
+	// 排序：刚解除阻塞的任务在前，被阻塞的任务在后，其余按时间
 	sort.SliceStable(items, func(i, j int) bool {
+		if ri, rj := dependencyRank(items[i]), dependencyRank(items[j]); ri != rj {
+			return ri < rj
+		}
 		if items[i].ScheduledAt.Equal(items[j].ScheduledAt) {
 			if items[i].Importance == items[j].Importance {
 				return items[i].PriorityScore > items[j].PriorityScore
@@ -129,27 +135,70 @@ func buildUpcomingItems(now, end time.Time, sources []string, limit int, events
 	return items
 }
 
-func buildTaskWindowFilter(userID primitive.ObjectID, graceStart, end time.Time) bson.M {
-	return bson.M{"$and": []bson.M{
-		{"$or": []bson.M{{"createdBy": userID.Hex()}, {"createdBy": userID}, {"user_id": userID}}},
+// dependencyRank 统一看板中依赖状态的排序分组
+func dependencyRank(it models.UnifiedItem) int {
+	switch {
+	case it.NewlyUnblocked:
+		return 0
+	case it.Blocked:
+		return 2
+	}
+	return 1
+}
+
+// parseAnyTime 兼容历史数据中日期字段为 DateTime / 字符串等多种存储形式
+func parseAnyTime(v interface{}) *time.Time {
+	switch tv := v.(type) {
+	case primitive.DateTime:
+		tt := tv.Time()
+		return &tt
+	case time.Time:
+		return &tv
+	case *time.Time:
+		return tv
+	case string:
+		if tv == "" {
+			return nil
+		}
+		if t1, err := time.Parse(time.RFC3339, tv); err == nil {
+			return &t1
+		}
+		if t2, err := time.Parse("2006-01-02", tv); err == nil {
+			return &t2
+		}
+	}
+	return nil
+}
+
+// taskScope 用户可见的任务范围 (个人任务 + 所在工作区的任务)，与任务仓储一致
+func (s *UnifiedService) taskScope(ctx context.Context, userID primitive.ObjectID) (bson.M, error) {
+	return repository.TaskAccessFilter(ctx, s.db, userID.Hex(), models.WorkspaceRoleViewer)
+}
+
+func buildTaskWindowFilter(scope bson.M, graceStart, end time.Time) bson.M {
+	return repository.Scoped(scope, bson.M{"$and": []bson.M{
 		{"status": bson.M{"$nin": []string{"Done", "done", "DONE", "已完成"}}},
 		{"$or": []bson.M{{"deadline": bson.M{"$gte": graceStart, "$lte": end}}, {"scheduledDate": bson.M{"$gte": graceStart, "$lte": end}}, {"dueDate": bson.M{"$gte": graceStart, "$lte": end}}}},
-	}}
+	}})
 }
 
-func buildUnscheduledFilter(userID primitive.ObjectID, since time.Time) bson.M {
-	return bson.M{
-		"$or":    []bson.M{{"createdBy": userID.Hex()}, {"createdBy": userID}, {"user_id": userID}},
+func buildUnscheduledFilter(scope bson.M, since time.Time) bson.M {
+	return repository.Scoped(scope, bson.M{
 		"status": bson.M{"$nin": []string{"Done", "done", "DONE", "已完成"}},
 		"$and": []bson.M{
 			{"$or": []bson.M{{"deadline": bson.M{"$exists": false}}, {"deadline": nil}}},
 			{"$or": []bson.M{{"scheduledDate": bson.M{"$exists": false}}, {"scheduledDate": nil}}},
 		},
 		"createdAt": bson.M{"$gte": since},
-	}
+	})
 }
 
 func (s *UnifiedService) GetUpcoming(ctx context.Context, userID primitive.ObjectID, hours int, sources []string, limit int) ([]models.UnifiedItem, *models.UnifiedUpcomingDebug, error) {
+	return s.GetUpcomingFiltered(ctx, userID, hours, sources, limit, nil)
+}
+
+// GetUpcomingFiltered 同 GetUpcoming，任务只保留同时满足 taskMatch 的 (保存的视图 / 过滤表达式)；事件与提醒不受影响
+func (s *UnifiedService) GetUpcomingFiltered(ctx context.Context, userID primitive.ObjectID, hours int, sources []string, limit int, taskMatch bson.M) ([]models.UnifiedItem, *models.UnifiedUpcomingDebug, error) {
 	if s == nil || s.db == nil {
 		return nil, nil, errors.New("unified service db not initialized")
 	}
@@ -158,6 +207,10 @@ func (s *UnifiedService) GetUpcoming(ctx context.Context, userID primitive.Objec
 	}
 	now := time.Now()
 	end := now.Add(time.Duration(hours) * time.Hour)
+	scope, err := s.taskScope(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	eventRepo := repository.NewEventRepository(s.db)
 	reminderRepo := repository.NewReminderRepository(s.db)
@@ -185,19 +238,22 @@ func (s *UnifiedService) GetUpcoming(ctx context.Context, userID primitive.Objec
 		defer wg.Done()
 		reminders, remindersErr = reminderSvc.GetUpcomingReminders(ctx, userID, hours)
 	}()
-	go func() { // priority tasks (already filters by createdBy)
+	go func() { // priority tasks (scoped to visible tasks)
 		defer wg.Done()
-		priorityTasks, priorityErr = taskSortSvc.GetPriorityTasks(ctx, userID)
+		priorityTasks, priorityErr = taskSortSvc.GetPriorityTasksMatching(ctx, userID, taskMatch)
 	}()
 	go func() { // normal tasks with deadline/scheduled/dueDate window + recent unscheduled tasks
 		defer wg.Done()
 		tasksColl := s.db.Collection("tasks")
 		// 新策略: 直接获取所有未完成任务(不加日期范围)以避免因日期字段为字符串/格式异常导致 Mongo 端过滤失败
 		// 之后在内存中解析 deadline / scheduledDate / dueDate，统一计算展示时间
-		baseFilter := bson.M{"$and": []bson.M{{"$or": []bson.M{{"createdBy": userID.Hex()}, {"createdBy": userID}, {"user_id": userID}}}, {"status": bson.M{"$nin": []string{"Done", "done", "DONE", "已完成"}}}}}
+		baseFilter := repository.Scoped(scope, bson.M{"status": bson.M{"$nin": []string{"Done", "done", "DONE", "已完成"}}})
+		if len(taskMatch) > 0 {
+			baseFilter = repository.Scoped(baseFilter, taskMatch)
+		}
 		// 设一个最大条数上限，防止用户有海量历史任务导致一次性拉取过大
 		maxTasks := int64(1000)
-		opts := options.Find().SetProjection(bson.M{"title": 1, "deadline": 1, "scheduledDate": 1, "dueDate": 1, "createdAt": 1}).SetSort(bson.D{{Key: "deadline", Value: 1}, {Key: "scheduledDate", Value: 1}, {Key: "createdAt", Value: -1}}).SetLimit(maxTasks)
+		opts := options.Find().SetProjection(bson.M{"title": 1, "deadline": 1, "scheduledDate": 1, "dueDate": 1, "createdAt": 1, "blocked": 1, "unblockedAt": 1}).SetSort(bson.D{{Key: "deadline", Value: 1}, {Key: "scheduledDate", Value: 1}, {Key: "createdAt", Value: -1}}).SetLimit(maxTasks)
 		cur, err := tasksColl.Find(ctx, baseFilter, opts)
 		if err != nil {
 			normalTasksErr = err
@@ -220,28 +276,6 @@ func (s *UnifiedService) GetUpcoming(ctx context.Context, userID primitive.Objec
 				continue
 			}
 			title, _ := raw["title"].(string)
-			parseAnyTime := func(v interface{}) *time.Time {
-				switch tv := v.(type) {
-				case primitive.DateTime:
-					tt := tv.Time()
-					return &tt
-				case time.Time:
-					return &tv
-				case *time.Time:
-					return tv
-				case string:
-					if tv == "" {
-						return nil
-					}
-					if t1, err := time.Parse(time.RFC3339, tv); err == nil {
-						return &t1
-					}
-					if t2, err := time.Parse("2006-01-02", tv); err == nil {
-						return &t2
-					}
-				}
-				return nil
-			}
 			deadline := parseAnyTime(raw["deadline"])
 			scheduled := parseAnyTime(raw["scheduledDate"])
 			var dueDate *time.Time
@@ -270,7 +304,8 @@ func (s *UnifiedService) GetUpcoming(ctx context.Context, userID primitive.Objec
 				unscheduled = true
 				unscheduledCount++
 			}
-			normalTasks = append(normalTasks, simpleTask{ID: id, Title: title, Deadline: deadline, ScheduledDate: sched, Unscheduled: unscheduled})
+			blocked, _ := raw["blocked"].(bool)
+			normalTasks = append(normalTasks, simpleTask{ID: id, Title: title, Deadline: deadline, ScheduledDate: sched, Unscheduled: unscheduled, Blocked: blocked, UnblockedAt: parseAnyTime(raw["unblockedAt"])})
 		}
 		log.Printf("unified: tasks(all-active) deadline=%d scheduled=%d dueDateOnly=%d unscheduled=%d total=%d", deadlineCount, scheduledCount, dueDateOnlyCount, unscheduledCount, len(normalTasks))
 	}()
@@ -295,8 +330,8 @@ func (s *UnifiedService) GetUpcoming(ctx context.Context, userID primitive.Objec
 		// Rebuild filters (cheap) for serialization
 		graceDays := 2
 		graceStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -graceDays)
-		wf := buildTaskWindowFilter(userID, graceStart, end)
-		uf := buildUnscheduledFilter(userID, now.Add(-24*time.Hour))
+		wf := buildTaskWindowFilter(scope, graceStart, end)
+		uf := buildUnscheduledFilter(scope, now.Add(-24*time.Hour))
 		dbg = &models.UnifiedUpcomingDebug{GraceDays: graceDays, Hours: hours, Now: now.Format(time.RFC3339), End: end.Format(time.RFC3339)}
 		// window counts recovered from log not stored; do a lightweight recount over normalTasks slice
 		for _, t := range normalTasks {
@@ -328,18 +363,19 @@ func (s *UnifiedService) GetCalendar(ctx context.Context, userID primitive.Objec
 	if s == nil || s.db == nil {
 		return models.UnifiedCalendarResponse{}, errors.New("unified service db not initialized")
 	}
-	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	end := start.AddDate(0, 1, 0)
+	// 月份边界与日期分桶均按用户时区
+	loc := NewUserService(repository.NewUserRepository(s.db)).Location(ctx, userID)
+	start, end := models.MonthRange(year, month, loc)
 	daysMap := make(map[string][]models.UnifiedCalendarItem)
 
 	// 使用仓储+服务
 	eventRepo := repository.NewEventRepository(s.db)
 	reminderRepo := repository.NewReminderRepository(s.db)
 	eventSvc := NewEventService(eventRepo)
-	events, _ := eventSvc.GetCalendarEvents(ctx, userID, year, month)
+	events, _ := eventSvc.GetCalendarEvents(ctx, userID, year, month, loc)
 	for day, evs := range events {
 		for _, ev := range evs {
-			daysMap[day] = append(daysMap[day], models.UnifiedCalendarItem{ID: ev.ID.Hex(), Source: "event", Title: ev.Title, ScheduledAt: ev.EventDate, Importance: ev.ImportanceLevel, DetailURL: "/events"})
+			daysMap[day] = append(daysMap[day], models.UnifiedCalendarItem{ID: ev.ID.Hex(), Source: "event", Title: ev.Title, ScheduledAt: ev.EventDate, Importance: ev.ImportanceLevel, DetailURL: "/events", OccurrenceKey: ev.OccurrenceKey})
 		}
 	}
 
@@ -353,16 +389,19 @@ func (s *UnifiedService) GetCalendar(ctx context.Context, userID primitive.Objec
 		if r.ReminderAt.Before(start) || r.ReminderAt.Equal(end) || r.ReminderAt.After(end) {
 			continue
 		}
-		dayKey := r.ReminderAt.Format("2006-01-02")
+		dayKey := models.DayKey(r.ReminderAt, loc)
 		daysMap[dayKey] = append(daysMap[dayKey], models.UnifiedCalendarItem{ID: r.ID.Hex(), Source: "reminder", Title: r.Message, ScheduledAt: r.ReminderAt, Importance: r.Importance, DetailURL: "/reminders"})
 	}
 
-	// 任务 (兼容 createdBy 字符串 / 未来可能的 user_id ObjectID)
+	// 任务 (个人任务 + 所在工作区的任务)
 	tasksColl := s.db.Collection("tasks")
-	tFilter := bson.M{"$and": []bson.M{
-		{"$or": []bson.M{{"createdBy": userID.Hex()}, {"user_id": userID}}},
-		{"$or": []bson.M{{"deadline": bson.M{"$gte": start, "$lt": end}}, {"scheduledDate": bson.M{"$gte": start, "$lt": end}}}},
-	}}
+	scope, err := s.taskScope(ctx, userID)
+	if err != nil {
+		return models.UnifiedCalendarResponse{}, err
+	}
+	tFilter := repository.Scoped(scope, bson.M{
+		"$or": []bson.M{{"deadline": bson.M{"$gte": start, "$lt": end}}, {"scheduledDate": bson.M{"$gte": start, "$lt": end}}},
+	})
 	cur, err := tasksColl.Find(ctx, tFilter, options.Find().SetProjection(bson.M{"title": 1, "deadline": 1, "scheduledDate": 1}))
 	if err == nil {
 		defer cur.Close(ctx)
@@ -381,7 +420,7 @@ func (s *UnifiedService) GetCalendar(ctx context.Context, userID primitive.Objec
 					d = doc.ScheduledDate
 				}
 				if d != nil {
-					key := d.Format("2006-01-02")
+					key := models.DayKey(*d, loc)
 					daysMap[key] = append(daysMap[key], models.UnifiedCalendarItem{ID: doc.ID, Source: "task", Title: doc.Title, ScheduledAt: *d, DetailURL: "/dashboard"})
 				}
 			}
@@ -397,3 +436,60 @@ func (s *UnifiedService) GetCalendar(ctx context.Context, userID primitive.Objec
 	}
 	return models.UnifiedCalendarResponse{Year: year, Month: month, Days: daysMap, Total: total}, nil
 }
+
+// GetFeedData 聚合 iCalendar 订阅所需数据：有效事件系列 (含例外)、有效提醒、带日期的任务
+func (s *UnifiedService) GetFeedData(ctx context.Context, userID primitive.ObjectID) (*models.CalendarFeedData, error) {
+	if s == nil || s.db == nil {
+		return nil, errors.New("unified service db not initialized")
+	}
+	data := &models.CalendarFeedData{TimeZone: NewUserService(repository.NewUserRepository(s.db)).TimeZone(ctx, userID)}
+	var err error
+	if data.Events, err = repository.NewEventRepository(s.db).ListActive(ctx, userID); err != nil {
+		return nil, err
+	}
+	if data.Reminders, err = repository.NewReminderRepository(s.db).ListByUser(ctx, userID, true); err != nil {
+		return nil, err
+	}
+	scope, err := s.taskScope(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	filter := repository.Scoped(scope, bson.M{
+		"$or": []bson.M{{"deadline": bson.M{"$ne": nil}}, {"scheduledDate": bson.M{"$ne": nil}}},
+	})
+	opts := options.Find().SetProjection(bson.M{"title": 1, "description": 1, "status": 1, "priority": 1, "deadline": 1, "scheduledDate": 1, "createdAt": 1, "updatedAt": 1}).SetSort(bson.D{{Key: "updatedAt", Value: -1}}).SetLimit(1000)
+	cur, err := s.db.Collection("tasks").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		var raw bson.M
+		if cur.Decode(&raw) != nil {
+			continue
+		}
+		t := models.Task{}
+		if oid, ok := raw["_id"].(primitive.ObjectID); ok {
+			t.ID = oid.Hex()
+		} else if id, ok := raw["_id"].(string); ok {
+			t.ID = id
+		}
+		t.Title, _ = raw["title"].(string)
+		t.Description, _ = raw["description"].(string)
+		t.Status, _ = raw["status"].(string)
+		t.Priority, _ = raw["priority"].(string)
+		t.Deadline = parseAnyTime(raw["deadline"])
+		t.ScheduledDate = parseAnyTime(raw["scheduledDate"])
+		if ts := parseAnyTime(raw["createdAt"]); ts != nil {
+			t.CreatedAt = *ts
+		}
+		if ts := parseAnyTime(raw["updatedAt"]); ts != nil {
+			t.UpdatedAt = *ts
+		}
+		if t.ID == "" || (t.Deadline == nil && t.ScheduledDate == nil) {
+			continue
+		}
+		data.Tasks = append(data.Tasks, t)
+	}
+	return data, nil
+}
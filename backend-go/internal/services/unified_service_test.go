@@ -63,3 +63,22 @@ func TestBuildUpcomingItems(t *testing.T) {
 }
 
 func ptrTime(ti time.Time) *time.Time { return &ti }
+
+// TestBuildUpcomingItemsDependencies 刚解除阻塞的任务前置，被阻塞的任务后置 (limit 优先裁掉)
+func TestBuildUpcomingItemsDependencies(t *testing.T) {
+	now := time.Now()
+	end := now.Add(48 * time.Hour)
+	justNow := now.Add(-time.Hour)
+	events := []models.Event{{ID: primitive.NewObjectID(), Title: "Event", EventDate: now.Add(6 * time.Hour)}}
+	normals := []simpleTask{
+		{ID: "blocked", Title: "Blocked", Deadline: ptrTime(now.Add(time.Hour)), Blocked: true},
+		{ID: "unblocked", Title: "Unblocked", Deadline: ptrTime(now.Add(30 * time.Hour)), UnblockedAt: &justNow},
+	}
+	items := buildUpcomingItems(now, end, nil, 0, events, nil, nil, normals)
+	if len(items) != 3 || items[0].ID != "unblocked" || !items[0].NewlyUnblocked || items[2].ID != "blocked" || !items[2].Blocked {
+		t.Fatalf("unexpected ordering: %+v", items)
+	}
+	if limited := buildUpcomingItems(now, end, nil, 2, events, nil, nil, normals); limited[1].ID == "blocked" {
+		t.Fatalf("blocked task should be truncated first: %+v", limited)
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/templates"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserService 用户资料 (时区等偏好)
+type UserService struct{ repo repository.UserRepository }
+
+func NewUserService(repo repository.UserRepository) *UserService { return &UserService{repo: repo} }
+
+func (s *UserService) GetProfile(ctx context.Context, userID primitive.ObjectID) (*models.User, error) {
+	if s.repo == nil {
+		return nil, errors.New("user repo nil")
+	}
+	return s.repo.FindByID(ctx, userID)
+}
+
+// UpdateProfile 更新个人资料，时区需为合法 IANA 名称、语言需为支持的语言 (空串表示清除)
+func (s *UserService) UpdateProfile(ctx context.Context, userID primitive.ObjectID, req models.UpdateProfileRequest) (*models.User, error) {
+	if s.repo == nil {
+		return nil, errors.New("user repo nil")
+	}
+	set := map[string]interface{}{}
+	if req.TimeZone != nil {
+		if err := models.ValidateTimeZone(*req.TimeZone); err != nil {
+			return nil, err
+		}
+		set["timeZone"] = *req.TimeZone
+	}
+	if req.Locale != nil {
+		if err := templates.Default.Validate(*req.Locale); err != nil {
+			return nil, err
+		}
+		set["locale"] = templates.Default.Normalize(*req.Locale)
+	}
+	return s.repo.UpdateFields(ctx, userID, set)
+}
+
+// TimeZone 用户时区名 (未设置返回空串)
+func (s *UserService) TimeZone(ctx context.Context, userID primitive.ObjectID) string {
+	if s == nil || s.repo == nil {
+		return ""
+	}
+	return s.repo.TimeZone(ctx, userID)
+}
+
+// Locale 用户语言，未设置时回退默认语言
+func (s *UserService) Locale(ctx context.Context, userID primitive.ObjectID) string {
+	if s == nil || s.repo == nil {
+		return templates.DefaultLocale
+	}
+	return templates.Default.Resolve(s.repo.Locale(ctx, userID))
+}
+
+// Location 用户时区，未设置时回退 UTC
+func (s *UserService) Location(ctx context.Context, userID primitive.ObjectID) *time.Location {
+	return models.LoadLocation(s.TimeZone(ctx, userID))
+}
+
+// LocationByHex 同 Location，接受字符串形式的用户 ID (报表等以字符串保存 userId)
+func (s *UserService) LocationByHex(ctx context.Context, userID string) *time.Location {
+	oid, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return time.UTC
+	}
+	return s.Location(ctx, oid)
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// 工作区相关错误 (未找到 / 角色不足见 repository.ErrWorkspaceNotFound / ErrWorkspaceForbidden)
+var (
+	ErrWorkspaceNameRequired   = errors.New("workspace name required")
+	ErrInvalidWorkspaceRole    = errors.New("invalid workspace role")
+	ErrWorkspaceMemberExists   = errors.New("user is already a workspace member")
+	ErrWorkspaceMemberNotFound = errors.New("workspace member not found")
+	ErrWorkspaceLastOwner      = errors.New("workspace must keep at least one owner")
+)
+
+// WorkspaceService 工作区与成员管理：成员可读，owner 管理工作区与成员，成员可自行退出
+type WorkspaceService struct {
+	repo  repository.WorkspaceRepository
+	users repository.UserRepository
+}
+
+func NewWorkspaceService(repo repository.WorkspaceRepository, users repository.UserRepository) *WorkspaceService {
+	return &WorkspaceService{repo: repo, users: users}
+}
+
+// Create 创建工作区，创建者成为 owner
+func (s *WorkspaceService) Create(ctx context.Context, userID primitive.ObjectID, req models.CreateWorkspaceRequest) (*models.Workspace, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("workspace service not init")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrWorkspaceNameRequired
+	}
+	now := time.Now()
+	w := &models.Workspace{
+		ID:          primitive.NewObjectID(),
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		OwnerID:     userID,
+		Members:     []models.WorkspaceMember{{UserID: userID, Role: models.WorkspaceRoleOwner, AddedAt: now}},
+		CreatedAt:   now,
+	}
+	if err := s.repo.Insert(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// List 用户所属的全部工作区
+func (s *WorkspaceService) List(ctx context.Context, userID primitive.ObjectID) ([]models.Workspace, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("workspace service not init")
+	}
+	return s.repo.ListByMember(ctx, userID)
+}
+
+// Get 读取工作区 (仅成员可见)
+func (s *WorkspaceService) Get(ctx context.Context, userID, id primitive.ObjectID) (*models.Workspace, error) {
+	return s.load(ctx, userID, id, models.WorkspaceRoleViewer)
+}
+
+// Update 修改名称 / 描述 (owner)
+func (s *WorkspaceService) Update(ctx context.Context, userID, id primitive.ObjectID, req models.UpdateWorkspaceRequest) (*models.Workspace, error) {
+	w, err := s.load(ctx, userID, id, models.WorkspaceRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	set := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, ErrWorkspaceNameRequired
+		}
+		set["name"] = name
+	}
+	if req.Description != nil {
+		set["description"] = strings.TrimSpace(*req.Description)
+	}
+	if len(set) == 0 {
+		return w, nil
+	}
+	return s.repo.UpdateFields(ctx, id, set)
+}
+
+// Delete 删除工作区 (owner)，其中的数据转为各自创建者的个人数据
+func (s *WorkspaceService) Delete(ctx context.Context, userID, id primitive.ObjectID) error {
+	if _, err := s.load(ctx, userID, id, models.WorkspaceRoleOwner); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// AddMember 按用户 ID、用户名或邮箱添加成员 (owner)
+func (s *WorkspaceService) AddMember(ctx context.Context, userID, id primitive.ObjectID, req models.WorkspaceMemberRequest) (*models.Workspace, error) {
+	if !models.ValidWorkspaceRole(req.Role) {
+		return nil, ErrInvalidWorkspaceRole
+	}
+	return s.changeMembers(ctx, userID, id, models.WorkspaceRoleOwner, func(w *models.Workspace) (*models.Workspace, error) {
+		if s.users == nil {
+			return nil, errors.New("user repo nil")
+		}
+		u, err := s.users.FindByLogin(ctx, strings.TrimSpace(req.User))
+		if err != nil {
+			return nil, err
+		}
+		memberID, err := primitive.ObjectIDFromHex(u.ID)
+		if err != nil {
+			return nil, repository.ErrUserNotFound
+		}
+		if w.RoleOf(memberID) != "" {
+			return nil, ErrWorkspaceMemberExists
+		}
+		return s.repo.AddMember(ctx, id, models.WorkspaceMember{UserID: memberID, Role: req.Role, AddedAt: time.Now()})
+	})
+}
+
+// UpdateMember 修改成员角色 (owner)；不能降级最后一名 owner
+func (s *WorkspaceService) UpdateMember(ctx context.Context, userID, id, memberID primitive.ObjectID, role string) (*models.Workspace, error) {
+	if !models.ValidWorkspaceRole(role) {
+		return nil, ErrInvalidWorkspaceRole
+	}
+	return s.changeMembers(ctx, userID, id, models.WorkspaceRoleOwner, func(w *models.Workspace) (*models.Workspace, error) {
+		current := w.RoleOf(memberID)
+		if current == "" {
+			return nil, ErrWorkspaceMemberNotFound
+		}
+		if current == role {
+			return w, nil
+		}
+		if current == models.WorkspaceRoleOwner && w.OwnerCount() <= 1 {
+			return nil, ErrWorkspaceLastOwner
+		}
+		return s.repo.SetMemberRole(ctx, id, memberID, role)
+	})
+}
+
+// RemoveMember 移除成员：owner 可移除任意成员，其他成员只能退出自己；不能移除最后一名 owner
+func (s *WorkspaceService) RemoveMember(ctx context.Context, userID, id, memberID primitive.ObjectID) (*models.Workspace, error) {
+	minRole := models.WorkspaceRoleOwner
+	if memberID == userID {
+		minRole = models.WorkspaceRoleViewer
+	}
+	return s.changeMembers(ctx, userID, id, minRole, func(w *models.Workspace) (*models.Workspace, error) {
+		role := w.RoleOf(memberID)
+		if role == "" {
+			return nil, ErrWorkspaceMemberNotFound
+		}
+		if role == models.WorkspaceRoleOwner && w.OwnerCount() <= 1 {
+			return nil, ErrWorkspaceLastOwner
+		}
+		return s.repo.RemoveMember(ctx, id, memberID)
+	})
+}
+
+// changeMembers 读取并校验后执行成员变更；条件更新因并发修改未命中时重新读取校验，最多 3 次
+func (s *WorkspaceService) changeMembers(ctx context.Context, userID, id primitive.ObjectID, minRole string, apply func(w *models.Workspace) (*models.Workspace, error)) (*models.Workspace, error) {
+	for attempt := 1; ; attempt++ {
+		w, err := s.load(ctx, userID, id, minRole)
+		if err != nil {
+			return nil, err
+		}
+		out, err := apply(w)
+		if errors.Is(err, repository.ErrWorkspaceMembersChanged) && attempt < 3 {
+			continue
+		}
+		return out, err
+	}
+}
+
+// load 读取工作区并校验角色：非成员返回 ErrWorkspaceNotFound，角色不足返回 ErrWorkspaceForbidden
+func (s *WorkspaceService) load(ctx context.Context, userID, id primitive.ObjectID, minRole string) (*models.Workspace, error) {
+	if s == nil || s.repo == nil {
+		return nil, errors.New("workspace service not init")
+	}
+	w, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	role := w.RoleOf(userID)
+	if role == "" {
+		return nil, repository.ErrWorkspaceNotFound
+	}
+	if !models.WorkspaceRoleAtLeast(role, minRole) {
+		return nil, repository.ErrWorkspaceForbidden
+	}
+	return w, nil
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+	"github.com/axfinn/todoIngPlus/backend-go/internal/repository"
+)
+
+// memWorkspaceRepo 以 map 模拟工作区集合
+type memWorkspaceRepo struct {
+	store map[primitive.ObjectID]*models.Workspace
+}
+
+func (r *memWorkspaceRepo) Insert(ctx context.Context, w *models.Workspace) error {
+	cp := *w
+	r.store[w.ID] = &cp
+	return nil
+}
+
+func (r *memWorkspaceRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Workspace, error) {
+	w, ok := r.store[id]
+	if !ok {
+		return nil, repository.ErrWorkspaceNotFound
+	}
+	cp := *w
+	return &cp, nil
+}
+
+func (r *memWorkspaceRepo) ListByMember(ctx context.Context, userID primitive.ObjectID) ([]models.Workspace, error) {
+	var out []models.Workspace
+	for _, w := range r.store {
+		if w.RoleOf(userID) != "" {
+			out = append(out, *w)
+		}
+	}
+	return out, nil
+}
+
+func (r *memWorkspaceRepo) UpdateFields(ctx context.Context, id primitive.ObjectID, set map[string]interface{}) (*models.Workspace, error) {
+	w, ok := r.store[id]
+	if !ok {
+		return nil, repository.ErrWorkspaceNotFound
+	}
+	if m, ok := set["members"].([]models.WorkspaceMember); ok {
+		w.Members = m
+	}
+	if n, ok := set["name"].(string); ok {
+		w.Name = n
+	}
+	return r.FindByID(ctx, id)
+}
+
+func (r *memWorkspaceRepo) AddMember(ctx context.Context, id primitive.ObjectID, m models.WorkspaceMember) (*models.Workspace, error) {
+	w, ok := r.store[id]
+	if !ok || w.RoleOf(m.UserID) != "" {
+		return nil, repository.ErrWorkspaceMembersChanged
+	}
+	w.Members = append(w.Members, m)
+	return r.FindByID(ctx, id)
+}
+
+func (r *memWorkspaceRepo) SetMemberRole(ctx context.Context, id, memberID primitive.ObjectID, role string) (*models.Workspace, error) {
+	w, ok := r.store[id]
+	if !ok || w.RoleOf(memberID) == "" || (role != models.WorkspaceRoleOwner && !r.otherOwner(w, memberID)) {
+		return nil, repository.ErrWorkspaceMembersChanged
+	}
+	members := make([]models.WorkspaceMember, len(w.Members))
+	copy(members, w.Members)
+	for i := range members {
+		if members[i].UserID == memberID {
+			members[i].Role = role
+		}
+	}
+	w.Members = members
+	return r.FindByID(ctx, id)
+}
+
+func (r *memWorkspaceRepo) RemoveMember(ctx context.Context, id, memberID primitive.ObjectID) (*models.Workspace, error) {
+	w, ok := r.store[id]
+	if !ok || w.RoleOf(memberID) == "" || !r.otherOwner(w, memberID) {
+		return nil, repository.ErrWorkspaceMembersChanged
+	}
+	var members []models.WorkspaceMember
+	for _, m := range w.Members {
+		if m.UserID != memberID {
+			members = append(members, m)
+		}
+	}
+	w.Members = members
+	return r.FindByID(ctx, id)
+}
+
+func (r *memWorkspaceRepo) otherOwner(w *models.Workspace, memberID primitive.ObjectID) bool {
+	for _, m := range w.Members {
+		if m.UserID != memberID && m.Role == models.WorkspaceRoleOwner {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memWorkspaceRepo) Delete(ctx context.Context, id primitive.ObjectID) error {
+	delete(r.store, id)
+	return nil
+}
+
+// staleWorkspaceRepo 首次读取返回旧快照，模拟并发修改前读到的状态
+type staleWorkspaceRepo struct {
+	*memWorkspaceRepo
+	stale *models.Workspace
+}
+
+func (r *staleWorkspaceRepo) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Workspace, error) {
+	if w := r.stale; w != nil {
+		r.stale = nil
+		return w, nil
+	}
+	return r.memWorkspaceRepo.FindByID(ctx, id)
+}
+
+// memUserRepo 只实现按登录名查找
+type memUserRepo struct {
+	repository.UserRepository
+	users []models.User
+}
+
+func (r *memUserRepo) FindByLogin(ctx context.Context, login string) (*models.User, error) {
+	for i := range r.users {
+		if u := r.users[i]; u.ID == login || u.Username == login || u.Email == login {
+			return &u, nil
+		}
+	}
+	return nil, repository.ErrUserNotFound
+}
+
+func TestWorkspaceServiceMembersAndRoles(t *testing.T) {
+	ctx := context.Background()
+	owner, bob, eve := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	users := &memUserRepo{users: []models.User{{ID: bob.Hex(), Username: "bob", Email: "bob@example.com"}, {ID: eve.Hex(), Username: "eve"}}}
+	svc := NewWorkspaceService(&memWorkspaceRepo{store: map[primitive.ObjectID]*models.Workspace{}}, users)
+
+	if _, err := svc.Create(ctx, owner, models.CreateWorkspaceRequest{Name: "  "}); !errors.Is(err, ErrWorkspaceNameRequired) {
+		t.Fatalf("expected name required, got %v", err)
+	}
+	ws, err := svc.Create(ctx, owner, models.CreateWorkspaceRequest{Name: "Team"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if ws.RoleOf(owner) != models.WorkspaceRoleOwner {
+		t.Fatalf("creator should be owner, got %q", ws.RoleOf(owner))
+	}
+
+	// 非成员不可见
+	if _, err := svc.Get(ctx, bob, ws.ID); !errors.Is(err, repository.ErrWorkspaceNotFound) {
+		t.Fatalf("non-member get: expected not found, got %v", err)
+	}
+	if _, err := svc.AddMember(ctx, owner, ws.ID, models.WorkspaceMemberRequest{User: "bob@example.com", Role: "admin"}); !errors.Is(err, ErrInvalidWorkspaceRole) {
+		t.Fatalf("expected invalid role, got %v", err)
+	}
+	if _, err := svc.AddMember(ctx, owner, ws.ID, models.WorkspaceMemberRequest{User: "bob@example.com", Role: models.WorkspaceRoleViewer}); err != nil {
+		t.Fatalf("add bob: %v", err)
+	}
+	if _, err := svc.AddMember(ctx, owner, ws.ID, models.WorkspaceMemberRequest{User: "bob", Role: models.WorkspaceRoleEditor}); !errors.Is(err, ErrWorkspaceMemberExists) {
+		t.Fatalf("expected member exists, got %v", err)
+	}
+
+	// viewer 可读但不能管理成员
+	if _, err := svc.Get(ctx, bob, ws.ID); err != nil {
+		t.Fatalf("viewer get: %v", err)
+	}
+	if _, err := svc.AddMember(ctx, bob, ws.ID, models.WorkspaceMemberRequest{User: "eve", Role: models.WorkspaceRoleViewer}); !errors.Is(err, repository.ErrWorkspaceForbidden) {
+		t.Fatalf("viewer add member: expected forbidden, got %v", err)
+	}
+
+	// 最后一名 owner 不能降级或退出
+	if _, err := svc.UpdateMember(ctx, owner, ws.ID, owner, models.WorkspaceRoleEditor); !errors.Is(err, ErrWorkspaceLastOwner) {
+		t.Fatalf("demote last owner: expected last owner, got %v", err)
+	}
+	if _, err := svc.RemoveMember(ctx, owner, ws.ID, owner); !errors.Is(err, ErrWorkspaceLastOwner) {
+		t.Fatalf("remove last owner: expected last owner, got %v", err)
+	}
+	if _, err := svc.UpdateMember(ctx, owner, ws.ID, bob, models.WorkspaceRoleOwner); err != nil {
+		t.Fatalf("promote bob: %v", err)
+	}
+	got, err := svc.RemoveMember(ctx, owner, ws.ID, owner)
+	if err != nil {
+		t.Fatalf("owner leaves after promoting bob: %v", err)
+	}
+	if got.RoleOf(owner) != "" || got.RoleOf(bob) != models.WorkspaceRoleOwner {
+		t.Fatalf("unexpected members after leave: %+v", got.Members)
+	}
+}
+
+func TestWorkspaceServiceKeepsOwnerUnderConcurrentChanges(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := primitive.NewObjectID(), primitive.NewObjectID()
+	ws := &models.Workspace{ID: primitive.NewObjectID(), Members: []models.WorkspaceMember{{UserID: alice, Role: models.WorkspaceRoleOwner}, {UserID: bob, Role: models.WorkspaceRoleOwner}}}
+	mem := &memWorkspaceRepo{store: map[primitive.ObjectID]*models.Workspace{ws.ID: ws}}
+	snapshot, _ := mem.FindByID(ctx, ws.ID)
+	repo := &staleWorkspaceRepo{memWorkspaceRepo: mem}
+	svc := NewWorkspaceService(repo, nil)
+
+	if _, err := svc.RemoveMember(ctx, alice, ws.ID, alice); err != nil {
+		t.Fatalf("alice leaves: %v", err)
+	}
+	// bob 仍读到两名 owner 的旧状态：条件更新未命中后重新读取，按最新状态拒绝
+	repo.stale = snapshot
+	if _, err := svc.RemoveMember(ctx, bob, ws.ID, bob); !errors.Is(err, ErrWorkspaceLastOwner) {
+		t.Fatalf("bob leaves with stale view: expected last owner, got %v", err)
+	}
+	repo.stale = snapshot
+	if _, err := svc.UpdateMember(ctx, bob, ws.ID, bob, models.WorkspaceRoleEditor); !errors.Is(err, ErrWorkspaceLastOwner) {
+		t.Fatalf("bob demotes self with stale view: expected last owner, got %v", err)
+	}
+	if got, _ := mem.FindByID(ctx, ws.ID); got.OwnerCount() != 1 || got.RoleOf(bob) != models.WorkspaceRoleOwner {
+		t.Fatalf("workspace must keep bob as owner, got %+v", got.Members)
+	}
+}
+
+func TestWorkspaceRoleAtLeast(t *testing.T) {
+	cases := []struct {
+		role, min string
+		want      bool
+	}{
+		{models.WorkspaceRoleOwner, models.WorkspaceRoleEditor, true},
+		{models.WorkspaceRoleEditor, models.WorkspaceRoleEditor, true},
+		{models.WorkspaceRoleViewer, models.WorkspaceRoleEditor, false},
+		{"", models.WorkspaceRoleViewer, false},
+	}
+	for _, c := range cases {
+		if got := models.WorkspaceRoleAtLeast(c.role, c.min); got != c.want {
+			t.Fatalf("WorkspaceRoleAtLeast(%q,%q)=%v want %v", c.role, c.min, got, c.want)
+		}
+	}
+	if got := models.WorkspaceRolesAtLeast(models.WorkspaceRoleEditor); len(got) != 2 {
+		t.Fatalf("roles at least editor: %v", got)
+	}
+}
+
+func TestTaskServiceSubtaskInheritsWorkspace(t *testing.T) {
+	repo, store := memTaskRepo(models.Task{ID: "p", Title: "parent", Status: "Todo", WorkspaceID: "ws1"})
+	svc := &TaskService{repo: repo}
+	pid := "p"
+	child, err := svc.Create(context.Background(), "u1", models.Task{Title: "child", ParentID: &pid, WorkspaceID: "other"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if store[child.ID].WorkspaceID != "ws1" {
+		t.Fatalf("subtask workspace = %q, want ws1", store[child.ID].WorkspaceID)
+	}
+}
+
+func TestTaskServiceAssigneeMustSeeTask(t *testing.T) {
+	ctx := context.Background()
+	owner, bob, eve := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	users := &memUserRepo{users: []models.User{{ID: owner.Hex(), Username: "owner"}, {ID: bob.Hex(), Username: "bob", Email: "bob@example.com"}, {ID: eve.Hex(), Username: "eve"}}}
+	ws := &models.Workspace{ID: primitive.NewObjectID(), Members: []models.WorkspaceMember{{UserID: owner, Role: models.WorkspaceRoleOwner}, {UserID: bob, Role: models.WorkspaceRoleViewer}}}
+	workspaces := &memWorkspaceRepo{store: map[primitive.ObjectID]*models.Workspace{ws.ID: ws}}
+	repo, store := memTaskRepo(
+		models.Task{ID: "personal", Title: "mine", Status: "Todo", CreatedBy: owner.Hex()},
+		models.Task{ID: "shared", Title: "team", Status: "Todo", CreatedBy: owner.Hex(), WorkspaceID: ws.ID.Hex()},
+	)
+	svc := NewTaskService(repo).WithDirectory(users, workspaces)
+
+	// 个人任务只能分配给创建者
+	bobLogin := "bob@example.com"
+	if _, err := svc.Update(ctx, owner.Hex(), models.TaskUpdateRequest{ID: "personal", Assignee: &bobLogin}); !errors.Is(err, ErrAssigneeNoAccess) {
+		t.Fatalf("personal task to bob: expected no access, got %v", err)
+	}
+	// 工作区任务可分配给成员，负责人保存为用户 ID
+	got, err := svc.Update(ctx, owner.Hex(), models.TaskUpdateRequest{ID: "shared", Assignee: &bobLogin})
+	if err != nil {
+		t.Fatalf("assign bob: %v", err)
+	}
+	if got.Assignee == nil || *got.Assignee != bob.Hex() {
+		t.Fatalf("assignee = %v, want %s", got.Assignee, bob.Hex())
+	}
+	eveLogin, ghost := "eve", "ghost"
+	if _, err := svc.Update(ctx, owner.Hex(), models.TaskUpdateRequest{ID: "shared", Assignee: &eveLogin}); !errors.Is(err, ErrAssigneeNoAccess) {
+		t.Fatalf("non-member eve: expected no access, got %v", err)
+	}
+	if _, err := svc.Update(ctx, owner.Hex(), models.TaskUpdateRequest{ID: "shared", Assignee: &ghost}); !errors.Is(err, ErrInvalidAssignee) {
+		t.Fatalf("unknown user: expected invalid assignee, got %v", err)
+	}
+	// 空字符串取消分配
+	empty := ""
+	if _, err := svc.Update(ctx, owner.Hex(), models.TaskUpdateRequest{ID: "shared", Assignee: &empty}); err != nil {
+		t.Fatalf("unassign: %v", err)
+	}
+	if store["shared"].Assignee != nil {
+		t.Fatalf("expected unassigned, got %v", *store["shared"].Assignee)
+	}
+	if _, _, err := svc.List(ctx, owner.Hex(), models.TaskQuery{View: "mine"}); !errors.Is(err, ErrInvalidTaskView) {
+		t.Fatalf("expected invalid view, got %v", err)
+	}
+}
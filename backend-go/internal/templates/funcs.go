@@ -0,0 +1,97 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// phrases 各语言的内置短语
+var phrases = map[string]map[string]string{
+	"zh-CN": {
+		"event.birthday":    "生日",
+		"event.anniversary": "纪念日",
+		"event.holiday":     "节日",
+		"event.meeting":     "会议",
+		"event.deadline":    "截止日期",
+		"event.custom":      "自定义事件",
+		"event.default":     "事件",
+		"days.today":        "今天",
+		"days.tomorrow":     "明天",
+		"days.later":        "%d天后",
+		"days.overdue":      "已过期",
+		"deadline.none":     "无截止日期",
+	},
+	"en-US": {
+		"event.birthday":    "Birthday",
+		"event.anniversary": "Anniversary",
+		"event.holiday":     "Holiday",
+		"event.meeting":     "Meeting",
+		"event.deadline":    "Deadline",
+		"event.custom":      "Custom event",
+		"event.default":     "Event",
+		"days.today":        "today",
+		"days.tomorrow":     "tomorrow",
+		"days.later":        "in %d days",
+		"days.overdue":      "overdue",
+		"deadline.none":     "No deadline",
+	},
+}
+
+// phrase 取短语，当前语言缺失时回退默认语言，仍缺失时返回 key
+func phrase(locale, key string) string {
+	if s, ok := phrases[locale][key]; ok {
+		return s
+	}
+	if s, ok := phrases[DefaultLocale][key]; ok {
+		return s
+	}
+	return key
+}
+
+// EventTypeName 事件类型的本地化名称
+func EventTypeName(locale, eventType string) string {
+	if s, ok := phrases[locale]["event."+strings.ToLower(eventType)]; ok {
+		return s
+	}
+	return phrase(locale, "event.default")
+}
+
+// DaysPhrase 距事件天数的本地化描述
+func DaysPhrase(locale string, days int) string {
+	switch {
+	case days < 0:
+		return phrase(locale, "days.overdue")
+	case days == 0:
+		return phrase(locale, "days.today")
+	case days == 1:
+		return phrase(locale, "days.tomorrow")
+	}
+	return fmt.Sprintf(phrase(locale, "days.later"), days)
+}
+
+// inLoc nil 时按 UTC 显示
+func inLoc(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc)
+}
+
+// funcs 模板可用函数 (绑定语言)
+func funcs(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"t":         func(key string) string { return phrase(locale, key) },
+		"eventType": func(eventType string) string { return EventTypeName(locale, eventType) },
+		"days":      func(days int) string { return DaysPhrase(locale, days) },
+		"at":        func(t time.Time, loc *time.Location) string { return inLoc(t, loc).Format("01-02 15:04") },
+		"datetime":  func(t time.Time, loc *time.Location) string { return inLoc(t, loc).Format("2006-01-02 15:04") },
+		"date":      func(t time.Time, loc *time.Location) string { return inLoc(t, loc).Format("2006-01-02") },
+		"deadline": func(t *time.Time, loc *time.Location) string {
+			if t == nil {
+				return phrase(locale, "deadline.none")
+			}
+			return inLoc(*t, loc).Format("01-02 15:04")
+		},
+	}
+}
@@ -0,0 +1,283 @@
+// Package templates 提醒、邮件与验证码文案模板：按语言加载内置目录 (locales/<locale>/*.tmpl)，
+// *.txt.tmpl 使用 text/template，*.html.tmpl 使用 html/template；提醒模板可由用户按事件类型覆盖。
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/axfinn/todoIngPlus/backend-go/internal/models"
+)
+
+//go:embed locales
+var localeFS embed.FS
+
+// DefaultLocale 未设置或不支持的语言回退到中文
+const DefaultLocale = "zh-CN"
+
+// 模板名称
+const (
+	ReminderSubject     = "reminder_subject"
+	ReminderText        = "reminder_text"
+	ReminderEmail       = "reminder_email"
+	VerificationSubject = "verification_subject"
+	VerificationEmail   = "verification_email"
+	DigestSubject       = "digest_subject"
+	DigestEmail         = "digest_email"
+)
+
+// OverridableKinds 用户可按事件类型覆盖的模板
+var OverridableKinds = []string{ReminderSubject, ReminderText, ReminderEmail}
+
+// maxOverrideSize 用户模板长度上限
+const maxOverrideSize = 8 << 10
+
+var (
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+	ErrInvalidTemplate   = errors.New("invalid template")
+)
+
+// ReminderData 提醒模板数据
+type ReminderData struct {
+	Event    models.Event
+	Reminder models.Reminder
+	Message  string         // 提醒正文 (邮件模板使用)
+	DaysLeft int            // 距事件的日历天数 (事件时区)
+	Loc      *time.Location // 时间显示时区 (事件时区)
+}
+
+// VerificationData 验证码邮件数据
+type VerificationData struct{ Code string }
+
+// DigestData 汇总邮件数据
+type DigestData struct {
+	Digest *models.Digest
+	Weekly bool
+	Loc    *time.Location // 用户时区
+}
+
+// localeSet 某语言的全部内置模板
+type localeSet struct {
+	text    map[string]*texttemplate.Template
+	html    map[string]*htmltemplate.Template
+	sources map[string]string
+}
+
+// Renderer 模板渲染器
+type Renderer struct {
+	locales map[string]*localeSet
+}
+
+// Default 内置目录的渲染器
+var Default = mustLoad()
+
+func mustLoad() *Renderer {
+	r, err := load(localeFS)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func load(fsys fs.FS) (*Renderer, error) {
+	dirs, err := fs.ReadDir(fsys, "locales")
+	if err != nil {
+		return nil, err
+	}
+	r := &Renderer{locales: map[string]*localeSet{}}
+	for _, dir := range dirs {
+		locale := dir.Name()
+		set := &localeSet{text: map[string]*texttemplate.Template{}, html: map[string]*htmltemplate.Template{}, sources: map[string]string{}}
+		files, err := fs.ReadDir(fsys, path.Join("locales", locale))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			b, err := fs.ReadFile(fsys, path.Join("locales", locale, f.Name()))
+			if err != nil {
+				return nil, err
+			}
+			name, isHTML, ok := templateName(f.Name())
+			if !ok {
+				continue
+			}
+			set.sources[name] = string(b)
+			if isHTML {
+				t, err := htmltemplate.New(name).Funcs(funcs(locale)).Parse(string(b))
+				if err != nil {
+					return nil, fmt.Errorf("%s/%s: %w", locale, f.Name(), err)
+				}
+				set.html[name] = t
+			} else {
+				t, err := texttemplate.New(name).Funcs(funcs(locale)).Parse(string(b))
+				if err != nil {
+					return nil, fmt.Errorf("%s/%s: %w", locale, f.Name(), err)
+				}
+				set.text[name] = t
+			}
+		}
+		r.locales[locale] = set
+	}
+	if r.locales[DefaultLocale] == nil {
+		return nil, fmt.Errorf("default locale %s missing", DefaultLocale)
+	}
+	return r, nil
+}
+
+// templateName reminder_email.html.tmpl -> reminder_email, html
+func templateName(file string) (string, bool, bool) {
+	switch {
+	case strings.HasSuffix(file, ".html.tmpl"):
+		return strings.TrimSuffix(file, ".html.tmpl"), true, true
+	case strings.HasSuffix(file, ".txt.tmpl"):
+		return strings.TrimSuffix(file, ".txt.tmpl"), false, true
+	}
+	return "", false, false
+}
+
+// isHTMLKind 邮件正文类模板使用 html/template
+func isHTMLKind(name string) bool { return strings.HasSuffix(name, "_email") }
+
+// Locales 支持的语言
+func (r *Renderer) Locales() []string {
+	out := make([]string, 0, len(r.locales))
+	for l := range r.locales {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Normalize 规范化语言标记 (en / en_us / EN-us -> en-US)，不支持时返回空串
+func (r *Renderer) Normalize(locale string) string {
+	l := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if l == "" {
+		return ""
+	}
+	for _, s := range r.Locales() {
+		if strings.ToLower(s) == l {
+			return s
+		}
+	}
+	// 仅语言部分匹配 (en-GB -> en-US)
+	lang := strings.SplitN(l, "-", 2)[0]
+	for _, s := range r.Locales() {
+		if strings.HasPrefix(strings.ToLower(s), lang+"-") {
+			return s
+		}
+	}
+	return ""
+}
+
+// Resolve 规范化语言，未设置或不支持时回退 DefaultLocale
+func (r *Renderer) Resolve(locale string) string {
+	if l := r.Normalize(locale); l != "" {
+		return l
+	}
+	return DefaultLocale
+}
+
+// FromAcceptLanguage 取 Accept-Language 中第一个支持的语言 (忽略 q 权重)，都不支持时回退 DefaultLocale
+func (r *Renderer) FromAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.SplitN(part, ";", 2)[0]
+		if l := r.Normalize(tag); l != "" {
+			return l
+		}
+	}
+	return DefaultLocale
+}
+
+// Validate 校验语言是否受支持 (空串表示未设置，合法)
+func (r *Renderer) Validate(locale string) error {
+	if locale != "" && r.Normalize(locale) == "" {
+		return fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+	return nil
+}
+
+// Source 内置模板源码 (供用户在此基础上修改)
+func (r *Renderer) Source(locale, name string) (string, bool) {
+	src, ok := r.locales[r.Resolve(locale)].sources[name]
+	return src, ok
+}
+
+// Render 使用内置模板渲染；文本模板去掉首尾空白
+func (r *Renderer) Render(locale, name string, data interface{}) (string, error) {
+	set := r.locales[r.Resolve(locale)]
+	var buf bytes.Buffer
+	if t, ok := set.html[name]; ok {
+		if err := t.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	if t, ok := set.text[name]; ok {
+		if err := t.Execute(&buf, data); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(buf.String()), nil
+	}
+	return "", fmt.Errorf("template %s not found", name)
+}
+
+// RenderOverride 使用用户模板渲染 (与内置模板相同的函数与数据)
+func (r *Renderer) RenderOverride(locale, name, body string, data interface{}) (string, error) {
+	locale = r.Resolve(locale)
+	var buf bytes.Buffer
+	if isHTMLKind(name) {
+		t, err := htmltemplate.New(name).Funcs(funcs(locale)).Parse(body)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		if err := t.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+		}
+		return buf.String(), nil
+	}
+	t, err := texttemplate.New(name).Funcs(funcs(locale)).Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// ValidateOverride 校验用户模板：名称可覆盖、长度受限，并以示例数据试渲染
+func (r *Renderer) ValidateOverride(name, body string) error {
+	ok := false
+	for _, k := range OverridableKinds {
+		ok = ok || k == name
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s is not overridable", ErrInvalidTemplate, name)
+	}
+	if strings.TrimSpace(body) == "" || len(body) > maxOverrideSize {
+		return fmt.Errorf("%w: body must be 1-%d bytes", ErrInvalidTemplate, maxOverrideSize)
+	}
+	_, err := r.RenderOverride(DefaultLocale, name, body, SampleReminder())
+	return err
+}
+
+// SampleReminder 预览与校验用的示例数据
+func SampleReminder() ReminderData {
+	at := time.Date(2030, 1, 2, 9, 30, 0, 0, time.UTC)
+	return ReminderData{
+		Event:    models.Event{Title: "Team sync", EventType: "meeting", EventDate: at, ImportanceLevel: 3, Location: "Room 1"},
+		Reminder: models.Reminder{AdvanceDays: 1, ReminderTimes: []string{"09:00"}},
+		Message:  "🔔 Team sync",
+		DaysLeft: 1,
+		Loc:      time.UTC,
+	}
+}
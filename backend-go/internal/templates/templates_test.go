@@ -0,0 +1,56 @@
+package templates
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	r := Default
+	require.Equal(t, []string{"en-US", "zh-CN"}, r.Locales())
+	require.Equal(t, "en-US", r.Normalize("en_us"))
+	require.Equal(t, "en-US", r.Normalize("en"))
+	require.Equal(t, "zh-CN", r.Normalize("zh-TW"))
+	require.Equal(t, "", r.Normalize("fr-FR"))
+	require.Equal(t, DefaultLocale, r.Resolve("fr-FR"))
+	require.Equal(t, "en-US", r.FromAcceptLanguage("fr-CH, en-GB;q=0.9, zh;q=0.8"))
+	require.Equal(t, DefaultLocale, r.FromAcceptLanguage(""))
+	require.NoError(t, r.Validate(""))
+	require.True(t, errors.Is(r.Validate("xx"), ErrUnsupportedLocale))
+}
+
+func TestRenderReminder(t *testing.T) {
+	data := SampleReminder()
+	zh, err := Default.Render("zh-CN", ReminderText, data)
+	require.NoError(t, err)
+	require.Equal(t, "🔔 会议提醒：Team sync (明天)", zh)
+
+	en, err := Default.Render("en-US", ReminderText, data)
+	require.NoError(t, err)
+	require.Equal(t, "🔔 Meeting reminder: Team sync (tomorrow)", en)
+
+	data.Event.Title = "<b>x</b>"
+	body, err := Default.Render("en-US", ReminderEmail, data)
+	require.NoError(t, err)
+	require.Contains(t, body, "&lt;b&gt;x&lt;/b&gt;")
+	require.Contains(t, body, "2030-01-02 09:30")
+
+	code, err := Default.Render("en-US", VerificationEmail, VerificationData{Code: "123456"})
+	require.NoError(t, err)
+	require.Contains(t, code, "<b>123456</b>")
+}
+
+func TestOverride(t *testing.T) {
+	require.NoError(t, Default.ValidateOverride(ReminderText, "{{.Event.Title}} {{days .DaysLeft}}"))
+	require.True(t, errors.Is(Default.ValidateOverride(VerificationEmail, "x"), ErrInvalidTemplate))
+	require.True(t, errors.Is(Default.ValidateOverride(ReminderText, "{{.Nope}}"), ErrInvalidTemplate))
+	require.True(t, errors.Is(Default.ValidateOverride(ReminderText, "{{"), ErrInvalidTemplate))
+	require.True(t, errors.Is(Default.ValidateOverride(ReminderText, strings.Repeat("x", maxOverrideSize+1)), ErrInvalidTemplate))
+
+	out, err := Default.RenderOverride("en-US", ReminderText, "{{eventType .Event.EventType}}: {{.Event.Title}} {{days .DaysLeft}}", SampleReminder())
+	require.NoError(t, err)
+	require.Equal(t, "Meeting: Team sync tomorrow", out)
+}
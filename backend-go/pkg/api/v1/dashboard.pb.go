@@ -326,6 +326,8 @@ func (x *GetDashboardDataResponse) GetData() *DashboardData {
 
 type GetPriorityTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`                                  // 过滤表达式
+	SavedViewId   string                 `protobuf:"bytes,2,opt,name=saved_view_id,json=savedViewId,proto3" json:"saved_view_id,omitempty"` // 保存的视图
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -360,6 +362,20 @@ func (*GetPriorityTasksRequest) Descriptor() ([]byte, []int) {
 	return file_dashboard_proto_rawDescGZIP(), []int{5}
 }
 
+func (x *GetPriorityTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetPriorityTasksRequest) GetSavedViewId() string {
+	if x != nil {
+		return x.SavedViewId
+	}
+	return ""
+}
+
 type GetPriorityTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -453,8 +469,10 @@ const file_dashboard_proto_rawDesc = "" +
 	"\x17GetDashboardDataRequest\"\x83\x01\n" +
 	"\x18GetDashboardDataResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x121\n" +
-	"\x04data\x18\x02 \x01(\v2\x1d.todoing.api.v1.DashboardDataR\x04data\"\x19\n" +
-	"\x17GetPriorityTasksRequest\"\x9a\x01\n" +
+	"\x04data\x18\x02 \x01(\v2\x1d.todoing.api.v1.DashboardDataR\x04data\"S\n" +
+	"\x17GetPriorityTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\"\n" +
+	"\rsaved_view_id\x18\x02 \x01(\tR\vsavedViewId\"\x9a\x01\n" +
 	"\x18GetPriorityTasksResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x122\n" +
 	"\x05tasks\x18\x02 \x03(\v2\x1c.todoing.api.v1.PriorityTaskR\x05tasks\x12\x14\n" +
@@ -213,8 +213,14 @@ type Event struct {
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	IsActive        bool                   `protobuf:"varint,15,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
 	LastTriggeredAt *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=last_triggered_at,json=lastTriggeredAt,proto3" json:"last_triggered_at,omitempty"` // 可为空
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// 循环展开后的单次发生 (仅日历/即将到来查询返回)
+	OccurrenceDate *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=occurrence_date,json=occurrenceDate,proto3" json:"occurrence_date,omitempty"`
+	OccurrenceKey  string                 `protobuf:"bytes,18,opt,name=occurrence_key,json=occurrenceKey,proto3" json:"occurrence_key,omitempty"` // <event_id>@<UTC 发生时间>
+	TimeZone       string                 `protobuf:"bytes,19,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                // IANA 时区, 空为 UTC
+	IcalUid        string                 `protobuf:"bytes,20,opt,name=ical_uid,json=icalUid,proto3" json:"ical_uid,omitempty"`                   // 从 .ics 导入时的 UID
+	WorkspaceId    string                 `protobuf:"bytes,21,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`       // 所属工作区，空为个人事件
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Event) Reset() {
@@ -359,6 +365,41 @@ func (x *Event) GetLastTriggeredAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Event) GetOccurrenceDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurrenceDate
+	}
+	return nil
+}
+
+func (x *Event) GetOccurrenceKey() string {
+	if x != nil {
+		return x.OccurrenceKey
+	}
+	return ""
+}
+
+func (x *Event) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Event) GetIcalUid() string {
+	if x != nil {
+		return x.IcalUid
+	}
+	return ""
+}
+
+func (x *Event) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
 // 创建事件
 type CreateEventRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -372,6 +413,8 @@ type CreateEventRequest struct {
 	Tags             []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
 	Location         string                 `protobuf:"bytes,9,opt,name=location,proto3" json:"location,omitempty"`
 	IsAllDay         bool                   `protobuf:"varint,10,opt,name=is_all_day,json=isAllDay,proto3" json:"is_all_day,omitempty"`
+	TimeZone         string                 `protobuf:"bytes,11,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`          // 可选, 默认沿用用户时区
+	WorkspaceId      string                 `protobuf:"bytes,12,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"` // 可选, 所属工作区 (需 editor 角色)
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -476,6 +519,20 @@ func (x *CreateEventRequest) GetIsAllDay() bool {
 	return false
 }
 
+func (x *CreateEventRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *CreateEventRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
 type CreateEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -640,6 +697,7 @@ type UpdateEventRequest struct {
 	Location         string                 `protobuf:"bytes,10,opt,name=location,proto3" json:"location,omitempty"`
 	IsAllDay         bool                   `protobuf:"varint,11,opt,name=is_all_day,json=isAllDay,proto3" json:"is_all_day,omitempty"`
 	IsActive         bool                   `protobuf:"varint,12,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	TimeZone         string                 `protobuf:"bytes,13,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -758,6 +816,13 @@ func (x *UpdateEventRequest) GetIsActive() bool {
 	return false
 }
 
+func (x *UpdateEventRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
 type UpdateEventResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -1734,11 +1799,256 @@ func (x *ListEventTimelineResponse) GetCount() int32 {
 	return 0
 }
 
+// 导入 .ics (按 UID 去重，重复导入会更新已有事件)
+type ImportEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IcsData       []byte                 `protobuf:"bytes,1,opt,name=ics_data,json=icsData,proto3" json:"ics_data,omitempty"`    // .ics 文件内容
+	TimeZone      string                 `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // 浮动时间使用的时区, 空则沿用用户时区
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEventsRequest) Reset() {
+	*x = ImportEventsRequest{}
+	mi := &file_event_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEventsRequest) ProtoMessage() {}
+
+func (x *ImportEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEventsRequest.ProtoReflect.Descriptor instead.
+func (*ImportEventsRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ImportEventsRequest) GetIcsData() []byte {
+	if x != nil {
+		return x.IcsData
+	}
+	return nil
+}
+
+func (x *ImportEventsRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type EventImportItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // VEVENT 在文件中的序号
+	Uid           string                 `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // created / updated / skipped / error
+	EventId       string                 `protobuf:"bytes,5,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Reminders     int32                  `protobuf:"varint,6,opt,name=reminders,proto3" json:"reminders,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Warnings      []string               `protobuf:"bytes,8,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventImportItem) Reset() {
+	*x = EventImportItem{}
+	mi := &file_event_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventImportItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventImportItem) ProtoMessage() {}
+
+func (x *EventImportItem) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventImportItem.ProtoReflect.Descriptor instead.
+func (*EventImportItem) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EventImportItem) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *EventImportItem) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *EventImportItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *EventImportItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EventImportItem) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *EventImportItem) GetReminders() int32 {
+	if x != nil {
+		return x.Reminders
+	}
+	return 0
+}
+
+func (x *EventImportItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *EventImportItem) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type ImportEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Created       int32                  `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,4,opt,name=updated,proto3" json:"updated,omitempty"`
+	Skipped       int32                  `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Failed        int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Items         []*EventImportItem     `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEventsResponse) Reset() {
+	*x = ImportEventsResponse{}
+	mi := &file_event_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEventsResponse) ProtoMessage() {}
+
+func (x *ImportEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEventsResponse.ProtoReflect.Descriptor instead.
+func (*ImportEventsResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ImportEventsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ImportEventsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetItems() []*EventImportItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 var File_event_proto protoreflect.FileDescriptor
 
 const file_event_proto_rawDesc = "" +
 	"\n" +
-	"\vevent.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\x99\x06\n" +
+	"\vevent.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\xe0\a\n" +
 	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
@@ -1761,10 +2071,15 @@ const file_event_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
 	"\tis_active\x18\x0f \x01(\bR\bisActive\x12F\n" +
-	"\x11last_triggered_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastTriggeredAt\x1aC\n" +
+	"\x11last_triggered_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastTriggeredAt\x12C\n" +
+	"\x0foccurrence_date\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\x0eoccurrenceDate\x12%\n" +
+	"\x0eoccurrence_key\x18\x12 \x01(\tR\roccurrenceKey\x12\x1b\n" +
+	"\ttime_zone\x18\x13 \x01(\tR\btimeZone\x12\x19\n" +
+	"\bical_uid\x18\x14 \x01(\tR\aicalUid\x12!\n" +
+	"\fworkspace_id\x18\x15 \x01(\tR\vworkspaceId\x1aC\n" +
 	"\x15RecurrenceConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x04\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xef\x04\n" +
 	"\x12CreateEventRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x128\n" +
@@ -1779,7 +2094,9 @@ const file_event_proto_rawDesc = "" +
 	"\blocation\x18\t \x01(\tR\blocation\x12\x1c\n" +
 	"\n" +
 	"is_all_day\x18\n" +
-	" \x01(\bR\bisAllDay\x1aC\n" +
+	" \x01(\bR\bisAllDay\x12\x1b\n" +
+	"\ttime_zone\x18\v \x01(\tR\btimeZone\x12!\n" +
+	"\fworkspace_id\x18\f \x01(\tR\vworkspaceId\x1aC\n" +
 	"\x15RecurrenceConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"x\n" +
@@ -1790,7 +2107,7 @@ const file_event_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"u\n" +
 	"\x10GetEventResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12+\n" +
-	"\x05event\x18\x02 \x01(\v2\x15.todoing.api.v1.EventR\x05event\"\xdc\x04\n" +
+	"\x05event\x18\x02 \x01(\v2\x15.todoing.api.v1.EventR\x05event\"\xf9\x04\n" +
 	"\x12UpdateEventRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -1807,7 +2124,8 @@ const file_event_proto_rawDesc = "" +
 	" \x01(\tR\blocation\x12\x1c\n" +
 	"\n" +
 	"is_all_day\x18\v \x01(\bR\bisAllDay\x12\x1b\n" +
-	"\tis_active\x18\f \x01(\bR\bisActive\x1aC\n" +
+	"\tis_active\x18\f \x01(\bR\bisActive\x12\x1b\n" +
+	"\ttime_zone\x18\r \x01(\tR\btimeZone\x1aC\n" +
 	"\x15RecurrenceConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"x\n" +
@@ -1885,7 +2203,27 @@ const file_event_proto_rawDesc = "" +
 	"\x19ListEventTimelineResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x122\n" +
 	"\x05items\x18\x02 \x03(\v2\x1c.todoing.api.v1.EventCommentR\x05items\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count*\xbc\x01\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"M\n" +
+	"\x13ImportEventsRequest\x12\x19\n" +
+	"\bics_data\x18\x01 \x01(\fR\aicsData\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"\xd2\x01\n" +
+	"\x0fEventImportItem\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x19\n" +
+	"\bevent_id\x18\x05 \x01(\tR\aeventId\x12\x1c\n" +
+	"\treminders\x18\x06 \x01(\x05R\treminders\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1a\n" +
+	"\bwarnings\x18\b \x03(\tR\bwarnings\"\xff\x01\n" +
+	"\x14ImportEventsResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x04 \x01(\x05R\aupdated\x12\x18\n" +
+	"\askipped\x18\x05 \x01(\x05R\askipped\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x125\n" +
+	"\x05items\x18\a \x03(\v2\x1f.todoing.api.v1.EventImportItemR\x05items*\xbc\x01\n" +
 	"\tEventType\x12\x1a\n" +
 	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13EVENT_TYPE_BIRTHDAY\x10\x01\x12\x1a\n" +
@@ -1904,7 +2242,7 @@ const file_event_proto_rawDesc = "" +
 	"\x10EventCommentType\x12\"\n" +
 	"\x1eEVENT_COMMENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17EVENT_COMMENT_TYPE_TEXT\x10\x01\x12\x1d\n" +
-	"\x19EVENT_COMMENT_TYPE_SYSTEM\x10\x022\x99\b\n" +
+	"\x19EVENT_COMMENT_TYPE_SYSTEM\x10\x022\xf4\b\n" +
 	"\fEventService\x12V\n" +
 	"\vCreateEvent\x12\".todoing.api.v1.CreateEventRequest\x1a#.todoing.api.v1.CreateEventResponse\x12M\n" +
 	"\bGetEvent\x12\x1f.todoing.api.v1.GetEventRequest\x1a .todoing.api.v1.GetEventResponse\x12V\n" +
@@ -1917,7 +2255,8 @@ const file_event_proto_rawDesc = "" +
 	"\x0fAddEventComment\x12&.todoing.api.v1.AddEventCommentRequest\x1a'.todoing.api.v1.AddEventCommentResponse\x12k\n" +
 	"\x12UpdateEventComment\x12).todoing.api.v1.UpdateEventCommentRequest\x1a*.todoing.api.v1.UpdateEventCommentResponse\x12Y\n" +
 	"\x12DeleteEventComment\x12).todoing.api.v1.DeleteEventCommentRequest\x1a\x18.todoing.api.v1.Response\x12h\n" +
-	"\x11ListEventTimeline\x12(.todoing.api.v1.ListEventTimelineRequest\x1a).todoing.api.v1.ListEventTimelineResponseB5Z3github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1b\x06proto3"
+	"\x11ListEventTimeline\x12(.todoing.api.v1.ListEventTimelineRequest\x1a).todoing.api.v1.ListEventTimelineResponse\x12Y\n" +
+	"\fImportEvents\x12#.todoing.api.v1.ImportEventsRequest\x1a$.todoing.api.v1.ImportEventsResponseB5Z3github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1b\x06proto3"
 
 var (
 	file_event_proto_rawDescOnce sync.Once
@@ -1932,7 +2271,7 @@ func file_event_proto_rawDescGZIP() []byte {
 }
 
 var file_event_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_event_proto_goTypes = []any{
 	(EventType)(0),                     // 0: todoing.api.v1.EventType
 	(RecurrenceType)(0),                // 1: todoing.api.v1.RecurrenceType
@@ -1960,84 +2299,92 @@ var file_event_proto_goTypes = []any{
 	(*DeleteEventCommentRequest)(nil),  // 23: todoing.api.v1.DeleteEventCommentRequest
 	(*ListEventTimelineRequest)(nil),   // 24: todoing.api.v1.ListEventTimelineRequest
 	(*ListEventTimelineResponse)(nil),  // 25: todoing.api.v1.ListEventTimelineResponse
-	nil,                                // 26: todoing.api.v1.Event.RecurrenceConfigEntry
-	nil,                                // 27: todoing.api.v1.CreateEventRequest.RecurrenceConfigEntry
-	nil,                                // 28: todoing.api.v1.UpdateEventRequest.RecurrenceConfigEntry
-	nil,                                // 29: todoing.api.v1.EventComment.MetaEntry
-	(*timestamppb.Timestamp)(nil),      // 30: google.protobuf.Timestamp
-	(*Response)(nil),                   // 31: todoing.api.v1.Response
-	(*PaginationRequest)(nil),          // 32: todoing.api.v1.PaginationRequest
-	(*PaginationResponse)(nil),         // 33: todoing.api.v1.PaginationResponse
+	(*ImportEventsRequest)(nil),        // 26: todoing.api.v1.ImportEventsRequest
+	(*EventImportItem)(nil),            // 27: todoing.api.v1.EventImportItem
+	(*ImportEventsResponse)(nil),       // 28: todoing.api.v1.ImportEventsResponse
+	nil,                                // 29: todoing.api.v1.Event.RecurrenceConfigEntry
+	nil,                                // 30: todoing.api.v1.CreateEventRequest.RecurrenceConfigEntry
+	nil,                                // 31: todoing.api.v1.UpdateEventRequest.RecurrenceConfigEntry
+	nil,                                // 32: todoing.api.v1.EventComment.MetaEntry
+	(*timestamppb.Timestamp)(nil),      // 33: google.protobuf.Timestamp
+	(*Response)(nil),                   // 34: todoing.api.v1.Response
+	(*PaginationRequest)(nil),          // 35: todoing.api.v1.PaginationRequest
+	(*PaginationResponse)(nil),         // 36: todoing.api.v1.PaginationResponse
 }
 var file_event_proto_depIdxs = []int32{
 	0,  // 0: todoing.api.v1.Event.event_type:type_name -> todoing.api.v1.EventType
-	30, // 1: todoing.api.v1.Event.event_date:type_name -> google.protobuf.Timestamp
+	33, // 1: todoing.api.v1.Event.event_date:type_name -> google.protobuf.Timestamp
 	1,  // 2: todoing.api.v1.Event.recurrence_type:type_name -> todoing.api.v1.RecurrenceType
-	26, // 3: todoing.api.v1.Event.recurrence_config:type_name -> todoing.api.v1.Event.RecurrenceConfigEntry
-	30, // 4: todoing.api.v1.Event.created_at:type_name -> google.protobuf.Timestamp
-	30, // 5: todoing.api.v1.Event.updated_at:type_name -> google.protobuf.Timestamp
-	30, // 6: todoing.api.v1.Event.last_triggered_at:type_name -> google.protobuf.Timestamp
-	0,  // 7: todoing.api.v1.CreateEventRequest.event_type:type_name -> todoing.api.v1.EventType
-	30, // 8: todoing.api.v1.CreateEventRequest.event_date:type_name -> google.protobuf.Timestamp
-	1,  // 9: todoing.api.v1.CreateEventRequest.recurrence_type:type_name -> todoing.api.v1.RecurrenceType
-	27, // 10: todoing.api.v1.CreateEventRequest.recurrence_config:type_name -> todoing.api.v1.CreateEventRequest.RecurrenceConfigEntry
-	31, // 11: todoing.api.v1.CreateEventResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 12: todoing.api.v1.CreateEventResponse.event:type_name -> todoing.api.v1.Event
-	31, // 13: todoing.api.v1.GetEventResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 14: todoing.api.v1.GetEventResponse.event:type_name -> todoing.api.v1.Event
-	0,  // 15: todoing.api.v1.UpdateEventRequest.event_type:type_name -> todoing.api.v1.EventType
-	30, // 16: todoing.api.v1.UpdateEventRequest.event_date:type_name -> google.protobuf.Timestamp
-	1,  // 17: todoing.api.v1.UpdateEventRequest.recurrence_type:type_name -> todoing.api.v1.RecurrenceType
-	28, // 18: todoing.api.v1.UpdateEventRequest.recurrence_config:type_name -> todoing.api.v1.UpdateEventRequest.RecurrenceConfigEntry
-	31, // 19: todoing.api.v1.UpdateEventResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 20: todoing.api.v1.UpdateEventResponse.event:type_name -> todoing.api.v1.Event
-	32, // 21: todoing.api.v1.ListEventsRequest.pagination:type_name -> todoing.api.v1.PaginationRequest
-	0,  // 22: todoing.api.v1.ListEventsRequest.event_type:type_name -> todoing.api.v1.EventType
-	31, // 23: todoing.api.v1.ListEventsResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 24: todoing.api.v1.ListEventsResponse.events:type_name -> todoing.api.v1.Event
-	33, // 25: todoing.api.v1.ListEventsResponse.pagination:type_name -> todoing.api.v1.PaginationResponse
-	31, // 26: todoing.api.v1.GetUpcomingEventsResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 27: todoing.api.v1.GetUpcomingEventsResponse.events:type_name -> todoing.api.v1.Event
-	3,  // 28: todoing.api.v1.CalendarDayEvents.events:type_name -> todoing.api.v1.Event
-	31, // 29: todoing.api.v1.GetCalendarEventsResponse.response:type_name -> todoing.api.v1.Response
-	16, // 30: todoing.api.v1.GetCalendarEventsResponse.days:type_name -> todoing.api.v1.CalendarDayEvents
-	2,  // 31: todoing.api.v1.EventComment.type:type_name -> todoing.api.v1.EventCommentType
-	29, // 32: todoing.api.v1.EventComment.meta:type_name -> todoing.api.v1.EventComment.MetaEntry
-	30, // 33: todoing.api.v1.EventComment.created_at:type_name -> google.protobuf.Timestamp
-	30, // 34: todoing.api.v1.EventComment.updated_at:type_name -> google.protobuf.Timestamp
-	31, // 35: todoing.api.v1.AddEventCommentResponse.response:type_name -> todoing.api.v1.Response
-	18, // 36: todoing.api.v1.AddEventCommentResponse.comment:type_name -> todoing.api.v1.EventComment
-	31, // 37: todoing.api.v1.UpdateEventCommentResponse.response:type_name -> todoing.api.v1.Response
-	18, // 38: todoing.api.v1.UpdateEventCommentResponse.comment:type_name -> todoing.api.v1.EventComment
-	31, // 39: todoing.api.v1.ListEventTimelineResponse.response:type_name -> todoing.api.v1.Response
-	18, // 40: todoing.api.v1.ListEventTimelineResponse.items:type_name -> todoing.api.v1.EventComment
-	4,  // 41: todoing.api.v1.EventService.CreateEvent:input_type -> todoing.api.v1.CreateEventRequest
-	6,  // 42: todoing.api.v1.EventService.GetEvent:input_type -> todoing.api.v1.GetEventRequest
-	8,  // 43: todoing.api.v1.EventService.UpdateEvent:input_type -> todoing.api.v1.UpdateEventRequest
-	10, // 44: todoing.api.v1.EventService.DeleteEvent:input_type -> todoing.api.v1.DeleteEventRequest
-	11, // 45: todoing.api.v1.EventService.ListEvents:input_type -> todoing.api.v1.ListEventsRequest
-	13, // 46: todoing.api.v1.EventService.GetUpcomingEvents:input_type -> todoing.api.v1.GetUpcomingEventsRequest
-	15, // 47: todoing.api.v1.EventService.GetCalendarEvents:input_type -> todoing.api.v1.GetCalendarEventsRequest
-	19, // 48: todoing.api.v1.EventService.AddEventComment:input_type -> todoing.api.v1.AddEventCommentRequest
-	21, // 49: todoing.api.v1.EventService.UpdateEventComment:input_type -> todoing.api.v1.UpdateEventCommentRequest
-	23, // 50: todoing.api.v1.EventService.DeleteEventComment:input_type -> todoing.api.v1.DeleteEventCommentRequest
-	24, // 51: todoing.api.v1.EventService.ListEventTimeline:input_type -> todoing.api.v1.ListEventTimelineRequest
-	5,  // 52: todoing.api.v1.EventService.CreateEvent:output_type -> todoing.api.v1.CreateEventResponse
-	7,  // 53: todoing.api.v1.EventService.GetEvent:output_type -> todoing.api.v1.GetEventResponse
-	9,  // 54: todoing.api.v1.EventService.UpdateEvent:output_type -> todoing.api.v1.UpdateEventResponse
-	31, // 55: todoing.api.v1.EventService.DeleteEvent:output_type -> todoing.api.v1.Response
-	12, // 56: todoing.api.v1.EventService.ListEvents:output_type -> todoing.api.v1.ListEventsResponse
-	14, // 57: todoing.api.v1.EventService.GetUpcomingEvents:output_type -> todoing.api.v1.GetUpcomingEventsResponse
-	17, // 58: todoing.api.v1.EventService.GetCalendarEvents:output_type -> todoing.api.v1.GetCalendarEventsResponse
-	20, // 59: todoing.api.v1.EventService.AddEventComment:output_type -> todoing.api.v1.AddEventCommentResponse
-	22, // 60: todoing.api.v1.EventService.UpdateEventComment:output_type -> todoing.api.v1.UpdateEventCommentResponse
-	31, // 61: todoing.api.v1.EventService.DeleteEventComment:output_type -> todoing.api.v1.Response
-	25, // 62: todoing.api.v1.EventService.ListEventTimeline:output_type -> todoing.api.v1.ListEventTimelineResponse
-	52, // [52:63] is the sub-list for method output_type
-	41, // [41:52] is the sub-list for method input_type
-	41, // [41:41] is the sub-list for extension type_name
-	41, // [41:41] is the sub-list for extension extendee
-	0,  // [0:41] is the sub-list for field type_name
+	29, // 3: todoing.api.v1.Event.recurrence_config:type_name -> todoing.api.v1.Event.RecurrenceConfigEntry
+	33, // 4: todoing.api.v1.Event.created_at:type_name -> google.protobuf.Timestamp
+	33, // 5: todoing.api.v1.Event.updated_at:type_name -> google.protobuf.Timestamp
+	33, // 6: todoing.api.v1.Event.last_triggered_at:type_name -> google.protobuf.Timestamp
+	33, // 7: todoing.api.v1.Event.occurrence_date:type_name -> google.protobuf.Timestamp
+	0,  // 8: todoing.api.v1.CreateEventRequest.event_type:type_name -> todoing.api.v1.EventType
+	33, // 9: todoing.api.v1.CreateEventRequest.event_date:type_name -> google.protobuf.Timestamp
+	1,  // 10: todoing.api.v1.CreateEventRequest.recurrence_type:type_name -> todoing.api.v1.RecurrenceType
+	30, // 11: todoing.api.v1.CreateEventRequest.recurrence_config:type_name -> todoing.api.v1.CreateEventRequest.RecurrenceConfigEntry
+	34, // 12: todoing.api.v1.CreateEventResponse.response:type_name -> todoing.api.v1.Response
+	3,  // 13: todoing.api.v1.CreateEventResponse.event:type_name -> todoing.api.v1.Event
+	34, // 14: todoing.api.v1.GetEventResponse.response:type_name -> todoing.api.v1.Response
+	3,  // 15: todoing.api.v1.GetEventResponse.event:type_name -> todoing.api.v1.Event
+	0,  // 16: todoing.api.v1.UpdateEventRequest.event_type:type_name -> todoing.api.v1.EventType
+	33, // 17: todoing.api.v1.UpdateEventRequest.event_date:type_name -> google.protobuf.Timestamp
+	1,  // 18: todoing.api.v1.UpdateEventRequest.recurrence_type:type_name -> todoing.api.v1.RecurrenceType
+	31, // 19: todoing.api.v1.UpdateEventRequest.recurrence_config:type_name -> todoing.api.v1.UpdateEventRequest.RecurrenceConfigEntry
+	34, // 20: todoing.api.v1.UpdateEventResponse.response:type_name -> todoing.api.v1.Response
+	3,  // 21: todoing.api.v1.UpdateEventResponse.event:type_name -> todoing.api.v1.Event
+	35, // 22: todoing.api.v1.ListEventsRequest.pagination:type_name -> todoing.api.v1.PaginationRequest
+	0,  // 23: todoing.api.v1.ListEventsRequest.event_type:type_name -> todoing.api.v1.EventType
+	34, // 24: todoing.api.v1.ListEventsResponse.response:type_name -> todoing.api.v1.Response
+	3,  // 25: todoing.api.v1.ListEventsResponse.events:type_name -> todoing.api.v1.Event
+	36, // 26: todoing.api.v1.ListEventsResponse.pagination:type_name -> todoing.api.v1.PaginationResponse
+	34, // 27: todoing.api.v1.GetUpcomingEventsResponse.response:type_name -> todoing.api.v1.Response
+	3,  // 28: todoing.api.v1.GetUpcomingEventsResponse.events:type_name -> todoing.api.v1.Event
+	3,  // 29: todoing.api.v1.CalendarDayEvents.events:type_name -> todoing.api.v1.Event
+	34, // 30: todoing.api.v1.GetCalendarEventsResponse.response:type_name -> todoing.api.v1.Response
+	16, // 31: todoing.api.v1.GetCalendarEventsResponse.days:type_name -> todoing.api.v1.CalendarDayEvents
+	2,  // 32: todoing.api.v1.EventComment.type:type_name -> todoing.api.v1.EventCommentType
+	32, // 33: todoing.api.v1.EventComment.meta:type_name -> todoing.api.v1.EventComment.MetaEntry
+	33, // 34: todoing.api.v1.EventComment.created_at:type_name -> google.protobuf.Timestamp
+	33, // 35: todoing.api.v1.EventComment.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 36: todoing.api.v1.AddEventCommentResponse.response:type_name -> todoing.api.v1.Response
+	18, // 37: todoing.api.v1.AddEventCommentResponse.comment:type_name -> todoing.api.v1.EventComment
+	34, // 38: todoing.api.v1.UpdateEventCommentResponse.response:type_name -> todoing.api.v1.Response
+	18, // 39: todoing.api.v1.UpdateEventCommentResponse.comment:type_name -> todoing.api.v1.EventComment
+	34, // 40: todoing.api.v1.ListEventTimelineResponse.response:type_name -> todoing.api.v1.Response
+	18, // 41: todoing.api.v1.ListEventTimelineResponse.items:type_name -> todoing.api.v1.EventComment
+	34, // 42: todoing.api.v1.ImportEventsResponse.response:type_name -> todoing.api.v1.Response
+	27, // 43: todoing.api.v1.ImportEventsResponse.items:type_name -> todoing.api.v1.EventImportItem
+	4,  // 44: todoing.api.v1.EventService.CreateEvent:input_type -> todoing.api.v1.CreateEventRequest
+	6,  // 45: todoing.api.v1.EventService.GetEvent:input_type -> todoing.api.v1.GetEventRequest
+	8,  // 46: todoing.api.v1.EventService.UpdateEvent:input_type -> todoing.api.v1.UpdateEventRequest
+	10, // 47: todoing.api.v1.EventService.DeleteEvent:input_type -> todoing.api.v1.DeleteEventRequest
+	11, // 48: todoing.api.v1.EventService.ListEvents:input_type -> todoing.api.v1.ListEventsRequest
+	13, // 49: todoing.api.v1.EventService.GetUpcomingEvents:input_type -> todoing.api.v1.GetUpcomingEventsRequest
+	15, // 50: todoing.api.v1.EventService.GetCalendarEvents:input_type -> todoing.api.v1.GetCalendarEventsRequest
+	19, // 51: todoing.api.v1.EventService.AddEventComment:input_type -> todoing.api.v1.AddEventCommentRequest
+	21, // 52: todoing.api.v1.EventService.UpdateEventComment:input_type -> todoing.api.v1.UpdateEventCommentRequest
+	23, // 53: todoing.api.v1.EventService.DeleteEventComment:input_type -> todoing.api.v1.DeleteEventCommentRequest
+	24, // 54: todoing.api.v1.EventService.ListEventTimeline:input_type -> todoing.api.v1.ListEventTimelineRequest
+	26, // 55: todoing.api.v1.EventService.ImportEvents:input_type -> todoing.api.v1.ImportEventsRequest
+	5,  // 56: todoing.api.v1.EventService.CreateEvent:output_type -> todoing.api.v1.CreateEventResponse
+	7,  // 57: todoing.api.v1.EventService.GetEvent:output_type -> todoing.api.v1.GetEventResponse
+	9,  // 58: todoing.api.v1.EventService.UpdateEvent:output_type -> todoing.api.v1.UpdateEventResponse
+	34, // 59: todoing.api.v1.EventService.DeleteEvent:output_type -> todoing.api.v1.Response
+	12, // 60: todoing.api.v1.EventService.ListEvents:output_type -> todoing.api.v1.ListEventsResponse
+	14, // 61: todoing.api.v1.EventService.GetUpcomingEvents:output_type -> todoing.api.v1.GetUpcomingEventsResponse
+	17, // 62: todoing.api.v1.EventService.GetCalendarEvents:output_type -> todoing.api.v1.GetCalendarEventsResponse
+	20, // 63: todoing.api.v1.EventService.AddEventComment:output_type -> todoing.api.v1.AddEventCommentResponse
+	22, // 64: todoing.api.v1.EventService.UpdateEventComment:output_type -> todoing.api.v1.UpdateEventCommentResponse
+	34, // 65: todoing.api.v1.EventService.DeleteEventComment:output_type -> todoing.api.v1.Response
+	25, // 66: todoing.api.v1.EventService.ListEventTimeline:output_type -> todoing.api.v1.ListEventTimelineResponse
+	28, // 67: todoing.api.v1.EventService.ImportEvents:output_type -> todoing.api.v1.ImportEventsResponse
+	56, // [56:68] is the sub-list for method output_type
+	44, // [44:56] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_event_proto_init() }
@@ -2052,7 +2399,7 @@ func file_event_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   27,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -30,6 +30,7 @@ const (
 	EventService_UpdateEventComment_FullMethodName = "/todoing.api.v1.EventService/UpdateEventComment"
 	EventService_DeleteEventComment_FullMethodName = "/todoing.api.v1.EventService/DeleteEventComment"
 	EventService_ListEventTimeline_FullMethodName  = "/todoing.api.v1.EventService/ListEventTimeline"
+	EventService_ImportEvents_FullMethodName       = "/todoing.api.v1.EventService/ImportEvents"
 )
 
 // EventServiceClient is the client API for EventService service.
@@ -47,6 +48,7 @@ type EventServiceClient interface {
 	UpdateEventComment(ctx context.Context, in *UpdateEventCommentRequest, opts ...grpc.CallOption) (*UpdateEventCommentResponse, error)
 	DeleteEventComment(ctx context.Context, in *DeleteEventCommentRequest, opts ...grpc.CallOption) (*Response, error)
 	ListEventTimeline(ctx context.Context, in *ListEventTimelineRequest, opts ...grpc.CallOption) (*ListEventTimelineResponse, error)
+	ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (*ImportEventsResponse, error)
 }
 
 type eventServiceClient struct {
@@ -167,6 +169,16 @@ func (c *eventServiceClient) ListEventTimeline(ctx context.Context, in *ListEven
 	return out, nil
 }
 
+func (c *eventServiceClient) ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (*ImportEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportEventsResponse)
+	err := c.cc.Invoke(ctx, EventService_ImportEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EventServiceServer is the server API for EventService service.
 // All implementations must embed UnimplementedEventServiceServer
 // for forward compatibility.
@@ -182,6 +194,7 @@ type EventServiceServer interface {
 	UpdateEventComment(context.Context, *UpdateEventCommentRequest) (*UpdateEventCommentResponse, error)
 	DeleteEventComment(context.Context, *DeleteEventCommentRequest) (*Response, error)
 	ListEventTimeline(context.Context, *ListEventTimelineRequest) (*ListEventTimelineResponse, error)
+	ImportEvents(context.Context, *ImportEventsRequest) (*ImportEventsResponse, error)
 	mustEmbedUnimplementedEventServiceServer()
 }
 
@@ -225,6 +238,9 @@ func (UnimplementedEventServiceServer) DeleteEventComment(context.Context, *Dele
 func (UnimplementedEventServiceServer) ListEventTimeline(context.Context, *ListEventTimelineRequest) (*ListEventTimelineResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListEventTimeline not implemented")
 }
+func (UnimplementedEventServiceServer) ImportEvents(context.Context, *ImportEventsRequest) (*ImportEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportEvents not implemented")
+}
 func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
 func (UnimplementedEventServiceServer) testEmbeddedByValue()                      {}
 
@@ -444,6 +460,24 @@ func _EventService_ListEventTimeline_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EventService_ImportEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).ImportEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_ImportEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).ImportEvents(ctx, req.(*ImportEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -495,6 +529,10 @@ var EventService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListEventTimeline",
 			Handler:    _EventService_ListEventTimeline_Handler,
 		},
+		{
+			MethodName: "ImportEvents",
+			Handler:    _EventService_ImportEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "event.proto",
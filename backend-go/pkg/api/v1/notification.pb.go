@@ -32,6 +32,10 @@ type Notification struct {
 	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ArchivedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	GroupKey      string                 `protobuf:"bytes,10,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"` // 线程键：同一线程的未读通知合并为一条
+	Count         int32                  `protobuf:"varint,11,opt,name=count,proto3" json:"count,omitempty"`                      // 合并的通知条数 (未合并为 0)
+	FirstAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=first_at,json=firstAt,proto3" json:"first_at,omitempty"`    // 线程中第一条通知的时间
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -122,6 +126,34 @@ func (x *Notification) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *Notification) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Notification) GetGroupKey() string {
+	if x != nil {
+		return x.GroupKey
+	}
+	return ""
+}
+
+func (x *Notification) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Notification) GetFirstAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstAt
+	}
+	return nil
+}
+
 type CreateNotificationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -234,10 +266,14 @@ func (x *CreateNotificationResponse) GetNotification() *Notification {
 	return nil
 }
 
+// cursor 为上一页返回的 next_cursor；archived 为 true 时只列出已归档通知
 type ListNotificationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UnreadOnly    bool                   `protobuf:"varint,1,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
 	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Archived      bool                   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -286,11 +322,33 @@ func (x *ListNotificationsRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *ListNotificationsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *ListNotificationsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
 type ListNotificationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
 	Notifications []*Notification        `protobuf:"bytes,2,rep,name=notifications,proto3" json:"notifications,omitempty"`
 	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -346,6 +404,13 @@ func (x *ListNotificationsResponse) GetCount() int32 {
 	return 0
 }
 
+func (x *ListNotificationsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type MarkNotificationReadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -530,11 +595,421 @@ func (x *MarkAllNotificationsReadResponse) GetModified() int64 {
 	return 0
 }
 
+type ArchiveNotificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Unarchive     bool                   `protobuf:"varint,2,opt,name=unarchive,proto3" json:"unarchive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveNotificationRequest) Reset() {
+	*x = ArchiveNotificationRequest{}
+	mi := &file_notification_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveNotificationRequest) ProtoMessage() {}
+
+func (x *ArchiveNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveNotificationRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ArchiveNotificationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ArchiveNotificationRequest) GetUnarchive() bool {
+	if x != nil {
+		return x.Unarchive
+	}
+	return false
+}
+
+type ArchiveNotificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveNotificationResponse) Reset() {
+	*x = ArchiveNotificationResponse{}
+	mi := &file_notification_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveNotificationResponse) ProtoMessage() {}
+
+func (x *ArchiveNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveNotificationResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ArchiveNotificationResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ArchiveNotificationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type DeleteNotificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationRequest) Reset() {
+	*x = DeleteNotificationRequest{}
+	mi := &file_notification_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationRequest) ProtoMessage() {}
+
+func (x *DeleteNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteNotificationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteNotificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationResponse) Reset() {
+	*x = DeleteNotificationResponse{}
+	mi := &file_notification_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationResponse) ProtoMessage() {}
+
+func (x *DeleteNotificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteNotificationResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *DeleteNotificationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetUnreadCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountsRequest) Reset() {
+	*x = GetUnreadCountsRequest{}
+	mi := &file_notification_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountsRequest) ProtoMessage() {}
+
+func (x *GetUnreadCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{13}
+}
+
+type GetUnreadCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	ByType        map[string]int64       `protobuf:"bytes,3,rep,name=by_type,json=byType,proto3" json:"by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadCountsResponse) Reset() {
+	*x = GetUnreadCountsResponse{}
+	mi := &file_notification_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadCountsResponse) ProtoMessage() {}
+
+func (x *GetUnreadCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetUnreadCountsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetUnreadCountsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetUnreadCountsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetUnreadCountsResponse) GetByType() map[string]int64 {
+	if x != nil {
+		return x.ByType
+	}
+	return nil
+}
+
+// 通知推送流：last_event_id 为最后收到的通知 ID，重连时补发其后的通知 (单次最多 100 条)
+type StreamNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastEventId   string                 `protobuf:"bytes,1,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamNotificationsRequest) Reset() {
+	*x = StreamNotificationsRequest{}
+	mi := &file_notification_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamNotificationsRequest) ProtoMessage() {}
+
+func (x *StreamNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*StreamNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StreamNotificationsRequest) GetLastEventId() string {
+	if x != nil {
+		return x.LastEventId
+	}
+	return ""
+}
+
+// event: notification (notification 有值) / dropped (消费过慢被丢弃，随后补发)
+type StreamNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Notification  *Notification          `protobuf:"bytes,2,opt,name=notification,proto3" json:"notification,omitempty"`
+	Dropped       int64                  `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"`                               // 本次丢弃条数
+	TotalDropped  int64                  `protobuf:"varint,4,opt,name=total_dropped,json=totalDropped,proto3" json:"total_dropped,omitempty"` // 本连接累计丢弃条数
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamNotificationsResponse) Reset() {
+	*x = StreamNotificationsResponse{}
+	mi := &file_notification_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamNotificationsResponse) ProtoMessage() {}
+
+func (x *StreamNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*StreamNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StreamNotificationsResponse) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *StreamNotificationsResponse) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+func (x *StreamNotificationsResponse) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *StreamNotificationsResponse) GetTotalDropped() int64 {
+	if x != nil {
+		return x.TotalDropped
+	}
+	return 0
+}
+
 var File_notification_proto protoreflect.FileDescriptor
 
 const file_notification_proto_rawDesc = "" +
 	"\n" +
-	"\x12notification.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\xf5\x02\n" +
+	"\x12notification.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\x9c\x04\n" +
 	"\fNotification\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
@@ -544,7 +1019,13 @@ const file_notification_proto_rawDesc = "" +
 	"\aread_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\x129\n" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12F\n" +
-	"\bmetadata\x18\b \x03(\v2*.todoing.api.v1.Notification.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\b \x03(\v2*.todoing.api.v1.Notification.MetadataEntryR\bmetadata\x12;\n" +
+	"\varchived_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x12\x1b\n" +
+	"\tgroup_key\x18\n" +
+	" \x01(\tR\bgroupKey\x12\x14\n" +
+	"\x05count\x18\v \x01(\x05R\x05count\x125\n" +
+	"\bfirst_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\afirstAt\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"d\n" +
@@ -554,15 +1035,20 @@ const file_notification_proto_rawDesc = "" +
 	"\bevent_id\x18\x03 \x01(\tR\aeventId\"\x94\x01\n" +
 	"\x1aCreateNotificationResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12@\n" +
-	"\fnotification\x18\x02 \x01(\v2\x1c.todoing.api.v1.NotificationR\fnotification\"Q\n" +
+	"\fnotification\x18\x02 \x01(\v2\x1c.todoing.api.v1.NotificationR\fnotification\"\x99\x01\n" +
 	"\x18ListNotificationsRequest\x12\x1f\n" +
 	"\vunread_only\x18\x01 \x01(\bR\n" +
 	"unreadOnly\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xab\x01\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\"\xcc\x01\n" +
 	"\x19ListNotificationsResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12B\n" +
 	"\rnotifications\x18\x02 \x03(\v2\x1c.todoing.api.v1.NotificationR\rnotifications\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count\"-\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"-\n" +
 	"\x1bMarkNotificationReadRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"n\n" +
 	"\x1cMarkNotificationReadResponse\x124\n" +
@@ -571,12 +1057,42 @@ const file_notification_proto_rawDesc = "" +
 	"\x1fMarkAllNotificationsReadRequest\"t\n" +
 	" MarkAllNotificationsReadResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12\x1a\n" +
-	"\bmodified\x18\x02 \x01(\x03R\bmodified2\xde\x03\n" +
+	"\bmodified\x18\x02 \x01(\x03R\bmodified\"J\n" +
+	"\x1aArchiveNotificationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tunarchive\x18\x02 \x01(\bR\tunarchive\"m\n" +
+	"\x1bArchiveNotificationResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"+\n" +
+	"\x19DeleteNotificationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"l\n" +
+	"\x1aDeleteNotificationResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\x18\n" +
+	"\x16GetUnreadCountsRequest\"\xee\x01\n" +
+	"\x17GetUnreadCountsResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12L\n" +
+	"\aby_type\x18\x03 \x03(\v23.todoing.api.v1.GetUnreadCountsResponse.ByTypeEntryR\x06byType\x1a9\n" +
+	"\vByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"@\n" +
+	"\x1aStreamNotificationsRequest\x12\"\n" +
+	"\rlast_event_id\x18\x01 \x01(\tR\vlastEventId\"\xb4\x01\n" +
+	"\x1bStreamNotificationsResponse\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05event\x12@\n" +
+	"\fnotification\x18\x02 \x01(\v2\x1c.todoing.api.v1.NotificationR\fnotification\x12\x18\n" +
+	"\adropped\x18\x03 \x01(\x03R\adropped\x12#\n" +
+	"\rtotal_dropped\x18\x04 \x01(\x03R\ftotalDropped2\x91\a\n" +
 	"\x13NotificationService\x12k\n" +
 	"\x12CreateNotification\x12).todoing.api.v1.CreateNotificationRequest\x1a*.todoing.api.v1.CreateNotificationResponse\x12h\n" +
 	"\x11ListNotifications\x12(.todoing.api.v1.ListNotificationsRequest\x1a).todoing.api.v1.ListNotificationsResponse\x12q\n" +
 	"\x14MarkNotificationRead\x12+.todoing.api.v1.MarkNotificationReadRequest\x1a,.todoing.api.v1.MarkNotificationReadResponse\x12}\n" +
-	"\x18MarkAllNotificationsRead\x12/.todoing.api.v1.MarkAllNotificationsReadRequest\x1a0.todoing.api.v1.MarkAllNotificationsReadResponseB5Z3github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1b\x06proto3"
+	"\x18MarkAllNotificationsRead\x12/.todoing.api.v1.MarkAllNotificationsReadRequest\x1a0.todoing.api.v1.MarkAllNotificationsReadResponse\x12n\n" +
+	"\x13ArchiveNotification\x12*.todoing.api.v1.ArchiveNotificationRequest\x1a+.todoing.api.v1.ArchiveNotificationResponse\x12k\n" +
+	"\x12DeleteNotification\x12).todoing.api.v1.DeleteNotificationRequest\x1a*.todoing.api.v1.DeleteNotificationResponse\x12b\n" +
+	"\x0fGetUnreadCounts\x12&.todoing.api.v1.GetUnreadCountsRequest\x1a'.todoing.api.v1.GetUnreadCountsResponse\x12p\n" +
+	"\x13StreamNotifications\x12*.todoing.api.v1.StreamNotificationsRequest\x1a+.todoing.api.v1.StreamNotificationsResponse0\x01B5Z3github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1b\x06proto3"
 
 var (
 	file_notification_proto_rawDescOnce sync.Once
@@ -590,7 +1106,7 @@ func file_notification_proto_rawDescGZIP() []byte {
 	return file_notification_proto_rawDescData
 }
 
-var file_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_notification_proto_goTypes = []any{
 	(*Notification)(nil),                     // 0: todoing.api.v1.Notification
 	(*CreateNotificationRequest)(nil),        // 1: todoing.api.v1.CreateNotificationRequest
@@ -601,33 +1117,57 @@ var file_notification_proto_goTypes = []any{
 	(*MarkNotificationReadResponse)(nil),     // 6: todoing.api.v1.MarkNotificationReadResponse
 	(*MarkAllNotificationsReadRequest)(nil),  // 7: todoing.api.v1.MarkAllNotificationsReadRequest
 	(*MarkAllNotificationsReadResponse)(nil), // 8: todoing.api.v1.MarkAllNotificationsReadResponse
-	nil,                                      // 9: todoing.api.v1.Notification.MetadataEntry
-	(*timestamppb.Timestamp)(nil),            // 10: google.protobuf.Timestamp
-	(*Response)(nil),                         // 11: todoing.api.v1.Response
+	(*ArchiveNotificationRequest)(nil),       // 9: todoing.api.v1.ArchiveNotificationRequest
+	(*ArchiveNotificationResponse)(nil),      // 10: todoing.api.v1.ArchiveNotificationResponse
+	(*DeleteNotificationRequest)(nil),        // 11: todoing.api.v1.DeleteNotificationRequest
+	(*DeleteNotificationResponse)(nil),       // 12: todoing.api.v1.DeleteNotificationResponse
+	(*GetUnreadCountsRequest)(nil),           // 13: todoing.api.v1.GetUnreadCountsRequest
+	(*GetUnreadCountsResponse)(nil),          // 14: todoing.api.v1.GetUnreadCountsResponse
+	(*StreamNotificationsRequest)(nil),       // 15: todoing.api.v1.StreamNotificationsRequest
+	(*StreamNotificationsResponse)(nil),      // 16: todoing.api.v1.StreamNotificationsResponse
+	nil,                                      // 17: todoing.api.v1.Notification.MetadataEntry
+	nil,                                      // 18: todoing.api.v1.GetUnreadCountsResponse.ByTypeEntry
+	(*timestamppb.Timestamp)(nil),            // 19: google.protobuf.Timestamp
+	(*Response)(nil),                         // 20: todoing.api.v1.Response
 }
 var file_notification_proto_depIdxs = []int32{
-	10, // 0: todoing.api.v1.Notification.read_at:type_name -> google.protobuf.Timestamp
-	10, // 1: todoing.api.v1.Notification.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 2: todoing.api.v1.Notification.metadata:type_name -> todoing.api.v1.Notification.MetadataEntry
-	11, // 3: todoing.api.v1.CreateNotificationResponse.response:type_name -> todoing.api.v1.Response
-	0,  // 4: todoing.api.v1.CreateNotificationResponse.notification:type_name -> todoing.api.v1.Notification
-	11, // 5: todoing.api.v1.ListNotificationsResponse.response:type_name -> todoing.api.v1.Response
-	0,  // 6: todoing.api.v1.ListNotificationsResponse.notifications:type_name -> todoing.api.v1.Notification
-	11, // 7: todoing.api.v1.MarkNotificationReadResponse.response:type_name -> todoing.api.v1.Response
-	11, // 8: todoing.api.v1.MarkAllNotificationsReadResponse.response:type_name -> todoing.api.v1.Response
-	1,  // 9: todoing.api.v1.NotificationService.CreateNotification:input_type -> todoing.api.v1.CreateNotificationRequest
-	3,  // 10: todoing.api.v1.NotificationService.ListNotifications:input_type -> todoing.api.v1.ListNotificationsRequest
-	5,  // 11: todoing.api.v1.NotificationService.MarkNotificationRead:input_type -> todoing.api.v1.MarkNotificationReadRequest
-	7,  // 12: todoing.api.v1.NotificationService.MarkAllNotificationsRead:input_type -> todoing.api.v1.MarkAllNotificationsReadRequest
-	2,  // 13: todoing.api.v1.NotificationService.CreateNotification:output_type -> todoing.api.v1.CreateNotificationResponse
-	4,  // 14: todoing.api.v1.NotificationService.ListNotifications:output_type -> todoing.api.v1.ListNotificationsResponse
-	6,  // 15: todoing.api.v1.NotificationService.MarkNotificationRead:output_type -> todoing.api.v1.MarkNotificationReadResponse
-	8,  // 16: todoing.api.v1.NotificationService.MarkAllNotificationsRead:output_type -> todoing.api.v1.MarkAllNotificationsReadResponse
-	13, // [13:17] is the sub-list for method output_type
-	9,  // [9:13] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	19, // 0: todoing.api.v1.Notification.read_at:type_name -> google.protobuf.Timestamp
+	19, // 1: todoing.api.v1.Notification.created_at:type_name -> google.protobuf.Timestamp
+	17, // 2: todoing.api.v1.Notification.metadata:type_name -> todoing.api.v1.Notification.MetadataEntry
+	19, // 3: todoing.api.v1.Notification.archived_at:type_name -> google.protobuf.Timestamp
+	19, // 4: todoing.api.v1.Notification.first_at:type_name -> google.protobuf.Timestamp
+	20, // 5: todoing.api.v1.CreateNotificationResponse.response:type_name -> todoing.api.v1.Response
+	0,  // 6: todoing.api.v1.CreateNotificationResponse.notification:type_name -> todoing.api.v1.Notification
+	20, // 7: todoing.api.v1.ListNotificationsResponse.response:type_name -> todoing.api.v1.Response
+	0,  // 8: todoing.api.v1.ListNotificationsResponse.notifications:type_name -> todoing.api.v1.Notification
+	20, // 9: todoing.api.v1.MarkNotificationReadResponse.response:type_name -> todoing.api.v1.Response
+	20, // 10: todoing.api.v1.MarkAllNotificationsReadResponse.response:type_name -> todoing.api.v1.Response
+	20, // 11: todoing.api.v1.ArchiveNotificationResponse.response:type_name -> todoing.api.v1.Response
+	20, // 12: todoing.api.v1.DeleteNotificationResponse.response:type_name -> todoing.api.v1.Response
+	20, // 13: todoing.api.v1.GetUnreadCountsResponse.response:type_name -> todoing.api.v1.Response
+	18, // 14: todoing.api.v1.GetUnreadCountsResponse.by_type:type_name -> todoing.api.v1.GetUnreadCountsResponse.ByTypeEntry
+	0,  // 15: todoing.api.v1.StreamNotificationsResponse.notification:type_name -> todoing.api.v1.Notification
+	1,  // 16: todoing.api.v1.NotificationService.CreateNotification:input_type -> todoing.api.v1.CreateNotificationRequest
+	3,  // 17: todoing.api.v1.NotificationService.ListNotifications:input_type -> todoing.api.v1.ListNotificationsRequest
+	5,  // 18: todoing.api.v1.NotificationService.MarkNotificationRead:input_type -> todoing.api.v1.MarkNotificationReadRequest
+	7,  // 19: todoing.api.v1.NotificationService.MarkAllNotificationsRead:input_type -> todoing.api.v1.MarkAllNotificationsReadRequest
+	9,  // 20: todoing.api.v1.NotificationService.ArchiveNotification:input_type -> todoing.api.v1.ArchiveNotificationRequest
+	11, // 21: todoing.api.v1.NotificationService.DeleteNotification:input_type -> todoing.api.v1.DeleteNotificationRequest
+	13, // 22: todoing.api.v1.NotificationService.GetUnreadCounts:input_type -> todoing.api.v1.GetUnreadCountsRequest
+	15, // 23: todoing.api.v1.NotificationService.StreamNotifications:input_type -> todoing.api.v1.StreamNotificationsRequest
+	2,  // 24: todoing.api.v1.NotificationService.CreateNotification:output_type -> todoing.api.v1.CreateNotificationResponse
+	4,  // 25: todoing.api.v1.NotificationService.ListNotifications:output_type -> todoing.api.v1.ListNotificationsResponse
+	6,  // 26: todoing.api.v1.NotificationService.MarkNotificationRead:output_type -> todoing.api.v1.MarkNotificationReadResponse
+	8,  // 27: todoing.api.v1.NotificationService.MarkAllNotificationsRead:output_type -> todoing.api.v1.MarkAllNotificationsReadResponse
+	10, // 28: todoing.api.v1.NotificationService.ArchiveNotification:output_type -> todoing.api.v1.ArchiveNotificationResponse
+	12, // 29: todoing.api.v1.NotificationService.DeleteNotification:output_type -> todoing.api.v1.DeleteNotificationResponse
+	14, // 30: todoing.api.v1.NotificationService.GetUnreadCounts:output_type -> todoing.api.v1.GetUnreadCountsResponse
+	16, // 31: todoing.api.v1.NotificationService.StreamNotifications:output_type -> todoing.api.v1.StreamNotificationsResponse
+	24, // [24:32] is the sub-list for method output_type
+	16, // [16:24] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_notification_proto_init() }
@@ -642,7 +1182,7 @@ func file_notification_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_proto_rawDesc), len(file_notification_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -77,22 +77,27 @@ func (ReminderType) EnumDescriptor() ([]byte, []int) {
 
 // 提醒
 type Reminder struct {
-	state         protoimpl.MessageState   `protogen:"open.v1"`
-	Id            string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	EventId       string                   `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	UserId        string                   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	AdvanceDays   int32                    `protobuf:"varint,4,opt,name=advance_days,json=advanceDays,proto3" json:"advance_days,omitempty"`
-	ReminderTimes []string                 `protobuf:"bytes,5,rep,name=reminder_times,json=reminderTimes,proto3" json:"reminder_times,omitempty"` // HH:MM
-	AbsoluteTimes []*timestamppb.Timestamp `protobuf:"bytes,6,rep,name=absolute_times,json=absoluteTimes,proto3" json:"absolute_times,omitempty"` // 与后端 AbsoluteTimes 对齐
-	ReminderType  ReminderType             `protobuf:"varint,7,opt,name=reminder_type,json=reminderType,proto3,enum=todoing.api.v1.ReminderType" json:"reminder_type,omitempty"`
-	CustomMessage string                   `protobuf:"bytes,8,opt,name=custom_message,json=customMessage,proto3" json:"custom_message,omitempty"`
-	IsActive      bool                     `protobuf:"varint,9,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
-	LastSent      *timestamppb.Timestamp   `protobuf:"bytes,10,opt,name=last_sent,json=lastSent,proto3" json:"last_sent,omitempty"`
-	NextSend      *timestamppb.Timestamp   `protobuf:"bytes,11,opt,name=next_send,json=nextSend,proto3" json:"next_send,omitempty"`
-	CreatedAt     *timestamppb.Timestamp   `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp   `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState   `protogen:"open.v1"`
+	Id               string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId          string                   `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UserId           string                   `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AdvanceDays      int32                    `protobuf:"varint,4,opt,name=advance_days,json=advanceDays,proto3" json:"advance_days,omitempty"`
+	ReminderTimes    []string                 `protobuf:"bytes,5,rep,name=reminder_times,json=reminderTimes,proto3" json:"reminder_times,omitempty"` // HH:MM
+	AbsoluteTimes    []*timestamppb.Timestamp `protobuf:"bytes,6,rep,name=absolute_times,json=absoluteTimes,proto3" json:"absolute_times,omitempty"` // 与后端 AbsoluteTimes 对齐
+	ReminderType     ReminderType             `protobuf:"varint,7,opt,name=reminder_type,json=reminderType,proto3,enum=todoing.api.v1.ReminderType" json:"reminder_type,omitempty"`
+	CustomMessage    string                   `protobuf:"bytes,8,opt,name=custom_message,json=customMessage,proto3" json:"custom_message,omitempty"`
+	IsActive         bool                     `protobuf:"varint,9,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	LastSent         *timestamppb.Timestamp   `protobuf:"bytes,10,opt,name=last_sent,json=lastSent,proto3" json:"last_sent,omitempty"`
+	NextSend         *timestamppb.Timestamp   `protobuf:"bytes,11,opt,name=next_send,json=nextSend,proto3" json:"next_send,omitempty"`
+	CreatedAt        *timestamppb.Timestamp   `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp   `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeliveryAttempts int32                    `protobuf:"varint,14,opt,name=delivery_attempts,json=deliveryAttempts,proto3" json:"delivery_attempts,omitempty"` // 当前提醒已失败的投递次数
+	LastError        string                   `protobuf:"bytes,15,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	DeadLetterAt     *timestamppb.Timestamp   `protobuf:"bytes,16,opt,name=dead_letter_at,json=deadLetterAt,proto3" json:"dead_letter_at,omitempty"` // 最近一次进入死信的时间
+	Channels         []string                 `protobuf:"bytes,17,rep,name=channels,proto3" json:"channels,omitempty"`                               // 发送渠道 ID 列表 (app / email / webhook ...)；reminder_type 仅表示 app/email 组合
+	WorkspaceId      string                   `protobuf:"bytes,18,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`      // 继承自所属事件
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Reminder) Reset() {
@@ -216,6 +221,41 @@ func (x *Reminder) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Reminder) GetDeliveryAttempts() int32 {
+	if x != nil {
+		return x.DeliveryAttempts
+	}
+	return 0
+}
+
+func (x *Reminder) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Reminder) GetDeadLetterAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeadLetterAt
+	}
+	return nil
+}
+
+func (x *Reminder) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+func (x *Reminder) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
 // 包含事件的提醒
 type ReminderWithEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -278,6 +318,7 @@ type CreateReminderRequest struct {
 	AbsoluteTimes []*timestamppb.Timestamp `protobuf:"bytes,4,rep,name=absolute_times,json=absoluteTimes,proto3" json:"absolute_times,omitempty"` // 二选一: 与 times 互斥
 	ReminderType  ReminderType             `protobuf:"varint,5,opt,name=reminder_type,json=reminderType,proto3,enum=todoing.api.v1.ReminderType" json:"reminder_type,omitempty"`
 	CustomMessage string                   `protobuf:"bytes,6,opt,name=custom_message,json=customMessage,proto3" json:"custom_message,omitempty"`
+	Channels      []string                 `protobuf:"bytes,7,rep,name=channels,proto3" json:"channels,omitempty"` // 非空时优先于 reminder_type
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -354,6 +395,13 @@ func (x *CreateReminderRequest) GetCustomMessage() string {
 	return ""
 }
 
+func (x *CreateReminderRequest) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
 type CreateReminderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -513,6 +561,7 @@ type UpdateReminderRequest struct {
 	ReminderType  ReminderType             `protobuf:"varint,5,opt,name=reminder_type,json=reminderType,proto3,enum=todoing.api.v1.ReminderType" json:"reminder_type,omitempty"`
 	CustomMessage string                   `protobuf:"bytes,6,opt,name=custom_message,json=customMessage,proto3" json:"custom_message,omitempty"`
 	IsActive      bool                     `protobuf:"varint,7,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	Channels      []string                 `protobuf:"bytes,8,rep,name=channels,proto3" json:"channels,omitempty"` // 非空时优先于 reminder_type
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -596,6 +645,13 @@ func (x *UpdateReminderRequest) GetIsActive() bool {
 	return false
 }
 
+func (x *UpdateReminderRequest) GetChannels() []string {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
 type UpdateReminderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
@@ -1483,6 +1539,251 @@ func (x *ToggleReminderActiveResponse) GetIsActive() bool {
 	return false
 }
 
+// 投递记录 (每个渠道每次尝试一条)
+type ReminderDelivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReminderId    string                 `protobuf:"bytes,2,opt,name=reminder_id,json=reminderId,proto3" json:"reminder_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Channel       string                 `protobuf:"bytes,4,opt,name=channel,proto3" json:"channel,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // sent / failed / dead_letter
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Attempt       int32                  `protobuf:"varint,7,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	LatencyMs     int64                  `protobuf:"varint,8,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	ScheduledFor  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`
+	Instance      string                 `protobuf:"bytes,10,opt,name=instance,proto3" json:"instance,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReminderDelivery) Reset() {
+	*x = ReminderDelivery{}
+	mi := &file_reminder_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReminderDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReminderDelivery) ProtoMessage() {}
+
+func (x *ReminderDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_reminder_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReminderDelivery.ProtoReflect.Descriptor instead.
+func (*ReminderDelivery) Descriptor() ([]byte, []int) {
+	return file_reminder_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReminderDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReminderDelivery) GetReminderId() string {
+	if x != nil {
+		return x.ReminderId
+	}
+	return ""
+}
+
+func (x *ReminderDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ReminderDelivery) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ReminderDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReminderDelivery) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ReminderDelivery) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *ReminderDelivery) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ReminderDelivery) GetScheduledFor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return nil
+}
+
+func (x *ReminderDelivery) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *ReminderDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ListReminderDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReminderId    string                 `protobuf:"bytes,1,opt,name=reminder_id,json=reminderId,proto3" json:"reminder_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	BeforeId      string                 `protobuf:"bytes,3,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReminderDeliveriesRequest) Reset() {
+	*x = ListReminderDeliveriesRequest{}
+	mi := &file_reminder_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReminderDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReminderDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListReminderDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reminder_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReminderDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListReminderDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_reminder_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListReminderDeliveriesRequest) GetReminderId() string {
+	if x != nil {
+		return x.ReminderId
+	}
+	return ""
+}
+
+func (x *ListReminderDeliveriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListReminderDeliveriesRequest) GetBeforeId() string {
+	if x != nil {
+		return x.BeforeId
+	}
+	return ""
+}
+
+type ListReminderDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Deliveries    []*ReminderDelivery    `protobuf:"bytes,2,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReminderDeliveriesResponse) Reset() {
+	*x = ListReminderDeliveriesResponse{}
+	mi := &file_reminder_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReminderDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReminderDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListReminderDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reminder_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReminderDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListReminderDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_reminder_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListReminderDeliveriesResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ListReminderDeliveriesResponse) GetDeliveries() []*ReminderDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *ListReminderDeliveriesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
 // 创建测试提醒
 type CreateTestReminderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1496,7 +1797,7 @@ type CreateTestReminderRequest struct {
 
 func (x *CreateTestReminderRequest) Reset() {
 	*x = CreateTestReminderRequest{}
-	mi := &file_reminder_proto_msgTypes[23]
+	mi := &file_reminder_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1508,7 +1809,7 @@ func (x *CreateTestReminderRequest) String() string {
 func (*CreateTestReminderRequest) ProtoMessage() {}
 
 func (x *CreateTestReminderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_reminder_proto_msgTypes[23]
+	mi := &file_reminder_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1521,7 +1822,7 @@ func (x *CreateTestReminderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTestReminderRequest.ProtoReflect.Descriptor instead.
 func (*CreateTestReminderRequest) Descriptor() ([]byte, []int) {
-	return file_reminder_proto_rawDescGZIP(), []int{23}
+	return file_reminder_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *CreateTestReminderRequest) GetEventId() string {
@@ -1565,7 +1866,7 @@ type CreateTestReminderResponse struct {
 
 func (x *CreateTestReminderResponse) Reset() {
 	*x = CreateTestReminderResponse{}
-	mi := &file_reminder_proto_msgTypes[24]
+	mi := &file_reminder_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1577,7 +1878,7 @@ func (x *CreateTestReminderResponse) String() string {
 func (*CreateTestReminderResponse) ProtoMessage() {}
 
 func (x *CreateTestReminderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_reminder_proto_msgTypes[24]
+	mi := &file_reminder_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1590,7 +1891,7 @@ func (x *CreateTestReminderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTestReminderResponse.ProtoReflect.Descriptor instead.
 func (*CreateTestReminderResponse) Descriptor() ([]byte, []int) {
-	return file_reminder_proto_rawDescGZIP(), []int{24}
+	return file_reminder_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *CreateTestReminderResponse) GetResponse() *Response {
@@ -1632,7 +1933,7 @@ var File_reminder_proto protoreflect.FileDescriptor
 
 const file_reminder_proto_rawDesc = "" +
 	"\n" +
-	"\x0ereminder.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\x1a\vevent.proto\"\xca\x04\n" +
+	"\x0ereminder.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\x1a\vevent.proto\"\x97\x06\n" +
 	"\bReminder\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x17\n" +
@@ -1649,17 +1950,24 @@ const file_reminder_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"v\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12+\n" +
+	"\x11delivery_attempts\x18\x0e \x01(\x05R\x10deliveryAttempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x0f \x01(\tR\tlastError\x12@\n" +
+	"\x0edead_letter_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\fdeadLetterAt\x12\x1a\n" +
+	"\bchannels\x18\x11 \x03(\tR\bchannels\x12!\n" +
+	"\fworkspace_id\x18\x12 \x01(\tR\vworkspaceId\"v\n" +
 	"\x11ReminderWithEvent\x124\n" +
 	"\breminder\x18\x01 \x01(\v2\x18.todoing.api.v1.ReminderR\breminder\x12+\n" +
-	"\x05event\x18\x02 \x01(\v2\x15.todoing.api.v1.EventR\x05event\"\xa9\x02\n" +
+	"\x05event\x18\x02 \x01(\v2\x15.todoing.api.v1.EventR\x05event\"\xc5\x02\n" +
 	"\x15CreateReminderRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12!\n" +
 	"\fadvance_days\x18\x02 \x01(\x05R\vadvanceDays\x12%\n" +
 	"\x0ereminder_times\x18\x03 \x03(\tR\rreminderTimes\x12A\n" +
 	"\x0eabsolute_times\x18\x04 \x03(\v2\x1a.google.protobuf.TimestampR\rabsoluteTimes\x12A\n" +
 	"\rreminder_type\x18\x05 \x01(\x0e2\x1c.todoing.api.v1.ReminderTypeR\freminderType\x12%\n" +
-	"\x0ecustom_message\x18\x06 \x01(\tR\rcustomMessage\"\x84\x01\n" +
+	"\x0ecustom_message\x18\x06 \x01(\tR\rcustomMessage\x12\x1a\n" +
+	"\bchannels\x18\a \x03(\tR\bchannels\"\x84\x01\n" +
 	"\x16CreateReminderResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x124\n" +
 	"\breminder\x18\x02 \x01(\v2\x18.todoing.api.v1.ReminderR\breminder\"$\n" +
@@ -1667,7 +1975,7 @@ const file_reminder_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x8a\x01\n" +
 	"\x13GetReminderResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12=\n" +
-	"\breminder\x18\x02 \x01(\v2!.todoing.api.v1.ReminderWithEventR\breminder\"\xbb\x02\n" +
+	"\breminder\x18\x02 \x01(\v2!.todoing.api.v1.ReminderWithEventR\breminder\"\xd7\x02\n" +
 	"\x15UpdateReminderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\fadvance_days\x18\x02 \x01(\x05R\vadvanceDays\x12%\n" +
@@ -1675,7 +1983,8 @@ const file_reminder_proto_rawDesc = "" +
 	"\x0eabsolute_times\x18\x04 \x03(\v2\x1a.google.protobuf.TimestampR\rabsoluteTimes\x12A\n" +
 	"\rreminder_type\x18\x05 \x01(\x0e2\x1c.todoing.api.v1.ReminderTypeR\freminderType\x12%\n" +
 	"\x0ecustom_message\x18\x06 \x01(\tR\rcustomMessage\x12\x1b\n" +
-	"\tis_active\x18\a \x01(\bR\bisActive\"\x84\x01\n" +
+	"\tis_active\x18\a \x01(\bR\bisActive\x12\x1a\n" +
+	"\bchannels\x18\b \x03(\tR\bchannels\"\x84\x01\n" +
 	"\x16UpdateReminderResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x124\n" +
 	"\breminder\x18\x02 \x01(\v2\x18.todoing.api.v1.ReminderR\breminder\"'\n" +
@@ -1741,7 +2050,34 @@ const file_reminder_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"q\n" +
 	"\x1cToggleReminderActiveResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12\x1b\n" +
-	"\tis_active\x18\x02 \x01(\bR\bisActive\"\x94\x01\n" +
+	"\tis_active\x18\x02 \x01(\bR\bisActive\"\xf7\x02\n" +
+	"\x10ReminderDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vreminder_id\x18\x02 \x01(\tR\n" +
+	"reminderId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x18\n" +
+	"\achannel\x18\x04 \x01(\tR\achannel\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x18\n" +
+	"\aattempt\x18\a \x01(\x05R\aattempt\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\b \x01(\x03R\tlatencyMs\x12?\n" +
+	"\rscheduled_for\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fscheduledFor\x12\x1a\n" +
+	"\binstance\x18\n" +
+	" \x01(\tR\binstance\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"s\n" +
+	"\x1dListReminderDeliveriesRequest\x12\x1f\n" +
+	"\vreminder_id\x18\x01 \x01(\tR\n" +
+	"reminderId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\tbefore_id\x18\x03 \x01(\tR\bbeforeId\"\xae\x01\n" +
+	"\x1eListReminderDeliveriesResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12@\n" +
+	"\n" +
+	"deliveries\x18\x02 \x03(\v2 .todoing.api.v1.ReminderDeliveryR\n" +
+	"deliveries\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\x94\x01\n" +
 	"\x19CreateTestReminderRequest\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
@@ -1760,7 +2096,7 @@ const file_reminder_proto_rawDesc = "" +
 	"\x19REMINDER_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11REMINDER_TYPE_APP\x10\x01\x12\x17\n" +
 	"\x13REMINDER_TYPE_EMAIL\x10\x02\x12\x16\n" +
-	"\x12REMINDER_TYPE_BOTH\x10\x032\xe4\b\n" +
+	"\x12REMINDER_TYPE_BOTH\x10\x032\xdd\t\n" +
 	"\x0fReminderService\x12_\n" +
 	"\x0eCreateReminder\x12%.todoing.api.v1.CreateReminderRequest\x1a&.todoing.api.v1.CreateReminderResponse\x12V\n" +
 	"\vGetReminder\x12\".todoing.api.v1.GetReminderRequest\x1a#.todoing.api.v1.GetReminderResponse\x12_\n" +
@@ -1772,7 +2108,8 @@ const file_reminder_proto_rawDesc = "" +
 	"\x0fPreviewReminder\x12&.todoing.api.v1.PreviewReminderRequest\x1a'.todoing.api.v1.PreviewReminderResponse\x12_\n" +
 	"\x0eSnoozeReminder\x12%.todoing.api.v1.SnoozeReminderRequest\x1a&.todoing.api.v1.SnoozeReminderResponse\x12q\n" +
 	"\x14ToggleReminderActive\x12+.todoing.api.v1.ToggleReminderActiveRequest\x1a,.todoing.api.v1.ToggleReminderActiveResponse\x12k\n" +
-	"\x12CreateTestReminder\x12).todoing.api.v1.CreateTestReminderRequest\x1a*.todoing.api.v1.CreateTestReminderResponseB5Z3github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1b\x06proto3"
+	"\x12CreateTestReminder\x12).todoing.api.v1.CreateTestReminderRequest\x1a*.todoing.api.v1.CreateTestReminderResponse\x12w\n" +
+	"\x16ListReminderDeliveries\x12-.todoing.api.v1.ListReminderDeliveriesRequest\x1a..todoing.api.v1.ListReminderDeliveriesResponseB5Z3github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1b\x06proto3"
 
 var (
 	file_reminder_proto_rawDescOnce sync.Once
@@ -1787,104 +2124,114 @@ func file_reminder_proto_rawDescGZIP() []byte {
 }
 
 var file_reminder_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_reminder_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_reminder_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_reminder_proto_goTypes = []any{
-	(ReminderType)(0),                    // 0: todoing.api.v1.ReminderType
-	(*Reminder)(nil),                     // 1: todoing.api.v1.Reminder
-	(*ReminderWithEvent)(nil),            // 2: todoing.api.v1.ReminderWithEvent
-	(*CreateReminderRequest)(nil),        // 3: todoing.api.v1.CreateReminderRequest
-	(*CreateReminderResponse)(nil),       // 4: todoing.api.v1.CreateReminderResponse
-	(*GetReminderRequest)(nil),           // 5: todoing.api.v1.GetReminderRequest
-	(*GetReminderResponse)(nil),          // 6: todoing.api.v1.GetReminderResponse
-	(*UpdateReminderRequest)(nil),        // 7: todoing.api.v1.UpdateReminderRequest
-	(*UpdateReminderResponse)(nil),       // 8: todoing.api.v1.UpdateReminderResponse
-	(*DeleteReminderRequest)(nil),        // 9: todoing.api.v1.DeleteReminderRequest
-	(*ListRemindersRequest)(nil),         // 10: todoing.api.v1.ListRemindersRequest
-	(*ListRemindersResponse)(nil),        // 11: todoing.api.v1.ListRemindersResponse
-	(*ListSimpleRemindersRequest)(nil),   // 12: todoing.api.v1.ListSimpleRemindersRequest
-	(*ListSimpleRemindersResponse)(nil),  // 13: todoing.api.v1.ListSimpleRemindersResponse
-	(*UpcomingReminder)(nil),             // 14: todoing.api.v1.UpcomingReminder
-	(*GetUpcomingRemindersRequest)(nil),  // 15: todoing.api.v1.GetUpcomingRemindersRequest
-	(*GetUpcomingRemindersResponse)(nil), // 16: todoing.api.v1.GetUpcomingRemindersResponse
-	(*PreviewReminderRequest)(nil),       // 17: todoing.api.v1.PreviewReminderRequest
-	(*PreviewReminderItem)(nil),          // 18: todoing.api.v1.PreviewReminderItem
-	(*PreviewReminderResponse)(nil),      // 19: todoing.api.v1.PreviewReminderResponse
-	(*SnoozeReminderRequest)(nil),        // 20: todoing.api.v1.SnoozeReminderRequest
-	(*SnoozeReminderResponse)(nil),       // 21: todoing.api.v1.SnoozeReminderResponse
-	(*ToggleReminderActiveRequest)(nil),  // 22: todoing.api.v1.ToggleReminderActiveRequest
-	(*ToggleReminderActiveResponse)(nil), // 23: todoing.api.v1.ToggleReminderActiveResponse
-	(*CreateTestReminderRequest)(nil),    // 24: todoing.api.v1.CreateTestReminderRequest
-	(*CreateTestReminderResponse)(nil),   // 25: todoing.api.v1.CreateTestReminderResponse
-	(*timestamppb.Timestamp)(nil),        // 26: google.protobuf.Timestamp
-	(*Event)(nil),                        // 27: todoing.api.v1.Event
-	(*Response)(nil),                     // 28: todoing.api.v1.Response
-	(*PaginationRequest)(nil),            // 29: todoing.api.v1.PaginationRequest
-	(*PaginationResponse)(nil),           // 30: todoing.api.v1.PaginationResponse
+	(ReminderType)(0),                      // 0: todoing.api.v1.ReminderType
+	(*Reminder)(nil),                       // 1: todoing.api.v1.Reminder
+	(*ReminderWithEvent)(nil),              // 2: todoing.api.v1.ReminderWithEvent
+	(*CreateReminderRequest)(nil),          // 3: todoing.api.v1.CreateReminderRequest
+	(*CreateReminderResponse)(nil),         // 4: todoing.api.v1.CreateReminderResponse
+	(*GetReminderRequest)(nil),             // 5: todoing.api.v1.GetReminderRequest
+	(*GetReminderResponse)(nil),            // 6: todoing.api.v1.GetReminderResponse
+	(*UpdateReminderRequest)(nil),          // 7: todoing.api.v1.UpdateReminderRequest
+	(*UpdateReminderResponse)(nil),         // 8: todoing.api.v1.UpdateReminderResponse
+	(*DeleteReminderRequest)(nil),          // 9: todoing.api.v1.DeleteReminderRequest
+	(*ListRemindersRequest)(nil),           // 10: todoing.api.v1.ListRemindersRequest
+	(*ListRemindersResponse)(nil),          // 11: todoing.api.v1.ListRemindersResponse
+	(*ListSimpleRemindersRequest)(nil),     // 12: todoing.api.v1.ListSimpleRemindersRequest
+	(*ListSimpleRemindersResponse)(nil),    // 13: todoing.api.v1.ListSimpleRemindersResponse
+	(*UpcomingReminder)(nil),               // 14: todoing.api.v1.UpcomingReminder
+	(*GetUpcomingRemindersRequest)(nil),    // 15: todoing.api.v1.GetUpcomingRemindersRequest
+	(*GetUpcomingRemindersResponse)(nil),   // 16: todoing.api.v1.GetUpcomingRemindersResponse
+	(*PreviewReminderRequest)(nil),         // 17: todoing.api.v1.PreviewReminderRequest
+	(*PreviewReminderItem)(nil),            // 18: todoing.api.v1.PreviewReminderItem
+	(*PreviewReminderResponse)(nil),        // 19: todoing.api.v1.PreviewReminderResponse
+	(*SnoozeReminderRequest)(nil),          // 20: todoing.api.v1.SnoozeReminderRequest
+	(*SnoozeReminderResponse)(nil),         // 21: todoing.api.v1.SnoozeReminderResponse
+	(*ToggleReminderActiveRequest)(nil),    // 22: todoing.api.v1.ToggleReminderActiveRequest
+	(*ToggleReminderActiveResponse)(nil),   // 23: todoing.api.v1.ToggleReminderActiveResponse
+	(*ReminderDelivery)(nil),               // 24: todoing.api.v1.ReminderDelivery
+	(*ListReminderDeliveriesRequest)(nil),  // 25: todoing.api.v1.ListReminderDeliveriesRequest
+	(*ListReminderDeliveriesResponse)(nil), // 26: todoing.api.v1.ListReminderDeliveriesResponse
+	(*CreateTestReminderRequest)(nil),      // 27: todoing.api.v1.CreateTestReminderRequest
+	(*CreateTestReminderResponse)(nil),     // 28: todoing.api.v1.CreateTestReminderResponse
+	(*timestamppb.Timestamp)(nil),          // 29: google.protobuf.Timestamp
+	(*Event)(nil),                          // 30: todoing.api.v1.Event
+	(*Response)(nil),                       // 31: todoing.api.v1.Response
+	(*PaginationRequest)(nil),              // 32: todoing.api.v1.PaginationRequest
+	(*PaginationResponse)(nil),             // 33: todoing.api.v1.PaginationResponse
 }
 var file_reminder_proto_depIdxs = []int32{
-	26, // 0: todoing.api.v1.Reminder.absolute_times:type_name -> google.protobuf.Timestamp
+	29, // 0: todoing.api.v1.Reminder.absolute_times:type_name -> google.protobuf.Timestamp
 	0,  // 1: todoing.api.v1.Reminder.reminder_type:type_name -> todoing.api.v1.ReminderType
-	26, // 2: todoing.api.v1.Reminder.last_sent:type_name -> google.protobuf.Timestamp
-	26, // 3: todoing.api.v1.Reminder.next_send:type_name -> google.protobuf.Timestamp
-	26, // 4: todoing.api.v1.Reminder.created_at:type_name -> google.protobuf.Timestamp
-	26, // 5: todoing.api.v1.Reminder.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 6: todoing.api.v1.ReminderWithEvent.reminder:type_name -> todoing.api.v1.Reminder
-	27, // 7: todoing.api.v1.ReminderWithEvent.event:type_name -> todoing.api.v1.Event
-	26, // 8: todoing.api.v1.CreateReminderRequest.absolute_times:type_name -> google.protobuf.Timestamp
-	0,  // 9: todoing.api.v1.CreateReminderRequest.reminder_type:type_name -> todoing.api.v1.ReminderType
-	28, // 10: todoing.api.v1.CreateReminderResponse.response:type_name -> todoing.api.v1.Response
-	1,  // 11: todoing.api.v1.CreateReminderResponse.reminder:type_name -> todoing.api.v1.Reminder
-	28, // 12: todoing.api.v1.GetReminderResponse.response:type_name -> todoing.api.v1.Response
-	2,  // 13: todoing.api.v1.GetReminderResponse.reminder:type_name -> todoing.api.v1.ReminderWithEvent
-	26, // 14: todoing.api.v1.UpdateReminderRequest.absolute_times:type_name -> google.protobuf.Timestamp
-	0,  // 15: todoing.api.v1.UpdateReminderRequest.reminder_type:type_name -> todoing.api.v1.ReminderType
-	28, // 16: todoing.api.v1.UpdateReminderResponse.response:type_name -> todoing.api.v1.Response
-	1,  // 17: todoing.api.v1.UpdateReminderResponse.reminder:type_name -> todoing.api.v1.Reminder
-	29, // 18: todoing.api.v1.ListRemindersRequest.pagination:type_name -> todoing.api.v1.PaginationRequest
-	28, // 19: todoing.api.v1.ListRemindersResponse.response:type_name -> todoing.api.v1.Response
-	2,  // 20: todoing.api.v1.ListRemindersResponse.reminders:type_name -> todoing.api.v1.ReminderWithEvent
-	30, // 21: todoing.api.v1.ListRemindersResponse.pagination:type_name -> todoing.api.v1.PaginationResponse
-	28, // 22: todoing.api.v1.ListSimpleRemindersResponse.response:type_name -> todoing.api.v1.Response
-	1,  // 23: todoing.api.v1.ListSimpleRemindersResponse.reminders:type_name -> todoing.api.v1.Reminder
-	26, // 24: todoing.api.v1.UpcomingReminder.event_date:type_name -> google.protobuf.Timestamp
-	26, // 25: todoing.api.v1.UpcomingReminder.reminder_at:type_name -> google.protobuf.Timestamp
-	28, // 26: todoing.api.v1.GetUpcomingRemindersResponse.response:type_name -> todoing.api.v1.Response
-	14, // 27: todoing.api.v1.GetUpcomingRemindersResponse.reminders:type_name -> todoing.api.v1.UpcomingReminder
-	26, // 28: todoing.api.v1.PreviewReminderItem.reminder_at:type_name -> google.protobuf.Timestamp
-	28, // 29: todoing.api.v1.PreviewReminderResponse.response:type_name -> todoing.api.v1.Response
-	18, // 30: todoing.api.v1.PreviewReminderResponse.schedule:type_name -> todoing.api.v1.PreviewReminderItem
-	28, // 31: todoing.api.v1.SnoozeReminderResponse.response:type_name -> todoing.api.v1.Response
-	28, // 32: todoing.api.v1.ToggleReminderActiveResponse.response:type_name -> todoing.api.v1.Response
-	28, // 33: todoing.api.v1.CreateTestReminderResponse.response:type_name -> todoing.api.v1.Response
-	1,  // 34: todoing.api.v1.CreateTestReminderResponse.reminder:type_name -> todoing.api.v1.Reminder
-	27, // 35: todoing.api.v1.CreateTestReminderResponse.event:type_name -> todoing.api.v1.Event
-	3,  // 36: todoing.api.v1.ReminderService.CreateReminder:input_type -> todoing.api.v1.CreateReminderRequest
-	5,  // 37: todoing.api.v1.ReminderService.GetReminder:input_type -> todoing.api.v1.GetReminderRequest
-	7,  // 38: todoing.api.v1.ReminderService.UpdateReminder:input_type -> todoing.api.v1.UpdateReminderRequest
-	9,  // 39: todoing.api.v1.ReminderService.DeleteReminder:input_type -> todoing.api.v1.DeleteReminderRequest
-	10, // 40: todoing.api.v1.ReminderService.ListReminders:input_type -> todoing.api.v1.ListRemindersRequest
-	12, // 41: todoing.api.v1.ReminderService.ListSimpleReminders:input_type -> todoing.api.v1.ListSimpleRemindersRequest
-	15, // 42: todoing.api.v1.ReminderService.GetUpcomingReminders:input_type -> todoing.api.v1.GetUpcomingRemindersRequest
-	17, // 43: todoing.api.v1.ReminderService.PreviewReminder:input_type -> todoing.api.v1.PreviewReminderRequest
-	20, // 44: todoing.api.v1.ReminderService.SnoozeReminder:input_type -> todoing.api.v1.SnoozeReminderRequest
-	22, // 45: todoing.api.v1.ReminderService.ToggleReminderActive:input_type -> todoing.api.v1.ToggleReminderActiveRequest
-	24, // 46: todoing.api.v1.ReminderService.CreateTestReminder:input_type -> todoing.api.v1.CreateTestReminderRequest
-	4,  // 47: todoing.api.v1.ReminderService.CreateReminder:output_type -> todoing.api.v1.CreateReminderResponse
-	6,  // 48: todoing.api.v1.ReminderService.GetReminder:output_type -> todoing.api.v1.GetReminderResponse
-	8,  // 49: todoing.api.v1.ReminderService.UpdateReminder:output_type -> todoing.api.v1.UpdateReminderResponse
-	28, // 50: todoing.api.v1.ReminderService.DeleteReminder:output_type -> todoing.api.v1.Response
-	11, // 51: todoing.api.v1.ReminderService.ListReminders:output_type -> todoing.api.v1.ListRemindersResponse
-	13, // 52: todoing.api.v1.ReminderService.ListSimpleReminders:output_type -> todoing.api.v1.ListSimpleRemindersResponse
-	16, // 53: todoing.api.v1.ReminderService.GetUpcomingReminders:output_type -> todoing.api.v1.GetUpcomingRemindersResponse
-	19, // 54: todoing.api.v1.ReminderService.PreviewReminder:output_type -> todoing.api.v1.PreviewReminderResponse
-	21, // 55: todoing.api.v1.ReminderService.SnoozeReminder:output_type -> todoing.api.v1.SnoozeReminderResponse
-	23, // 56: todoing.api.v1.ReminderService.ToggleReminderActive:output_type -> todoing.api.v1.ToggleReminderActiveResponse
-	25, // 57: todoing.api.v1.ReminderService.CreateTestReminder:output_type -> todoing.api.v1.CreateTestReminderResponse
-	47, // [47:58] is the sub-list for method output_type
-	36, // [36:47] is the sub-list for method input_type
-	36, // [36:36] is the sub-list for extension type_name
-	36, // [36:36] is the sub-list for extension extendee
-	0,  // [0:36] is the sub-list for field type_name
+	29, // 2: todoing.api.v1.Reminder.last_sent:type_name -> google.protobuf.Timestamp
+	29, // 3: todoing.api.v1.Reminder.next_send:type_name -> google.protobuf.Timestamp
+	29, // 4: todoing.api.v1.Reminder.created_at:type_name -> google.protobuf.Timestamp
+	29, // 5: todoing.api.v1.Reminder.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 6: todoing.api.v1.Reminder.dead_letter_at:type_name -> google.protobuf.Timestamp
+	1,  // 7: todoing.api.v1.ReminderWithEvent.reminder:type_name -> todoing.api.v1.Reminder
+	30, // 8: todoing.api.v1.ReminderWithEvent.event:type_name -> todoing.api.v1.Event
+	29, // 9: todoing.api.v1.CreateReminderRequest.absolute_times:type_name -> google.protobuf.Timestamp
+	0,  // 10: todoing.api.v1.CreateReminderRequest.reminder_type:type_name -> todoing.api.v1.ReminderType
+	31, // 11: todoing.api.v1.CreateReminderResponse.response:type_name -> todoing.api.v1.Response
+	1,  // 12: todoing.api.v1.CreateReminderResponse.reminder:type_name -> todoing.api.v1.Reminder
+	31, // 13: todoing.api.v1.GetReminderResponse.response:type_name -> todoing.api.v1.Response
+	2,  // 14: todoing.api.v1.GetReminderResponse.reminder:type_name -> todoing.api.v1.ReminderWithEvent
+	29, // 15: todoing.api.v1.UpdateReminderRequest.absolute_times:type_name -> google.protobuf.Timestamp
+	0,  // 16: todoing.api.v1.UpdateReminderRequest.reminder_type:type_name -> todoing.api.v1.ReminderType
+	31, // 17: todoing.api.v1.UpdateReminderResponse.response:type_name -> todoing.api.v1.Response
+	1,  // 18: todoing.api.v1.UpdateReminderResponse.reminder:type_name -> todoing.api.v1.Reminder
+	32, // 19: todoing.api.v1.ListRemindersRequest.pagination:type_name -> todoing.api.v1.PaginationRequest
+	31, // 20: todoing.api.v1.ListRemindersResponse.response:type_name -> todoing.api.v1.Response
+	2,  // 21: todoing.api.v1.ListRemindersResponse.reminders:type_name -> todoing.api.v1.ReminderWithEvent
+	33, // 22: todoing.api.v1.ListRemindersResponse.pagination:type_name -> todoing.api.v1.PaginationResponse
+	31, // 23: todoing.api.v1.ListSimpleRemindersResponse.response:type_name -> todoing.api.v1.Response
+	1,  // 24: todoing.api.v1.ListSimpleRemindersResponse.reminders:type_name -> todoing.api.v1.Reminder
+	29, // 25: todoing.api.v1.UpcomingReminder.event_date:type_name -> google.protobuf.Timestamp
+	29, // 26: todoing.api.v1.UpcomingReminder.reminder_at:type_name -> google.protobuf.Timestamp
+	31, // 27: todoing.api.v1.GetUpcomingRemindersResponse.response:type_name -> todoing.api.v1.Response
+	14, // 28: todoing.api.v1.GetUpcomingRemindersResponse.reminders:type_name -> todoing.api.v1.UpcomingReminder
+	29, // 29: todoing.api.v1.PreviewReminderItem.reminder_at:type_name -> google.protobuf.Timestamp
+	31, // 30: todoing.api.v1.PreviewReminderResponse.response:type_name -> todoing.api.v1.Response
+	18, // 31: todoing.api.v1.PreviewReminderResponse.schedule:type_name -> todoing.api.v1.PreviewReminderItem
+	31, // 32: todoing.api.v1.SnoozeReminderResponse.response:type_name -> todoing.api.v1.Response
+	31, // 33: todoing.api.v1.ToggleReminderActiveResponse.response:type_name -> todoing.api.v1.Response
+	29, // 34: todoing.api.v1.ReminderDelivery.scheduled_for:type_name -> google.protobuf.Timestamp
+	29, // 35: todoing.api.v1.ReminderDelivery.created_at:type_name -> google.protobuf.Timestamp
+	31, // 36: todoing.api.v1.ListReminderDeliveriesResponse.response:type_name -> todoing.api.v1.Response
+	24, // 37: todoing.api.v1.ListReminderDeliveriesResponse.deliveries:type_name -> todoing.api.v1.ReminderDelivery
+	31, // 38: todoing.api.v1.CreateTestReminderResponse.response:type_name -> todoing.api.v1.Response
+	1,  // 39: todoing.api.v1.CreateTestReminderResponse.reminder:type_name -> todoing.api.v1.Reminder
+	30, // 40: todoing.api.v1.CreateTestReminderResponse.event:type_name -> todoing.api.v1.Event
+	3,  // 41: todoing.api.v1.ReminderService.CreateReminder:input_type -> todoing.api.v1.CreateReminderRequest
+	5,  // 42: todoing.api.v1.ReminderService.GetReminder:input_type -> todoing.api.v1.GetReminderRequest
+	7,  // 43: todoing.api.v1.ReminderService.UpdateReminder:input_type -> todoing.api.v1.UpdateReminderRequest
+	9,  // 44: todoing.api.v1.ReminderService.DeleteReminder:input_type -> todoing.api.v1.DeleteReminderRequest
+	10, // 45: todoing.api.v1.ReminderService.ListReminders:input_type -> todoing.api.v1.ListRemindersRequest
+	12, // 46: todoing.api.v1.ReminderService.ListSimpleReminders:input_type -> todoing.api.v1.ListSimpleRemindersRequest
+	15, // 47: todoing.api.v1.ReminderService.GetUpcomingReminders:input_type -> todoing.api.v1.GetUpcomingRemindersRequest
+	17, // 48: todoing.api.v1.ReminderService.PreviewReminder:input_type -> todoing.api.v1.PreviewReminderRequest
+	20, // 49: todoing.api.v1.ReminderService.SnoozeReminder:input_type -> todoing.api.v1.SnoozeReminderRequest
+	22, // 50: todoing.api.v1.ReminderService.ToggleReminderActive:input_type -> todoing.api.v1.ToggleReminderActiveRequest
+	27, // 51: todoing.api.v1.ReminderService.CreateTestReminder:input_type -> todoing.api.v1.CreateTestReminderRequest
+	25, // 52: todoing.api.v1.ReminderService.ListReminderDeliveries:input_type -> todoing.api.v1.ListReminderDeliveriesRequest
+	4,  // 53: todoing.api.v1.ReminderService.CreateReminder:output_type -> todoing.api.v1.CreateReminderResponse
+	6,  // 54: todoing.api.v1.ReminderService.GetReminder:output_type -> todoing.api.v1.GetReminderResponse
+	8,  // 55: todoing.api.v1.ReminderService.UpdateReminder:output_type -> todoing.api.v1.UpdateReminderResponse
+	31, // 56: todoing.api.v1.ReminderService.DeleteReminder:output_type -> todoing.api.v1.Response
+	11, // 57: todoing.api.v1.ReminderService.ListReminders:output_type -> todoing.api.v1.ListRemindersResponse
+	13, // 58: todoing.api.v1.ReminderService.ListSimpleReminders:output_type -> todoing.api.v1.ListSimpleRemindersResponse
+	16, // 59: todoing.api.v1.ReminderService.GetUpcomingReminders:output_type -> todoing.api.v1.GetUpcomingRemindersResponse
+	19, // 60: todoing.api.v1.ReminderService.PreviewReminder:output_type -> todoing.api.v1.PreviewReminderResponse
+	21, // 61: todoing.api.v1.ReminderService.SnoozeReminder:output_type -> todoing.api.v1.SnoozeReminderResponse
+	23, // 62: todoing.api.v1.ReminderService.ToggleReminderActive:output_type -> todoing.api.v1.ToggleReminderActiveResponse
+	28, // 63: todoing.api.v1.ReminderService.CreateTestReminder:output_type -> todoing.api.v1.CreateTestReminderResponse
+	26, // 64: todoing.api.v1.ReminderService.ListReminderDeliveries:output_type -> todoing.api.v1.ListReminderDeliveriesResponse
+	53, // [53:65] is the sub-list for method output_type
+	41, // [41:53] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_reminder_proto_init() }
@@ -1900,7 +2247,7 @@ func file_reminder_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reminder_proto_rawDesc), len(file_reminder_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   25,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -299,6 +299,30 @@ func local_request_ReminderService_CreateTestReminder_0(ctx context.Context, mar
 	return msg, metadata, err
 }
 
+func request_ReminderService_ListReminderDeliveries_0(ctx context.Context, marshaler runtime.Marshaler, client ReminderServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq ListReminderDeliveriesRequest
+		metadata runtime.ServerMetadata
+	)
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	msg, err := client.ListReminderDeliveries(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
+	return msg, metadata, err
+}
+
+func local_request_ReminderService_ListReminderDeliveries_0(ctx context.Context, marshaler runtime.Marshaler, server ReminderServiceServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq ListReminderDeliveriesRequest
+		metadata runtime.ServerMetadata
+	)
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	msg, err := server.ListReminderDeliveries(ctx, &protoReq)
+	return msg, metadata, err
+}
+
 // RegisterReminderServiceHandlerServer registers the http handlers for service ReminderService to "mux".
 // UnaryRPC     :call ReminderServiceServer directly.
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
@@ -525,6 +549,26 @@ func RegisterReminderServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 		}
 		forward_ReminderService_CreateTestReminder_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
+	mux.Handle(http.MethodPost, pattern_ReminderService_ListReminderDeliveries_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/todoing.api.v1.ReminderService/ListReminderDeliveries", runtime.WithHTTPPathPattern("/todoing.api.v1.ReminderService/ListReminderDeliveries"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_ReminderService_ListReminderDeliveries_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_ReminderService_ListReminderDeliveries_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
 
 	return nil
 }
@@ -752,33 +796,52 @@ func RegisterReminderServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 		}
 		forward_ReminderService_CreateTestReminder_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
+	mux.Handle(http.MethodPost, pattern_ReminderService_ListReminderDeliveries_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/todoing.api.v1.ReminderService/ListReminderDeliveries", runtime.WithHTTPPathPattern("/todoing.api.v1.ReminderService/ListReminderDeliveries"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := request_ReminderService_ListReminderDeliveries_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_ReminderService_ListReminderDeliveries_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
 	return nil
 }
 
 var (
-	pattern_ReminderService_CreateReminder_0       = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "CreateReminder"}, ""))
-	pattern_ReminderService_GetReminder_0          = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "GetReminder"}, ""))
-	pattern_ReminderService_UpdateReminder_0       = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "UpdateReminder"}, ""))
-	pattern_ReminderService_DeleteReminder_0       = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "DeleteReminder"}, ""))
-	pattern_ReminderService_ListReminders_0        = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "ListReminders"}, ""))
-	pattern_ReminderService_ListSimpleReminders_0  = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "ListSimpleReminders"}, ""))
-	pattern_ReminderService_GetUpcomingReminders_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "GetUpcomingReminders"}, ""))
-	pattern_ReminderService_PreviewReminder_0      = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "PreviewReminder"}, ""))
-	pattern_ReminderService_SnoozeReminder_0       = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "SnoozeReminder"}, ""))
-	pattern_ReminderService_ToggleReminderActive_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "ToggleReminderActive"}, ""))
-	pattern_ReminderService_CreateTestReminder_0   = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "CreateTestReminder"}, ""))
+	pattern_ReminderService_CreateReminder_0         = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "CreateReminder"}, ""))
+	pattern_ReminderService_GetReminder_0            = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "GetReminder"}, ""))
+	pattern_ReminderService_UpdateReminder_0         = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "UpdateReminder"}, ""))
+	pattern_ReminderService_DeleteReminder_0         = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "DeleteReminder"}, ""))
+	pattern_ReminderService_ListReminders_0          = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "ListReminders"}, ""))
+	pattern_ReminderService_ListSimpleReminders_0    = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "ListSimpleReminders"}, ""))
+	pattern_ReminderService_GetUpcomingReminders_0   = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "GetUpcomingReminders"}, ""))
+	pattern_ReminderService_PreviewReminder_0        = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "PreviewReminder"}, ""))
+	pattern_ReminderService_SnoozeReminder_0         = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "SnoozeReminder"}, ""))
+	pattern_ReminderService_ToggleReminderActive_0   = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "ToggleReminderActive"}, ""))
+	pattern_ReminderService_CreateTestReminder_0     = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "CreateTestReminder"}, ""))
+	pattern_ReminderService_ListReminderDeliveries_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"todoing.api.v1.ReminderService", "ListReminderDeliveries"}, ""))
 )
 
 var (
-	forward_ReminderService_CreateReminder_0       = runtime.ForwardResponseMessage
-	forward_ReminderService_GetReminder_0          = runtime.ForwardResponseMessage
-	forward_ReminderService_UpdateReminder_0       = runtime.ForwardResponseMessage
-	forward_ReminderService_DeleteReminder_0       = runtime.ForwardResponseMessage
-	forward_ReminderService_ListReminders_0        = runtime.ForwardResponseMessage
-	forward_ReminderService_ListSimpleReminders_0  = runtime.ForwardResponseMessage
-	forward_ReminderService_GetUpcomingReminders_0 = runtime.ForwardResponseMessage
-	forward_ReminderService_PreviewReminder_0      = runtime.ForwardResponseMessage
-	forward_ReminderService_SnoozeReminder_0       = runtime.ForwardResponseMessage
-	forward_ReminderService_ToggleReminderActive_0 = runtime.ForwardResponseMessage
-	forward_ReminderService_CreateTestReminder_0   = runtime.ForwardResponseMessage
+	forward_ReminderService_CreateReminder_0         = runtime.ForwardResponseMessage
+	forward_ReminderService_GetReminder_0            = runtime.ForwardResponseMessage
+	forward_ReminderService_UpdateReminder_0         = runtime.ForwardResponseMessage
+	forward_ReminderService_DeleteReminder_0         = runtime.ForwardResponseMessage
+	forward_ReminderService_ListReminders_0          = runtime.ForwardResponseMessage
+	forward_ReminderService_ListSimpleReminders_0    = runtime.ForwardResponseMessage
+	forward_ReminderService_GetUpcomingReminders_0   = runtime.ForwardResponseMessage
+	forward_ReminderService_PreviewReminder_0        = runtime.ForwardResponseMessage
+	forward_ReminderService_SnoozeReminder_0         = runtime.ForwardResponseMessage
+	forward_ReminderService_ToggleReminderActive_0   = runtime.ForwardResponseMessage
+	forward_ReminderService_CreateTestReminder_0     = runtime.ForwardResponseMessage
+	forward_ReminderService_ListReminderDeliveries_0 = runtime.ForwardResponseMessage
 )
@@ -19,17 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ReminderService_CreateReminder_FullMethodName       = "/todoing.api.v1.ReminderService/CreateReminder"
-	ReminderService_GetReminder_FullMethodName          = "/todoing.api.v1.ReminderService/GetReminder"
-	ReminderService_UpdateReminder_FullMethodName       = "/todoing.api.v1.ReminderService/UpdateReminder"
-	ReminderService_DeleteReminder_FullMethodName       = "/todoing.api.v1.ReminderService/DeleteReminder"
-	ReminderService_ListReminders_FullMethodName        = "/todoing.api.v1.ReminderService/ListReminders"
-	ReminderService_ListSimpleReminders_FullMethodName  = "/todoing.api.v1.ReminderService/ListSimpleReminders"
-	ReminderService_GetUpcomingReminders_FullMethodName = "/todoing.api.v1.ReminderService/GetUpcomingReminders"
-	ReminderService_PreviewReminder_FullMethodName      = "/todoing.api.v1.ReminderService/PreviewReminder"
-	ReminderService_SnoozeReminder_FullMethodName       = "/todoing.api.v1.ReminderService/SnoozeReminder"
-	ReminderService_ToggleReminderActive_FullMethodName = "/todoing.api.v1.ReminderService/ToggleReminderActive"
-	ReminderService_CreateTestReminder_FullMethodName   = "/todoing.api.v1.ReminderService/CreateTestReminder"
+	ReminderService_CreateReminder_FullMethodName         = "/todoing.api.v1.ReminderService/CreateReminder"
+	ReminderService_GetReminder_FullMethodName            = "/todoing.api.v1.ReminderService/GetReminder"
+	ReminderService_UpdateReminder_FullMethodName         = "/todoing.api.v1.ReminderService/UpdateReminder"
+	ReminderService_DeleteReminder_FullMethodName         = "/todoing.api.v1.ReminderService/DeleteReminder"
+	ReminderService_ListReminders_FullMethodName          = "/todoing.api.v1.ReminderService/ListReminders"
+	ReminderService_ListSimpleReminders_FullMethodName    = "/todoing.api.v1.ReminderService/ListSimpleReminders"
+	ReminderService_GetUpcomingReminders_FullMethodName   = "/todoing.api.v1.ReminderService/GetUpcomingReminders"
+	ReminderService_PreviewReminder_FullMethodName        = "/todoing.api.v1.ReminderService/PreviewReminder"
+	ReminderService_SnoozeReminder_FullMethodName         = "/todoing.api.v1.ReminderService/SnoozeReminder"
+	ReminderService_ToggleReminderActive_FullMethodName   = "/todoing.api.v1.ReminderService/ToggleReminderActive"
+	ReminderService_CreateTestReminder_FullMethodName     = "/todoing.api.v1.ReminderService/CreateTestReminder"
+	ReminderService_ListReminderDeliveries_FullMethodName = "/todoing.api.v1.ReminderService/ListReminderDeliveries"
 )
 
 // ReminderServiceClient is the client API for ReminderService service.
@@ -47,6 +48,7 @@ type ReminderServiceClient interface {
 	SnoozeReminder(ctx context.Context, in *SnoozeReminderRequest, opts ...grpc.CallOption) (*SnoozeReminderResponse, error)
 	ToggleReminderActive(ctx context.Context, in *ToggleReminderActiveRequest, opts ...grpc.CallOption) (*ToggleReminderActiveResponse, error)
 	CreateTestReminder(ctx context.Context, in *CreateTestReminderRequest, opts ...grpc.CallOption) (*CreateTestReminderResponse, error)
+	ListReminderDeliveries(ctx context.Context, in *ListReminderDeliveriesRequest, opts ...grpc.CallOption) (*ListReminderDeliveriesResponse, error)
 }
 
 type reminderServiceClient struct {
@@ -167,6 +169,16 @@ func (c *reminderServiceClient) CreateTestReminder(ctx context.Context, in *Crea
 	return out, nil
 }
 
+func (c *reminderServiceClient) ListReminderDeliveries(ctx context.Context, in *ListReminderDeliveriesRequest, opts ...grpc.CallOption) (*ListReminderDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReminderDeliveriesResponse)
+	err := c.cc.Invoke(ctx, ReminderService_ListReminderDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReminderServiceServer is the server API for ReminderService service.
 // All implementations must embed UnimplementedReminderServiceServer
 // for forward compatibility.
@@ -182,6 +194,7 @@ type ReminderServiceServer interface {
 	SnoozeReminder(context.Context, *SnoozeReminderRequest) (*SnoozeReminderResponse, error)
 	ToggleReminderActive(context.Context, *ToggleReminderActiveRequest) (*ToggleReminderActiveResponse, error)
 	CreateTestReminder(context.Context, *CreateTestReminderRequest) (*CreateTestReminderResponse, error)
+	ListReminderDeliveries(context.Context, *ListReminderDeliveriesRequest) (*ListReminderDeliveriesResponse, error)
 	mustEmbedUnimplementedReminderServiceServer()
 }
 
@@ -225,6 +238,9 @@ func (UnimplementedReminderServiceServer) ToggleReminderActive(context.Context,
 func (UnimplementedReminderServiceServer) CreateTestReminder(context.Context, *CreateTestReminderRequest) (*CreateTestReminderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateTestReminder not implemented")
 }
+func (UnimplementedReminderServiceServer) ListReminderDeliveries(context.Context, *ListReminderDeliveriesRequest) (*ListReminderDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReminderDeliveries not implemented")
+}
 func (UnimplementedReminderServiceServer) mustEmbedUnimplementedReminderServiceServer() {}
 func (UnimplementedReminderServiceServer) testEmbeddedByValue()                         {}
 
@@ -444,6 +460,24 @@ func _ReminderService_CreateTestReminder_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReminderService_ListReminderDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReminderDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReminderServiceServer).ListReminderDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReminderService_ListReminderDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReminderServiceServer).ListReminderDeliveries(ctx, req.(*ListReminderDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ReminderService_ServiceDesc is the grpc.ServiceDesc for ReminderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -495,6 +529,10 @@ var ReminderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateTestReminder",
 			Handler:    _ReminderService_CreateTestReminder_Handler,
 		},
+		{
+			MethodName: "ListReminderDeliveries",
+			Handler:    _ReminderService_ListReminderDeliveries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "reminder.proto",
@@ -128,6 +128,59 @@ func (TaskPriority) EnumDescriptor() ([]byte, []int) {
 	return file_task_proto_rawDescGZIP(), []int{1}
 }
 
+// 任务列表视图
+type TaskView int32
+
+const (
+	TaskView_TASK_VIEW_ALL            TaskView = 0
+	TaskView_TASK_VIEW_ASSIGNED_TO_ME TaskView = 1
+	TaskView_TASK_VIEW_CREATED_BY_ME  TaskView = 2
+	TaskView_TASK_VIEW_UNASSIGNED     TaskView = 3
+)
+
+// Enum value maps for TaskView.
+var (
+	TaskView_name = map[int32]string{
+		0: "TASK_VIEW_ALL",
+		1: "TASK_VIEW_ASSIGNED_TO_ME",
+		2: "TASK_VIEW_CREATED_BY_ME",
+		3: "TASK_VIEW_UNASSIGNED",
+	}
+	TaskView_value = map[string]int32{
+		"TASK_VIEW_ALL":            0,
+		"TASK_VIEW_ASSIGNED_TO_ME": 1,
+		"TASK_VIEW_CREATED_BY_ME":  2,
+		"TASK_VIEW_UNASSIGNED":     3,
+	}
+)
+
+func (x TaskView) Enum() *TaskView {
+	p := new(TaskView)
+	*p = x
+	return p
+}
+
+func (x TaskView) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskView) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskView) Type() protoreflect.EnumType {
+	return &file_task_proto_enumTypes[2]
+}
+
+func (x TaskView) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskView.Descriptor instead.
+func (TaskView) EnumDescriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{2}
+}
+
 // 任务评论
 type TaskComment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -189,29 +242,167 @@ func (x *TaskComment) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// 任务模型
-type Task struct {
+// 检查项 (轻量，不是独立任务)
+type ChecklistItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Status        TaskStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=todoing.api.v1.TaskStatus" json:"status,omitempty"`
-	Priority      TaskPriority           `protobuf:"varint,5,opt,name=priority,proto3,enum=todoing.api.v1.TaskPriority" json:"priority,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"` // deprecated: 旧字段，对应后端 deadline，保留兼容
-	UserId        string                 `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ScheduledDate *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=scheduled_date,json=scheduledDate,proto3" json:"scheduled_date,omitempty"` // scheduledDate
-	Deadline      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=deadline,proto3" json:"deadline,omitempty"`                                // 真正截止日期
-	Assignee      string                 `protobuf:"bytes,12,opt,name=assignee,proto3" json:"assignee,omitempty"`                                // 新增: 与后端 Assignee 对齐（可能为空）
-	Comments      []*TaskComment         `protobuf:"bytes,13,rep,name=comments,proto3" json:"comments,omitempty"`                                // 新增: 评论列表
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 新建时可为空
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Done          bool                   `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	DoneAt        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=done_at,json=doneAt,proto3" json:"done_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecklistItem) Reset() {
+	*x = ChecklistItem{}
+	mi := &file_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecklistItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecklistItem) ProtoMessage() {}
+
+func (x *ChecklistItem) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecklistItem.ProtoReflect.Descriptor instead.
+func (*ChecklistItem) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChecklistItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChecklistItem) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChecklistItem) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ChecklistItem) GetDoneAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DoneAt
+	}
+	return nil
+}
+
+// 子任务与检查项完成进度 (done / total)
+type TaskProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Done          int32                  `protobuf:"varint,1,opt,name=done,proto3" json:"done,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *TaskProgress) Reset() {
+	*x = TaskProgress{}
+	mi := &file_task_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskProgress) ProtoMessage() {}
+
+func (x *TaskProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
+func (*TaskProgress) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaskProgress) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// 任务模型
+type Task struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status          TaskStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=todoing.api.v1.TaskStatus" json:"status,omitempty"`
+	Priority        TaskPriority           `protobuf:"varint,5,opt,name=priority,proto3,enum=todoing.api.v1.TaskPriority" json:"priority,omitempty"`
+	DueDate         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"` // deprecated: 旧字段，对应后端 deadline，保留兼容
+	UserId          string                 `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ScheduledDate   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=scheduled_date,json=scheduledDate,proto3" json:"scheduled_date,omitempty"` // scheduledDate
+	Deadline        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=deadline,proto3" json:"deadline,omitempty"`                                // 真正截止日期
+	Assignee        string                 `protobuf:"bytes,12,opt,name=assignee,proto3" json:"assignee,omitempty"`                                // 新增: 与后端 Assignee 对齐（可能为空）
+	Comments        []*TaskComment         `protobuf:"bytes,13,rep,name=comments,proto3" json:"comments,omitempty"`                                // 新增: 评论列表
+	ParentId        string                 `protobuf:"bytes,14,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`                // 父任务 ID (子任务)
+	Checklist       []*ChecklistItem       `protobuf:"bytes,15,rep,name=checklist,proto3" json:"checklist,omitempty"`
+	AutoComplete    bool                   `protobuf:"varint,16,opt,name=auto_complete,json=autoComplete,proto3" json:"auto_complete,omitempty"` // 子任务与检查项全部完成时自动完成
+	Progress        *TaskProgress          `protobuf:"bytes,17,opt,name=progress,proto3" json:"progress,omitempty"`
+	SubtaskDeadline *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=subtask_deadline,json=subtaskDeadline,proto3" json:"subtask_deadline,omitempty"` // 未完成子任务中最早的截止日期
+	Subtasks        []*Task                `protobuf:"bytes,19,rep,name=subtasks,proto3" json:"subtasks,omitempty"`                                      // 子任务层级 (仅 GetTask 返回)
+	BlockedBy       []string               `protobuf:"bytes,20,rep,name=blocked_by,json=blockedBy,proto3" json:"blocked_by,omitempty"`                   // 阻塞本任务的任务 ID
+	Blocked         bool                   `protobuf:"varint,21,opt,name=blocked,proto3" json:"blocked,omitempty"`                                       // 存在未完成的阻塞任务
+	UnblockedAt     *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=unblocked_at,json=unblockedAt,proto3" json:"unblocked_at,omitempty"`             // 最近一次解除阻塞的时间
+	Recurrence      string                 `protobuf:"bytes,23,opt,name=recurrence,proto3" json:"recurrence,omitempty"`                                  // RRULE，完成后生成下一个实例
+	SeriesId        string                 `protobuf:"bytes,24,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`                      // 循环系列首个实例 ID
+	PreviousId      string                 `protobuf:"bytes,25,opt,name=previous_id,json=previousId,proto3" json:"previous_id,omitempty"`                // 上一个实例
+	NextId          string                 `protobuf:"bytes,26,opt,name=next_id,json=nextId,proto3" json:"next_id,omitempty"`                            // 完成后生成的下一个实例
+	WorkspaceId     string                 `protobuf:"bytes,27,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`             // 所属工作区，空为个人任务
+	Tags            []string               `protobuf:"bytes,28,rep,name=tags,proto3" json:"tags,omitempty"`
+	CustomFields    map[string]string      `protobuf:"bytes,29,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 自定义字段取值 (数字为十进制文本，日期为 RFC3339)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
 func (x *Task) Reset() {
 	*x = Task{}
-	mi := &file_task_proto_msgTypes[1]
+	mi := &file_task_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -223,7 +414,7 @@ func (x *Task) String() string {
 func (*Task) ProtoMessage() {}
 
 func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[1]
+	mi := &file_task_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -236,7 +427,7 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task.ProtoReflect.Descriptor instead.
 func (*Task) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{1}
+	return file_task_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Task) GetId() string {
@@ -330,6 +521,118 @@ func (x *Task) GetComments() []*TaskComment {
 	return nil
 }
 
+func (x *Task) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Task) GetChecklist() []*ChecklistItem {
+	if x != nil {
+		return x.Checklist
+	}
+	return nil
+}
+
+func (x *Task) GetAutoComplete() bool {
+	if x != nil {
+		return x.AutoComplete
+	}
+	return false
+}
+
+func (x *Task) GetProgress() *TaskProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *Task) GetSubtaskDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubtaskDeadline
+	}
+	return nil
+}
+
+func (x *Task) GetSubtasks() []*Task {
+	if x != nil {
+		return x.Subtasks
+	}
+	return nil
+}
+
+func (x *Task) GetBlockedBy() []string {
+	if x != nil {
+		return x.BlockedBy
+	}
+	return nil
+}
+
+func (x *Task) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *Task) GetUnblockedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnblockedAt
+	}
+	return nil
+}
+
+func (x *Task) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *Task) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *Task) GetPreviousId() string {
+	if x != nil {
+		return x.PreviousId
+	}
+	return ""
+}
+
+func (x *Task) GetNextId() string {
+	if x != nil {
+		return x.NextId
+	}
+	return ""
+}
+
+func (x *Task) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *Task) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Task) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
 // 创建任务请求
 type CreateTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -340,13 +643,20 @@ type CreateTaskRequest struct {
 	Deadline      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deadline,proto3" json:"deadline,omitempty"` // 使用统一字段
 	ScheduledDate *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=scheduled_date,json=scheduledDate,proto3" json:"scheduled_date,omitempty"`
 	Assignee      string                 `protobuf:"bytes,7,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	ParentId      string                 `protobuf:"bytes,8,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Checklist     []*ChecklistItem       `protobuf:"bytes,9,rep,name=checklist,proto3" json:"checklist,omitempty"`
+	AutoComplete  bool                   `protobuf:"varint,10,opt,name=auto_complete,json=autoComplete,proto3" json:"auto_complete,omitempty"`
+	Recurrence    string                 `protobuf:"bytes,11,opt,name=recurrence,proto3" json:"recurrence,omitempty"`                      // RRULE，如 FREQ=WEEKLY;BYDAY=MO
+	WorkspaceId   string                 `protobuf:"bytes,12,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"` // 所属工作区 (需 editor 角色)；子任务沿用父任务的工作区
+	Tags          []string               `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	CustomFields  map[string]string      `protobuf:"bytes,14,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 按所属范围的字段定义校验
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateTaskRequest) Reset() {
 	*x = CreateTaskRequest{}
-	mi := &file_task_proto_msgTypes[2]
+	mi := &file_task_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -358,7 +668,7 @@ func (x *CreateTaskRequest) String() string {
 func (*CreateTaskRequest) ProtoMessage() {}
 
 func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[2]
+	mi := &file_task_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +681,7 @@ func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
 func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{2}
+	return file_task_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateTaskRequest) GetTitle() string {
@@ -423,6 +733,55 @@ func (x *CreateTaskRequest) GetAssignee() string {
 	return ""
 }
 
+func (x *CreateTaskRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetChecklist() []*ChecklistItem {
+	if x != nil {
+		return x.Checklist
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetAutoComplete() bool {
+	if x != nil {
+		return x.AutoComplete
+	}
+	return false
+}
+
+func (x *CreateTaskRequest) GetRecurrence() string {
+	if x != nil {
+		return x.Recurrence
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
 // 创建任务响应
 type CreateTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -434,7 +793,7 @@ type CreateTaskResponse struct {
 
 func (x *CreateTaskResponse) Reset() {
 	*x = CreateTaskResponse{}
-	mi := &file_task_proto_msgTypes[3]
+	mi := &file_task_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -446,7 +805,7 @@ func (x *CreateTaskResponse) String() string {
 func (*CreateTaskResponse) ProtoMessage() {}
 
 func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[3]
+	mi := &file_task_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -459,7 +818,7 @@ func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
 func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{3}
+	return file_task_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CreateTaskResponse) GetResponse() *Response {
@@ -483,13 +842,18 @@ type GetTasksRequest struct {
 	Status        TaskStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=todoing.api.v1.TaskStatus" json:"status,omitempty"`
 	Priority      TaskPriority           `protobuf:"varint,3,opt,name=priority,proto3,enum=todoing.api.v1.TaskPriority" json:"priority,omitempty"`
 	ActiveOnly    bool                   `protobuf:"varint,4,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"` // 预留
+	View          TaskView               `protobuf:"varint,5,opt,name=view,proto3,enum=todoing.api.v1.TaskView" json:"view,omitempty"`
+	Query         string                 `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`                                  // 过滤表达式，如 priority:High due:<7d -status:Done assignee:me
+	Sort          string                 `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`                                    // 排序，逗号分隔，- 前缀降序，如 -priority,due
+	SavedViewId   string                 `protobuf:"bytes,8,opt,name=saved_view_id,json=savedViewId,proto3" json:"saved_view_id,omitempty"` // 保存的视图，与 query 取且，sort 覆盖视图排序
+	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`                                    // 同时包含全部标签
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTasksRequest) Reset() {
 	*x = GetTasksRequest{}
-	mi := &file_task_proto_msgTypes[4]
+	mi := &file_task_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -501,7 +865,7 @@ func (x *GetTasksRequest) String() string {
 func (*GetTasksRequest) ProtoMessage() {}
 
 func (x *GetTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[4]
+	mi := &file_task_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +878,7 @@ func (x *GetTasksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTasksRequest.ProtoReflect.Descriptor instead.
 func (*GetTasksRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{4}
+	return file_task_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetTasksRequest) GetPagination() *PaginationRequest {
@@ -545,6 +909,41 @@ func (x *GetTasksRequest) GetActiveOnly() bool {
 	return false
 }
 
+func (x *GetTasksRequest) GetView() TaskView {
+	if x != nil {
+		return x.View
+	}
+	return TaskView_TASK_VIEW_ALL
+}
+
+func (x *GetTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetTasksRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GetTasksRequest) GetSavedViewId() string {
+	if x != nil {
+		return x.SavedViewId
+	}
+	return ""
+}
+
+func (x *GetTasksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 // 获取任务列表响应
 type GetTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -557,7 +956,7 @@ type GetTasksResponse struct {
 
 func (x *GetTasksResponse) Reset() {
 	*x = GetTasksResponse{}
-	mi := &file_task_proto_msgTypes[5]
+	mi := &file_task_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -569,7 +968,7 @@ func (x *GetTasksResponse) String() string {
 func (*GetTasksResponse) ProtoMessage() {}
 
 func (x *GetTasksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[5]
+	mi := &file_task_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +981,7 @@ func (x *GetTasksResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTasksResponse.ProtoReflect.Descriptor instead.
 func (*GetTasksResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{5}
+	return file_task_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetTasksResponse) GetResponse() *Response {
@@ -616,7 +1015,7 @@ type GetTaskRequest struct {
 
 func (x *GetTaskRequest) Reset() {
 	*x = GetTaskRequest{}
-	mi := &file_task_proto_msgTypes[6]
+	mi := &file_task_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -628,7 +1027,7 @@ func (x *GetTaskRequest) String() string {
 func (*GetTaskRequest) ProtoMessage() {}
 
 func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[6]
+	mi := &file_task_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -641,7 +1040,7 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
 func (*GetTaskRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{6}
+	return file_task_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetTaskRequest) GetId() string {
@@ -662,7 +1061,7 @@ type GetTaskResponse struct {
 
 func (x *GetTaskResponse) Reset() {
 	*x = GetTaskResponse{}
-	mi := &file_task_proto_msgTypes[7]
+	mi := &file_task_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -674,7 +1073,7 @@ func (x *GetTaskResponse) String() string {
 func (*GetTaskResponse) ProtoMessage() {}
 
 func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[7]
+	mi := &file_task_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -687,7 +1086,7 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
 func (*GetTaskResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{7}
+	return file_task_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetTaskResponse) GetResponse() *Response {
@@ -714,15 +1113,21 @@ type UpdateTaskRequest struct {
 	Priority      TaskPriority           `protobuf:"varint,5,opt,name=priority,proto3,enum=todoing.api.v1.TaskPriority" json:"priority,omitempty"`
 	Deadline      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
 	ScheduledDate *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=scheduled_date,json=scheduledDate,proto3" json:"scheduled_date,omitempty"`
-	Assignee      string                 `protobuf:"bytes,8,opt,name=assignee,proto3" json:"assignee,omitempty"`
-	Comments      []*TaskComment         `protobuf:"bytes,9,rep,name=comments,proto3" json:"comments,omitempty"` // 全量替换
+	Assignee      *string                `protobuf:"bytes,8,opt,name=assignee,proto3,oneof" json:"assignee,omitempty"` // 用户 ID、用户名或邮箱；空字符串取消分配
+	Comments      []*TaskComment         `protobuf:"bytes,9,rep,name=comments,proto3" json:"comments,omitempty"`       // 全量替换
+	Checklist     []*ChecklistItem       `protobuf:"bytes,10,rep,name=checklist,proto3" json:"checklist,omitempty"`    // 非空时全量替换
+	AutoComplete  *bool                  `protobuf:"varint,11,opt,name=auto_complete,json=autoComplete,proto3,oneof" json:"auto_complete,omitempty"`
+	Recurrence    *string                `protobuf:"bytes,12,opt,name=recurrence,proto3,oneof" json:"recurrence,omitempty"`                                                                                             // 空字符串停止循环
+	Tags          []string               `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`                                                                                                               // 非空时全量替换
+	ClearTags     bool                   `protobuf:"varint,14,opt,name=clear_tags,json=clearTags,proto3" json:"clear_tags,omitempty"`                                                                                   // 清空标签
+	CustomFields  map[string]string      `protobuf:"bytes,15,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 与现有取值合并，空字符串删除该字段
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateTaskRequest) Reset() {
 	*x = UpdateTaskRequest{}
-	mi := &file_task_proto_msgTypes[8]
+	mi := &file_task_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -734,7 +1139,7 @@ func (x *UpdateTaskRequest) String() string {
 func (*UpdateTaskRequest) ProtoMessage() {}
 
 func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[8]
+	mi := &file_task_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -747,7 +1152,7 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{8}
+	return file_task_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UpdateTaskRequest) GetId() string {
@@ -800,8 +1205,8 @@ func (x *UpdateTaskRequest) GetScheduledDate() *timestamppb.Timestamp {
 }
 
 func (x *UpdateTaskRequest) GetAssignee() string {
-	if x != nil {
-		return x.Assignee
+	if x != nil && x.Assignee != nil {
+		return *x.Assignee
 	}
 	return ""
 }
@@ -813,6 +1218,48 @@ func (x *UpdateTaskRequest) GetComments() []*TaskComment {
 	return nil
 }
 
+func (x *UpdateTaskRequest) GetChecklist() []*ChecklistItem {
+	if x != nil {
+		return x.Checklist
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetAutoComplete() bool {
+	if x != nil && x.AutoComplete != nil {
+		return *x.AutoComplete
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetRecurrence() string {
+	if x != nil && x.Recurrence != nil {
+		return *x.Recurrence
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetClearTags() bool {
+	if x != nil {
+		return x.ClearTags
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetCustomFields() map[string]string {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
 // 更新任务响应
 type UpdateTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -824,7 +1271,7 @@ type UpdateTaskResponse struct {
 
 func (x *UpdateTaskResponse) Reset() {
 	*x = UpdateTaskResponse{}
-	mi := &file_task_proto_msgTypes[9]
+	mi := &file_task_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -836,7 +1283,7 @@ func (x *UpdateTaskResponse) String() string {
 func (*UpdateTaskResponse) ProtoMessage() {}
 
 func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[9]
+	mi := &file_task_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -849,7 +1296,7 @@ func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
 func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{9}
+	return file_task_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateTaskResponse) GetResponse() *Response {
@@ -876,7 +1323,7 @@ type DeleteTaskRequest struct {
 
 func (x *DeleteTaskRequest) Reset() {
 	*x = DeleteTaskRequest{}
-	mi := &file_task_proto_msgTypes[10]
+	mi := &file_task_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -888,7 +1335,7 @@ func (x *DeleteTaskRequest) String() string {
 func (*DeleteTaskRequest) ProtoMessage() {}
 
 func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[10]
+	mi := &file_task_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -901,7 +1348,7 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{10}
+	return file_task_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteTaskRequest) GetId() string {
@@ -911,20 +1358,187 @@ func (x *DeleteTaskRequest) GetId() string {
 	return ""
 }
 
-// 优先任务 (Dashboard, Unified 使用)
-type PriorityTask struct {
+// 循环任务历史：系列中的全部实例 (按创建时间排序)
+type GetTaskHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	SeriesId      string                 `protobuf:"bytes,2,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	Tasks         []*Task                `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskHistoryResponse) Reset() {
+	*x = GetTaskHistoryResponse{}
+	mi := &file_task_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskHistoryResponse) ProtoMessage() {}
+
+func (x *GetTaskHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTaskHistoryResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GetTaskHistoryResponse) GetSeriesId() string {
+	if x != nil {
+		return x.SeriesId
+	}
+	return ""
+}
+
+func (x *GetTaskHistoryResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// 任务依赖：blocker_id 完成前 task_id 处于阻塞状态
+type TaskDependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	BlockerId     string                 `protobuf:"bytes,2,opt,name=blocker_id,json=blockerId,proto3" json:"blocker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskDependencyRequest) Reset() {
+	*x = TaskDependencyRequest{}
+	mi := &file_task_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDependencyRequest) ProtoMessage() {}
+
+func (x *TaskDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDependencyRequest.ProtoReflect.Descriptor instead.
+func (*TaskDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TaskDependencyRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskDependencyRequest) GetBlockerId() string {
+	if x != nil {
+		return x.BlockerId
+	}
+	return ""
+}
+
+type TaskDependencyResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
-	PriorityScore float64                `protobuf:"fixed64,2,opt,name=priority_score,json=priorityScore,proto3" json:"priority_score,omitempty"`
-	DaysLeft      int32                  `protobuf:"varint,3,opt,name=days_left,json=daysLeft,proto3" json:"days_left,omitempty"`
-	IsUrgent      bool                   `protobuf:"varint,4,opt,name=is_urgent,json=isUrgent,proto3" json:"is_urgent,omitempty"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *TaskDependencyResponse) Reset() {
+	*x = TaskDependencyResponse{}
+	mi := &file_task_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDependencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDependencyResponse) ProtoMessage() {}
+
+func (x *TaskDependencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDependencyResponse.ProtoReflect.Descriptor instead.
+func (*TaskDependencyResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TaskDependencyResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *TaskDependencyResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// 优先任务 (Dashboard, Unified 使用)
+type PriorityTask struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Task           *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	PriorityScore  float64                `protobuf:"fixed64,2,opt,name=priority_score,json=priorityScore,proto3" json:"priority_score,omitempty"`
+	DaysLeft       int32                  `protobuf:"varint,3,opt,name=days_left,json=daysLeft,proto3" json:"days_left,omitempty"`
+	IsUrgent       bool                   `protobuf:"varint,4,opt,name=is_urgent,json=isUrgent,proto3" json:"is_urgent,omitempty"`
+	NewlyUnblocked bool                   `protobuf:"varint,5,opt,name=newly_unblocked,json=newlyUnblocked,proto3" json:"newly_unblocked,omitempty"` // 阻塞任务刚完成
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *PriorityTask) Reset() {
 	*x = PriorityTask{}
-	mi := &file_task_proto_msgTypes[11]
+	mi := &file_task_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -936,7 +1550,7 @@ func (x *PriorityTask) String() string {
 func (*PriorityTask) ProtoMessage() {}
 
 func (x *PriorityTask) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[11]
+	mi := &file_task_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,7 +1563,7 @@ func (x *PriorityTask) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PriorityTask.ProtoReflect.Descriptor instead.
 func (*PriorityTask) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{11}
+	return file_task_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *PriorityTask) GetTask() *Task {
@@ -980,6 +1594,13 @@ func (x *PriorityTask) GetIsUrgent() bool {
 	return false
 }
 
+func (x *PriorityTask) GetNewlyUnblocked() bool {
+	if x != nil {
+		return x.NewlyUnblocked
+	}
+	return false
+}
+
 // 任务排序配置
 type TaskSortConfig struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -996,7 +1617,7 @@ type TaskSortConfig struct {
 
 func (x *TaskSortConfig) Reset() {
 	*x = TaskSortConfig{}
-	mi := &file_task_proto_msgTypes[12]
+	mi := &file_task_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1008,7 +1629,7 @@ func (x *TaskSortConfig) String() string {
 func (*TaskSortConfig) ProtoMessage() {}
 
 func (x *TaskSortConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[12]
+	mi := &file_task_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1021,7 +1642,7 @@ func (x *TaskSortConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskSortConfig.ProtoReflect.Descriptor instead.
 func (*TaskSortConfig) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{12}
+	return file_task_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *TaskSortConfig) GetUserId() string {
@@ -1086,7 +1707,7 @@ type UpdateTaskSortConfigRequest struct {
 
 func (x *UpdateTaskSortConfigRequest) Reset() {
 	*x = UpdateTaskSortConfigRequest{}
-	mi := &file_task_proto_msgTypes[13]
+	mi := &file_task_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1098,7 +1719,7 @@ func (x *UpdateTaskSortConfigRequest) String() string {
 func (*UpdateTaskSortConfigRequest) ProtoMessage() {}
 
 func (x *UpdateTaskSortConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[13]
+	mi := &file_task_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1111,7 +1732,7 @@ func (x *UpdateTaskSortConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskSortConfigRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTaskSortConfigRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{13}
+	return file_task_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *UpdateTaskSortConfigRequest) GetPriorityDays() int32 {
@@ -1152,7 +1773,7 @@ type UpdateTaskSortConfigResponse struct {
 
 func (x *UpdateTaskSortConfigResponse) Reset() {
 	*x = UpdateTaskSortConfigResponse{}
-	mi := &file_task_proto_msgTypes[14]
+	mi := &file_task_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1164,7 +1785,7 @@ func (x *UpdateTaskSortConfigResponse) String() string {
 func (*UpdateTaskSortConfigResponse) ProtoMessage() {}
 
 func (x *UpdateTaskSortConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[14]
+	mi := &file_task_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1177,7 +1798,7 @@ func (x *UpdateTaskSortConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskSortConfigResponse.ProtoReflect.Descriptor instead.
 func (*UpdateTaskSortConfigResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{14}
+	return file_task_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *UpdateTaskSortConfigResponse) GetResponse() *Response {
@@ -1202,7 +1823,7 @@ type GetTaskSortConfigRequest struct {
 
 func (x *GetTaskSortConfigRequest) Reset() {
 	*x = GetTaskSortConfigRequest{}
-	mi := &file_task_proto_msgTypes[15]
+	mi := &file_task_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1214,7 +1835,7 @@ func (x *GetTaskSortConfigRequest) String() string {
 func (*GetTaskSortConfigRequest) ProtoMessage() {}
 
 func (x *GetTaskSortConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[15]
+	mi := &file_task_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +1848,7 @@ func (x *GetTaskSortConfigRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskSortConfigRequest.ProtoReflect.Descriptor instead.
 func (*GetTaskSortConfigRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{15}
+	return file_task_proto_rawDescGZIP(), []int{20}
 }
 
 type GetTaskSortConfigResponse struct {
@@ -1240,7 +1861,7 @@ type GetTaskSortConfigResponse struct {
 
 func (x *GetTaskSortConfigResponse) Reset() {
 	*x = GetTaskSortConfigResponse{}
-	mi := &file_task_proto_msgTypes[16]
+	mi := &file_task_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1252,7 +1873,7 @@ func (x *GetTaskSortConfigResponse) String() string {
 func (*GetTaskSortConfigResponse) ProtoMessage() {}
 
 func (x *GetTaskSortConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[16]
+	mi := &file_task_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1265,7 +1886,7 @@ func (x *GetTaskSortConfigResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskSortConfigResponse.ProtoReflect.Descriptor instead.
 func (*GetTaskSortConfigResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{16}
+	return file_task_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetTaskSortConfigResponse) GetResponse() *Response {
@@ -1282,10 +1903,724 @@ func (x *GetTaskSortConfigResponse) GetConfig() *TaskSortConfig {
 	return nil
 }
 
-var File_task_proto protoreflect.FileDescriptor
+// 保存的任务视图 (命名的过滤表达式 + 排序)
+type SavedView struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Query         string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Sort          string                 `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_task_proto_rawDesc = "" +
-	"\n" +
+func (x *SavedView) Reset() {
+	*x = SavedView{}
+	mi := &file_task_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedView) ProtoMessage() {}
+
+func (x *SavedView) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedView.ProtoReflect.Descriptor instead.
+func (*SavedView) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SavedView) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SavedView) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedView) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SavedView) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SavedView) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SavedView) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListSavedViewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedViewsRequest) Reset() {
+	*x = ListSavedViewsRequest{}
+	mi := &file_task_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedViewsRequest) ProtoMessage() {}
+
+func (x *ListSavedViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedViewsRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedViewsRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{23}
+}
+
+type ListSavedViewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Views         []*SavedView           `protobuf:"bytes,2,rep,name=views,proto3" json:"views,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedViewsResponse) Reset() {
+	*x = ListSavedViewsResponse{}
+	mi := &file_task_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedViewsResponse) ProtoMessage() {}
+
+func (x *ListSavedViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedViewsResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedViewsResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListSavedViewsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ListSavedViewsResponse) GetViews() []*SavedView {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+type SaveViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 为空时创建，否则整体替换
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Query         string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Sort          string                 `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveViewRequest) Reset() {
+	*x = SaveViewRequest{}
+	mi := &file_task_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveViewRequest) ProtoMessage() {}
+
+func (x *SaveViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveViewRequest.ProtoReflect.Descriptor instead.
+func (*SaveViewRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SaveViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SaveViewRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SaveViewRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SaveViewRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type SaveViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	View          *SavedView             `protobuf:"bytes,2,opt,name=view,proto3" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveViewResponse) Reset() {
+	*x = SaveViewResponse{}
+	mi := &file_task_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveViewResponse) ProtoMessage() {}
+
+func (x *SaveViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveViewResponse.ProtoReflect.Descriptor instead.
+func (*SaveViewResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SaveViewResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SaveViewResponse) GetView() *SavedView {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+type DeleteSavedViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedViewRequest) Reset() {
+	*x = DeleteSavedViewRequest{}
+	mi := &file_task_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedViewRequest) ProtoMessage() {}
+
+func (x *DeleteSavedViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedViewRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedViewRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteSavedViewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// 任务自定义字段定义 (workspace_id 为空时为个人字段)
+type TaskFieldDefinition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`       // text | number | date | select
+	Options       []string               `protobuf:"bytes,6,rep,name=options,proto3" json:"options,omitempty"` // select 的可选值
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskFieldDefinition) Reset() {
+	*x = TaskFieldDefinition{}
+	mi := &file_task_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskFieldDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskFieldDefinition) ProtoMessage() {}
+
+func (x *TaskFieldDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskFieldDefinition.ProtoReflect.Descriptor instead.
+func (*TaskFieldDefinition) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TaskFieldDefinition) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskFieldDefinition) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *TaskFieldDefinition) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *TaskFieldDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaskFieldDefinition) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TaskFieldDefinition) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *TaskFieldDefinition) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TaskFieldDefinition) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListTaskFieldsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskFieldsRequest) Reset() {
+	*x = ListTaskFieldsRequest{}
+	mi := &file_task_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskFieldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskFieldsRequest) ProtoMessage() {}
+
+func (x *ListTaskFieldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskFieldsRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskFieldsRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListTaskFieldsRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type ListTaskFieldsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Fields        []*TaskFieldDefinition `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskFieldsResponse) Reset() {
+	*x = ListTaskFieldsResponse{}
+	mi := &file_task_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskFieldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskFieldsResponse) ProtoMessage() {}
+
+func (x *ListTaskFieldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskFieldsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskFieldsResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListTaskFieldsResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ListTaskFieldsResponse) GetFields() []*TaskFieldDefinition {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type SaveTaskFieldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 为空时创建；更新时仅修改 name 与 options
+	WorkspaceId   string                 `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Options       []string               `protobuf:"bytes,6,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveTaskFieldRequest) Reset() {
+	*x = SaveTaskFieldRequest{}
+	mi := &file_task_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveTaskFieldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTaskFieldRequest) ProtoMessage() {}
+
+func (x *SaveTaskFieldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTaskFieldRequest.ProtoReflect.Descriptor instead.
+func (*SaveTaskFieldRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SaveTaskFieldRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SaveTaskFieldRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *SaveTaskFieldRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SaveTaskFieldRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SaveTaskFieldRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SaveTaskFieldRequest) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type SaveTaskFieldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Response      *Response              `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Field         *TaskFieldDefinition   `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveTaskFieldResponse) Reset() {
+	*x = SaveTaskFieldResponse{}
+	mi := &file_task_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveTaskFieldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTaskFieldResponse) ProtoMessage() {}
+
+func (x *SaveTaskFieldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTaskFieldResponse.ProtoReflect.Descriptor instead.
+func (*SaveTaskFieldResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SaveTaskFieldResponse) GetResponse() *Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SaveTaskFieldResponse) GetField() *TaskFieldDefinition {
+	if x != nil {
+		return x.Field
+	}
+	return nil
+}
+
+type DeleteTaskFieldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskFieldRequest) Reset() {
+	*x = DeleteTaskFieldRequest{}
+	mi := &file_task_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskFieldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskFieldRequest) ProtoMessage() {}
+
+func (x *DeleteTaskFieldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskFieldRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskFieldRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteTaskFieldRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_task_proto protoreflect.FileDescriptor
+
+const file_task_proto_rawDesc = "" +
+	"\n" +
 	"\n" +
 	"task.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"{\n" +
 	"\vTaskComment\x12\x12\n" +
@@ -1293,7 +2628,16 @@ const file_task_proto_rawDesc = "" +
 	"\n" +
 	"created_by\x18\x02 \x01(\tR\tcreatedBy\x129\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd2\x04\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"|\n" +
+	"\rChecklistItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\bR\x04done\x123\n" +
+	"\adone_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06doneAt\"8\n" +
+	"\fTaskProgress\x12\x12\n" +
+	"\x04done\x18\x01 \x01(\x05R\x04done\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xb8\n" +
+	"\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -1310,7 +2654,30 @@ const file_task_proto_rawDesc = "" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\rscheduledDate\x126\n" +
 	"\bdeadline\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x1a\n" +
 	"\bassignee\x18\f \x01(\tR\bassignee\x127\n" +
-	"\bcomments\x18\r \x03(\v2\x1b.todoing.api.v1.TaskCommentR\bcomments\"\xd0\x02\n" +
+	"\bcomments\x18\r \x03(\v2\x1b.todoing.api.v1.TaskCommentR\bcomments\x12\x1b\n" +
+	"\tparent_id\x18\x0e \x01(\tR\bparentId\x12;\n" +
+	"\tchecklist\x18\x0f \x03(\v2\x1d.todoing.api.v1.ChecklistItemR\tchecklist\x12#\n" +
+	"\rauto_complete\x18\x10 \x01(\bR\fautoComplete\x128\n" +
+	"\bprogress\x18\x11 \x01(\v2\x1c.todoing.api.v1.TaskProgressR\bprogress\x12E\n" +
+	"\x10subtask_deadline\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\x0fsubtaskDeadline\x120\n" +
+	"\bsubtasks\x18\x13 \x03(\v2\x14.todoing.api.v1.TaskR\bsubtasks\x12\x1d\n" +
+	"\n" +
+	"blocked_by\x18\x14 \x03(\tR\tblockedBy\x12\x18\n" +
+	"\ablocked\x18\x15 \x01(\bR\ablocked\x12=\n" +
+	"\funblocked_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\vunblockedAt\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\x17 \x01(\tR\n" +
+	"recurrence\x12\x1b\n" +
+	"\tseries_id\x18\x18 \x01(\tR\bseriesId\x12\x1f\n" +
+	"\vprevious_id\x18\x19 \x01(\tR\n" +
+	"previousId\x12\x17\n" +
+	"\anext_id\x18\x1a \x01(\tR\x06nextId\x12!\n" +
+	"\fworkspace_id\x18\x1b \x01(\tR\vworkspaceId\x12\x12\n" +
+	"\x04tags\x18\x1c \x03(\tR\x04tags\x12K\n" +
+	"\rcustom_fields\x18\x1d \x03(\v2&.todoing.api.v1.Task.CustomFieldsEntryR\fcustomFields\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc1\x05\n" +
 	"\x11CreateTaskRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x122\n" +
@@ -1318,10 +2685,23 @@ const file_task_proto_rawDesc = "" +
 	"\bpriority\x18\x04 \x01(\x0e2\x1c.todoing.api.v1.TaskPriorityR\bpriority\x126\n" +
 	"\bdeadline\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12A\n" +
 	"\x0escheduled_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rscheduledDate\x12\x1a\n" +
-	"\bassignee\x18\a \x01(\tR\bassignee\"t\n" +
+	"\bassignee\x18\a \x01(\tR\bassignee\x12\x1b\n" +
+	"\tparent_id\x18\b \x01(\tR\bparentId\x12;\n" +
+	"\tchecklist\x18\t \x03(\v2\x1d.todoing.api.v1.ChecklistItemR\tchecklist\x12#\n" +
+	"\rauto_complete\x18\n" +
+	" \x01(\bR\fautoComplete\x12\x1e\n" +
+	"\n" +
+	"recurrence\x18\v \x01(\tR\n" +
+	"recurrence\x12!\n" +
+	"\fworkspace_id\x18\f \x01(\tR\vworkspaceId\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x12X\n" +
+	"\rcustom_fields\x18\x0e \x03(\v23.todoing.api.v1.CreateTaskRequest.CustomFieldsEntryR\fcustomFields\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"t\n" +
 	"\x12CreateTaskResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12(\n" +
-	"\x04task\x18\x02 \x01(\v2\x14.todoing.api.v1.TaskR\x04task\"\xe3\x01\n" +
+	"\x04task\x18\x02 \x01(\v2\x14.todoing.api.v1.TaskR\x04task\"\xf3\x02\n" +
 	"\x0fGetTasksRequest\x12A\n" +
 	"\n" +
 	"pagination\x18\x01 \x01(\v2!.todoing.api.v1.PaginationRequestR\n" +
@@ -1329,7 +2709,12 @@ const file_task_proto_rawDesc = "" +
 	"\x06status\x18\x02 \x01(\x0e2\x1a.todoing.api.v1.TaskStatusR\x06status\x128\n" +
 	"\bpriority\x18\x03 \x01(\x0e2\x1c.todoing.api.v1.TaskPriorityR\bpriority\x12\x1f\n" +
 	"\vactive_only\x18\x04 \x01(\bR\n" +
-	"activeOnly\"\xb8\x01\n" +
+	"activeOnly\x12,\n" +
+	"\x04view\x18\x05 \x01(\x0e2\x18.todoing.api.v1.TaskViewR\x04view\x12\x14\n" +
+	"\x05query\x18\x06 \x01(\tR\x05query\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12\"\n" +
+	"\rsaved_view_id\x18\b \x01(\tR\vsavedViewId\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\"\xb8\x01\n" +
 	"\x10GetTasksResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12*\n" +
 	"\x05tasks\x18\x02 \x03(\v2\x14.todoing.api.v1.TaskR\x05tasks\x12B\n" +
@@ -1340,7 +2725,7 @@ const file_task_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"q\n" +
 	"\x0fGetTaskResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12(\n" +
-	"\x04task\x18\x02 \x01(\v2\x14.todoing.api.v1.TaskR\x04task\"\x99\x03\n" +
+	"\x04task\x18\x02 \x01(\v2\x14.todoing.api.v1.TaskR\x04task\"\xa6\x06\n" +
 	"\x11UpdateTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -1348,19 +2733,47 @@ const file_task_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\x0e2\x1a.todoing.api.v1.TaskStatusR\x06status\x128\n" +
 	"\bpriority\x18\x05 \x01(\x0e2\x1c.todoing.api.v1.TaskPriorityR\bpriority\x126\n" +
 	"\bdeadline\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12A\n" +
-	"\x0escheduled_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rscheduledDate\x12\x1a\n" +
-	"\bassignee\x18\b \x01(\tR\bassignee\x127\n" +
-	"\bcomments\x18\t \x03(\v2\x1b.todoing.api.v1.TaskCommentR\bcomments\"t\n" +
+	"\x0escheduled_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rscheduledDate\x12\x1f\n" +
+	"\bassignee\x18\b \x01(\tH\x00R\bassignee\x88\x01\x01\x127\n" +
+	"\bcomments\x18\t \x03(\v2\x1b.todoing.api.v1.TaskCommentR\bcomments\x12;\n" +
+	"\tchecklist\x18\n" +
+	" \x03(\v2\x1d.todoing.api.v1.ChecklistItemR\tchecklist\x12(\n" +
+	"\rauto_complete\x18\v \x01(\bH\x01R\fautoComplete\x88\x01\x01\x12#\n" +
+	"\n" +
+	"recurrence\x18\f \x01(\tH\x02R\n" +
+	"recurrence\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"clear_tags\x18\x0e \x01(\bR\tclearTags\x12X\n" +
+	"\rcustom_fields\x18\x0f \x03(\v23.todoing.api.v1.UpdateTaskRequest.CustomFieldsEntryR\fcustomFields\x1a?\n" +
+	"\x11CustomFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_assigneeB\x10\n" +
+	"\x0e_auto_completeB\r\n" +
+	"\v_recurrence\"t\n" +
 	"\x12UpdateTaskResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12(\n" +
 	"\x04task\x18\x02 \x01(\v2\x14.todoing.api.v1.TaskR\x04task\"#\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x99\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x97\x01\n" +
+	"\x16GetTaskHistoryResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12\x1b\n" +
+	"\tseries_id\x18\x02 \x01(\tR\bseriesId\x12*\n" +
+	"\x05tasks\x18\x03 \x03(\v2\x14.todoing.api.v1.TaskR\x05tasks\"O\n" +
+	"\x15TaskDependencyRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1d\n" +
+	"\n" +
+	"blocker_id\x18\x02 \x01(\tR\tblockerId\"x\n" +
+	"\x16TaskDependencyResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12(\n" +
+	"\x04task\x18\x02 \x01(\v2\x14.todoing.api.v1.TaskR\x04task\"\xc2\x01\n" +
 	"\fPriorityTask\x12(\n" +
 	"\x04task\x18\x01 \x01(\v2\x14.todoing.api.v1.TaskR\x04task\x12%\n" +
 	"\x0epriority_score\x18\x02 \x01(\x01R\rpriorityScore\x12\x1b\n" +
 	"\tdays_left\x18\x03 \x01(\x05R\bdaysLeft\x12\x1b\n" +
-	"\tis_urgent\x18\x04 \x01(\bR\bisUrgent\"\xc0\x02\n" +
+	"\tis_urgent\x18\x04 \x01(\bR\bisUrgent\x12'\n" +
+	"\x0fnewly_unblocked\x18\x05 \x01(\bR\x0enewlyUnblocked\"\xc0\x02\n" +
 	"\x0eTaskSortConfig\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
 	"\rpriority_days\x18\x02 \x01(\x05R\fpriorityDays\x12*\n" +
@@ -1382,7 +2795,58 @@ const file_task_proto_rawDesc = "" +
 	"\x18GetTaskSortConfigRequest\"\x89\x01\n" +
 	"\x19GetTaskSortConfigResponse\x124\n" +
 	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x126\n" +
-	"\x06config\x18\x02 \x01(\v2\x1e.todoing.api.v1.TaskSortConfigR\x06config*r\n" +
+	"\x06config\x18\x02 \x01(\v2\x1e.todoing.api.v1.TaskSortConfigR\x06config\"\xcf\x01\n" +
+	"\tSavedView\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x17\n" +
+	"\x15ListSavedViewsRequest\"\x7f\n" +
+	"\x16ListSavedViewsResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12/\n" +
+	"\x05views\x18\x02 \x03(\v2\x19.todoing.api.v1.SavedViewR\x05views\"_\n" +
+	"\x0fSaveViewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\"w\n" +
+	"\x10SaveViewResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12-\n" +
+	"\x04view\x18\x02 \x01(\v2\x19.todoing.api.v1.SavedViewR\x04view\"(\n" +
+	"\x16DeleteSavedViewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x92\x02\n" +
+	"\x13TaskFieldDefinition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x18\n" +
+	"\aoptions\x18\x06 \x03(\tR\aoptions\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\":\n" +
+	"\x15ListTaskFieldsRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"\x8b\x01\n" +
+	"\x16ListTaskFieldsResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x12;\n" +
+	"\x06fields\x18\x02 \x03(\v2#.todoing.api.v1.TaskFieldDefinitionR\x06fields\"\x9d\x01\n" +
+	"\x14SaveTaskFieldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x18\n" +
+	"\aoptions\x18\x06 \x03(\tR\aoptions\"\x88\x01\n" +
+	"\x15SaveTaskFieldResponse\x124\n" +
+	"\bresponse\x18\x01 \x01(\v2\x18.todoing.api.v1.ResponseR\bresponse\x129\n" +
+	"\x05field\x18\x02 \x01(\v2#.todoing.api.v1.TaskFieldDefinitionR\x05field\"(\n" +
+	"\x16DeleteTaskFieldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*r\n" +
 	"\n" +
 	"TaskStatus\x12\x1b\n" +
 	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x14\n" +
@@ -1393,7 +2857,12 @@ const file_task_proto_rawDesc = "" +
 	"\x19TASK_PRIORITY_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11TASK_PRIORITY_LOW\x10\x01\x12\x18\n" +
 	"\x14TASK_PRIORITY_MEDIUM\x10\x02\x12\x16\n" +
-	"\x12TASK_PRIORITY_HIGH\x10\x032\xfa\x04\n" +
+	"\x12TASK_PRIORITY_HIGH\x10\x03*r\n" +
+	"\bTaskView\x12\x11\n" +
+	"\rTASK_VIEW_ALL\x10\x00\x12\x1c\n" +
+	"\x18TASK_VIEW_ASSIGNED_TO_ME\x10\x01\x12\x1b\n" +
+	"\x17TASK_VIEW_CREATED_BY_ME\x10\x02\x12\x18\n" +
+	"\x14TASK_VIEW_UNASSIGNED\x10\x032\xb8\v\n" +
 	"\vTaskService\x12S\n" +
 	"\n" +
 	"CreateTask\x12!.todoing.api.v1.CreateTaskRequest\x1a\".todoing.api.v1.CreateTaskResponse\x12M\n" +
@@ -1402,9 +2871,18 @@ const file_task_proto_rawDesc = "" +
 	"\n" +
 	"UpdateTask\x12!.todoing.api.v1.UpdateTaskRequest\x1a\".todoing.api.v1.UpdateTaskResponse\x12I\n" +
 	"\n" +
-	"DeleteTask\x12!.todoing.api.v1.DeleteTaskRequest\x1a\x18.todoing.api.v1.Response\x12h\n" +
+	"DeleteTask\x12!.todoing.api.v1.DeleteTaskRequest\x1a\x18.todoing.api.v1.Response\x12X\n" +
+	"\x0eGetTaskHistory\x12\x1e.todoing.api.v1.GetTaskRequest\x1a&.todoing.api.v1.GetTaskHistoryResponse\x12b\n" +
+	"\x11AddTaskDependency\x12%.todoing.api.v1.TaskDependencyRequest\x1a&.todoing.api.v1.TaskDependencyResponse\x12e\n" +
+	"\x14RemoveTaskDependency\x12%.todoing.api.v1.TaskDependencyRequest\x1a&.todoing.api.v1.TaskDependencyResponse\x12h\n" +
 	"\x11GetTaskSortConfig\x12(.todoing.api.v1.GetTaskSortConfigRequest\x1a).todoing.api.v1.GetTaskSortConfigResponse\x12q\n" +
-	"\x14UpdateTaskSortConfig\x12+.todoing.api.v1.UpdateTaskSortConfigRequest\x1a,.todoing.api.v1.UpdateTaskSortConfigResponseB5Z3github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1b\x06proto3"
+	"\x14UpdateTaskSortConfig\x12+.todoing.api.v1.UpdateTaskSortConfigRequest\x1a,.todoing.api.v1.UpdateTaskSortConfigResponse\x12_\n" +
+	"\x0eListSavedViews\x12%.todoing.api.v1.ListSavedViewsRequest\x1a&.todoing.api.v1.ListSavedViewsResponse\x12M\n" +
+	"\bSaveView\x12\x1f.todoing.api.v1.SaveViewRequest\x1a .todoing.api.v1.SaveViewResponse\x12S\n" +
+	"\x0fDeleteSavedView\x12&.todoing.api.v1.DeleteSavedViewRequest\x1a\x18.todoing.api.v1.Response\x12_\n" +
+	"\x0eListTaskFields\x12%.todoing.api.v1.ListTaskFieldsRequest\x1a&.todoing.api.v1.ListTaskFieldsResponse\x12\\\n" +
+	"\rSaveTaskField\x12$.todoing.api.v1.SaveTaskFieldRequest\x1a%.todoing.api.v1.SaveTaskFieldResponse\x12S\n" +
+	"\x0fDeleteTaskField\x12&.todoing.api.v1.DeleteTaskFieldRequest\x1a\x18.todoing.api.v1.ResponseB5Z3github.com/axfinn/todoIngPlus/backend-go/pkg/api/v1b\x06proto3"
 
 var (
 	file_task_proto_rawDescOnce sync.Once
@@ -1418,90 +2896,157 @@ func file_task_proto_rawDescGZIP() []byte {
 	return file_task_proto_rawDescData
 }
 
-var file_task_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_task_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_task_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_task_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_task_proto_goTypes = []any{
 	(TaskStatus)(0),                      // 0: todoing.api.v1.TaskStatus
 	(TaskPriority)(0),                    // 1: todoing.api.v1.TaskPriority
-	(*TaskComment)(nil),                  // 2: todoing.api.v1.TaskComment
-	(*Task)(nil),                         // 3: todoing.api.v1.Task
-	(*CreateTaskRequest)(nil),            // 4: todoing.api.v1.CreateTaskRequest
-	(*CreateTaskResponse)(nil),           // 5: todoing.api.v1.CreateTaskResponse
-	(*GetTasksRequest)(nil),              // 6: todoing.api.v1.GetTasksRequest
-	(*GetTasksResponse)(nil),             // 7: todoing.api.v1.GetTasksResponse
-	(*GetTaskRequest)(nil),               // 8: todoing.api.v1.GetTaskRequest
-	(*GetTaskResponse)(nil),              // 9: todoing.api.v1.GetTaskResponse
-	(*UpdateTaskRequest)(nil),            // 10: todoing.api.v1.UpdateTaskRequest
-	(*UpdateTaskResponse)(nil),           // 11: todoing.api.v1.UpdateTaskResponse
-	(*DeleteTaskRequest)(nil),            // 12: todoing.api.v1.DeleteTaskRequest
-	(*PriorityTask)(nil),                 // 13: todoing.api.v1.PriorityTask
-	(*TaskSortConfig)(nil),               // 14: todoing.api.v1.TaskSortConfig
-	(*UpdateTaskSortConfigRequest)(nil),  // 15: todoing.api.v1.UpdateTaskSortConfigRequest
-	(*UpdateTaskSortConfigResponse)(nil), // 16: todoing.api.v1.UpdateTaskSortConfigResponse
-	(*GetTaskSortConfigRequest)(nil),     // 17: todoing.api.v1.GetTaskSortConfigRequest
-	(*GetTaskSortConfigResponse)(nil),    // 18: todoing.api.v1.GetTaskSortConfigResponse
-	(*timestamppb.Timestamp)(nil),        // 19: google.protobuf.Timestamp
-	(*Response)(nil),                     // 20: todoing.api.v1.Response
-	(*PaginationRequest)(nil),            // 21: todoing.api.v1.PaginationRequest
-	(*PaginationResponse)(nil),           // 22: todoing.api.v1.PaginationResponse
+	(TaskView)(0),                        // 2: todoing.api.v1.TaskView
+	(*TaskComment)(nil),                  // 3: todoing.api.v1.TaskComment
+	(*ChecklistItem)(nil),                // 4: todoing.api.v1.ChecklistItem
+	(*TaskProgress)(nil),                 // 5: todoing.api.v1.TaskProgress
+	(*Task)(nil),                         // 6: todoing.api.v1.Task
+	(*CreateTaskRequest)(nil),            // 7: todoing.api.v1.CreateTaskRequest
+	(*CreateTaskResponse)(nil),           // 8: todoing.api.v1.CreateTaskResponse
+	(*GetTasksRequest)(nil),              // 9: todoing.api.v1.GetTasksRequest
+	(*GetTasksResponse)(nil),             // 10: todoing.api.v1.GetTasksResponse
+	(*GetTaskRequest)(nil),               // 11: todoing.api.v1.GetTaskRequest
+	(*GetTaskResponse)(nil),              // 12: todoing.api.v1.GetTaskResponse
+	(*UpdateTaskRequest)(nil),            // 13: todoing.api.v1.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),           // 14: todoing.api.v1.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),            // 15: todoing.api.v1.DeleteTaskRequest
+	(*GetTaskHistoryResponse)(nil),       // 16: todoing.api.v1.GetTaskHistoryResponse
+	(*TaskDependencyRequest)(nil),        // 17: todoing.api.v1.TaskDependencyRequest
+	(*TaskDependencyResponse)(nil),       // 18: todoing.api.v1.TaskDependencyResponse
+	(*PriorityTask)(nil),                 // 19: todoing.api.v1.PriorityTask
+	(*TaskSortConfig)(nil),               // 20: todoing.api.v1.TaskSortConfig
+	(*UpdateTaskSortConfigRequest)(nil),  // 21: todoing.api.v1.UpdateTaskSortConfigRequest
+	(*UpdateTaskSortConfigResponse)(nil), // 22: todoing.api.v1.UpdateTaskSortConfigResponse
+	(*GetTaskSortConfigRequest)(nil),     // 23: todoing.api.v1.GetTaskSortConfigRequest
+	(*GetTaskSortConfigResponse)(nil),    // 24: todoing.api.v1.GetTaskSortConfigResponse
+	(*SavedView)(nil),                    // 25: todoing.api.v1.SavedView
+	(*ListSavedViewsRequest)(nil),        // 26: todoing.api.v1.ListSavedViewsRequest
+	(*ListSavedViewsResponse)(nil),       // 27: todoing.api.v1.ListSavedViewsResponse
+	(*SaveViewRequest)(nil),              // 28: todoing.api.v1.SaveViewRequest
+	(*SaveViewResponse)(nil),             // 29: todoing.api.v1.SaveViewResponse
+	(*DeleteSavedViewRequest)(nil),       // 30: todoing.api.v1.DeleteSavedViewRequest
+	(*TaskFieldDefinition)(nil),          // 31: todoing.api.v1.TaskFieldDefinition
+	(*ListTaskFieldsRequest)(nil),        // 32: todoing.api.v1.ListTaskFieldsRequest
+	(*ListTaskFieldsResponse)(nil),       // 33: todoing.api.v1.ListTaskFieldsResponse
+	(*SaveTaskFieldRequest)(nil),         // 34: todoing.api.v1.SaveTaskFieldRequest
+	(*SaveTaskFieldResponse)(nil),        // 35: todoing.api.v1.SaveTaskFieldResponse
+	(*DeleteTaskFieldRequest)(nil),       // 36: todoing.api.v1.DeleteTaskFieldRequest
+	nil,                                  // 37: todoing.api.v1.Task.CustomFieldsEntry
+	nil,                                  // 38: todoing.api.v1.CreateTaskRequest.CustomFieldsEntry
+	nil,                                  // 39: todoing.api.v1.UpdateTaskRequest.CustomFieldsEntry
+	(*timestamppb.Timestamp)(nil),        // 40: google.protobuf.Timestamp
+	(*Response)(nil),                     // 41: todoing.api.v1.Response
+	(*PaginationRequest)(nil),            // 42: todoing.api.v1.PaginationRequest
+	(*PaginationResponse)(nil),           // 43: todoing.api.v1.PaginationResponse
 }
 var file_task_proto_depIdxs = []int32{
-	19, // 0: todoing.api.v1.TaskComment.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 1: todoing.api.v1.Task.status:type_name -> todoing.api.v1.TaskStatus
-	1,  // 2: todoing.api.v1.Task.priority:type_name -> todoing.api.v1.TaskPriority
-	19, // 3: todoing.api.v1.Task.due_date:type_name -> google.protobuf.Timestamp
-	19, // 4: todoing.api.v1.Task.created_at:type_name -> google.protobuf.Timestamp
-	19, // 5: todoing.api.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
-	19, // 6: todoing.api.v1.Task.scheduled_date:type_name -> google.protobuf.Timestamp
-	19, // 7: todoing.api.v1.Task.deadline:type_name -> google.protobuf.Timestamp
-	2,  // 8: todoing.api.v1.Task.comments:type_name -> todoing.api.v1.TaskComment
-	0,  // 9: todoing.api.v1.CreateTaskRequest.status:type_name -> todoing.api.v1.TaskStatus
-	1,  // 10: todoing.api.v1.CreateTaskRequest.priority:type_name -> todoing.api.v1.TaskPriority
-	19, // 11: todoing.api.v1.CreateTaskRequest.deadline:type_name -> google.protobuf.Timestamp
-	19, // 12: todoing.api.v1.CreateTaskRequest.scheduled_date:type_name -> google.protobuf.Timestamp
-	20, // 13: todoing.api.v1.CreateTaskResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 14: todoing.api.v1.CreateTaskResponse.task:type_name -> todoing.api.v1.Task
-	21, // 15: todoing.api.v1.GetTasksRequest.pagination:type_name -> todoing.api.v1.PaginationRequest
-	0,  // 16: todoing.api.v1.GetTasksRequest.status:type_name -> todoing.api.v1.TaskStatus
-	1,  // 17: todoing.api.v1.GetTasksRequest.priority:type_name -> todoing.api.v1.TaskPriority
-	20, // 18: todoing.api.v1.GetTasksResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 19: todoing.api.v1.GetTasksResponse.tasks:type_name -> todoing.api.v1.Task
-	22, // 20: todoing.api.v1.GetTasksResponse.pagination:type_name -> todoing.api.v1.PaginationResponse
-	20, // 21: todoing.api.v1.GetTaskResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 22: todoing.api.v1.GetTaskResponse.task:type_name -> todoing.api.v1.Task
-	0,  // 23: todoing.api.v1.UpdateTaskRequest.status:type_name -> todoing.api.v1.TaskStatus
-	1,  // 24: todoing.api.v1.UpdateTaskRequest.priority:type_name -> todoing.api.v1.TaskPriority
-	19, // 25: todoing.api.v1.UpdateTaskRequest.deadline:type_name -> google.protobuf.Timestamp
-	19, // 26: todoing.api.v1.UpdateTaskRequest.scheduled_date:type_name -> google.protobuf.Timestamp
-	2,  // 27: todoing.api.v1.UpdateTaskRequest.comments:type_name -> todoing.api.v1.TaskComment
-	20, // 28: todoing.api.v1.UpdateTaskResponse.response:type_name -> todoing.api.v1.Response
-	3,  // 29: todoing.api.v1.UpdateTaskResponse.task:type_name -> todoing.api.v1.Task
-	3,  // 30: todoing.api.v1.PriorityTask.task:type_name -> todoing.api.v1.Task
-	19, // 31: todoing.api.v1.TaskSortConfig.created_at:type_name -> google.protobuf.Timestamp
-	19, // 32: todoing.api.v1.TaskSortConfig.updated_at:type_name -> google.protobuf.Timestamp
-	20, // 33: todoing.api.v1.UpdateTaskSortConfigResponse.response:type_name -> todoing.api.v1.Response
-	14, // 34: todoing.api.v1.UpdateTaskSortConfigResponse.config:type_name -> todoing.api.v1.TaskSortConfig
-	20, // 35: todoing.api.v1.GetTaskSortConfigResponse.response:type_name -> todoing.api.v1.Response
-	14, // 36: todoing.api.v1.GetTaskSortConfigResponse.config:type_name -> todoing.api.v1.TaskSortConfig
-	4,  // 37: todoing.api.v1.TaskService.CreateTask:input_type -> todoing.api.v1.CreateTaskRequest
-	6,  // 38: todoing.api.v1.TaskService.GetTasks:input_type -> todoing.api.v1.GetTasksRequest
-	8,  // 39: todoing.api.v1.TaskService.GetTask:input_type -> todoing.api.v1.GetTaskRequest
-	10, // 40: todoing.api.v1.TaskService.UpdateTask:input_type -> todoing.api.v1.UpdateTaskRequest
-	12, // 41: todoing.api.v1.TaskService.DeleteTask:input_type -> todoing.api.v1.DeleteTaskRequest
-	17, // 42: todoing.api.v1.TaskService.GetTaskSortConfig:input_type -> todoing.api.v1.GetTaskSortConfigRequest
-	15, // 43: todoing.api.v1.TaskService.UpdateTaskSortConfig:input_type -> todoing.api.v1.UpdateTaskSortConfigRequest
-	5,  // 44: todoing.api.v1.TaskService.CreateTask:output_type -> todoing.api.v1.CreateTaskResponse
-	7,  // 45: todoing.api.v1.TaskService.GetTasks:output_type -> todoing.api.v1.GetTasksResponse
-	9,  // 46: todoing.api.v1.TaskService.GetTask:output_type -> todoing.api.v1.GetTaskResponse
-	11, // 47: todoing.api.v1.TaskService.UpdateTask:output_type -> todoing.api.v1.UpdateTaskResponse
-	20, // 48: todoing.api.v1.TaskService.DeleteTask:output_type -> todoing.api.v1.Response
-	18, // 49: todoing.api.v1.TaskService.GetTaskSortConfig:output_type -> todoing.api.v1.GetTaskSortConfigResponse
-	16, // 50: todoing.api.v1.TaskService.UpdateTaskSortConfig:output_type -> todoing.api.v1.UpdateTaskSortConfigResponse
-	44, // [44:51] is the sub-list for method output_type
-	37, // [37:44] is the sub-list for method input_type
-	37, // [37:37] is the sub-list for extension type_name
-	37, // [37:37] is the sub-list for extension extendee
-	0,  // [0:37] is the sub-list for field type_name
+	40, // 0: todoing.api.v1.TaskComment.created_at:type_name -> google.protobuf.Timestamp
+	40, // 1: todoing.api.v1.ChecklistItem.done_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: todoing.api.v1.Task.status:type_name -> todoing.api.v1.TaskStatus
+	1,  // 3: todoing.api.v1.Task.priority:type_name -> todoing.api.v1.TaskPriority
+	40, // 4: todoing.api.v1.Task.due_date:type_name -> google.protobuf.Timestamp
+	40, // 5: todoing.api.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	40, // 6: todoing.api.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	40, // 7: todoing.api.v1.Task.scheduled_date:type_name -> google.protobuf.Timestamp
+	40, // 8: todoing.api.v1.Task.deadline:type_name -> google.protobuf.Timestamp
+	3,  // 9: todoing.api.v1.Task.comments:type_name -> todoing.api.v1.TaskComment
+	4,  // 10: todoing.api.v1.Task.checklist:type_name -> todoing.api.v1.ChecklistItem
+	5,  // 11: todoing.api.v1.Task.progress:type_name -> todoing.api.v1.TaskProgress
+	40, // 12: todoing.api.v1.Task.subtask_deadline:type_name -> google.protobuf.Timestamp
+	6,  // 13: todoing.api.v1.Task.subtasks:type_name -> todoing.api.v1.Task
+	40, // 14: todoing.api.v1.Task.unblocked_at:type_name -> google.protobuf.Timestamp
+	37, // 15: todoing.api.v1.Task.custom_fields:type_name -> todoing.api.v1.Task.CustomFieldsEntry
+	0,  // 16: todoing.api.v1.CreateTaskRequest.status:type_name -> todoing.api.v1.TaskStatus
+	1,  // 17: todoing.api.v1.CreateTaskRequest.priority:type_name -> todoing.api.v1.TaskPriority
+	40, // 18: todoing.api.v1.CreateTaskRequest.deadline:type_name -> google.protobuf.Timestamp
+	40, // 19: todoing.api.v1.CreateTaskRequest.scheduled_date:type_name -> google.protobuf.Timestamp
+	4,  // 20: todoing.api.v1.CreateTaskRequest.checklist:type_name -> todoing.api.v1.ChecklistItem
+	38, // 21: todoing.api.v1.CreateTaskRequest.custom_fields:type_name -> todoing.api.v1.CreateTaskRequest.CustomFieldsEntry
+	41, // 22: todoing.api.v1.CreateTaskResponse.response:type_name -> todoing.api.v1.Response
+	6,  // 23: todoing.api.v1.CreateTaskResponse.task:type_name -> todoing.api.v1.Task
+	42, // 24: todoing.api.v1.GetTasksRequest.pagination:type_name -> todoing.api.v1.PaginationRequest
+	0,  // 25: todoing.api.v1.GetTasksRequest.status:type_name -> todoing.api.v1.TaskStatus
+	1,  // 26: todoing.api.v1.GetTasksRequest.priority:type_name -> todoing.api.v1.TaskPriority
+	2,  // 27: todoing.api.v1.GetTasksRequest.view:type_name -> todoing.api.v1.TaskView
+	41, // 28: todoing.api.v1.GetTasksResponse.response:type_name -> todoing.api.v1.Response
+	6,  // 29: todoing.api.v1.GetTasksResponse.tasks:type_name -> todoing.api.v1.Task
+	43, // 30: todoing.api.v1.GetTasksResponse.pagination:type_name -> todoing.api.v1.PaginationResponse
+	41, // 31: todoing.api.v1.GetTaskResponse.response:type_name -> todoing.api.v1.Response
+	6,  // 32: todoing.api.v1.GetTaskResponse.task:type_name -> todoing.api.v1.Task
+	0,  // 33: todoing.api.v1.UpdateTaskRequest.status:type_name -> todoing.api.v1.TaskStatus
+	1,  // 34: todoing.api.v1.UpdateTaskRequest.priority:type_name -> todoing.api.v1.TaskPriority
+	40, // 35: todoing.api.v1.UpdateTaskRequest.deadline:type_name -> google.protobuf.Timestamp
+	40, // 36: todoing.api.v1.UpdateTaskRequest.scheduled_date:type_name -> google.protobuf.Timestamp
+	3,  // 37: todoing.api.v1.UpdateTaskRequest.comments:type_name -> todoing.api.v1.TaskComment
+	4,  // 38: todoing.api.v1.UpdateTaskRequest.checklist:type_name -> todoing.api.v1.ChecklistItem
+	39, // 39: todoing.api.v1.UpdateTaskRequest.custom_fields:type_name -> todoing.api.v1.UpdateTaskRequest.CustomFieldsEntry
+	41, // 40: todoing.api.v1.UpdateTaskResponse.response:type_name -> todoing.api.v1.Response
+	6,  // 41: todoing.api.v1.UpdateTaskResponse.task:type_name -> todoing.api.v1.Task
+	41, // 42: todoing.api.v1.GetTaskHistoryResponse.response:type_name -> todoing.api.v1.Response
+	6,  // 43: todoing.api.v1.GetTaskHistoryResponse.tasks:type_name -> todoing.api.v1.Task
+	41, // 44: todoing.api.v1.TaskDependencyResponse.response:type_name -> todoing.api.v1.Response
+	6,  // 45: todoing.api.v1.TaskDependencyResponse.task:type_name -> todoing.api.v1.Task
+	6,  // 46: todoing.api.v1.PriorityTask.task:type_name -> todoing.api.v1.Task
+	40, // 47: todoing.api.v1.TaskSortConfig.created_at:type_name -> google.protobuf.Timestamp
+	40, // 48: todoing.api.v1.TaskSortConfig.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 49: todoing.api.v1.UpdateTaskSortConfigResponse.response:type_name -> todoing.api.v1.Response
+	20, // 50: todoing.api.v1.UpdateTaskSortConfigResponse.config:type_name -> todoing.api.v1.TaskSortConfig
+	41, // 51: todoing.api.v1.GetTaskSortConfigResponse.response:type_name -> todoing.api.v1.Response
+	20, // 52: todoing.api.v1.GetTaskSortConfigResponse.config:type_name -> todoing.api.v1.TaskSortConfig
+	40, // 53: todoing.api.v1.SavedView.created_at:type_name -> google.protobuf.Timestamp
+	40, // 54: todoing.api.v1.SavedView.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 55: todoing.api.v1.ListSavedViewsResponse.response:type_name -> todoing.api.v1.Response
+	25, // 56: todoing.api.v1.ListSavedViewsResponse.views:type_name -> todoing.api.v1.SavedView
+	41, // 57: todoing.api.v1.SaveViewResponse.response:type_name -> todoing.api.v1.Response
+	25, // 58: todoing.api.v1.SaveViewResponse.view:type_name -> todoing.api.v1.SavedView
+	40, // 59: todoing.api.v1.TaskFieldDefinition.created_at:type_name -> google.protobuf.Timestamp
+	40, // 60: todoing.api.v1.TaskFieldDefinition.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 61: todoing.api.v1.ListTaskFieldsResponse.response:type_name -> todoing.api.v1.Response
+	31, // 62: todoing.api.v1.ListTaskFieldsResponse.fields:type_name -> todoing.api.v1.TaskFieldDefinition
+	41, // 63: todoing.api.v1.SaveTaskFieldResponse.response:type_name -> todoing.api.v1.Response
+	31, // 64: todoing.api.v1.SaveTaskFieldResponse.field:type_name -> todoing.api.v1.TaskFieldDefinition
+	7,  // 65: todoing.api.v1.TaskService.CreateTask:input_type -> todoing.api.v1.CreateTaskRequest
+	9,  // 66: todoing.api.v1.TaskService.GetTasks:input_type -> todoing.api.v1.GetTasksRequest
+	11, // 67: todoing.api.v1.TaskService.GetTask:input_type -> todoing.api.v1.GetTaskRequest
+	13, // 68: todoing.api.v1.TaskService.UpdateTask:input_type -> todoing.api.v1.UpdateTaskRequest
+	15, // 69: todoing.api.v1.TaskService.DeleteTask:input_type -> todoing.api.v1.DeleteTaskRequest
+	11, // 70: todoing.api.v1.TaskService.GetTaskHistory:input_type -> todoing.api.v1.GetTaskRequest
+	17, // 71: todoing.api.v1.TaskService.AddTaskDependency:input_type -> todoing.api.v1.TaskDependencyRequest
+	17, // 72: todoing.api.v1.TaskService.RemoveTaskDependency:input_type -> todoing.api.v1.TaskDependencyRequest
+	23, // 73: todoing.api.v1.TaskService.GetTaskSortConfig:input_type -> todoing.api.v1.GetTaskSortConfigRequest
+	21, // 74: todoing.api.v1.TaskService.UpdateTaskSortConfig:input_type -> todoing.api.v1.UpdateTaskSortConfigRequest
+	26, // 75: todoing.api.v1.TaskService.ListSavedViews:input_type -> todoing.api.v1.ListSavedViewsRequest
+	28, // 76: todoing.api.v1.TaskService.SaveView:input_type -> todoing.api.v1.SaveViewRequest
+	30, // 77: todoing.api.v1.TaskService.DeleteSavedView:input_type -> todoing.api.v1.DeleteSavedViewRequest
+	32, // 78: todoing.api.v1.TaskService.ListTaskFields:input_type -> todoing.api.v1.ListTaskFieldsRequest
+	34, // 79: todoing.api.v1.TaskService.SaveTaskField:input_type -> todoing.api.v1.SaveTaskFieldRequest
+	36, // 80: todoing.api.v1.TaskService.DeleteTaskField:input_type -> todoing.api.v1.DeleteTaskFieldRequest
+	8,  // 81: todoing.api.v1.TaskService.CreateTask:output_type -> todoing.api.v1.CreateTaskResponse
+	10, // 82: todoing.api.v1.TaskService.GetTasks:output_type -> todoing.api.v1.GetTasksResponse
+	12, // 83: todoing.api.v1.TaskService.GetTask:output_type -> todoing.api.v1.GetTaskResponse
+	14, // 84: todoing.api.v1.TaskService.UpdateTask:output_type -> todoing.api.v1.UpdateTaskResponse
+	41, // 85: todoing.api.v1.TaskService.DeleteTask:output_type -> todoing.api.v1.Response
+	16, // 86: todoing.api.v1.TaskService.GetTaskHistory:output_type -> todoing.api.v1.GetTaskHistoryResponse
+	18, // 87: todoing.api.v1.TaskService.AddTaskDependency:output_type -> todoing.api.v1.TaskDependencyResponse
+	18, // 88: todoing.api.v1.TaskService.RemoveTaskDependency:output_type -> todoing.api.v1.TaskDependencyResponse
+	24, // 89: todoing.api.v1.TaskService.GetTaskSortConfig:output_type -> todoing.api.v1.GetTaskSortConfigResponse
+	22, // 90: todoing.api.v1.TaskService.UpdateTaskSortConfig:output_type -> todoing.api.v1.UpdateTaskSortConfigResponse
+	27, // 91: todoing.api.v1.TaskService.ListSavedViews:output_type -> todoing.api.v1.ListSavedViewsResponse
+	29, // 92: todoing.api.v1.TaskService.SaveView:output_type -> todoing.api.v1.SaveViewResponse
+	41, // 93: todoing.api.v1.TaskService.DeleteSavedView:output_type -> todoing.api.v1.Response
+	33, // 94: todoing.api.v1.TaskService.ListTaskFields:output_type -> todoing.api.v1.ListTaskFieldsResponse
+	35, // 95: todoing.api.v1.TaskService.SaveTaskField:output_type -> todoing.api.v1.SaveTaskFieldResponse
+	41, // 96: todoing.api.v1.TaskService.DeleteTaskField:output_type -> todoing.api.v1.Response
+	81, // [81:97] is the sub-list for method output_type
+	65, // [65:81] is the sub-list for method input_type
+	65, // [65:65] is the sub-list for extension type_name
+	65, // [65:65] is the sub-list for extension extendee
+	0,  // [0:65] is the sub-list for field type_name
 }
 
 func init() { file_task_proto_init() }
@@ -1510,13 +3055,14 @@ func file_task_proto_init() {
 		return
 	}
 	file_common_proto_init()
+	file_task_proto_msgTypes[10].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_task_proto_rawDesc), len(file_task_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   17,
+			NumEnums:      3,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
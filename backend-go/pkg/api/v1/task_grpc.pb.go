@@ -24,8 +24,17 @@ const (
 	TaskService_GetTask_FullMethodName              = "/todoing.api.v1.TaskService/GetTask"
 	TaskService_UpdateTask_FullMethodName           = "/todoing.api.v1.TaskService/UpdateTask"
 	TaskService_DeleteTask_FullMethodName           = "/todoing.api.v1.TaskService/DeleteTask"
+	TaskService_GetTaskHistory_FullMethodName       = "/todoing.api.v1.TaskService/GetTaskHistory"
+	TaskService_AddTaskDependency_FullMethodName    = "/todoing.api.v1.TaskService/AddTaskDependency"
+	TaskService_RemoveTaskDependency_FullMethodName = "/todoing.api.v1.TaskService/RemoveTaskDependency"
 	TaskService_GetTaskSortConfig_FullMethodName    = "/todoing.api.v1.TaskService/GetTaskSortConfig"
 	TaskService_UpdateTaskSortConfig_FullMethodName = "/todoing.api.v1.TaskService/UpdateTaskSortConfig"
+	TaskService_ListSavedViews_FullMethodName       = "/todoing.api.v1.TaskService/ListSavedViews"
+	TaskService_SaveView_FullMethodName             = "/todoing.api.v1.TaskService/SaveView"
+	TaskService_DeleteSavedView_FullMethodName      = "/todoing.api.v1.TaskService/DeleteSavedView"
+	TaskService_ListTaskFields_FullMethodName       = "/todoing.api.v1.TaskService/ListTaskFields"
+	TaskService_SaveTaskField_FullMethodName        = "/todoing.api.v1.TaskService/SaveTaskField"
+	TaskService_DeleteTaskField_FullMethodName      = "/todoing.api.v1.TaskService/DeleteTaskField"
 )
 
 // TaskServiceClient is the client API for TaskService service.
@@ -44,9 +53,22 @@ type TaskServiceClient interface {
 	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error)
 	// 删除任务
 	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*Response, error)
+	// 循环任务历史
+	GetTaskHistory(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskHistoryResponse, error)
+	// 任务依赖 (形成环时返回 FAILED_PRECONDITION)
+	AddTaskDependency(ctx context.Context, in *TaskDependencyRequest, opts ...grpc.CallOption) (*TaskDependencyResponse, error)
+	RemoveTaskDependency(ctx context.Context, in *TaskDependencyRequest, opts ...grpc.CallOption) (*TaskDependencyResponse, error)
 	// 排序配置
 	GetTaskSortConfig(ctx context.Context, in *GetTaskSortConfigRequest, opts ...grpc.CallOption) (*GetTaskSortConfigResponse, error)
 	UpdateTaskSortConfig(ctx context.Context, in *UpdateTaskSortConfigRequest, opts ...grpc.CallOption) (*UpdateTaskSortConfigResponse, error)
+	// 保存的视图 (名称重复返回 ALREADY_EXISTS)
+	ListSavedViews(ctx context.Context, in *ListSavedViewsRequest, opts ...grpc.CallOption) (*ListSavedViewsResponse, error)
+	SaveView(ctx context.Context, in *SaveViewRequest, opts ...grpc.CallOption) (*SaveViewResponse, error)
+	DeleteSavedView(ctx context.Context, in *DeleteSavedViewRequest, opts ...grpc.CallOption) (*Response, error)
+	// 自定义字段定义 (Key 重复返回 ALREADY_EXISTS，工作区字段需 owner 角色)
+	ListTaskFields(ctx context.Context, in *ListTaskFieldsRequest, opts ...grpc.CallOption) (*ListTaskFieldsResponse, error)
+	SaveTaskField(ctx context.Context, in *SaveTaskFieldRequest, opts ...grpc.CallOption) (*SaveTaskFieldResponse, error)
+	DeleteTaskField(ctx context.Context, in *DeleteTaskFieldRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type taskServiceClient struct {
@@ -107,6 +129,36 @@ func (c *taskServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskReques
 	return out, nil
 }
 
+func (c *taskServiceClient) GetTaskHistory(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskHistoryResponse)
+	err := c.cc.Invoke(ctx, TaskService_GetTaskHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) AddTaskDependency(ctx context.Context, in *TaskDependencyRequest, opts ...grpc.CallOption) (*TaskDependencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskDependencyResponse)
+	err := c.cc.Invoke(ctx, TaskService_AddTaskDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) RemoveTaskDependency(ctx context.Context, in *TaskDependencyRequest, opts ...grpc.CallOption) (*TaskDependencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskDependencyResponse)
+	err := c.cc.Invoke(ctx, TaskService_RemoveTaskDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskServiceClient) GetTaskSortConfig(ctx context.Context, in *GetTaskSortConfigRequest, opts ...grpc.CallOption) (*GetTaskSortConfigResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTaskSortConfigResponse)
@@ -127,6 +179,66 @@ func (c *taskServiceClient) UpdateTaskSortConfig(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *taskServiceClient) ListSavedViews(ctx context.Context, in *ListSavedViewsRequest, opts ...grpc.CallOption) (*ListSavedViewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedViewsResponse)
+	err := c.cc.Invoke(ctx, TaskService_ListSavedViews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) SaveView(ctx context.Context, in *SaveViewRequest, opts ...grpc.CallOption) (*SaveViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveViewResponse)
+	err := c.cc.Invoke(ctx, TaskService_SaveView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) DeleteSavedView(ctx context.Context, in *DeleteSavedViewRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, TaskService_DeleteSavedView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ListTaskFields(ctx context.Context, in *ListTaskFieldsRequest, opts ...grpc.CallOption) (*ListTaskFieldsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskFieldsResponse)
+	err := c.cc.Invoke(ctx, TaskService_ListTaskFields_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) SaveTaskField(ctx context.Context, in *SaveTaskFieldRequest, opts ...grpc.CallOption) (*SaveTaskFieldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveTaskFieldResponse)
+	err := c.cc.Invoke(ctx, TaskService_SaveTaskField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) DeleteTaskField(ctx context.Context, in *DeleteTaskFieldRequest, opts ...grpc.CallOption) (*Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Response)
+	err := c.cc.Invoke(ctx, TaskService_DeleteTaskField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskServiceServer is the server API for TaskService service.
 // All implementations must embed UnimplementedTaskServiceServer
 // for forward compatibility.
@@ -143,9 +255,22 @@ type TaskServiceServer interface {
 	UpdateTask(context.Context, *UpdateTaskRequest) (*UpdateTaskResponse, error)
 	// 删除任务
 	DeleteTask(context.Context, *DeleteTaskRequest) (*Response, error)
+	// 循环任务历史
+	GetTaskHistory(context.Context, *GetTaskRequest) (*GetTaskHistoryResponse, error)
+	// 任务依赖 (形成环时返回 FAILED_PRECONDITION)
+	AddTaskDependency(context.Context, *TaskDependencyRequest) (*TaskDependencyResponse, error)
+	RemoveTaskDependency(context.Context, *TaskDependencyRequest) (*TaskDependencyResponse, error)
 	// 排序配置
 	GetTaskSortConfig(context.Context, *GetTaskSortConfigRequest) (*GetTaskSortConfigResponse, error)
 	UpdateTaskSortConfig(context.Context, *UpdateTaskSortConfigRequest) (*UpdateTaskSortConfigResponse, error)
+	// 保存的视图 (名称重复返回 ALREADY_EXISTS)
+	ListSavedViews(context.Context, *ListSavedViewsRequest) (*ListSavedViewsResponse, error)
+	SaveView(context.Context, *SaveViewRequest) (*SaveViewResponse, error)
+	DeleteSavedView(context.Context, *DeleteSavedViewRequest) (*Response, error)
+	// 自定义字段定义 (Key 重复返回 ALREADY_EXISTS，工作区字段需 owner 角色)
+	ListTaskFields(context.Context, *ListTaskFieldsRequest) (*ListTaskFieldsResponse, error)
+	SaveTaskField(context.Context, *SaveTaskFieldRequest) (*SaveTaskFieldResponse, error)
+	DeleteTaskField(context.Context, *DeleteTaskFieldRequest) (*Response, error)
 	mustEmbedUnimplementedTaskServiceServer()
 }
 
@@ -171,12 +296,39 @@ func (UnimplementedTaskServiceServer) UpdateTask(context.Context, *UpdateTaskReq
 func (UnimplementedTaskServiceServer) DeleteTask(context.Context, *DeleteTaskRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
 }
+func (UnimplementedTaskServiceServer) GetTaskHistory(context.Context, *GetTaskRequest) (*GetTaskHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskHistory not implemented")
+}
+func (UnimplementedTaskServiceServer) AddTaskDependency(context.Context, *TaskDependencyRequest) (*TaskDependencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTaskDependency not implemented")
+}
+func (UnimplementedTaskServiceServer) RemoveTaskDependency(context.Context, *TaskDependencyRequest) (*TaskDependencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTaskDependency not implemented")
+}
 func (UnimplementedTaskServiceServer) GetTaskSortConfig(context.Context, *GetTaskSortConfigRequest) (*GetTaskSortConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTaskSortConfig not implemented")
 }
 func (UnimplementedTaskServiceServer) UpdateTaskSortConfig(context.Context, *UpdateTaskSortConfigRequest) (*UpdateTaskSortConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskSortConfig not implemented")
 }
+func (UnimplementedTaskServiceServer) ListSavedViews(context.Context, *ListSavedViewsRequest) (*ListSavedViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedViews not implemented")
+}
+func (UnimplementedTaskServiceServer) SaveView(context.Context, *SaveViewRequest) (*SaveViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveView not implemented")
+}
+func (UnimplementedTaskServiceServer) DeleteSavedView(context.Context, *DeleteSavedViewRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavedView not implemented")
+}
+func (UnimplementedTaskServiceServer) ListTaskFields(context.Context, *ListTaskFieldsRequest) (*ListTaskFieldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskFields not implemented")
+}
+func (UnimplementedTaskServiceServer) SaveTaskField(context.Context, *SaveTaskFieldRequest) (*SaveTaskFieldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveTaskField not implemented")
+}
+func (UnimplementedTaskServiceServer) DeleteTaskField(context.Context, *DeleteTaskFieldRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTaskField not implemented")
+}
 func (UnimplementedTaskServiceServer) mustEmbedUnimplementedTaskServiceServer() {}
 func (UnimplementedTaskServiceServer) testEmbeddedByValue()                     {}
 
@@ -288,6 +440,60 @@ func _TaskService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_GetTaskHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetTaskHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetTaskHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetTaskHistory(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_AddTaskDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).AddTaskDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_AddTaskDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).AddTaskDependency(ctx, req.(*TaskDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_RemoveTaskDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskDependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RemoveTaskDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_RemoveTaskDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RemoveTaskDependency(ctx, req.(*TaskDependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskService_GetTaskSortConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTaskSortConfigRequest)
 	if err := dec(in); err != nil {
@@ -324,6 +530,114 @@ func _TaskService_UpdateTaskSortConfig_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_ListSavedViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListSavedViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ListSavedViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListSavedViews(ctx, req.(*ListSavedViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_SaveView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SaveView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SaveView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SaveView(ctx, req.(*SaveViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_DeleteSavedView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DeleteSavedView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DeleteSavedView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DeleteSavedView(ctx, req.(*DeleteSavedViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ListTaskFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskFieldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListTaskFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ListTaskFields_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListTaskFields(ctx, req.(*ListTaskFieldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_SaveTaskField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveTaskFieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).SaveTaskField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_SaveTaskField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).SaveTaskField(ctx, req.(*SaveTaskFieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_DeleteTaskField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskFieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DeleteTaskField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DeleteTaskField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DeleteTaskField(ctx, req.(*DeleteTaskFieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -351,6 +665,18 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTask",
 			Handler:    _TaskService_DeleteTask_Handler,
 		},
+		{
+			MethodName: "GetTaskHistory",
+			Handler:    _TaskService_GetTaskHistory_Handler,
+		},
+		{
+			MethodName: "AddTaskDependency",
+			Handler:    _TaskService_AddTaskDependency_Handler,
+		},
+		{
+			MethodName: "RemoveTaskDependency",
+			Handler:    _TaskService_RemoveTaskDependency_Handler,
+		},
 		{
 			MethodName: "GetTaskSortConfig",
 			Handler:    _TaskService_GetTaskSortConfig_Handler,
@@ -359,6 +685,30 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateTaskSortConfig",
 			Handler:    _TaskService_UpdateTaskSortConfig_Handler,
 		},
+		{
+			MethodName: "ListSavedViews",
+			Handler:    _TaskService_ListSavedViews_Handler,
+		},
+		{
+			MethodName: "SaveView",
+			Handler:    _TaskService_SaveView_Handler,
+		},
+		{
+			MethodName: "DeleteSavedView",
+			Handler:    _TaskService_DeleteSavedView_Handler,
+		},
+		{
+			MethodName: "ListTaskFields",
+			Handler:    _TaskService_ListTaskFields_Handler,
+		},
+		{
+			MethodName: "SaveTaskField",
+			Handler:    _TaskService_SaveTaskField_Handler,
+		},
+		{
+			MethodName: "DeleteTaskField",
+			Handler:    _TaskService_DeleteTaskField_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "task.proto",
@@ -36,7 +36,10 @@ type UnifiedItem struct {
 	RelatedEventId   string                 `protobuf:"bytes,9,opt,name=related_event_id,json=relatedEventId,proto3" json:"related_event_id,omitempty"`
 	DetailUrl        string                 `protobuf:"bytes,10,opt,name=detail_url,json=detailUrl,proto3" json:"detail_url,omitempty"`
 	SourceId         string                 `protobuf:"bytes,11,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
-	IsUnscheduled    bool                   `protobuf:"varint,12,opt,name=is_unscheduled,json=isUnscheduled,proto3" json:"is_unscheduled,omitempty"` // 与后端未安排任务逻辑对齐
+	IsUnscheduled    bool                   `protobuf:"varint,12,opt,name=is_unscheduled,json=isUnscheduled,proto3" json:"is_unscheduled,omitempty"`    // 与后端未安排任务逻辑对齐
+	OccurrenceKey    string                 `protobuf:"bytes,13,opt,name=occurrence_key,json=occurrenceKey,proto3" json:"occurrence_key,omitempty"`     // 事件单次发生标识 (循环事件展开)
+	Blocked          bool                   `protobuf:"varint,14,opt,name=blocked,proto3" json:"blocked,omitempty"`                                     // 任务被未完成的依赖阻塞 (排在后面)
+	NewlyUnblocked   bool                   `protobuf:"varint,15,opt,name=newly_unblocked,json=newlyUnblocked,proto3" json:"newly_unblocked,omitempty"` // 任务刚解除阻塞 (排在前面)
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -155,13 +158,36 @@ func (x *UnifiedItem) GetIsUnscheduled() bool {
 	return false
 }
 
+func (x *UnifiedItem) GetOccurrenceKey() string {
+	if x != nil {
+		return x.OccurrenceKey
+	}
+	return ""
+}
+
+func (x *UnifiedItem) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *UnifiedItem) GetNewlyUnblocked() bool {
+	if x != nil {
+		return x.NewlyUnblocked
+	}
+	return false
+}
+
 // Upcoming 请求
 type GetUnifiedUpcomingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Hours         int32                  `protobuf:"varint,1,opt,name=hours,proto3" json:"hours,omitempty"`    // 默认72
-	Sources       []string               `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"` // task,event,reminder
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`    // 默认50
-	Debug         bool                   `protobuf:"varint,4,opt,name=debug,proto3" json:"debug,omitempty"`    // 是否包含调试
+	Hours         int32                  `protobuf:"varint,1,opt,name=hours,proto3" json:"hours,omitempty"`                                 // 默认72
+	Sources       []string               `protobuf:"bytes,2,rep,name=sources,proto3" json:"sources,omitempty"`                              // task,event,reminder
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                 // 默认50
+	Debug         bool                   `protobuf:"varint,4,opt,name=debug,proto3" json:"debug,omitempty"`                                 // 是否包含调试
+	Query         string                 `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`                                  // 过滤表达式，只筛选任务
+	SavedViewId   string                 `protobuf:"bytes,6,opt,name=saved_view_id,json=savedViewId,proto3" json:"saved_view_id,omitempty"` // 保存的视图，只筛选任务
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -224,6 +250,20 @@ func (x *GetUnifiedUpcomingRequest) GetDebug() bool {
 	return false
 }
 
+func (x *GetUnifiedUpcomingRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetUnifiedUpcomingRequest) GetSavedViewId() string {
+	if x != nil {
+		return x.SavedViewId
+	}
+	return ""
+}
+
 // 统计分布
 type UnifiedUpcomingStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -623,6 +663,7 @@ type UnifiedCalendarItem struct {
 	ScheduledAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
 	Importance    int32                  `protobuf:"varint,5,opt,name=importance,proto3" json:"importance,omitempty"`
 	DetailUrl     string                 `protobuf:"bytes,6,opt,name=detail_url,json=detailUrl,proto3" json:"detail_url,omitempty"`
+	OccurrenceKey string                 `protobuf:"bytes,7,opt,name=occurrence_key,json=occurrenceKey,proto3" json:"occurrence_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -699,6 +740,13 @@ func (x *UnifiedCalendarItem) GetDetailUrl() string {
 	return ""
 }
 
+func (x *UnifiedCalendarItem) GetOccurrenceKey() string {
+	if x != nil {
+		return x.OccurrenceKey
+	}
+	return ""
+}
+
 type UnifiedCalendarDay struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
@@ -839,7 +887,7 @@ var File_unified_proto protoreflect.FileDescriptor
 
 const file_unified_proto_rawDesc = "" +
 	"\n" +
-	"\runified.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\xa8\x03\n" +
+	"\runified.proto\x12\x0etodoing.api.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fcommon.proto\"\x92\x04\n" +
 	"\vUnifiedItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x12\x14\n" +
@@ -856,12 +904,17 @@ const file_unified_proto_rawDesc = "" +
 	"detail_url\x18\n" +
 	" \x01(\tR\tdetailUrl\x12\x1b\n" +
 	"\tsource_id\x18\v \x01(\tR\bsourceId\x12%\n" +
-	"\x0eis_unscheduled\x18\f \x01(\bR\risUnscheduled\"w\n" +
+	"\x0eis_unscheduled\x18\f \x01(\bR\risUnscheduled\x12%\n" +
+	"\x0eoccurrence_key\x18\r \x01(\tR\roccurrenceKey\x12\x18\n" +
+	"\ablocked\x18\x0e \x01(\bR\ablocked\x12'\n" +
+	"\x0fnewly_unblocked\x18\x0f \x01(\bR\x0enewlyUnblocked\"\xb1\x01\n" +
 	"\x19GetUnifiedUpcomingRequest\x12\x14\n" +
 	"\x05hours\x18\x01 \x01(\x05R\x05hours\x12\x18\n" +
 	"\asources\x18\x02 \x03(\tR\asources\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
-	"\x05debug\x18\x04 \x01(\bR\x05debug\"b\n" +
+	"\x05debug\x18\x04 \x01(\bR\x05debug\x12\x14\n" +
+	"\x05query\x18\x05 \x01(\tR\x05query\x12\"\n" +
+	"\rsaved_view_id\x18\x06 \x01(\tR\vsavedViewId\"b\n" +
 	"\x14UnifiedUpcomingStats\x12\x14\n" +
 	"\x05tasks\x18\x01 \x01(\x05R\x05tasks\x12\x16\n" +
 	"\x06events\x18\x02 \x01(\x05R\x06events\x12\x1c\n" +
@@ -899,7 +952,7 @@ const file_unified_proto_rawDesc = "" +
 	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
 	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x18\n" +
 	"\asources\x18\x03 \x03(\tR\asources\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xd1\x01\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xf8\x01\n" +
 	"\x13UnifiedCalendarItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x12\x14\n" +
@@ -909,7 +962,8 @@ const file_unified_proto_rawDesc = "" +
 	"importance\x18\x05 \x01(\x05R\n" +
 	"importance\x12\x1d\n" +
 	"\n" +
-	"detail_url\x18\x06 \x01(\tR\tdetailUrl\"c\n" +
+	"detail_url\x18\x06 \x01(\tR\tdetailUrl\x12%\n" +
+	"\x0eoccurrence_key\x18\a \x01(\tR\roccurrenceKey\"c\n" +
 	"\x12UnifiedCalendarDay\x12\x12\n" +
 	"\x04date\x18\x01 \x01(\tR\x04date\x129\n" +
 	"\x05items\x18\x02 \x03(\v2#.todoing.api.v1.UnifiedCalendarItemR\x05items\"\xf5\x01\n" +